@@ -16,6 +16,7 @@ package resources
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"reflect"
@@ -24,6 +25,7 @@ import (
 	"time"
 
 	"github.com/fatih/camelcase"
+	"github.com/google/emitto/source/sensor/eve"
 
 	log "github.com/golang/glog"
 	spb "github.com/google/emitto/source/sensor/proto"
@@ -80,6 +82,35 @@ func RuleToProto(r *Rule) *pb.Rule {
 	}
 }
 
+// ProtoToDataset converts a proto Dataset to an internal Dataset.
+func ProtoToDataset(d *pb.Dataset) *Dataset {
+	return &Dataset{
+		Name:     d.GetName(),
+		Type:     DatasetType(d.GetType()),
+		Members:  append([]string(nil), d.GetMembers()...),
+		LocZones: append([]string(nil), d.GetLocationZones()...),
+	}
+}
+
+// DatasetToProto converts an internal Dataset to a proto Dataset.
+func DatasetToProto(d *Dataset) *pb.Dataset {
+	return &pb.Dataset{
+		Name:          d.Name,
+		Type:          string(d.Type),
+		Members:       append([]string(nil), d.Members...),
+		LocationZones: append([]string(nil), d.LocZones...),
+	}
+}
+
+// DatasetToSensorProto converts an internal Dataset to a proto Dataset sent to sensors.
+func DatasetToSensorProto(d *Dataset) *spb.Dataset {
+	return &spb.Dataset{
+		Name:    d.Name,
+		Type:    string(d.Type),
+		Members: append([]string(nil), d.Members...),
+	}
+}
+
 // MakeRuleFile builds a rule file given Rule objects.
 func MakeRuleFile(rules []*Rule) []byte {
 	var buf bytes.Buffer
@@ -121,9 +152,12 @@ func ProtoToSensorMessage(m *spb.SensorMessage) *SensorMessage {
 	}
 	switch t := m.Type.(type) {
 	case *spb.SensorMessage_Alert:
+		msg.Type = Alert
 		msg.Time = time.Unix(m.GetAlert().GetTime().GetSeconds(), 0).Format(time.RFC1123Z)
 		msg.Host = m.GetAlert().GetHost().String()
+		msg.Status = m.GetAlert().GetStatus().String()
 	case *spb.SensorMessage_Heartbeat:
+		msg.Type = Heartbeat
 		msg.Time = time.Unix(m.GetHeartbeat().GetTime().GetSeconds(), 0).Format(time.RFC1123Z)
 		msg.Host = m.GetHeartbeat().GetHost().String()
 	default:
@@ -132,10 +166,211 @@ func ProtoToSensorMessage(m *spb.SensorMessage) *SensorMessage {
 	return msg
 }
 
-// ProtoToSensorRequest converts a proto SensorMessage to an internal SensorRequest.
+// ProtoToSensorRequest converts a proto SensorMessage to an internal SensorRequest. Ruleset
+// statistics are summed across Suricata tenants.
 func ProtoToSensorRequest(m *spb.SensorMessage) *SensorRequest {
-	return &SensorRequest{
+	r := &SensorRequest{
 		ID:     m.GetResponse().GetId(),
 		Status: m.GetResponse().GetStatus().String(),
 	}
+	for _, s := range m.GetResponse().GetRulesetStats() {
+		r.RulesLoaded += s.GetRulesLoaded()
+		r.RulesFailed += s.GetRulesFailed()
+	}
+	if d := m.GetResponse().GetDiagnostics(); d != nil {
+		r.Diagnostics = d.String()
+	}
+	r.CommandResult = m.GetResponse().GetCommandResult()
+	return r
+}
+
+// SensorRequestToProto converts an internal SensorRequest to a proto SensorRequest.
+func SensorRequestToProto(r *SensorRequest) *pb.SensorRequest {
+	return &pb.SensorRequest{
+		Id:            r.ID,
+		Time:          r.Time,
+		ClientId:      r.ClientID,
+		Type:          string(r.Type),
+		Status:        r.Status,
+		RuleFile:      r.RuleFile,
+		Diagnostics:   r.Diagnostics,
+		Command:       r.Command,
+		CommandResult: r.CommandResult,
+		RulesLoaded:   r.RulesLoaded,
+		RulesFailed:   r.RulesFailed,
+		FailedRuleIds: r.FailedRuleIDs,
+	}
+}
+
+// ProtoToSensorHealth converts a proto Heartbeat from the given Fleetspeak client to an internal
+// SensorHealth. Capture counters are summed across interfaces, falling back to the kernel
+// counters if no interface statistics are reported.
+func ProtoToSensorHealth(clientID string, h *spb.Heartbeat) *SensorHealth {
+	s := h.GetSuricata()
+	sh := &SensorHealth{
+		ClientID:        clientID,
+		Time:            time.Unix(h.GetTime().GetSeconds(), 0).Format(time.RFC1123Z),
+		Host:            h.GetHost().String(),
+		SensorVersion:   h.GetSensorVersion(),
+		RuleFileSHA256:  hex.EncodeToString(h.GetRuleFileSha256()),
+		SuricataVersion: s.GetVersion(),
+		SuricataUptime:  s.GetUptimeSeconds(),
+		RunningMode:     s.GetRunningMode(),
+		CapturePackets:  s.GetKernelPackets(),
+		CaptureDrops:    s.GetKernelDrops(),
+		RulesLoaded:     s.GetRulesLoaded(),
+		RulesFailed:     s.GetRulesFailed(),
+		SuricataError:   s.GetError(),
+	}
+	if len(s.GetInterfaces()) > 0 {
+		sh.CapturePackets, sh.CaptureDrops = 0, 0
+		for _, i := range s.GetInterfaces() {
+			sh.CapturePackets += i.GetPackets()
+			sh.CaptureDrops += i.GetDrops()
+		}
+	}
+	return sh
+}
+
+// SensorHealthToProto converts an internal SensorHealth to a proto SensorHealth.
+func SensorHealthToProto(h *SensorHealth) *pb.SensorHealth {
+	return &pb.SensorHealth{
+		ClientId:              h.ClientID,
+		Time:                  h.Time,
+		Host:                  h.Host,
+		SensorVersion:         h.SensorVersion,
+		RuleFileSha256:        h.RuleFileSHA256,
+		SuricataVersion:       h.SuricataVersion,
+		SuricataUptimeSeconds: h.SuricataUptime,
+		RunningMode:           h.RunningMode,
+		CapturePackets:        h.CapturePackets,
+		CaptureDrops:          h.CaptureDrops,
+		RulesLoaded:           h.RulesLoaded,
+		RulesFailed:           h.RulesFailed,
+		SuricataError:         h.SuricataError,
+	}
+}
+
+// DriftEventToProto converts an internal DriftEvent to a proto DriftEvent.
+func DriftEventToProto(e *DriftEvent) *pb.DriftEvent {
+	return &pb.DriftEvent{
+		Id:                     e.ID,
+		ClientId:               e.ClientID,
+		Time:                   e.Time,
+		DeployRequestId:        e.DeployRequestID,
+		ExpectedRuleFileSha256: e.ExpectedSHA256,
+		ReportedRuleFileSha256: e.ReportedSHA256,
+	}
+}
+
+// ProtoToAlertEvents converts the proto EVEAlerts of a SensorMessage from the given Fleetspeak
+// client to internal AlertEvents. Events without a valid timestamp use the batch time, and
+// timestamps without a zone offset are taken as UTC.
+func ProtoToAlertEvents(clientID string, m *spb.SensorMessage) []*AlertEvent {
+	a := m.GetEveAlerts()
+	var events []*AlertEvent
+	for i, e := range a.GetEvents() {
+		t, err := eve.ParseTimestamp(e.GetTimestamp(), time.UTC)
+		if err != nil {
+			t = time.Unix(a.GetTime().GetSeconds(), int64(a.GetTime().GetNanos()))
+		}
+		events = append(events, &AlertEvent{
+			ID:          fmt.Sprintf("%s-%d", m.GetId(), i),
+			ClientID:    clientID,
+			Time:        t.UTC(),
+			Host:        a.GetHost().GetFqdn(),
+			Location:    a.GetHost().GetOrg(),
+			Zone:        a.GetHost().GetZone(),
+			SignatureID: int64(e.GetAlert().GetSignatureId()),
+			Signature:   e.GetAlert().GetSignature(),
+			Category:    e.GetAlert().GetCategory(),
+			Severity:    int64(e.GetAlert().GetSeverity()),
+			Action:      e.GetAlert().GetAction(),
+			SrcIP:       e.GetSrcIp(),
+			SrcPort:     int64(e.GetSrcPort()),
+			DestIP:      e.GetDestIp(),
+			DestPort:    int64(e.GetDestPort()),
+			Proto:       e.GetProto(),
+			AppProto:    e.GetAppProto(),
+		})
+	}
+	return events
+}
+
+// ProtoToRuleHits converts the proto RuleHits of a SensorMessage from the given Fleetspeak client
+// to internal RuleHits, one per signature revision.
+func ProtoToRuleHits(clientID string, m *spb.SensorMessage) []*RuleHits {
+	rh := m.GetRuleHits()
+	start := time.Unix(rh.GetStart().GetSeconds(), int64(rh.GetStart().GetNanos())).UTC()
+	end := time.Unix(rh.GetEnd().GetSeconds(), int64(rh.GetEnd().GetNanos())).UTC()
+	var hits []*RuleHits
+	for _, h := range rh.GetHits() {
+		hits = append(hits, &RuleHits{
+			ID:          fmt.Sprintf("%s-%d-%d", m.GetId(), h.GetSignatureId(), h.GetRev()),
+			ClientID:    clientID,
+			Host:        rh.GetHost().GetFqdn(),
+			Location:    rh.GetHost().GetOrg(),
+			Zone:        rh.GetHost().GetZone(),
+			SignatureID: h.GetSignatureId(),
+			Rev:         int64(h.GetRev()),
+			Count:       h.GetCount(),
+			Start:       start,
+			End:         end,
+		})
+	}
+	return hits
+}
+
+// AlertEventToProto converts an internal AlertEvent to a proto AlertEvent.
+func AlertEventToProto(e *AlertEvent) *pb.AlertEvent {
+	return &pb.AlertEvent{
+		Id:          e.ID,
+		ClientId:    e.ClientID,
+		Time:        e.Time.Format(time.RFC1123Z),
+		Host:        e.Host,
+		Location:    e.Location,
+		Zone:        e.Zone,
+		RuleId:      e.RuleID,
+		SignatureId: e.SignatureID,
+		Signature:   e.Signature,
+		Category:    e.Category,
+		Severity:    e.Severity,
+		Action:      e.Action,
+		SrcIp:       e.SrcIP,
+		SrcPort:     e.SrcPort,
+		DestIp:      e.DestIP,
+		DestPort:    e.DestPort,
+		Proto:       e.Proto,
+		AppProto:    e.AppProto,
+	}
+}
+
+// QuarantineToProto converts an internal Quarantine to a proto Quarantine.
+func QuarantineToProto(q *Quarantine) *pb.Quarantine {
+	return &pb.Quarantine{
+		Id:       q.ID,
+		RuleId:   q.RuleID,
+		Location: q.Location,
+		Zone:     q.Zone,
+		Action:   string(q.Action),
+		Hits:     q.Hits,
+		Window:   q.Window,
+		Time:     q.Time,
+		Active:   q.Active,
+		UndoTime: q.UndoTime,
+	}
+}
+
+// AuditEventToProto converts an internal AuditEvent to a proto AuditEvent.
+func AuditEventToProto(e *AuditEvent) *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Id:       e.ID,
+		Time:     e.Time.Format(time.RFC1123Z),
+		Actor:    e.Actor,
+		Action:   e.Action,
+		RuleId:   e.RuleID,
+		Location: e.Location,
+		Zone:     e.Zone,
+		Details:  e.Details,
+	}
 }
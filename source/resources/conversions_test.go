@@ -16,13 +16,16 @@ package resources
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/go-cmp/cmp"
 
 	tpb "github.com/golang/protobuf/ptypes/timestamp"
 	spb "github.com/google/emitto/source/sensor/proto"
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
 	pb "github.com/google/emitto/source/server/proto"
+	rpb "google.golang.org/genproto/googleapis/rpc/status"
 )
 
 func TestProtoToLocation(t *testing.T) {
@@ -85,6 +88,31 @@ func TestRuleToProto(t *testing.T) {
 	}
 }
 
+func TestDatasetConversions(t *testing.T) {
+	p := &pb.Dataset{
+		Name:          "bad_ips",
+		Type:          "ipv4",
+		Members:       []string{"10.0.0.1", "10.0.0.2"},
+		LocationZones: []string{"test:dmz"},
+	}
+	d := &Dataset{
+		Name:     "bad_ips",
+		Type:     DatasetIPv4,
+		Members:  []string{"10.0.0.1", "10.0.0.2"},
+		LocZones: []string{"test:dmz"},
+	}
+	if diff := cmp.Diff(d, ProtoToDataset(p)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(p, DatasetToProto(d), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	want := &spb.Dataset{Name: "bad_ips", Type: "ipv4", Members: []string{"10.0.0.1", "10.0.0.2"}}
+	if diff := cmp.Diff(want, DatasetToSensorProto(d), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestMakeRuleFile(t *testing.T) {
 	rules := []*Rule{
 		{
@@ -168,6 +196,7 @@ func TestProtoToSensorMessage(t *testing.T) {
 			want: &SensorMessage{
 				ID:   "test_id",
 				Time: "Thu, 01 Jan 1970 00:02:03 +0000",
+				Type: Heartbeat,
 				Host: `fqdn:"id1" ip:"id2" uuid:"id3" org:"org" zone:"zone" `,
 			},
 		},
@@ -177,15 +206,18 @@ func TestProtoToSensorMessage(t *testing.T) {
 				Id: "test_id",
 				Type: &spb.SensorMessage_Alert{
 					Alert: &spb.SensorAlert{
-						Time: &tpb.Timestamp{Seconds: 123},
-						Host: &spb.Host{Fqdn: "id1", Ip: "id2", Uuid: "id3", Org: "org", Zone: "zone"},
+						Time:   &tpb.Timestamp{Seconds: 123},
+						Host:   &spb.Host{Fqdn: "id1", Ip: "id2", Uuid: "id3", Org: "org", Zone: "zone"},
+						Status: &rpb.Status{Code: 13, Message: "alert"},
 					},
 				},
 			},
 			want: &SensorMessage{
-				ID:   "test_id",
-				Time: "Thu, 01 Jan 1970 00:02:03 +0000",
-				Host: `fqdn:"id1" ip:"id2" uuid:"id3" org:"org" zone:"zone" `,
+				ID:     "test_id",
+				Time:   "Thu, 01 Jan 1970 00:02:03 +0000",
+				Type:   Alert,
+				Host:   `fqdn:"id1" ip:"id2" uuid:"id3" org:"org" zone:"zone" `,
+				Status: `code:13 message:"alert" `,
 			},
 		},
 	} {
@@ -194,3 +226,152 @@ func TestProtoToSensorMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestProtoToSensorRequest(t *testing.T) {
+	m := &spb.SensorMessage{
+		Type: &spb.SensorMessage_Response{
+			Response: &spb.SensorResponse{
+				Id:     "test_id",
+				Status: &rpb.Status{Message: "OK"},
+				RulesetStats: []*spb.RulesetStats{
+					{TenantId: 1, RulesLoaded: 10, RulesFailed: 1},
+					{TenantId: 2, RulesLoaded: 5, RulesFailed: 2},
+				},
+			},
+		},
+	}
+	want := &SensorRequest{
+		ID:          "test_id",
+		Status:      `message:"OK" `,
+		RulesLoaded: 15,
+		RulesFailed: 3,
+	}
+	if diff := cmp.Diff(want, ProtoToSensorRequest(m)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	m.GetResponse().RulesetStats = nil
+	m.GetResponse().Diagnostics = &spb.Diagnostics{SuricataVersion: "4.1.4"}
+	want = &SensorRequest{
+		ID:          "test_id",
+		Status:      `message:"OK" `,
+		Diagnostics: `suricata_version:"4.1.4" `,
+	}
+	if diff := cmp.Diff(want, ProtoToSensorRequest(m)); diff != "" {
+		t.Errorf("diagnostics: expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	m.GetResponse().Diagnostics = nil
+	m.GetResponse().CommandResult = `{"pkts":10}`
+	want = &SensorRequest{
+		ID:            "test_id",
+		Status:        `message:"OK" `,
+		CommandResult: `{"pkts":10}`,
+	}
+	if diff := cmp.Diff(want, ProtoToSensorRequest(m)); diff != "" {
+		t.Errorf("command result: expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProtoToAlertEvents(t *testing.T) {
+	m := &spb.SensorMessage{
+		Id: "msg",
+		Type: &spb.SensorMessage_EveAlerts{
+			EveAlerts: &spb.EVEAlerts{
+				Time: &tpb.Timestamp{Seconds: 1557756000},
+				Host: &spb.Host{Fqdn: "sensor", Org: "test", Zone: "dmz"},
+				Events: []*evepb.EVE{
+					{
+						Timestamp: "2019-05-13T16:12:19.384640+0200",
+						SrcIp:     "10.0.0.1",
+						SrcPort:   1234,
+						DestIp:    "10.0.0.2",
+						DestPort:  80,
+						Proto:     "TCP",
+						AppProto:  "http",
+						Alert: &evepb.Alert{
+							Action:      "allowed",
+							SignatureId: 1001,
+							Signature:   "test",
+							Category:    "scan",
+							Severity:    2,
+						},
+					},
+					{Timestamp: "yesterday"},
+					{Timestamp: "2019-05-13T14:12:19.5"},
+				},
+			},
+		},
+	}
+	want := []*AlertEvent{
+		{
+			ID:          "msg-0",
+			ClientID:    "0A",
+			Time:        time.Date(2019, 5, 13, 14, 12, 19, 384640000, time.UTC),
+			Host:        "sensor",
+			Location:    "test",
+			Zone:        "dmz",
+			SignatureID: 1001,
+			Signature:   "test",
+			Category:    "scan",
+			Severity:    2,
+			Action:      "allowed",
+			SrcIP:       "10.0.0.1",
+			SrcPort:     1234,
+			DestIP:      "10.0.0.2",
+			DestPort:    80,
+			Proto:       "TCP",
+			AppProto:    "http",
+		},
+		{
+			ID:       "msg-1",
+			ClientID: "0A",
+			Time:     time.Unix(1557756000, 0).UTC(),
+			Host:     "sensor",
+			Location: "test",
+			Zone:     "dmz",
+		},
+		{
+			ID:       "msg-2",
+			ClientID: "0A",
+			Time:     time.Date(2019, 5, 13, 14, 12, 19, 500000000, time.UTC),
+			Host:     "sensor",
+			Location: "test",
+			Zone:     "dmz",
+		},
+	}
+	if diff := cmp.Diff(want, ProtoToAlertEvents("0A", m)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProtoToRuleHits(t *testing.T) {
+	m := &spb.SensorMessage{
+		Id: "msg",
+		Type: &spb.SensorMessage_RuleHits{
+			RuleHits: &spb.RuleHits{
+				Start: &tpb.Timestamp{Seconds: 1557748800},
+				End:   &tpb.Timestamp{Seconds: 1557752400},
+				Host:  &spb.Host{Fqdn: "sensor", Org: "test", Zone: "dmz"},
+				Hits:  []*spb.RuleHit{{SignatureId: 1001, Rev: 2, Count: 5}},
+			},
+		},
+	}
+	want := []*RuleHits{
+		{
+			ID:          "msg-1001-2",
+			ClientID:    "0A",
+			Host:        "sensor",
+			Location:    "test",
+			Zone:        "dmz",
+			SignatureID: 1001,
+			Rev:         2,
+			Count:       5,
+			Start:       time.Date(2019, 5, 13, 12, 0, 0, 0, time.UTC),
+			End:         time.Date(2019, 5, 13, 13, 0, 0, 0, time.UTC),
+		},
+	}
+	if diff := cmp.Diff(want, ProtoToRuleHits("0A", m)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
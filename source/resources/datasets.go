@@ -0,0 +1,178 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// DatasetType is the Suricata type of a dataset.
+type DatasetType string
+
+// Suricata dataset types.
+const (
+	DatasetString DatasetType = "string"
+	DatasetMD5    DatasetType = "md5"
+	DatasetSHA256 DatasetType = "sha256"
+	DatasetIPv4   DatasetType = "ipv4"
+	DatasetIPv6   DatasetType = "ipv6"
+)
+
+// Dataset is a list of indicators, e.g. domains or file hashes, matched by Suricata rules with the
+// dataset keyword.
+type Dataset struct {
+	// The unique dataset name, also naming the dataset file on sensors.
+	Name string `mutable:"false"`
+	// Suricata dataset type.
+	Type DatasetType `mutable:"false"`
+	// Members of the dataset, normalized by NormalizeDatasetMember.
+	Members []string `mutable:"true" datastore:",noindex"`
+	// Select in which organization and zone the dataset is deployed, e.g. "google:dmz".
+	LocZones []string `mutable:"true"`
+	// Last modified time of the dataset. Applied by the Store.
+	LastModified string `mutable:"true"`
+}
+
+var datasetNameRE = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateDatasetName checks that a dataset name is safe to use as a file name.
+func ValidateDatasetName(name string) error {
+	if !datasetNameRE.MatchString(name) {
+		return fmt.Errorf("invalid dataset name %q: only letters, digits, '-' and '_' are allowed", name)
+	}
+	return nil
+}
+
+// NormalizeDatasetMember validates a member of a dataset of the given type and returns its
+// canonical form, e.g. a lowercase hash.
+func NormalizeDatasetMember(t DatasetType, m string) (string, error) {
+	switch t {
+	case DatasetString:
+		if m == "" || strings.ContainsAny(m, "\r\n") {
+			return "", fmt.Errorf("invalid string %q", m)
+		}
+		return m, nil
+	case DatasetMD5, DatasetSHA256:
+		n := 16
+		if t == DatasetSHA256 {
+			n = 32
+		}
+		if b, err := hex.DecodeString(m); err != nil || len(b) != n {
+			return "", fmt.Errorf("invalid %s hash %q", t, m)
+		}
+		return strings.ToLower(m), nil
+	case DatasetIPv4:
+		ip := net.ParseIP(m)
+		if ip == nil || ip.To4() == nil {
+			return "", fmt.Errorf("invalid IPv4 address %q", m)
+		}
+		return ip.String(), nil
+	case DatasetIPv6:
+		ip := net.ParseIP(m)
+		if ip == nil || ip.To4() != nil {
+			return "", fmt.Errorf("invalid IPv6 address %q", m)
+		}
+		return ip.String(), nil
+	default:
+		return "", fmt.Errorf("unknown dataset type %q", t)
+	}
+}
+
+// NormalizeDatasetMembers normalizes and deduplicates members of a dataset of the given type,
+// preserving their order.
+func NormalizeDatasetMembers(t DatasetType, members []string) ([]string, error) {
+	seen := make(map[string]bool)
+	res := make([]string, 0, len(members))
+	for _, m := range members {
+		n, err := NormalizeDatasetMember(t, m)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[n] {
+			seen[n] = true
+			res = append(res, n)
+		}
+	}
+	return res, nil
+}
+
+// NormalizeDataset validates a dataset, normalizing and deduplicating its members in place.
+func NormalizeDataset(d *Dataset) error {
+	if err := ValidateDatasetName(d.Name); err != nil {
+		return err
+	}
+	switch d.Type {
+	case DatasetString, DatasetMD5, DatasetSHA256, DatasetIPv4, DatasetIPv6:
+	default:
+		return fmt.Errorf("unknown dataset type %q", d.Type)
+	}
+	members, err := NormalizeDatasetMembers(d.Type, d.Members)
+	if err != nil {
+		return err
+	}
+	d.Members = members
+	return nil
+}
+
+// UpdateDatasetMembers returns the members with the removed members dropped and the added members
+// appended, unless already present. Members both added and removed are kept. The result is never
+// nil, so that it can be stored when empty.
+func UpdateDatasetMembers(members, add, remove []string) []string {
+	removed := make(map[string]bool)
+	for _, m := range remove {
+		removed[m] = true
+	}
+	res := make([]string, 0, len(members)+len(add))
+	present := make(map[string]bool)
+	for _, m := range members {
+		if !removed[m] && !present[m] {
+			present[m] = true
+			res = append(res, m)
+		}
+	}
+	for _, m := range add {
+		if !present[m] {
+			present[m] = true
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// DecodeDatasetMember returns a member from its encoding in a Suricata dataset file.
+func DecodeDatasetMember(t DatasetType, m string) (string, error) {
+	if t != DatasetString {
+		return m, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(m)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 string %q: %v", m, err)
+	}
+	return string(b), nil
+}
+
+// EncodeDatasetMember returns a member as written in a Suricata dataset file, where strings are
+// base64-encoded.
+func EncodeDatasetMember(t DatasetType, m string) string {
+	if t == DatasetString {
+		return base64.StdEncoding.EncodeToString([]byte(m))
+	}
+	return m
+}
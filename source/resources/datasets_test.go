@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeDatasetMember(t *testing.T) {
+	for _, tt := range []struct {
+		typ     DatasetType
+		member  string
+		want    string
+		wantErr bool
+	}{
+		{typ: DatasetString, member: "evil.example.com", want: "evil.example.com"},
+		{typ: DatasetString, member: "", wantErr: true},
+		{typ: DatasetString, member: "a\nb", wantErr: true},
+		{typ: DatasetMD5, member: "D41D8CD98F00B204E9800998ECF8427E", want: "d41d8cd98f00b204e9800998ecf8427e"},
+		{typ: DatasetMD5, member: "d41d8cd9", wantErr: true},
+		{typ: DatasetSHA256, member: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", want: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{typ: DatasetSHA256, member: "d41d8cd98f00b204e9800998ecf8427e", wantErr: true},
+		{typ: DatasetIPv4, member: "10.0.0.1", want: "10.0.0.1"},
+		{typ: DatasetIPv4, member: "2001:db8::1", wantErr: true},
+		{typ: DatasetIPv6, member: "2001:DB8:0::1", want: "2001:db8::1"},
+		{typ: DatasetIPv6, member: "10.0.0.1", wantErr: true},
+		{typ: "url", member: "http://example.com", wantErr: true},
+	} {
+		got, err := NormalizeDatasetMember(tt.typ, tt.member)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeDatasetMember(%q, %q) got err=%v, wantErr=%t", tt.typ, tt.member, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeDatasetMember(%q, %q) got %q, want %q", tt.typ, tt.member, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeDataset(t *testing.T) {
+	d := &Dataset{Name: "bad_ips", Type: DatasetIPv4, Members: []string{"10.0.0.1", "10.0.0.2", "10.0.0.1"}}
+	if err := NormalizeDataset(d); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"10.0.0.1", "10.0.0.2"}, d.Members); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	for _, d := range []*Dataset{
+		{Name: "../etc/passwd", Type: DatasetString},
+		{Name: "bad_ips", Type: "ip"},
+		{Name: "bad_ips", Type: DatasetIPv4, Members: []string{"evil.example.com"}},
+	} {
+		if err := NormalizeDataset(d); err == nil {
+			t.Errorf("NormalizeDataset(%+v) expected an error", d)
+		}
+	}
+}
+
+func TestUpdateDatasetMembers(t *testing.T) {
+	got := UpdateDatasetMembers([]string{"a", "b", "c"}, []string{"d", "a", "c"}, []string{"b", "c"})
+	if diff := cmp.Diff([]string{"a", "d", "c"}, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if got := UpdateDatasetMembers([]string{"a"}, nil, []string{"a"}); got == nil || len(got) != 0 {
+		t.Errorf("got %#v, want an empty non-nil slice", got)
+	}
+}
+
+func TestEncodeDatasetMember(t *testing.T) {
+	if got, want := EncodeDatasetMember(DatasetString, "evil.example.com"), "ZXZpbC5leGFtcGxlLmNvbQ=="; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := EncodeDatasetMember(DatasetIPv4, "10.0.0.1"), "10.0.0.1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDecodeDatasetMember(t *testing.T) {
+	for _, m := range []string{"evil.example.com", "a b"} {
+		got, err := DecodeDatasetMember(DatasetString, EncodeDatasetMember(DatasetString, m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != m {
+			t.Errorf("got %q, want %q", got, m)
+		}
+	}
+	if _, err := DecodeDatasetMember(DatasetString, "evil.example.com"); err == nil {
+		t.Error("expected an error for an invalid base64 string")
+	}
+}
@@ -15,6 +15,8 @@
 // Package resources contains common objects and conversion functions.
 package resources
 
+import "time"
+
 const (
 	// fleetspeakPrefix is the default label prefix prepended to all client labels.
 	fleetspeakPrefix = "alphabet-"
@@ -24,6 +26,11 @@ const (
 	LocationZonePrefix = fleetspeakPrefix + "location-zone-"
 )
 
+// LocationLabels returns the labels targeting a sensor in the zone of a location.
+func LocationLabels(name, zone string) []string {
+	return []string{LocationNamePrefix + name, LocationZonePrefix + zone}
+}
+
 // Location defines an arbirary organization of sensors, segmented into a least one zone.
 type Location struct {
 	// The unique name of the location, e.g. "company1".
@@ -73,8 +80,11 @@ type SensorRequestType string
 
 // Sensor request types as described in the sensor proto.
 const (
-	DeployRules SensorRequestType = "DeployRules"
-	ReloadRules SensorRequestType = "ReloadRules"
+	DeployRules        SensorRequestType = "DeployRules"
+	ReloadRules        SensorRequestType = "ReloadRules"
+	CollectDiagnostics SensorRequestType = "CollectDiagnostics"
+	RunCommand         SensorRequestType = "RunCommand"
+	UpdateDataset      SensorRequestType = "UpdateDataset"
 )
 
 // SensorRequest contains the details and state of a sensor request message.
@@ -87,8 +97,29 @@ type SensorRequest struct {
 	ClientID string `mutable:"false"`
 	// Type of message.
 	Type SensorRequestType `mutable:"false"`
+	// File store path of the deployed rule file, for DeployRules requests.
+	RuleFile string `mutable:"false"`
+	// Hex-encoded SHA-256 digest of the deployed rule file, for DeployRules requests.
+	RuleFileSHA256 string `mutable:"false"`
+	// Name and zones of the deployed location, for DeployRules requests.
+	Location string   `mutable:"false"`
+	Zones    []string `mutable:"false"`
+	// Deployment generation of the location, for DeployRules requests.
+	Generation int64 `mutable:"false"`
+	// Name of the Suricata socket command, for RunCommand requests.
+	Command string `mutable:"false"`
 	// Status of the request.
 	Status string `mutable:"true"`
+	// Number of rules loaded by Suricata after a DeployRules request.
+	RulesLoaded int64 `mutable:"true"`
+	// Number of rules Suricata failed to load after a DeployRules request.
+	RulesFailed int64 `mutable:"true"`
+	// IDs of the rules which Suricata rejected or failed to load. Applied by the Service.
+	FailedRuleIDs []int64 `mutable:"true"`
+	// Diagnostics reported by the sensor in text format, for CollectDiagnostics requests.
+	Diagnostics string `mutable:"true" datastore:",noindex"`
+	// Raw JSON result reported by the sensor, for RunCommand requests.
+	CommandResult string `mutable:"true" datastore:",noindex"`
 	// Last modified time of the message. Applied by the Store.
 	LastModified string `mutable:"true"`
 }
@@ -119,4 +150,287 @@ type SensorMessage struct {
 	Host string `mutable:"false"`
 	// Status of the request.
 	Status string `mutable:"false"`
+	// Time the message was stored by the server, set by the store.
+	Received time.Time `mutable:"false"`
+}
+
+// SensorMessageFilter selects SensorMessages. Empty fields match all messages.
+type SensorMessageFilter struct {
+	Type SensorMessageType
+	// Only select messages at or after Since.
+	Since time.Time
+	// Only select messages stored at or after ReceivedSince.
+	ReceivedSince time.Time
+}
+
+// Match returns true if the filter selects the message. Messages with an invalid time are only
+// selected if Since is zero.
+func (f *SensorMessageFilter) Match(m *SensorMessage) bool {
+	if f.Type != "" && m.Type != f.Type {
+		return false
+	}
+	if !f.ReceivedSince.IsZero() && m.Received.Before(f.ReceivedSince) {
+		return false
+	}
+	if f.Since.IsZero() {
+		return true
+	}
+	t, err := time.Parse(time.RFC1123Z, m.Time)
+	return err == nil && !t.Before(f.Since)
+}
+
+// SensorHealth contains the latest health information reported by a sensor heartbeat.
+type SensorHealth struct {
+	// Fleetspeak client ID (Hex-encoded bytes).
+	ClientID string `mutable:"false"`
+	// Heartbeat time.
+	Time string `mutable:"true"`
+	// Host information of sender.
+	Host string `mutable:"true"`
+	// Version of the sensor binary.
+	SensorVersion string `mutable:"true"`
+	// Hex-encoded SHA-256 digest of the installed rule file.
+	RuleFileSHA256 string `mutable:"true"`
+	// Suricata version.
+	SuricataVersion string `mutable:"true"`
+	// Time Suricata has been running, in seconds.
+	SuricataUptime int64 `mutable:"true"`
+	// Suricata running mode.
+	RunningMode string `mutable:"true"`
+	// Packets captured, summed across interfaces.
+	CapturePackets int64 `mutable:"true"`
+	// Packets dropped, summed across interfaces.
+	CaptureDrops int64 `mutable:"true"`
+	// Number of rules loaded by Suricata.
+	RulesLoaded int64 `mutable:"true"`
+	// Number of rules Suricata failed to load.
+	RulesFailed int64 `mutable:"true"`
+	// Error encountered by the sensor while collecting Suricata health.
+	SuricataError string `mutable:"true"`
+	// Last modified time of the health. Applied by the Store.
+	LastModified string `mutable:"true"`
+}
+
+// Deployment records the last successful rule deployment to a sensor.
+type Deployment struct {
+	// Fleetspeak client ID (Hex-encoded bytes).
+	ClientID string `mutable:"false"`
+	// ID of the DeployRules sensor request.
+	RequestID string `mutable:"true"`
+	// File store path of the deployed rule file.
+	RuleFile string `mutable:"true"`
+	// Hex-encoded SHA-256 digest of the deployed rule file.
+	RuleFileSHA256 string `mutable:"true"`
+	// Name and zones of the deployed location.
+	Location string   `mutable:"true"`
+	Zones    []string `mutable:"true"`
+	// Deployment generation of the location.
+	Generation int64 `mutable:"true"`
+	// Time the deployment was confirmed by the sensor.
+	Time string `mutable:"true"`
+	// Hex-encoded SHA-256 digest of the drifted rule file last reported by the sensor. Empty if the
+	// sensor is running the deployed rule file.
+	DriftSHA256 string `mutable:"true"`
+	// Last modified time of the deployment. Applied by the Store.
+	LastModified string `mutable:"true"`
+}
+
+// DriftEvent records a sensor reporting a rule file other than the one last deployed to it.
+type DriftEvent struct {
+	// The unique event ID.
+	ID string `mutable:"false"`
+	// Fleetspeak client ID (Hex-encoded bytes).
+	ClientID string `mutable:"false"`
+	// Detection time of the drift.
+	Time string `mutable:"false"`
+	// ID of the DeployRules sensor request of the last successful deployment.
+	DeployRequestID string `mutable:"false"`
+	// Hex-encoded SHA-256 digest of the deployed rule file.
+	ExpectedSHA256 string `mutable:"false"`
+	// Hex-encoded SHA-256 digest of the rule file reported by the sensor.
+	ReportedSHA256 string `mutable:"false"`
+}
+
+// AlertEvent is a Suricata alert event forwarded by a sensor.
+type AlertEvent struct {
+	// The unique event ID.
+	ID string `mutable:"false"`
+	// Fleetspeak client ID (Hex-encoded bytes).
+	ClientID string `mutable:"false"`
+	// Time of the alert.
+	Time time.Time `mutable:"false"`
+	// FQDN of the sensor.
+	Host string `mutable:"false"`
+	// Location name (organization) of the sensor.
+	Location string `mutable:"false"`
+	// Location zone of the sensor.
+	Zone string `mutable:"false"`
+	// ID of the rule matching the alert signature, or 0 if unknown.
+	RuleID int64 `mutable:"false"`
+	// Suricata alert signature ID.
+	SignatureID int64 `mutable:"false"`
+	// Suricata alert signature message.
+	Signature string `mutable:"false"`
+	// Suricata alert category.
+	Category string `mutable:"false"`
+	// Suricata alert severity.
+	Severity int64 `mutable:"false"`
+	// Suricata alert action, e.g. "allowed".
+	Action string `mutable:"false"`
+	// Source IP address and port.
+	SrcIP   string `mutable:"false"`
+	SrcPort int64  `mutable:"false"`
+	// Destination IP address and port.
+	DestIP   string `mutable:"false"`
+	DestPort int64  `mutable:"false"`
+	// Transport and application protocols.
+	Proto    string `mutable:"false"`
+	AppProto string `mutable:"false"`
+	// Time the event was stored by the server, set by the store.
+	Received time.Time `mutable:"false"`
+}
+
+// AlertEventFilter selects AlertEvents. Empty fields match all events.
+type AlertEventFilter struct {
+	RuleID   int64
+	Location string
+	Zone     string
+	ClientID string
+	Host     string
+	// Only select events at or after Since.
+	Since time.Time
+	// Only select events stored at or after ReceivedSince. It cannot be combined with Since.
+	ReceivedSince time.Time
+	// Maximum number of events; 0 for no limit.
+	Limit int
+}
+
+// Match returns true if the filter selects the event, regardless of the limit.
+func (f *AlertEventFilter) Match(e *AlertEvent) bool {
+	switch {
+	case f.RuleID != 0 && e.RuleID != f.RuleID:
+		return false
+	case f.Location != "" && e.Location != f.Location:
+		return false
+	case f.Zone != "" && e.Zone != f.Zone:
+		return false
+	case f.ClientID != "" && e.ClientID != f.ClientID:
+		return false
+	case f.Host != "" && e.Host != f.Host:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.ReceivedSince.IsZero() && e.Received.Before(f.ReceivedSince):
+		return false
+	}
+	return true
+}
+
+// RuleHits is the number of Suricata alerts of a signature reported by a sensor over a period.
+type RuleHits struct {
+	// The unique ID of the report entry.
+	ID string `mutable:"false"`
+	// Fleetspeak client ID (Hex-encoded bytes).
+	ClientID string `mutable:"false"`
+	// FQDN of the sensor.
+	Host string `mutable:"false"`
+	// Location name (organization) of the sensor.
+	Location string `mutable:"false"`
+	// Location zone of the sensor.
+	Zone string `mutable:"false"`
+	// ID of the rule matching the signature, or 0 if unknown.
+	RuleID int64 `mutable:"false"`
+	// Suricata signature ID and revision.
+	SignatureID int64 `mutable:"false"`
+	Rev         int64 `mutable:"false"`
+	// Number of alerts.
+	Count int64 `mutable:"false"`
+	// Start and end of the reporting period.
+	Start time.Time `mutable:"false"`
+	End   time.Time `mutable:"false"`
+}
+
+// RuleHitsFilter selects RuleHits. Empty fields match all entries.
+type RuleHitsFilter struct {
+	RuleIDs  []int64
+	Location string
+	Zone     string
+	// Only select entries whose reporting period ends at or after Since.
+	Since time.Time
+	// Only select entries whose reporting period starts before Until.
+	Until time.Time
+}
+
+// Match returns true if the filter selects the entry.
+func (f *RuleHitsFilter) Match(h *RuleHits) bool {
+	switch {
+	case f.Location != "" && h.Location != f.Location:
+		return false
+	case f.Zone != "" && h.Zone != f.Zone:
+		return false
+	case !f.Since.IsZero() && h.End.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !h.Start.Before(f.Until):
+		return false
+	}
+	if len(f.RuleIDs) == 0 {
+		return true
+	}
+	for _, id := range f.RuleIDs {
+		if h.RuleID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// QuarantineAction defines how a noisy rule is quarantined.
+type QuarantineAction string
+
+const (
+	// QuarantineRemove removes the rule from the location zone.
+	QuarantineRemove QuarantineAction = "remove"
+	// QuarantineSuppress adds a threshold to the rule in the location zone.
+	QuarantineSuppress QuarantineAction = "suppress"
+)
+
+// Quarantine records a rule automatically quarantined for flooding a location zone with alerts.
+type Quarantine struct {
+	// The unique quarantine ID.
+	ID string `mutable:"false"`
+	// ID of the quarantined rule.
+	RuleID int64 `mutable:"false"`
+	// Location name and zone flooded by the rule.
+	Location string `mutable:"false"`
+	Zone     string `mutable:"false"`
+	// Quarantine action.
+	Action QuarantineAction `mutable:"false"`
+	// Number of hits within the detection window which caused the quarantine.
+	Hits int64 `mutable:"false"`
+	// Detection window, e.g. "1h0m0s".
+	Window string `mutable:"false"`
+	// Quarantine time.
+	Time string `mutable:"false"`
+	// Whether the quarantine is in effect, i.e. was not undone.
+	Active bool `mutable:"true"`
+	// Time the quarantine was undone.
+	UndoTime string `mutable:"true"`
+}
+
+// AuditEvent records an automatic change made by the server.
+type AuditEvent struct {
+	// The unique event ID.
+	ID string `mutable:"false"`
+	// Event time.
+	Time time.Time `mutable:"false"`
+	// Component or user making the change, e.g. "quarantine".
+	Actor string `mutable:"false"`
+	// Change made, e.g. "quarantine_rule".
+	Action string `mutable:"false"`
+	// Rule, location and zone affected by the change, if any.
+	RuleID   int64  `mutable:"false"`
+	Location string `mutable:"false"`
+	Zone     string `mutable:"false"`
+	// Human readable details.
+	Details string `mutable:"false"`
 }
@@ -0,0 +1,172 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides a local status and control endpoint for operators of an Emitto sensor.
+//
+// The endpoint serves:
+//
+//	GET  /status             the sensor status as JSON
+//	POST /actions/heartbeat  sends a heartbeat to the server
+//	POST /actions/redeploy   deploys again the rules of the last DeployRules request
+//
+// Actions require an "Authorization: Bearer <token>" header and are disabled without a token.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/emitto/source/sensor/client"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+)
+
+// Controller represents the sensor client controlled by the endpoint.
+type Controller interface {
+	// Status returns the current sensor status.
+	Status() *client.Status
+	// SendHeartbeat sends a heartbeat to the server.
+	SendHeartbeat()
+	// RedeployLast deploys again the rules of the last DeployRules request.
+	RedeployLast(ctx context.Context) (*status.Status, error)
+}
+
+// actionResult is the response to an action.
+type actionResult struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type handler struct {
+	ctrl  Controller
+	token []byte
+}
+
+// NewHandler returns the http.Handler of the endpoint. Actions are authenticated with token, and
+// disabled if it is empty.
+func NewHandler(ctrl Controller, token string) http.Handler {
+	h := &handler{ctrl: ctrl, token: []byte(token)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", h.status)
+	mux.HandleFunc("/actions/heartbeat", h.action(h.heartbeat))
+	mux.HandleFunc("/actions/redeploy", h.action(h.redeploy))
+	return mux
+}
+
+func (h *handler) status(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.ctrl.Status())
+}
+
+// action wraps an action handler with method and authentication checks.
+func (h *handler) action(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if len(h.token) == 0 {
+			http.Error(w, "actions are disabled", http.StatusForbidden)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), h.token) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		log.Infof("Running local action %q", r.URL.Path)
+		fn(w, r)
+	}
+}
+
+func (h *handler) heartbeat(w http.ResponseWriter, r *http.Request) {
+	h.ctrl.SendHeartbeat()
+	writeJSON(w, http.StatusOK, &actionResult{Code: "OK"})
+}
+
+func (h *handler) redeploy(w http.ResponseWriter, r *http.Request) {
+	s, err := h.ctrl.RedeployLast(r.Context())
+	if s == nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	res := &actionResult{Code: s.Code().String(), Message: s.Message()}
+	if err != nil {
+		res.Message = fmt.Sprintf("%s; failed to report to the server: %v", res.Message, err)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(append(b, '\n'))
+}
+
+// Listen listens on a local address: either "unix:<path>" for a Unix socket, only accessible to
+// its owner, or a TCP address on a loopback interface, e.g. "localhost:8081".
+func Listen(addr string) (net.Listener, error) {
+	if path := strings.TrimPrefix(addr, "unix:"); path != addr {
+		// Remove a stale socket left by a previous run.
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale socket %q: %v", path, err)
+		}
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(path, 0600); err != nil {
+			l.Close()
+			return nil, fmt.Errorf("failed to restrict access to socket %q: %v", path, err)
+		}
+		return l, nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %v", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("address %q is not a loopback address", addr)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// LoadToken reads the action token from a file, ignoring surrounding whitespace. An empty path
+// returns an empty token, disabling actions.
+func LoadToken(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read admin token file %q: %v", path, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
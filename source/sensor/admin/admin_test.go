@@ -0,0 +1,193 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/emitto/source/sensor/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeController struct {
+	heartbeats int
+	redeploys  int
+	noDeploy   bool
+}
+
+func (c *fakeController) Status() *client.Status {
+	return &client.Status{Version: "1.2.3", RuleFileSHA256: "abcd"}
+}
+
+func (c *fakeController) SendHeartbeat() {
+	c.heartbeats++
+}
+
+func (c *fakeController) RedeployLast(context.Context) (*status.Status, error) {
+	if c.noDeploy {
+		return nil, errors.New("no DeployRules request")
+	}
+	c.redeploys++
+	return status.New(codes.OK, ""), nil
+}
+
+func TestHandler(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		token          string
+		method         string
+		path           string
+		auth           string
+		noDeploy       bool
+		wantCode       int
+		wantHeartbeats int
+		wantRedeploys  int
+	}{
+		{
+			desc:     "status",
+			method:   http.MethodGet,
+			path:     "/status",
+			wantCode: http.StatusOK,
+		},
+		{
+			desc:     "status with wrong method",
+			method:   http.MethodPost,
+			path:     "/status",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			desc:           "heartbeat",
+			token:          "secret",
+			method:         http.MethodPost,
+			path:           "/actions/heartbeat",
+			auth:           "Bearer secret",
+			wantCode:       http.StatusOK,
+			wantHeartbeats: 1,
+		},
+		{
+			desc:     "heartbeat with wrong token",
+			token:    "secret",
+			method:   http.MethodPost,
+			path:     "/actions/heartbeat",
+			auth:     "Bearer guess",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			desc:     "heartbeat without token",
+			token:    "secret",
+			method:   http.MethodPost,
+			path:     "/actions/heartbeat",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			desc:     "actions disabled",
+			method:   http.MethodPost,
+			path:     "/actions/heartbeat",
+			auth:     "Bearer ",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			desc:     "heartbeat with wrong method",
+			token:    "secret",
+			method:   http.MethodGet,
+			path:     "/actions/heartbeat",
+			auth:     "Bearer secret",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			desc:          "redeploy",
+			token:         "secret",
+			method:        http.MethodPost,
+			path:          "/actions/redeploy",
+			auth:          "Bearer secret",
+			wantCode:      http.StatusOK,
+			wantRedeploys: 1,
+		},
+		{
+			desc:     "redeploy without deployment",
+			token:    "secret",
+			method:   http.MethodPost,
+			path:     "/actions/redeploy",
+			auth:     "Bearer secret",
+			noDeploy: true,
+			wantCode: http.StatusConflict,
+		},
+	} {
+		ctrl := &fakeController{noDeploy: tt.noDeploy}
+		r := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.auth != "" {
+			r.Header.Set("Authorization", tt.auth)
+		}
+		w := httptest.NewRecorder()
+		NewHandler(ctrl, tt.token).ServeHTTP(w, r)
+		if w.Code != tt.wantCode {
+			t.Errorf("%s: got status code %d, want %d", tt.desc, w.Code, tt.wantCode)
+		}
+		if ctrl.heartbeats != tt.wantHeartbeats || ctrl.redeploys != tt.wantRedeploys {
+			t.Errorf("%s: got %d heartbeats and %d redeploys, want %d and %d", tt.desc, ctrl.heartbeats, ctrl.redeploys, tt.wantHeartbeats, tt.wantRedeploys)
+		}
+		if tt.path == "/status" && w.Code == http.StatusOK {
+			var s client.Status
+			if err := json.Unmarshal(w.Body.Bytes(), &s); err != nil {
+				t.Errorf("%s: failed to parse status: %v", tt.desc, err)
+			} else if s.RuleFileSHA256 != "abcd" {
+				t.Errorf("%s: got rule file digest %q, want %q", tt.desc, s.RuleFileSHA256, "abcd")
+			}
+		}
+	}
+}
+
+func TestListen(t *testing.T) {
+	d, err := ioutil.TempDir("", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, tt := range []struct {
+		addr    string
+		wantErr bool
+	}{
+		{addr: "unix:" + filepath.Join(d, "admin.sock")},
+		{addr: "127.0.0.1:0"},
+		{addr: "localhost:0"},
+		{addr: "0.0.0.0:8081", wantErr: true},
+		{addr: ":8081", wantErr: true},
+		{addr: "192.0.2.1:8081", wantErr: true},
+		{addr: "localhost", wantErr: true},
+	} {
+		l, err := Listen(tt.addr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Listen(%q): got err=%v, wantErr=%t", tt.addr, err, tt.wantErr)
+		}
+		if err == nil {
+			l.Close()
+		}
+	}
+	sock := filepath.Join(d, "admin.sock")
+	fi, err := os.Stat(sock)
+	if err == nil && fi.Mode().Perm() != 0600 {
+		t.Errorf("got socket permissions %v, want 0600", fi.Mode().Perm())
+	}
+}
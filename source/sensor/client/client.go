@@ -16,22 +16,26 @@
 package client
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/sensor/eve"
 	"github.com/google/emitto/source/sensor/fleetspeak"
 	"github.com/google/emitto/source/sensor/host"
+	"github.com/google/emitto/source/sensor/ledger"
+	"github.com/google/emitto/source/sensor/queue"
 	"github.com/google/emitto/source/sensor/suricata"
+	"github.com/google/emitto/source/sensor/suricata/socket"
+	"github.com/google/emitto/source/sensor/transport"
 	"github.com/google/uuid"
+	"golang.org/x/crypto/ed25519"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
 	log "github.com/golang/glog"
@@ -44,6 +48,30 @@ import (
 type SuricataController interface {
 	// ReloadRules reloads Suricata rules.
 	ReloadRules() error
+	// LastReloadTime returns the time of the last successful ruleset reload.
+	LastReloadTime() (time.Time, error)
+	// RulesetStats returns the number of loaded and failed rules per tenant.
+	RulesetStats() ([]*socket.RuleStats, error)
+	// FailedRules returns the rules which failed to load during the last ruleset reload.
+	FailedRules() ([]*socket.FailedRule, error)
+	// Health collects Suricata health information.
+	Health() (*suricata.Health, error)
+	// Version returns the Suricata version.
+	Version() (string, error)
+	// DumpCounters returns the Suricata performance counters.
+	DumpCounters() (socket.Counters, error)
+	// DatasetAdd adds a value to a loaded dataset.
+	DatasetAdd(name, typ, value string) error
+	// DatasetRemove removes a value from a loaded dataset.
+	DatasetRemove(name, typ, value string) error
+	// RunCommand runs a socket command and returns its raw JSON response message.
+	RunCommand(name string, args map[string]string) ([]byte, error)
+}
+
+// RuleValidator represents a Suricata rule file validator.
+type RuleValidator interface {
+	// Validate tests a rule file, returning a *suricata.ValidationError if it is rejected.
+	Validate(ruleFile string) error
 }
 
 // FleetspeakClient represents a Fleetspeak client.
@@ -54,34 +82,217 @@ type FleetspeakClient interface {
 	Receive(done <-chan struct{})
 	// Messages provides access to the messages received from Fleetspeak.
 	Messages() chan *fspb.Message
+	// State returns the activity of the channel to Fleetspeak.
+	State() fleetspeak.State
+}
+
+// queuedClient sends messages through a durable queue, returning once they are queued.
+type queuedClient struct {
+	FleetspeakClient
+	q *queue.Queue
+}
+
+func (c *queuedClient) SendMessage(m *pb.SensorMessage) (string, error) {
+	if err := c.q.Enqueue(m); err != nil {
+		return "", err
+	}
+	return m.GetId(), nil
+}
+
+func (c *queuedClient) SetLabels(labels []string) {
+	if l, ok := c.FleetspeakClient.(labeler); ok {
+		l.SetLabels(labels)
+	}
+}
+
+// labeler is implemented by transports to which the sensor registers with labels.
+type labeler interface {
+	// SetLabels changes the labels of the sensor.
+	SetLabels(labels []string)
+}
+
+// maxLedgerRequests is the number of processed request IDs kept to detect duplicates.
+const maxLedgerRequests = 1000
+
+// Transports to the server.
+const (
+	TransportFleetspeak = "fleetspeak"
+	TransportGRPC       = "grpc"
+)
+
+// Config contains the Emitto sensor client configuration.
+type Config struct {
+	// Transport to the server: TransportFleetspeak if empty, or TransportGRPC.
+	Transport string
+	// Fleetspeak client socket.
+	FleetspeakSocket string
+	// Address of the Emitto server sensor endpoint, and the TLS credentials of the sensor, for the
+	// gRPC transport.
+	ServerAddr  string
+	ServerCreds credentials.TransportCredentials
+	// Labels of the sensor for the gRPC transport. Derived from Org and Zone if empty.
+	Labels []string
+	// Time after which a message unacknowledged by the server is considered lost.
+	AckTimeout time.Duration
+	// Durable queue of the messages sent to the server. Messages are sent directly if nil.
+	Queue *queue.Config
+	// Path of the file recording the processed requests and the installed deployment generations.
+	// They are only kept in memory if empty.
+	RequestLedger string
+	// Age after which DeployRules requests are rejected as stale. Disabled if zero.
+	RequestExpiry time.Duration
+	// Suricata Unix socket.
+	SuricataSocket string
+	// Suricata binary used to validate rule files. Validation is skipped if empty.
+	SuricataBinary string
+	// Suricata configuration file used to validate rule files.
+	SuricataConfig string
+	// Suricata rule file path.
+	RuleFile string
+	// Suricata log file, collected for diagnostics.
+	SuricataLog string
+	// Suricata socket commands the server is allowed to run. None are allowed if empty.
+	AllowedCommands []string
+	// Directory of the Suricata dataset files. Deployments with datasets are rejected if empty.
+	DatasetDir string
+	// Sensor configuration file, hashed for diagnostics.
+	ConfigFile string
+	// Pinned key for verifying rule files. Verification is skipped if nil.
+	RuleKey ed25519.PublicKey
+	// Sensor organization.
+	Org string
+	// Sensor zone.
+	Zone string
+	// Path of the file keeping the sensor UUID.
+	UUIDFile string
+	// Names of the interfaces Suricata captures traffic on. Identified from the interface state
+	// if empty.
+	CaptureInterfaces []string
+	// Version of the sensor binary, reported in heartbeats.
+	Version string
 }
 
 // Client represents a Emitto sensor client.
 type Client struct {
 	FSClient  FleetspeakClient
 	ctrl      SuricataController
+	validator RuleValidator
 	ruleStore filestore.FileStore
 	host      *host.Host
-	org       string
-	zone      string
 	ruleFile  string
+	// Suricata log file and sensor configuration file, collected for diagnostics.
+	suricataLog string
+	configFile  string
+	// Suricata socket commands the server is allowed to run.
+	allowedCommands map[string]bool
+	// Directory of the Suricata dataset files.
+	datasetDir string
+	// Pinned key for verifying rule files. Verification is skipped if nil.
+	ruleKey ed25519.PublicKey
+	version string
+	// Configured labels of the sensor for the gRPC transport.
+	labels []string
+	// Durable queue of the messages sent to the server, if any.
+	queue *queue.Queue
+	// Processed requests and installed deployment generations.
+	ledger *ledger.Ledger
+	// Age after which DeployRules requests are rejected as stale. Disabled if zero.
+	requestExpiry time.Duration
+	// Serializes rule deployments, requested by the server or redeployed locally.
+	deployMu sync.Mutex
+
+	// Guards the fields below, which change while the client is running.
+	mu   sync.RWMutex
+	org  string
+	zone string
+	// Recently processed requests, most recent last.
+	requests []*RequestRecord
+	// Last successfully deployed DeployRules request.
+	lastDeploy *pb.SensorRequest
+	// Running EVE monitor, if any.
+	monitor *EVEMonitor
 }
 
 // New creates a new Emitto sensor client.
-func New(ctx context.Context, fleetspeakSocket, org, zone, ruleFile, suricataSocket string, filestore filestore.FileStore) (*Client, error) {
-	h, err := host.New()
+func New(ctx context.Context, cfg *Config, filestore filestore.FileStore) (*Client, error) {
+	h, err := host.New(&host.Config{UUIDFile: cfg.UUIDFile, CaptureInterfaces: cfg.CaptureInterfaces})
 	if err != nil {
 		return nil, fmt.Errorf("failed to created new Host: %v", err)
 	}
-	return &Client{
-		FSClient:  fleetspeak.New(fleetspeakSocket),
-		ctrl:      suricata.NewController(suricataSocket),
+	fs, err := newTransport(cfg, h)
+	if err != nil {
+		return nil, err
+	}
+	l, err := ledger.Open(cfg.RequestLedger, maxLedgerRequests)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		FSClient:  fs,
+		ctrl:      suricata.NewController(cfg.SuricataSocket),
 		ruleStore: filestore,
 		host:      h,
-		org:       org,
-		zone:      zone,
-		ruleFile:  ruleFile,
-	}, nil
+		org:       cfg.Org,
+		zone:      cfg.Zone,
+		ruleFile:  cfg.RuleFile,
+		ruleKey:   cfg.RuleKey,
+		version:   cfg.Version,
+		labels:    cfg.Labels,
+		ledger:    l,
+
+		requestExpiry: cfg.RequestExpiry,
+		suricataLog:   cfg.SuricataLog,
+		configFile:    cfg.ConfigFile,
+		datasetDir:    cfg.DatasetDir,
+
+		allowedCommands: make(map[string]bool),
+	}
+	for _, cmd := range cfg.AllowedCommands {
+		c.allowedCommands[cmd] = true
+	}
+	if cfg.Queue != nil {
+		q, err := queue.New(cfg.Queue, fs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create outbound queue: %v", err)
+		}
+		go q.Run(ctx)
+		c.queue = q
+		c.FSClient = &queuedClient{FleetspeakClient: fs, q: q}
+	}
+	if cfg.SuricataBinary != "" {
+		c.validator = suricata.NewValidator(cfg.SuricataBinary, cfg.SuricataConfig)
+	}
+	return c, nil
+}
+
+// newTransport returns the client of the configured transport to the server.
+func newTransport(cfg *Config, h *host.Host) (FleetspeakClient, error) {
+	switch cfg.Transport {
+	case "", TransportFleetspeak:
+		return fleetspeak.New(cfg.FleetspeakSocket, cfg.AckTimeout), nil
+	case TransportGRPC:
+		id, err := uuid.Parse(h.UUID())
+		if err != nil {
+			return nil, fmt.Errorf("invalid sensor UUID: %v", err)
+		}
+		labels := cfg.Labels
+		if len(labels) == 0 {
+			labels = resources.LocationLabels(cfg.Org, cfg.Zone)
+		}
+		t, err := transport.New(&transport.Config{
+			Addr:       cfg.ServerAddr,
+			Creds:      cfg.ServerCreds,
+			ClientID:   id[:],
+			Labels:     labels,
+			AckTimeout: cfg.AckTimeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gRPC transport: %v", err)
+		}
+		return t, nil
+	default:
+		return nil, fmt.Errorf("unknown transport %q", cfg.Transport)
+	}
 }
 
 // ProcessMessage handles a Fleetspeak message from Emitto.
@@ -90,29 +301,75 @@ func (c *Client) ProcessMessage(ctx context.Context, m *fspb.Message) error {
 	if err := ptypes.UnmarshalAny(m.Data, &req); err != nil {
 		return fmt.Errorf("failed to unmarshal Fleetspeak message: %v", err)
 	}
+	received := time.Now()
+	// Fleetspeak may deliver a request more than once; only the first delivery is processed.
+	if e, ok := c.ledger.Lookup(req.GetId()); ok {
+		log.Warningf("Ignoring duplicate request %q, processed at %v", req.GetId(), e.Time)
+		s := status.New(codes.AlreadyExists, fmt.Sprintf("request already processed at %s with status %s", e.Time.Format(time.RFC3339), e.Code))
+		c.recordRequest(req.GetId(), "duplicate", received, s)
+		return c.sendResponse(req.GetId(), s, nil)
+	}
+	var (
+		typ  string
+		s    *status.Status
+		resp *pb.SensorResponse
+	)
 	switch t := req.Type.(type) {
 	case *pb.SensorRequest_DeployRules:
 		log.Infof("Received DeployRules request %q", req.GetId())
-		return c.sendResponse(req.GetId(), c.deployRules(ctx, t.DeployRules.GetRuleFile()))
+		typ, resp = "deploy_rules", new(pb.SensorResponse)
+		s = c.deploy(ctx, &req, resp)
 	case *pb.SensorRequest_ReloadRules:
 		log.Infof("Received ReloadRules request %q", req.GetId())
-		return c.sendResponse(req.GetId(), c.reloadRules())
+		typ, s = "reload_rules", c.reloadRules()
+	case *pb.SensorRequest_CollectDiagnostics:
+		log.Infof("Received CollectDiagnostics request %q", req.GetId())
+		typ, s = "collect_diagnostics", status.New(codes.OK, "OK")
+		resp = &pb.SensorResponse{Diagnostics: c.collectDiagnostics(t.CollectDiagnostics)}
+	case *pb.SensorRequest_RunCommand:
+		log.Infof("Received RunCommand request %q for command %q", req.GetId(), t.RunCommand.GetName())
+		typ, resp = "run_command", new(pb.SensorResponse)
+		s = c.runCommand(t.RunCommand, resp)
+	case *pb.SensorRequest_UpdateDataset:
+		log.Infof("Received UpdateDataset request %q for dataset %q", req.GetId(), t.UpdateDataset.GetName())
+		typ, s = "update_dataset", c.updateDataset(t.UpdateDataset)
 	default:
-		return c.sendResponse(req.GetId(), status.New(codes.InvalidArgument, fmt.Sprintf("unknown request type: %T", t)))
+		typ, s = "unknown", status.New(codes.InvalidArgument, fmt.Sprintf("unknown request type: %T", t))
 	}
+	c.recordRequest(req.GetId(), typ, received, s)
+	// Requests which failed transiently are processed again if they are delivered again.
+	if terminal(s.Code()) {
+		if err := c.ledger.Record(req.GetId(), s.Code().String()); err != nil {
+			log.Errorf("Failed to record request %q: %v", req.GetId(), err)
+		}
+	}
+	return c.sendResponse(req.GetId(), s, resp)
 }
 
-// sendResponse sends a SensorResponse to the Fleetspeak client.
-func (c *Client) sendResponse(id string, s *status.Status) error {
+// terminal returns false for the status codes of transient failures, e.g. rule files which could
+// not be downloaded yet or Suricata socket errors.
+func terminal(code codes.Code) bool {
+	switch code {
+	case codes.NotFound, codes.Unavailable, codes.Aborted, codes.Internal, codes.Unknown:
+		return false
+	default:
+		return true
+	}
+}
+
+// sendResponse sends a SensorResponse to the Fleetspeak client. Request specific details can be
+// provided in resp, which may be nil.
+func (c *Client) sendResponse(id string, s *status.Status, resp *pb.SensorResponse) error {
 	if err := c.host.Update(); err != nil {
 		return fmt.Errorf("failed to update host info: %v", err)
 	}
-	resp := &pb.SensorResponse{
-		Id:     id,
-		Time:   ptypes.TimestampNow(),
-		Host:   c.getHostInfo(),
-		Status: s.Proto(),
+	if resp == nil {
+		resp = new(pb.SensorResponse)
 	}
+	resp.Id = id
+	resp.Time = ptypes.TimestampNow()
+	resp.Host = c.getHostInfo()
+	resp.Status = s.Proto()
 	msg := &pb.SensorMessage{
 		Id: uuid.New().String(),
 		Type: &pb.SensorMessage_Response{
@@ -121,179 +378,90 @@ func (c *Client) sendResponse(id string, s *status.Status) error {
 	}
 	_, err := c.FSClient.SendMessage(msg)
 	if err != nil {
-		return fmt.Errorf("failed to send response: %+v", resp)
+		return fmt.Errorf("failed to send response (%s): %v", id, err)
 	}
 	log.V(1).Infof("Sent response: %+v", resp)
 	return nil
 }
 
-// deployRules fetches an updated rule file, replaces the existing rule file with the updated
-// version, and then issues a command for Suricata to reload the rule engine.
-func (c *Client) deployRules(ctx context.Context, ruleFile string) *status.Status {
-	rules, err := c.ruleStore.GetRuleFile(ctx, ruleFile)
-	if err != nil {
-		return status.New(codes.NotFound, fmt.Sprintf("failed to download rules from Cloud Storage: %v", err))
-	}
-	if _, err := os.Stat(c.ruleFile); os.IsNotExist(err) {
-		return status.New(codes.NotFound, fmt.Sprintf("rule file does not exist %q", c.ruleFile))
-	}
-	// Backup existing rule before writing.
-	backup, err := createBackup(c.ruleFile)
-	if err != nil {
-		return status.New(codes.Internal, fmt.Sprintf("failed to create backup for the rule file %q: %v", c.ruleFile, err))
-	}
-
-	if err := ioutil.WriteFile(c.ruleFile, rules, 0644); err != nil {
-		// Restore rule from backup file.
-		if err := copyFile(backup, c.ruleFile); err != nil {
-			log.Errorf("failed to restore rule file %q from backup: %q", c.ruleFile, backup)
-		}
-		return status.New(codes.Internal, fmt.Sprintf("failed to write rule file to disk: %v", err))
-	}
-	log.Infof("Successfully wrote new rules to %q", c.ruleFile)
-	if err := c.reloadRules(); err != nil {
-		return status.New(codes.FailedPrecondition, fmt.Sprintf("failed to reload Suricata rules: %v", err))
-	}
-	log.Info("Successfully reloaded Suricata rules")
-	return status.New(codes.OK, "OK")
-}
-
 // reloadRules reloads rules via the Suricata socket.
 func (c *Client) reloadRules() *status.Status {
 	if err := c.ctrl.ReloadRules(); err != nil {
-		return status.New(codes.FailedPrecondition, fmt.Sprintf("failed to issue socket command: %v", err))
+		return status.New(codes.Unavailable, fmt.Sprintf("failed to issue socket command: %v", err))
 	}
 	return status.New(codes.OK, "OK")
 }
 
-func (c *Client) getHostInfo() *pb.Host {
-	return &pb.Host{
-		Fqdn: c.host.FQDN(),
-		Ip:   c.host.IP().String(),
-		Org:  c.org,
-		Zone: c.zone,
+// runCommand runs an allowed Suricata socket command, setting its result in resp.
+func (c *Client) runCommand(cmd *pb.RunCommand, resp *pb.SensorResponse) *status.Status {
+	if !c.allowedCommands[cmd.GetName()] {
+		return status.New(codes.PermissionDenied, fmt.Sprintf("command %q is not allowed", cmd.GetName()))
 	}
-}
-
-// MonitorSurcataEVELog reads logs from last `d` duration of time,
-// and sends a notification alert if the threshold is exceeded
-func (c *Client) MonitorSurcataEVELog(d time.Duration, threshold int, logFile string) {
-	lines, err := readLines(logFile)
+	res, err := c.ctrl.RunCommand(cmd.GetName(), cmd.GetArgs())
 	if err != nil {
-		log.Errorf("Error reading lines from log file %v: %v", logFile, err)
-		return
+		return status.New(codes.Unavailable, fmt.Sprintf("failed to run command %q: %v", cmd.GetName(), err))
 	}
+	resp.CommandResult = string(res)
+	return status.New(codes.OK, "OK")
+}
 
-	now := time.Now().UTC()
-	alerts := 0
-	for i := len(lines) - 1; i >= 0; i-- {
-		if alerts > threshold {
-			break
-		}
-		eve, err := parseLogLine(lines[i])
-		if err != nil {
-			log.Error(err)
-			c.sendAlertNotification(err.Error())
-			return
-		}
-
-		// https://suricata.readthedocs.io/en/suricata-4.1.4/output/eve/eve-json-format.html#event-type-alert
-		if eve.EventType != "alert" {
-			continue
-		}
-
-		t, err := time.Parse("2006-01-02T15:04:05.999999+0000", eve.Timestamp)
-		if err != nil {
-			e := fmt.Errorf("Error parsing timestamp %q: %v", eve.Timestamp, err)
-			log.Error(e)
-			c.sendAlertNotification(e.Error())
-		}
-
-		// Only check last logs within specified duration.
-		if now.Sub(t) > d {
-			break
-		}
+// SetIdentity sets the sensor organization, zone and capture interfaces reported to the server.
+func (c *Client) SetIdentity(org, zone string, captureInterfaces []string) {
+	c.mu.Lock()
+	changed := c.org != org || c.zone != zone
+	c.org, c.zone = org, zone
+	c.mu.Unlock()
+	// Labels derived from the organization and zone follow their changes.
+	if l, ok := c.FSClient.(labeler); ok && changed && len(c.labels) == 0 {
+		l.SetLabels(resources.LocationLabels(org, zone))
+	}
+	c.host.SetCaptureInterfaces(captureInterfaces)
+	if err := c.host.Update(); err != nil {
+		log.Warningf("Failed to update host info: %v", err)
+	}
+}
 
-		alerts++
+func (c *Client) getHostInfo() *pb.Host {
+	c.mu.RLock()
+	org, zone := c.org, c.zone
+	c.mu.RUnlock()
+	h := &pb.Host{
+		Fqdn:          c.host.FQDN(),
+		Uuid:          c.host.UUID(),
+		Org:           org,
+		Zone:          zone,
+		Os:            c.host.OS(),
+		KernelVersion: c.host.KernelVersion(),
+	}
+	if ip := c.host.IP(); ip != nil {
+		h.Ip = ip.String()
 	}
-	// If the threshold is exceeded, send notification.
-	if alerts > threshold {
-		c.sendAlertNotification(fmt.Sprintf("Sensor has detected: %v alerts in last: %v", alerts, d))
+	for _, i := range c.host.Interfaces() {
+		h.Interfaces = append(h.Interfaces, &pb.NetworkInterface{
+			Name:      i.Name,
+			Mac:       i.MAC,
+			Addresses: i.Addresses,
+			Mtu:       int32(i.MTU),
+			Up:        i.Up,
+			Capture:   i.Capture,
+		})
 	}
+	return h
 }
 
 // SendHeartbeat sends a heartbeat message to the server.
 func (c *Client) SendHeartbeat() {
-	c.FSClient.SendMessage(&pb.SensorMessage{
+	if _, err := c.FSClient.SendMessage(&pb.SensorMessage{
 		Id: uuid.New().String(),
 		Type: &pb.SensorMessage_Heartbeat{
-			Heartbeat: &pb.Heartbeat{
-				Time: ptypes.TimestampNow(),
-				Host: c.getHostInfo(),
-			},
+			Heartbeat: c.heartbeat(),
 		},
-	})
-}
-
-func (c *Client) sendAlertNotification(alert string) {
-	c.FSClient.SendMessage(&pb.SensorMessage{
-		Id: uuid.New().String(),
-		Type: &pb.SensorMessage_Alert{
-			Alert: &pb.SensorAlert{
-				Time:   ptypes.TimestampNow(),
-				Host:   c.getHostInfo(),
-				Status: status.New(codes.Internal, alert).Proto(),
-			},
-		},
-	})
-}
-
-// createBackup creates copy of filename to filename_YYMMDD_number, with number randomly
-// chosen such that the file name is unique and returns the chosen file name.
-func createBackup(filename string) (string, error) {
-	// create backup file.
-	f, err := ioutil.TempFile(filepath.Dir(filename), fmt.Sprintf("%v_%v_", filepath.Base(filename), time.Now().Format("060102")))
-	if err != nil {
-		return "", err
-	}
-	backup := f.Name()
-	f.Close()
-	if err := copyFile(filename, backup); err != nil {
-		return "", err
+	}); err != nil {
+		log.Errorf("Failed to send heartbeat: %v", err)
 	}
-	return backup, nil
-}
-
-func copyFile(src, dest string) error {
-	data, err := ioutil.ReadFile(src)
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(dest, data, 0644)
-}
-
-// Note: currently suricata eve.json file is ~1.5GB, reading ~1GB file with 400k lines
-// takes less than a second, so this should not become performance bottleneck in near future.
-func readLines(path string) ([]string, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
 }
 
 // parseLogLine unmarshals json and returns as EVE protobuf message.
 func parseLogLine(line string) (*evepb.EVE, error) {
-	var eve evepb.EVE
-	if err := json.Unmarshal([]byte(line), &eve); err != nil {
-		return nil, fmt.Errorf("cannot unmarshal json string %q: %v", line, err)
-	}
-	return &eve, nil
+	return eve.Decode([]byte(line))
 }
@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -24,17 +25,79 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/sensor/eve"
+	"github.com/google/emitto/source/sensor/fleetspeak"
 	"github.com/google/emitto/source/sensor/host"
+	"github.com/google/emitto/source/sensor/ledger"
+	"github.com/google/emitto/source/sensor/suricata"
+	"github.com/google/emitto/source/sensor/suricata/socket"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
 )
 
-type fakeSuricataController struct{}
+// fakeSuricataController advances the reload time on every successful reload, unless stuck.
+type fakeSuricataController struct {
+	reloadErr  error
+	stuck      bool
+	reloads    int
+	reloadTime time.Time
+	stats      []*socket.RuleStats
+	failed     []*socket.FailedRule
+	health     *suricata.Health
+	healthErr  error
+	counters   socket.Counters
+	commands   []string
+	datasets   []string
+	datasetErr error
+}
+
+func (s *fakeSuricataController) ReloadRules() error {
+	s.reloads++
+	if s.reloadErr != nil {
+		return s.reloadErr
+	}
+	if !s.stuck {
+		s.reloadTime = s.reloadTime.Add(time.Second)
+	}
+	return nil
+}
+
+func (s *fakeSuricataController) LastReloadTime() (time.Time, error) { return s.reloadTime, nil }
+
+func (s *fakeSuricataController) RulesetStats() ([]*socket.RuleStats, error) { return s.stats, nil }
+
+func (s *fakeSuricataController) FailedRules() ([]*socket.FailedRule, error) { return s.failed, nil }
+
+func (s *fakeSuricataController) Health() (*suricata.Health, error) { return s.health, s.healthErr }
 
-func (s *fakeSuricataController) ReloadRules() error { return nil }
+func (s *fakeSuricataController) Version() (string, error) {
+	if s.health == nil {
+		return "", s.healthErr
+	}
+	return s.health.Version, s.healthErr
+}
+
+func (s *fakeSuricataController) DumpCounters() (socket.Counters, error) { return s.counters, nil }
+
+func (s *fakeSuricataController) DatasetAdd(name, typ, value string) error {
+	s.datasets = append(s.datasets, fmt.Sprintf("add %s %s %s", name, typ, value))
+	return s.datasetErr
+}
+
+func (s *fakeSuricataController) DatasetRemove(name, typ, value string) error {
+	s.datasets = append(s.datasets, fmt.Sprintf("remove %s %s %s", name, typ, value))
+	return s.datasetErr
+}
+
+func (s *fakeSuricataController) RunCommand(name string, args map[string]string) ([]byte, error) {
+	s.commands = append(s.commands, name)
+	return []byte(fmt.Sprintf(`{"command":%q}`, name)), nil
+}
 
 func TestSocketReloadRules(t *testing.T) {
 	c := &Client{
@@ -48,6 +111,56 @@ func TestSocketReloadRules(t *testing.T) {
 	}
 }
 
+func TestProcessRunCommand(t *testing.T) {
+	l, err := ledger.Open("", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeFleetspeakClient{}
+	ctrl := &fakeSuricataController{}
+	c := &Client{
+		FSClient:        f,
+		ctrl:            ctrl,
+		host:            &host.Host{},
+		ledger:          l,
+		allowedCommands: map[string]bool{"iface-stat": true},
+	}
+	for _, tt := range []struct {
+		id, command string
+		want        codes.Code
+		wantResult  string
+	}{
+		{id: "1", command: "iface-stat", want: codes.OK, wantResult: `{"command":"iface-stat"}`},
+		{id: "2", command: "shutdown", want: codes.PermissionDenied},
+	} {
+		data, err := ptypes.MarshalAny(&pb.SensorRequest{
+			Id: tt.id,
+			Type: &pb.SensorRequest_RunCommand{RunCommand: &pb.RunCommand{
+				Name: tt.command,
+				Args: map[string]string{"iface": "eth0"},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Msgs = nil
+		if err := c.ProcessMessage(context.Background(), &fspb.Message{Data: data}); err != nil {
+			t.Fatalf("ProcessMessage() failed: %v", err)
+		}
+		if len(f.Msgs) != 1 {
+			t.Fatalf("%s: got %d messages, want a response", tt.command, len(f.Msgs))
+		}
+		resp := f.Msgs[0].GetResponse()
+		if got := codes.Code(resp.GetStatus().GetCode()); got != tt.want || resp.GetCommandResult() != tt.wantResult {
+			t.Errorf("%s: got response %v, want code %v and result %q", tt.command, resp, tt.want, tt.wantResult)
+		}
+	}
+	// Disallowed commands never reach Suricata.
+	if diff := cmp.Diff([]string{"iface-stat"}, ctrl.commands); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestParseLogLine(t *testing.T) {
 	for _, tt := range []struct {
 		desc    string
@@ -83,9 +196,9 @@ func TestParseLogLine(t *testing.T) {
 	}
 }
 
-// Creates temporary log file with 10 logs 1 second apart, checks if the sensor emits the alert.
-func TestMonitorSurcataEVELog(t *testing.T) {
-
+// Appends 10 alerts 1 second apart to the EVE log, and checks that the sensor emits a single sensor
+// alert while the policy threshold remains exceeded.
+func TestEVEMonitor(t *testing.T) {
 	f := &fakeFleetspeakClient{}
 	client := &Client{
 		FSClient: f,
@@ -97,58 +210,286 @@ func TestMonitorSurcataEVELog(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(d)
+	logFile := filepath.Join(d, "eve.json")
+	if err := ioutil.WriteFile(logFile, nil, 0666); err != nil {
+		t.Fatalf("failed to write in file %v: %v", logFile, err)
+	}
 
-	var lines []string
-	now := time.Now().UTC()
-	for i := 0; i < 10; i++ {
-		timestamp := now.Add(time.Duration(-i) * time.Second).Format("2006-01-02T15:04:05.999999+0000")
-		lines = append(lines, fmt.Sprintf(`{"timestamp": %q, "event_type": "alert"}`, timestamp))
+	m, err := client.NewEVEMonitor(logFile, filepath.Join(d, "eve.checkpoint"), eve.DefaultPolicy(time.Minute, 9), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
 	}
 
-	logFile := filepath.Join(d, "eve.json")
-	if err := ioutil.WriteFile(logFile, []byte(strings.Join(lines, "\n")), 0666); err != nil {
-		t.Fatalf("failed to write in file %v: %v", logFile, err)
+	appendAlerts := func(n int) {
+		var lines []string
+		now := time.Now().UTC()
+		for i := 0; i < n; i++ {
+			timestamp := now.Add(time.Duration(-i) * time.Second).Format("2006-01-02T15:04:05.999999+0000")
+			lines = append(lines, fmt.Sprintf(`{"timestamp": %q, "event_type": "alert", "src_ip": "10.0.0.1", "alert": {"signature_id": 1, "signature": "a"}}`+"\n", timestamp))
+		}
+		lf, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer lf.Close()
+		if _, err := lf.WriteString(strings.Join(lines, "")); err != nil {
+			t.Fatalf("failed to write in file %v: %v", logFile, err)
+		}
 	}
-	client.MonitorSurcataEVELog(time.Minute, 9, logFile)
-	if len(f.Msgs) != 1 {
-		t.Errorf("TestMonitorSurcataEVELog(%v, %v, %v), expected to emit alert message", time.Minute, 9, logFile)
+
+	appendAlerts(9)
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
 	}
-	f.Msgs = f.Msgs[:0]
-	client.MonitorSurcataEVELog(time.Second, 1, logFile)
 	if len(f.Msgs) != 0 {
-		t.Errorf("TestMonitorSurcataEVELog(%v, %v, %v), emitted unexpected alert messages: %+v", time.Minute, 1, logFile, f.Msgs)
+		t.Errorf("Poll() emitted unexpected alert messages below the threshold: %+v", f.Msgs)
+	}
+	appendAlerts(1)
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Msgs) != 1 {
+		t.Fatalf("Poll() emitted %d alert messages, want 1", len(f.Msgs))
+	}
+	want := &pb.SensorAlert{
+		Policy:        "default",
+		AlertCount:    10,
+		WindowSeconds: 60,
+		TopSignatures: []*pb.AlertContributor{{Key: "1", Description: "a", Count: 10}},
+		TopHosts:      []*pb.AlertContributor{{Key: "10.0.0.1", Count: 10}},
+	}
+	got := f.Msgs[0].GetAlert()
+	got.Time, got.Host, got.Status = nil, nil, nil
+	if diff := cmp.Diff(want, got, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	appendAlerts(1)
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Msgs) != 1 {
+		t.Errorf("Poll() emitted %d alert messages while the threshold remained exceeded, want 1", len(f.Msgs))
+	}
+	gotStats := m.Stats()
+	gotStats.LastPoll = time.Time{}
+	wantStats := EVEStats{LogFile: logFile, Events: 11, Alerts: 11, SensorAlerts: 1}
+	if diff := cmp.Diff(wantStats, gotStats); diff != "" {
+		t.Errorf("stats mismatch (-want +got):\n%s", diff)
 	}
 }
 
-// Creates temporary file, calls createBackup and compares content of returned file to original file.
-func TestCreateBackup(t *testing.T) {
-	d, err := ioutil.TempDir("/tmp", "test")
+func TestEVEMonitorUpdate(t *testing.T) {
+	f := &fakeFleetspeakClient{}
+	client := &Client{
+		FSClient: f,
+		host:     &host.Host{},
+	}
+
+	d, err := ioutil.TempDir("/tmp", "eve-logs")
 	if err != nil {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(d)
+	logFiles := []string{filepath.Join(d, "eve.json"), filepath.Join(d, "eve2.json")}
+	for _, lf := range logFiles {
+		if err := ioutil.WriteFile(lf, nil, 0666); err != nil {
+			t.Fatalf("failed to write in file %v: %v", lf, err)
+		}
+	}
+	appendAlerts := func(logFile string, n int) {
+		lf, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer lf.Close()
+		timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.999999+0000")
+		for i := 0; i < n; i++ {
+			if _, err := fmt.Fprintf(lf, `{"timestamp": %q, "event_type": "alert", "alert": {"signature_id": 1}}`+"\n", timestamp); err != nil {
+				t.Fatalf("failed to write in file %v: %v", logFile, err)
+			}
+		}
+	}
+	poll := func(m *EVEMonitor, wantMsgs int) {
+		t.Helper()
+		if err := m.Poll(); err != nil {
+			t.Fatal(err)
+		}
+		if len(f.Msgs) != wantMsgs {
+			t.Fatalf("Poll() emitted %d alert messages in total, want %d", len(f.Msgs), wantMsgs)
+		}
+	}
+
+	m, err := client.NewEVEMonitor(logFiles[0], "", eve.DefaultPolicy(time.Minute, 2), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	poll(m, 0)
+	appendAlerts(logFiles[0], 2)
+	poll(m, 0)
+
+	// Alert counts are kept while the policy is unchanged.
+	if err := m.Update(logFiles[0], "", eve.DefaultPolicy(time.Minute, 2), nil); err != nil {
+		t.Fatal(err)
+	}
+	appendAlerts(logFiles[0], 1)
+	poll(m, 1)
+
+	// The new EVE log is followed from its end.
+	if err := m.Update(logFiles[1], "", eve.DefaultPolicy(time.Minute, 0), nil); err != nil {
+		t.Fatal(err)
+	}
+	appendAlerts(logFiles[0], 1)
+	poll(m, 1)
+	appendAlerts(logFiles[1], 1)
+	poll(m, 2)
+	if got := f.Msgs[1].GetAlert().GetAlertCount(); got != 1 {
+		t.Errorf("got an alert count of %d after the policy change, want 1", got)
+	}
+
+	// Invalid settings leave the monitor unchanged.
+	if err := m.Update(logFiles[0], "", eve.DefaultPolicy(time.Minute, 0), &AlertForwarding{SampleRate: 2, BatchSize: 1}); err == nil {
+		t.Error("expected an error for an invalid sample rate")
+	}
+	appendAlerts(logFiles[0], 5)
+	poll(m, 2)
+}
 
-	srcData := []byte{1, 2, 3}
-	srcFile := filepath.Join(d, "src.txt")
-	if err := ioutil.WriteFile(srcFile, srcData, 0644); err != nil {
-		t.Fatalf("failed to write in file %v: %v", srcFile, err)
+func TestEVEMonitorForwarding(t *testing.T) {
+	f := &fakeFleetspeakClient{}
+	client := &Client{
+		FSClient: f,
+		host:     &host.Host{},
 	}
-	backup, err := createBackup(srcFile)
+
+	d, err := ioutil.TempDir("/tmp", "eve-logs")
 	if err != nil {
-		t.Errorf("createBackup(%v) returned an error: %v", srcFile, err)
+		t.Fatalf("failed to create temp dir: %v", err)
 	}
-	backupData, err := ioutil.ReadFile(backup)
+	defer os.RemoveAll(d)
+	logFile := filepath.Join(d, "eve.json")
+	if err := ioutil.WriteFile(logFile, nil, 0666); err != nil {
+		t.Fatalf("failed to write in file %v: %v", logFile, err)
+	}
+
+	m, err := client.NewEVEMonitor(logFile, "", eve.DefaultPolicy(time.Minute, 1000), &AlertForwarding{
+		SampleRate: 1,
+		Burst:      5,
+		BatchSize:  2,
+	})
 	if err != nil {
-		t.Errorf("error reading file %v: %v", srcFile, err)
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
 	}
-	if diff := cmp.Diff(srcData, backupData); diff != "" {
-		t.Errorf("backup file does not match source (-want +got):\n%s", diff)
+
+	var lines []string
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.999999+0000")
+	for i := 1; i <= 7; i++ {
+		lines = append(lines, fmt.Sprintf(`{"timestamp": %q, "event_type": "alert", "alert": {"signature_id": %d}}`, timestamp, i))
+	}
+	lines = append(lines, fmt.Sprintf(`{"timestamp": %q, "event_type": "flow"}`, timestamp))
+	if err := ioutil.WriteFile(logFile, []byte(strings.Join(lines, "\n")+"\n"), 0666); err != nil {
+		t.Fatalf("failed to write in file %v: %v", logFile, err)
+	}
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The burst allows 5 alert events, sent in batches of 2.
+	type batch struct {
+		SIDs    []int32
+		Dropped int64
+	}
+	var got []batch
+	for _, msg := range f.Msgs {
+		var b batch
+		for _, e := range msg.GetEveAlerts().GetEvents() {
+			b.SIDs = append(b.SIDs, e.GetAlert().GetSignatureId())
+		}
+		b.Dropped = msg.GetEveAlerts().GetDropped()
+		got = append(got, b)
+	}
+	want := []batch{
+		{SIDs: []int32{1, 2}},
+		{SIDs: []int32{3, 4}},
+		{SIDs: []int32{5}, Dropped: 2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportRuleHits(t *testing.T) {
+	f := &fakeFleetspeakClient{}
+	client := &Client{
+		FSClient: f,
+		host:     &host.Host{},
+	}
+
+	d, err := ioutil.TempDir("/tmp", "eve-logs")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(d)
+	logFile := filepath.Join(d, "eve.json")
+	if err := ioutil.WriteFile(logFile, nil, 0666); err != nil {
+		t.Fatalf("failed to write in file %v: %v", logFile, err)
+	}
+
+	m, err := client.NewEVEMonitor(logFile, "", eve.DefaultPolicy(time.Minute, 1000), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	// No report is sent without hits.
+	m.ReportRuleHits()
+	if len(f.Msgs) != 0 {
+		t.Fatalf("ReportRuleHits() sent %d messages without hits, want 0", len(f.Msgs))
+	}
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.999999+0000")
+	lines := []string{
+		fmt.Sprintf(`{"timestamp": %q, "event_type": "alert", "alert": {"signature_id": 2, "rev": 1}}`, timestamp),
+		fmt.Sprintf(`{"timestamp": %q, "event_type": "alert", "alert": {"signature_id": 1, "rev": 4}}`, timestamp),
+		fmt.Sprintf(`{"timestamp": %q, "event_type": "alert", "alert": {"signature_id": 2, "rev": 1}}`, timestamp),
+	}
+	if err := ioutil.WriteFile(logFile, []byte(strings.Join(lines, "\n")+"\n"), 0666); err != nil {
+		t.Fatalf("failed to write in file %v: %v", logFile, err)
+	}
+	if err := m.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	m.ReportRuleHits()
+	if len(f.Msgs) != 1 {
+		t.Fatalf("ReportRuleHits() sent %d messages, want 1", len(f.Msgs))
+	}
+	want := []*pb.RuleHit{
+		{SignatureId: 1, Rev: 4, Count: 1},
+		{SignatureId: 2, Rev: 1, Count: 2},
+	}
+	if diff := cmp.Diff(want, f.Msgs[0].GetRuleHits().GetHits(), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
 	}
 }
 
 type fakeFleetspeakClient struct {
 	FleetspeakClient
-	Msgs []*pb.SensorMessage
+	Msgs  []*pb.SensorMessage
+	state fleetspeak.State
+}
+
+func (c *fakeFleetspeakClient) State() fleetspeak.State {
+	return c.state
 }
 
 func (c *fakeFleetspeakClient) SendMessage(m *pb.SensorMessage) (string, error) {
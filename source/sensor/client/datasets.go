@@ -0,0 +1,241 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/emitto/source/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+// installedDataset is a dataset installed by a deployment, with what it replaced.
+type installedDataset struct {
+	name string
+	typ  resources.DatasetType
+	path string
+	// Members of the replaced dataset file, and whether it existed.
+	old     []string
+	existed bool
+	// Members added and removed live.
+	add, remove []string
+}
+
+// installDatasets rewrites the files of the deployed datasets, so that Suricata loads their full
+// content on restart, and applies the differences to the datasets loaded by the running Suricata.
+// Datasets which are not loaded yet are loaded from their files on the next ruleset reload. The
+// installed datasets are returned for rollbackDatasets. On error, nothing remains installed.
+func (c *Client) installDatasets(datasets []*pb.Dataset) ([]*installedDataset, *status.Status) {
+	if len(datasets) == 0 {
+		return nil, status.New(codes.OK, "OK")
+	}
+	if c.datasetDir == "" {
+		return nil, status.New(codes.FailedPrecondition, fmt.Sprintf("refusing to install %d dataset(s) without a dataset directory", len(datasets)))
+	}
+	// All datasets are checked before any file is written.
+	members := make([][]string, len(datasets))
+	for i, d := range datasets {
+		if err := resources.ValidateDatasetName(d.GetName()); err != nil {
+			return nil, status.New(codes.InvalidArgument, err.Error())
+		}
+		var err error
+		if members[i], err = resources.NormalizeDatasetMembers(resources.DatasetType(d.GetType()), d.GetMembers()); err != nil {
+			return nil, status.New(codes.InvalidArgument, fmt.Sprintf("invalid member of dataset %q: %v", d.GetName(), err))
+		}
+	}
+	var installed []*installedDataset
+	for i, d := range datasets {
+		in := &installedDataset{
+			name: d.GetName(),
+			typ:  resources.DatasetType(d.GetType()),
+			path: c.datasetFile(d.GetName()),
+		}
+		_, err := os.Stat(in.path)
+		in.existed = err == nil
+		if in.old, err = readDataset(in.path, in.typ); err != nil {
+			c.rollbackDatasets(installed)
+			return nil, status.New(codes.Internal, fmt.Sprintf("failed to read dataset file: %v", err))
+		}
+		if err := writeDataset(in.path, in.typ, members[i]); err != nil {
+			c.rollbackDatasets(installed)
+			return nil, status.New(codes.Internal, fmt.Sprintf("failed to write dataset file: %v", err))
+		}
+		installed = append(installed, in)
+		in.add, in.remove = diffMembers(in.old, members[i])
+		if n, err := c.applyDataset(in.name, in.typ, in.add, in.remove); err != nil {
+			log.Warningf("Failed to apply %d change(s) to dataset %q live: %v", n, in.name, err)
+		}
+		log.Infof("Installed dataset %q with %d member(s) to %q", in.name, len(members[i]), in.path)
+	}
+	return installed, status.New(codes.OK, "OK")
+}
+
+// rollbackDatasets restores the dataset files replaced by installDatasets, and reverts the changes
+// applied live. Failures are logged, so that the rollback of the remaining datasets proceeds.
+func (c *Client) rollbackDatasets(installed []*installedDataset) {
+	for i := len(installed) - 1; i >= 0; i-- {
+		in := installed[i]
+		var err error
+		if in.existed {
+			err = writeDataset(in.path, in.typ, in.old)
+		} else {
+			err = os.Remove(in.path)
+		}
+		if err != nil {
+			log.Errorf("Failed to restore dataset file %q: %v", in.path, err)
+		}
+		if n, err := c.applyDataset(in.name, in.typ, in.remove, in.add); err != nil {
+			log.Warningf("Failed to revert %d change(s) to dataset %q live: %v", n, in.name, err)
+		}
+		log.Infof("Rolled back dataset %q", in.name)
+	}
+}
+
+// updateDataset applies changes to a dataset live via the Suricata socket, and records them in the
+// dataset file so that they survive restarts.
+func (c *Client) updateDataset(req *pb.UpdateDataset) *status.Status {
+	if c.datasetDir == "" {
+		return status.New(codes.FailedPrecondition, "no dataset directory configured")
+	}
+	if err := resources.ValidateDatasetName(req.GetName()); err != nil {
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+	t := resources.DatasetType(req.GetType())
+	add, err := resources.NormalizeDatasetMembers(t, req.GetAdd())
+	if err != nil {
+		return status.New(codes.InvalidArgument, fmt.Sprintf("invalid member to add: %v", err))
+	}
+	remove, err := resources.NormalizeDatasetMembers(t, req.GetRemove())
+	if err != nil {
+		return status.New(codes.InvalidArgument, fmt.Sprintf("invalid member to remove: %v", err))
+	}
+	path := c.datasetFile(req.GetName())
+	members, err := readDataset(path, t)
+	if err != nil {
+		return status.New(codes.Internal, fmt.Sprintf("failed to read dataset file: %v", err))
+	}
+	if err := writeDataset(path, t, resources.UpdateDatasetMembers(members, add, remove)); err != nil {
+		return status.New(codes.Internal, fmt.Sprintf("failed to write dataset file: %v", err))
+	}
+	if n, err := c.applyDataset(req.GetName(), t, add, remove); err != nil {
+		return status.New(codes.FailedPrecondition, fmt.Sprintf("updated dataset file %q, but failed to apply %d change(s) live: %v", path, n, err))
+	}
+	log.Infof("Updated dataset %q: added %d and removed %d member(s)", req.GetName(), len(add), len(remove))
+	return status.New(codes.OK, "OK")
+}
+
+// applyDataset adds and removes members of a dataset loaded by Suricata. It returns the number of
+// changes which failed and the first error.
+func (c *Client) applyDataset(name string, t resources.DatasetType, add, remove []string) (int, error) {
+	var (
+		failed   int
+		firstErr error
+	)
+	record := func(err error) {
+		if err != nil {
+			if failed == 0 {
+				firstErr = err
+			}
+			failed++
+		}
+	}
+	for _, m := range remove {
+		record(c.ctrl.DatasetRemove(name, string(t), m))
+	}
+	for _, m := range add {
+		record(c.ctrl.DatasetAdd(name, string(t), m))
+	}
+	return failed, firstErr
+}
+
+// datasetFile returns the path of the file of a dataset.
+func (c *Client) datasetFile(name string) string {
+	return filepath.Join(c.datasetDir, name+".lst")
+}
+
+// readDataset returns the members in a dataset file. A missing file has no members.
+func readDataset(path string, t resources.DatasetType) ([]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var members []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if s.Text() == "" {
+			continue
+		}
+		m, err := resources.DecodeDatasetMember(t, s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		members = append(members, m)
+	}
+	return members, s.Err()
+}
+
+// writeDataset atomically replaces a dataset file with the members, one per line.
+func writeDataset(path string, t resources.DatasetType, members []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, m := range members {
+		buf.WriteString(resources.EncodeDatasetMember(t, m))
+		buf.WriteByte('\n')
+	}
+	candidate, err := writeCandidate(path, buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(candidate, path); err != nil {
+		os.Remove(candidate)
+		return err
+	}
+	return nil
+}
+
+// diffMembers returns the members added and removed between two versions of a dataset.
+func diffMembers(old, new []string) (add, remove []string) {
+	inOld := make(map[string]bool)
+	for _, m := range old {
+		inOld[m] = true
+	}
+	inNew := make(map[string]bool)
+	for _, m := range new {
+		inNew[m] = true
+		if !inOld[m] {
+			add = append(add, m)
+		}
+	}
+	for _, m := range old {
+		if !inNew[m] {
+			remove = append(remove, m)
+		}
+	}
+	return add, remove
+}
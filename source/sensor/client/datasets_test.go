@@ -0,0 +1,260 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/sensor/host"
+	"github.com/google/emitto/source/sensor/ledger"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestDeployRulesDatasets(t *testing.T) {
+	ctx := context.Background()
+	d, err := ioutil.TempDir("", "datasets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	ruleFile := filepath.Join(d, "emitto.rules")
+	if err := ioutil.WriteFile(ruleFile, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	datasetDir := filepath.Join(d, "data")
+	if err := os.Mkdir(datasetDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(datasetDir, "bad_ips.lst"), []byte("10.0.0.1\n10.0.0.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := filestore.NewMemoryFileStore()
+	if err := fs.AddRuleFile(ctx, "a/rules", []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &fakeSuricataController{}
+	c := &Client{
+		ctrl:       ctrl,
+		ruleStore:  fs,
+		ruleFile:   ruleFile,
+		datasetDir: datasetDir,
+	}
+	req := &pb.DeployRules{RuleFile: "a/rules", Datasets: []*pb.Dataset{
+		{Name: "bad_ips", Type: "ipv4", Members: []string{"10.0.0.2", "10.0.0.3"}},
+		{Name: "bad_domains", Type: "string", Members: []string{"evil.example.com"}},
+	}}
+	if got := c.deployRules(ctx, req, new(pb.SensorResponse)); got.Code() != codes.OK {
+		t.Fatalf("deployRules() got %v, want OK", got.Proto())
+	}
+	if got, want := readFile(t, filepath.Join(datasetDir, "bad_ips.lst")), "10.0.0.2\n10.0.0.3\n"; got != want {
+		t.Errorf("got bad_ips file %q, want %q", got, want)
+	}
+	if got, want := readFile(t, filepath.Join(datasetDir, "bad_domains.lst")), "ZXZpbC5leGFtcGxlLmNvbQ==\n"; got != want {
+		t.Errorf("got bad_domains file %q, want %q", got, want)
+	}
+	// Only the differences with the previous files are applied live.
+	want := []string{
+		"remove bad_ips ipv4 10.0.0.1",
+		"add bad_ips ipv4 10.0.0.3",
+		"add bad_domains string evil.example.com",
+	}
+	if diff := cmp.Diff(want, ctrl.datasets); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Datasets are restored if the rules are invalid or fail to reload.
+	for _, tt := range []struct {
+		desc      string
+		validator RuleValidator
+		ctrl      *fakeSuricataController
+		want      codes.Code
+	}{
+		{
+			desc:      "invalid rules",
+			validator: &fakeValidator{err: errors.New("exec failed")},
+			ctrl:      &fakeSuricataController{},
+			want:      codes.Internal,
+		},
+		{
+			desc: "reload failure",
+			ctrl: &fakeSuricataController{stuck: true},
+			want: codes.Aborted,
+		},
+	} {
+		c.validator, c.ctrl = tt.validator, tt.ctrl
+		req := &pb.DeployRules{RuleFile: "a/rules", Datasets: []*pb.Dataset{
+			{Name: "bad_ips", Type: "ipv4", Members: []string{"10.0.0.4"}},
+			{Name: "bad_hashes", Type: "string", Members: []string{"x"}},
+		}}
+		if got := c.deployRules(ctx, req, new(pb.SensorResponse)); got.Code() != tt.want {
+			t.Errorf("%s: deployRules() got %v, want code %v", tt.desc, got.Proto(), tt.want)
+		}
+		if got, want := readFile(t, filepath.Join(datasetDir, "bad_ips.lst")), "10.0.0.2\n10.0.0.3\n"; got != want {
+			t.Errorf("%s: got bad_ips file %q, want %q", tt.desc, got, want)
+		}
+		if _, err := os.Stat(filepath.Join(datasetDir, "bad_hashes.lst")); !os.IsNotExist(err) {
+			t.Errorf("%s: got err=%v for the bad_hashes file, want it removed", tt.desc, err)
+		}
+		want := []string{
+			"remove bad_ips ipv4 10.0.0.2",
+			"remove bad_ips ipv4 10.0.0.3",
+			"add bad_ips ipv4 10.0.0.4",
+			"add bad_hashes string x",
+			"remove bad_hashes string x",
+			"remove bad_ips ipv4 10.0.0.4",
+			"add bad_ips ipv4 10.0.0.2",
+			"add bad_ips ipv4 10.0.0.3",
+		}
+		if diff := cmp.Diff(want, tt.ctrl.datasets); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+	c.validator, c.ctrl = nil, ctrl
+
+	for _, tt := range []struct {
+		desc       string
+		datasetDir string
+		dataset    *pb.Dataset
+		want       codes.Code
+	}{
+		{
+			desc:    "no dataset directory",
+			dataset: &pb.Dataset{Name: "bad_ips", Type: "ipv4"},
+			want:    codes.FailedPrecondition,
+		},
+		{
+			desc:       "invalid name",
+			datasetDir: datasetDir,
+			dataset:    &pb.Dataset{Name: "../bad_ips", Type: "ipv4"},
+			want:       codes.InvalidArgument,
+		},
+		{
+			desc:       "invalid member",
+			datasetDir: datasetDir,
+			dataset:    &pb.Dataset{Name: "bad_ips", Type: "ipv4", Members: []string{"evil.example.com"}},
+			want:       codes.InvalidArgument,
+		},
+	} {
+		c.datasetDir = tt.datasetDir
+		req := &pb.DeployRules{RuleFile: "a/rules", Datasets: []*pb.Dataset{tt.dataset}}
+		if got := c.deployRules(ctx, req, new(pb.SensorResponse)); got.Code() != tt.want {
+			t.Errorf("%s: deployRules() got %v, want code %v", tt.desc, got.Proto(), tt.want)
+		}
+	}
+}
+
+func TestProcessUpdateDataset(t *testing.T) {
+	d, err := ioutil.TempDir("", "datasets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	path := filepath.Join(d, "bad_ips.lst")
+	if err := ioutil.WriteFile(path, []byte("10.0.0.1\n10.0.0.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := ledger.Open("", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeFleetspeakClient{}
+	ctrl := &fakeSuricataController{}
+	c := &Client{
+		FSClient:   f,
+		ctrl:       ctrl,
+		host:       &host.Host{},
+		ledger:     l,
+		datasetDir: d,
+	}
+	for _, tt := range []struct {
+		id         string
+		req        *pb.UpdateDataset
+		datasetErr error
+		want       codes.Code
+		wantFile   string
+	}{
+		{
+			id:       "1",
+			req:      &pb.UpdateDataset{Name: "bad_ips", Type: "ipv4", Add: []string{"10.0.0.3"}, Remove: []string{"10.0.0.1"}},
+			want:     codes.OK,
+			wantFile: "10.0.0.2\n10.0.0.3\n",
+		},
+		{
+			id:       "2",
+			req:      &pb.UpdateDataset{Name: "bad_ips", Type: "ipv4", Add: []string{"10.0.0.4"}},
+			want:     codes.FailedPrecondition,
+			wantFile: "10.0.0.2\n10.0.0.3\n10.0.0.4\n",
+			// The file is updated even if the dataset is not loaded by Suricata.
+			datasetErr: errors.New("set not found"),
+		},
+		{
+			id:       "3",
+			req:      &pb.UpdateDataset{Name: "bad_ips", Type: "ipv4", Add: []string{"evil.example.com"}},
+			want:     codes.InvalidArgument,
+			wantFile: "10.0.0.2\n10.0.0.3\n10.0.0.4\n",
+		},
+	} {
+		data, err := ptypes.MarshalAny(&pb.SensorRequest{
+			Id:   tt.id,
+			Type: &pb.SensorRequest_UpdateDataset{UpdateDataset: tt.req},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Msgs = nil
+		ctrl.datasetErr = tt.datasetErr
+		if err := c.ProcessMessage(context.Background(), &fspb.Message{Data: data}); err != nil {
+			t.Fatalf("ProcessMessage() failed: %v", err)
+		}
+		if len(f.Msgs) != 1 {
+			t.Fatalf("request %s: got %d messages, want a response", tt.id, len(f.Msgs))
+		}
+		if got := codes.Code(f.Msgs[0].GetResponse().GetStatus().GetCode()); got != tt.want {
+			t.Errorf("request %s: got code %v, want %v", tt.id, got, tt.want)
+		}
+		if got := readFile(t, path); got != tt.wantFile {
+			t.Errorf("request %s: got dataset file %q, want %q", tt.id, got, tt.wantFile)
+		}
+	}
+	want := []string{
+		"remove bad_ips ipv4 10.0.0.1",
+		"add bad_ips ipv4 10.0.0.3",
+		"add bad_ips ipv4 10.0.0.4",
+	}
+	if diff := cmp.Diff(want, ctrl.datasets); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
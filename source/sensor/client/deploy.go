@@ -0,0 +1,333 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/sensor/suricata"
+	"github.com/google/emitto/source/signing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+// maxBackups is the number of rule file backups kept in the rule directory.
+const maxBackups = 5
+
+// deploy checks and deploys the rules of a DeployRules request. If the deployment succeeds, the
+// generation of its location is recorded and the request is kept for RedeployLast.
+func (c *Client) deploy(ctx context.Context, req *pb.SensorRequest, resp *pb.SensorResponse) *status.Status {
+	c.deployMu.Lock()
+	defer c.deployMu.Unlock()
+	d := req.GetDeployRules()
+	if s := c.checkDeploy(req, d); s.Code() != codes.OK {
+		log.Warningf("Rejected DeployRules request %q: %s", req.GetId(), s.Message())
+		return s
+	}
+	s := c.deployRules(ctx, d, resp)
+	if s.Code() != codes.OK {
+		return s
+	}
+	if err := c.ledger.SetGeneration(d.GetLocation(), d.GetGeneration()); err != nil {
+		log.Errorf("Failed to record deployment generation: %v", err)
+	}
+	c.mu.Lock()
+	c.lastDeploy = req
+	c.mu.Unlock()
+	return s
+}
+
+// deployRules fetches an updated rule file, verifies and validates it, and installs it as a
+// transaction: the new rule file is swapped in atomically and Suricata is reloaded. If the reload
+// fails, the previous rule file is restored and Suricata is reloaded again. Datasets of the
+// deployment are installed beforehand, and restored with the rule file if the deployment fails.
+// Rules rejected during validation, and the ruleset statistics after a successful reload, are
+// added to resp.
+func (c *Client) deployRules(ctx context.Context, req *pb.DeployRules, resp *pb.SensorResponse) *status.Status {
+	rules, err := c.ruleStore.GetRuleFile(ctx, req.GetRuleFile())
+	if err != nil {
+		return status.New(codes.NotFound, fmt.Sprintf("failed to download rules from Cloud Storage: %v", err))
+	}
+	if s := c.verifyRules(rules, req); s.Code() != codes.OK {
+		return s
+	}
+	if _, err := os.Stat(c.ruleFile); os.IsNotExist(err) {
+		return status.New(codes.NotFound, fmt.Sprintf("rule file does not exist %q", c.ruleFile))
+	}
+	// Datasets are installed first, since the rules may load them.
+	installed, s := c.installDatasets(req.GetDatasets())
+	if s.Code() != codes.OK {
+		return s
+	}
+	abort := func(s *status.Status) *status.Status {
+		c.rollbackDatasets(installed)
+		return s
+	}
+	candidate, err := writeCandidate(c.ruleFile, rules)
+	if err != nil {
+		return abort(status.New(codes.Internal, fmt.Sprintf("failed to write candidate rule file: %v", err)))
+	}
+	defer os.Remove(candidate) // No-op once the candidate is installed.
+
+	if s := c.validateRules(candidate, resp); s.Code() != codes.OK {
+		return abort(s)
+	}
+	// Backup existing rule before installing.
+	backup, err := createBackup(c.ruleFile)
+	if err != nil {
+		return abort(status.New(codes.Internal, fmt.Sprintf("failed to create backup for the rule file %q: %v", c.ruleFile, err)))
+	}
+	if err := rotateBackups(c.ruleFile, maxBackups); err != nil {
+		log.Warningf("Failed to rotate backups for %q: %v", c.ruleFile, err)
+	}
+	if err := os.Rename(candidate, c.ruleFile); err != nil {
+		return abort(status.New(codes.Internal, fmt.Sprintf("failed to install rule file: %v", err)))
+	}
+	log.Infof("Successfully installed new rules to %q", c.ruleFile)
+
+	s = c.reloadAndCheck()
+	if s.Code() == codes.OK {
+		log.Info("Successfully reloaded Suricata rules")
+		c.addRulesetStats(resp)
+		return s
+	}
+	log.Errorf("Failed to reload new rules, rolling back to %q: %v", backup, s.Message())
+	// The datasets are restored first, so that the previous rules are reloaded with them.
+	c.rollbackDatasets(installed)
+	if err := c.rollback(backup); err != nil {
+		return status.New(codes.Internal, fmt.Sprintf("failed to reload Suricata rules: %v; rollback failed: %v", s.Message(), err))
+	}
+	return status.New(codes.Aborted, fmt.Sprintf("failed to reload Suricata rules: %v; rolled back to previous rules", s.Message()))
+}
+
+// checkDeploy rejects DeployRules requests which expired, or which are older than the deployment
+// installed for their location. Deployments without a generation are not ordered.
+func (c *Client) checkDeploy(req *pb.SensorRequest, d *pb.DeployRules) *status.Status {
+	if c.requestExpiry > 0 && req.GetTime() != nil {
+		t, err := ptypes.Timestamp(req.GetTime())
+		if err != nil {
+			return status.New(codes.InvalidArgument, fmt.Sprintf("invalid request time: %v", err))
+		}
+		if age := time.Since(t); age > c.requestExpiry {
+			return status.New(codes.DeadlineExceeded, fmt.Sprintf("refusing stale deployment sent at %s, older than %v", t.Format(time.RFC3339), c.requestExpiry))
+		}
+	}
+	if g := d.GetGeneration(); g > 0 {
+		if installed := c.ledger.Generation(d.GetLocation()); g < installed {
+			return status.New(codes.FailedPrecondition, fmt.Sprintf("refusing stale deployment of generation %d for location %q, older than installed generation %d", g, d.GetLocation(), installed))
+		}
+	}
+	return status.New(codes.OK, "OK")
+}
+
+// verifyRules checks the downloaded rules against the digest and signature of the request using
+// the pinned key. Rule files which were tampered with or truncated are rejected with DataLoss.
+func (c *Client) verifyRules(rules []byte, req *pb.DeployRules) *status.Status {
+	if c.ruleKey == nil {
+		return status.New(codes.OK, "OK")
+	}
+	if len(req.GetSha256()) == 0 || len(req.GetSignature()) == 0 {
+		return status.New(codes.Unauthenticated, fmt.Sprintf("rule file %q is not signed", req.GetRuleFile()))
+	}
+	if err := signing.Verify(c.ruleKey, rules, &signing.Bundle{SHA256: req.GetSha256(), Signature: req.GetSignature()}); err != nil {
+		return status.New(codes.DataLoss, fmt.Sprintf("failed to verify rule file %q: %v", req.GetRuleFile(), err))
+	}
+	log.Infof("Verified signature of rule file %q", req.GetRuleFile())
+	return status.New(codes.OK, "OK")
+}
+
+// validateRules tests the candidate rule file with Suricata. Rules rejected by Suricata are added
+// to resp.
+func (c *Client) validateRules(candidate string, resp *pb.SensorResponse) *status.Status {
+	if c.validator == nil {
+		return status.New(codes.OK, "OK")
+	}
+	err := c.validator.Validate(candidate)
+	if err == nil {
+		log.Infof("Successfully validated new rules for %q", c.ruleFile)
+		return status.New(codes.OK, "OK")
+	}
+	verr, ok := err.(*suricata.ValidationError)
+	if !ok {
+		return status.New(codes.Internal, fmt.Sprintf("failed to validate rules: %v", err))
+	}
+	for _, e := range verr.RuleErrors {
+		resp.RuleErrors = append(resp.RuleErrors, &pb.RuleError{
+			Line:    int32(e.Line),
+			Sid:     e.SID,
+			Rule:    e.Rule,
+			Message: e.Message,
+		})
+	}
+	return status.New(codes.InvalidArgument, fmt.Sprintf("refusing to install invalid rules: %v", verr))
+}
+
+// reloadAndCheck reloads the rules and confirms that Suricata completed the reload by checking
+// that the last reload time has advanced. The check is skipped if the reload time is unavailable.
+func (c *Client) reloadAndCheck() *status.Status {
+	before, err := c.ctrl.LastReloadTime()
+	if err != nil {
+		log.Warningf("Unable to get last ruleset reload time; skipping reload check: %v", err)
+	}
+	if s := c.reloadRules(); s.Code() != codes.OK {
+		return s
+	}
+	if err != nil {
+		return status.New(codes.OK, "OK")
+	}
+	after, err := c.ctrl.LastReloadTime()
+	if err != nil {
+		return status.New(codes.Unknown, fmt.Sprintf("failed to confirm ruleset reload: %v", err))
+	}
+	if !after.After(before) {
+		return status.New(codes.FailedPrecondition, fmt.Sprintf("ruleset was not reloaded; last reload at %v", after))
+	}
+	return status.New(codes.OK, "OK")
+}
+
+// addRulesetStats adds the Suricata ruleset statistics and the rules which failed to load to resp.
+// Suricata skips rules which fail to load during a reload, so these are not deployment errors.
+func (c *Client) addRulesetStats(resp *pb.SensorResponse) {
+	stats, err := c.ctrl.RulesetStats()
+	if err != nil {
+		log.Warningf("Failed to get ruleset stats: %v", err)
+	}
+	for _, st := range stats {
+		resp.RulesetStats = append(resp.RulesetStats, &pb.RulesetStats{
+			TenantId:     int32(st.ID),
+			RulesLoaded:  st.RulesLoaded,
+			RulesFailed:  st.RulesFailed,
+			RulesSkipped: st.RulesSkipped,
+		})
+	}
+	failed, err := c.ctrl.FailedRules()
+	if err != nil {
+		log.Warningf("Failed to get failed rules: %v", err)
+	}
+	for _, r := range failed {
+		resp.FailedRules = append(resp.FailedRules, &pb.RuleError{
+			Line:     int32(r.Line),
+			Sid:      suricata.ParseSID(r.Rule),
+			Rule:     r.Rule,
+			Filename: r.Filename,
+		})
+	}
+	if len(failed) > 0 {
+		log.Warningf("Suricata failed to load %d rule(s)", len(failed))
+	}
+}
+
+// rollback restores the rule file from the backup and reloads Suricata.
+func (c *Client) rollback(backup string) error {
+	data, err := ioutil.ReadFile(backup)
+	if err != nil {
+		return fmt.Errorf("failed to read backup %q: %v", backup, err)
+	}
+	restored, err := writeCandidate(c.ruleFile, data)
+	if err != nil {
+		return fmt.Errorf("failed to write restored rule file: %v", err)
+	}
+	if err := os.Rename(restored, c.ruleFile); err != nil {
+		os.Remove(restored)
+		return fmt.Errorf("failed to restore rule file %q: %v", c.ruleFile, err)
+	}
+	if s := c.reloadRules(); s.Code() != codes.OK {
+		return fmt.Errorf("failed to reload restored rules: %v", s.Message())
+	}
+	log.Infof("Restored rule file %q from %q", c.ruleFile, backup)
+	return nil
+}
+
+// writeCandidate writes data to a new file in the same directory as filename, so that it can be
+// atomically renamed over filename, and returns the chosen file name.
+func writeCandidate(filename string, data []byte) (string, error) {
+	f, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".candidate_")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0644)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// createBackup creates copy of filename to filename_YYMMDD_number, with number randomly
+// chosen such that the file name is unique and returns the chosen file name.
+func createBackup(filename string) (string, error) {
+	// create backup file.
+	f, err := ioutil.TempFile(filepath.Dir(filename), fmt.Sprintf("%v_%v_", filepath.Base(filename), time.Now().Format("060102")))
+	if err != nil {
+		return "", err
+	}
+	backup := f.Name()
+	f.Close()
+	if err := copyFile(filename, backup); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// rotateBackups removes all but the newest keep backups of filename.
+func rotateBackups(filename string, keep int) error {
+	files, err := ioutil.ReadDir(filepath.Dir(filename))
+	if err != nil {
+		return err
+	}
+	var backups []os.FileInfo
+	for _, f := range files {
+		if !f.IsDir() && strings.HasPrefix(f.Name(), filepath.Base(filename)+"_") {
+			backups = append(backups, f)
+		}
+	}
+	if len(backups) <= keep {
+		return nil
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().After(backups[j].ModTime())
+	})
+	for _, f := range backups[keep:] {
+		if err := os.Remove(filepath.Join(filepath.Dir(filename), f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dest string) error {
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dest, data, 0644)
+}
@@ -0,0 +1,480 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/sensor/host"
+	"github.com/google/emitto/source/sensor/ledger"
+	"github.com/google/emitto/source/sensor/suricata"
+	"github.com/google/emitto/source/sensor/suricata/socket"
+	"github.com/google/emitto/source/signing"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/ed25519"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+)
+
+func TestVerifyRules(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := []byte("alert tcp any any -> any any (msg:\"test\"; sid:1;)\n")
+	b := signing.Sign(priv, rules)
+
+	for _, tt := range []struct {
+		desc  string
+		key   ed25519.PublicKey
+		rules []byte
+		req   *pb.DeployRules
+		want  codes.Code
+	}{
+		{
+			desc:  "no pinned key",
+			rules: rules,
+			req:   &pb.DeployRules{RuleFile: "a"},
+			want:  codes.OK,
+		},
+		{
+			desc:  "valid signature",
+			key:   pub,
+			rules: rules,
+			req:   &pb.DeployRules{RuleFile: "a", Sha256: b.SHA256, Signature: b.Signature},
+			want:  codes.OK,
+		},
+		{
+			desc:  "unsigned",
+			key:   pub,
+			rules: rules,
+			req:   &pb.DeployRules{RuleFile: "a"},
+			want:  codes.Unauthenticated,
+		},
+		{
+			desc:  "truncated",
+			key:   pub,
+			rules: rules[:len(rules)-5],
+			req:   &pb.DeployRules{RuleFile: "a", Sha256: b.SHA256, Signature: b.Signature},
+			want:  codes.DataLoss,
+		},
+		{
+			desc:  "tampered",
+			key:   pub,
+			rules: []byte("pass tcp any any -> any any (sid:1;)\n"),
+			req:   &pb.DeployRules{RuleFile: "a", Sha256: b.SHA256, Signature: b.Signature},
+			want:  codes.DataLoss,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Client{ruleKey: tt.key}
+			if got := c.verifyRules(tt.rules, tt.req); got.Code() != tt.want {
+				t.Errorf("verifyRules() got %v, want code %v", got.Proto(), tt.want)
+			}
+		})
+	}
+}
+
+type fakeValidator struct {
+	err error
+}
+
+func (v *fakeValidator) Validate(string) error { return v.err }
+
+func TestDeployRulesValidation(t *testing.T) {
+	ctx := context.Background()
+	d, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, tt := range []struct {
+		desc      string
+		validator *fakeValidator
+		want      codes.Code
+		wantRules string
+		wantErrs  []*pb.RuleError
+	}{
+		{
+			desc:      "valid rules",
+			validator: &fakeValidator{},
+			want:      codes.OK,
+			wantRules: "new",
+		},
+		{
+			desc: "rejected rules",
+			validator: &fakeValidator{err: &suricata.ValidationError{
+				RuleErrors: []*suricata.RuleError{{Line: 1, SID: 100, Rule: "new", Message: "bad rule"}},
+			}},
+			want:      codes.InvalidArgument,
+			wantRules: "old",
+			wantErrs:  []*pb.RuleError{{Line: 1, Sid: 100, Rule: "new", Message: "bad rule"}},
+		},
+		{
+			desc:      "validator failure",
+			validator: &fakeValidator{err: errors.New("exec failed")},
+			want:      codes.Internal,
+			wantRules: "old",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ruleFile := filepath.Join(d, "emitto.rules")
+			if err := ioutil.WriteFile(ruleFile, []byte("old"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			fs := filestore.NewMemoryFileStore()
+			if err := fs.AddRuleFile(ctx, "a/rules", []byte("new")); err != nil {
+				t.Fatal(err)
+			}
+			c := &Client{
+				ctrl:      &fakeSuricataController{},
+				validator: tt.validator,
+				ruleStore: fs,
+				ruleFile:  ruleFile,
+			}
+			resp := new(pb.SensorResponse)
+			if got := c.deployRules(ctx, &pb.DeployRules{RuleFile: "a/rules"}, resp); got.Code() != tt.want {
+				t.Errorf("deployRules() got %v, want code %v", got.Proto(), tt.want)
+			}
+			if diff := cmp.Diff(tt.wantErrs, resp.GetRuleErrors(), cmp.Comparer(proto.Equal)); diff != "" {
+				t.Errorf("rule errors mismatch (-want +got):\n%s", diff)
+			}
+			got, err := ioutil.ReadFile(ruleFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.wantRules {
+				t.Errorf("rule file contains %q, want %q", got, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestDeployRulesRollback(t *testing.T) {
+	ctx := context.Background()
+	d, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, tt := range []struct {
+		desc        string
+		ctrl        *fakeSuricataController
+		want        codes.Code
+		wantRules   string
+		wantReloads int
+	}{
+		{
+			desc:        "successful reload",
+			ctrl:        &fakeSuricataController{},
+			want:        codes.OK,
+			wantRules:   "new",
+			wantReloads: 1,
+		},
+		{
+			desc:        "ruleset not reloaded",
+			ctrl:        &fakeSuricataController{stuck: true},
+			want:        codes.Aborted,
+			wantRules:   "old",
+			wantReloads: 2,
+		},
+		{
+			desc:        "reload and rollback reload failure",
+			ctrl:        &fakeSuricataController{reloadErr: errors.New("socket error")},
+			want:        codes.Internal,
+			wantRules:   "old",
+			wantReloads: 2,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ruleFile := filepath.Join(d, "emitto.rules")
+			if err := ioutil.WriteFile(ruleFile, []byte("old"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			fs := filestore.NewMemoryFileStore()
+			if err := fs.AddRuleFile(ctx, "a/rules", []byte("new")); err != nil {
+				t.Fatal(err)
+			}
+			c := &Client{
+				ctrl:      tt.ctrl,
+				ruleStore: fs,
+				ruleFile:  ruleFile,
+			}
+			if got := c.deployRules(ctx, &pb.DeployRules{RuleFile: "a/rules"}, new(pb.SensorResponse)); got.Code() != tt.want {
+				t.Errorf("deployRules() got %v, want code %v", got.Proto(), tt.want)
+			}
+			if tt.ctrl.reloads != tt.wantReloads {
+				t.Errorf("got %d reloads, want %d", tt.ctrl.reloads, tt.wantReloads)
+			}
+			got, err := ioutil.ReadFile(ruleFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.wantRules {
+				t.Errorf("rule file contains %q, want %q", got, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestDeployRulesRulesetStats(t *testing.T) {
+	ctx := context.Background()
+	d, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	ruleFile := filepath.Join(d, "emitto.rules")
+	if err := ioutil.WriteFile(ruleFile, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := filestore.NewMemoryFileStore()
+	if err := fs.AddRuleFile(ctx, "a/rules", []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{
+		ctrl: &fakeSuricataController{
+			stats: []*socket.RuleStats{{RulesLoaded: 10, RulesFailed: 1}},
+			failed: []*socket.FailedRule{{
+				Rule:     "alert ip any any -> any any (foo; sid:100;)",
+				Filename: ruleFile,
+				Line:     3,
+			}},
+		},
+		ruleStore: fs,
+		ruleFile:  ruleFile,
+	}
+	resp := new(pb.SensorResponse)
+	if got := c.deployRules(ctx, &pb.DeployRules{RuleFile: "a/rules"}, resp); got.Code() != codes.OK {
+		t.Fatalf("deployRules() got %v, want OK", got.Proto())
+	}
+	want := &pb.SensorResponse{
+		RulesetStats: []*pb.RulesetStats{{RulesLoaded: 10, RulesFailed: 1}},
+		FailedRules: []*pb.RuleError{{
+			Line:     3,
+			Sid:      100,
+			Rule:     "alert ip any any -> any any (foo; sid:100;)",
+			Filename: ruleFile,
+		}},
+	}
+	if diff := cmp.Diff(want, resp, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProcessDeployRules(t *testing.T) {
+	ctx := context.Background()
+	d, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	ruleFile := filepath.Join(d, "emitto.rules")
+	if err := ioutil.WriteFile(ruleFile, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := filestore.NewMemoryFileStore()
+	if err := fs.AddRuleFile(ctx, "a/rules", []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	l, err := ledger.Open(filepath.Join(d, "requests.json"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetGeneration("a", 10); err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeFleetspeakClient{}
+	c := &Client{
+		FSClient:      f,
+		ctrl:          &fakeSuricataController{},
+		host:          &host.Host{},
+		ruleStore:     fs,
+		ruleFile:      ruleFile,
+		ledger:        l,
+		requestExpiry: 24 * time.Hour,
+	}
+
+	now := ptypes.TimestampNow()
+	expired, err := ptypes.TimestampProto(time.Now().Add(-48 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transient := &pb.SensorRequest{Id: "5", Time: now, Type: &pb.SensorRequest_DeployRules{DeployRules: &pb.DeployRules{RuleFile: "b/rules"}}}
+	for _, tt := range []struct {
+		desc   string
+		before func()
+		req    *pb.SensorRequest
+		want   codes.Code
+	}{
+		{
+			desc: "older generation",
+			req:  &pb.SensorRequest{Id: "1", Time: now, Type: deployType("a", 5)},
+			want: codes.FailedPrecondition,
+		},
+		{
+			desc: "expired",
+			req:  &pb.SensorRequest{Id: "2", Time: expired, Type: deployType("a", 20)},
+			want: codes.DeadlineExceeded,
+		},
+		{
+			desc: "newer generation",
+			req:  &pb.SensorRequest{Id: "3", Time: now, Type: deployType("a", 20)},
+			want: codes.OK,
+		},
+		{
+			desc: "duplicate",
+			req:  &pb.SensorRequest{Id: "3", Time: now, Type: deployType("a", 20)},
+			want: codes.AlreadyExists,
+		},
+		{
+			desc: "duplicate of a rejected request",
+			req:  &pb.SensorRequest{Id: "1", Time: now, Type: deployType("a", 30)},
+			want: codes.AlreadyExists,
+		},
+		{
+			desc: "no generation",
+			req:  &pb.SensorRequest{Id: "4", Time: now, Type: deployType("", 0)},
+			want: codes.OK,
+		},
+		{
+			desc: "missing rule file",
+			req:  transient,
+			want: codes.NotFound,
+		},
+		{
+			desc: "redelivery after a transient failure",
+			before: func() {
+				if err := fs.AddRuleFile(ctx, "b/rules", []byte("newer")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			req:  transient,
+			want: codes.OK,
+		},
+	} {
+		if tt.before != nil {
+			tt.before()
+		}
+		data, err := ptypes.MarshalAny(tt.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Msgs = nil
+		if err := c.ProcessMessage(ctx, &fspb.Message{Data: data}); err != nil {
+			t.Fatalf("%s: ProcessMessage() failed: %v", tt.desc, err)
+		}
+		if len(f.Msgs) != 1 {
+			t.Fatalf("%s: got %d messages, want a response", tt.desc, len(f.Msgs))
+		}
+		resp := f.Msgs[0].GetResponse()
+		if got := codes.Code(resp.GetStatus().GetCode()); resp.GetId() != tt.req.GetId() || got != tt.want {
+			t.Errorf("%s: got response %v, want code %v", tt.desc, resp, tt.want)
+		}
+	}
+	if g := l.Generation("a"); g != 20 {
+		t.Errorf("got installed generation %d, want 20", g)
+	}
+}
+
+func deployType(location string, generation int64) *pb.SensorRequest_DeployRules {
+	return &pb.SensorRequest_DeployRules{DeployRules: &pb.DeployRules{
+		RuleFile:   "a/rules",
+		Location:   location,
+		Generation: generation,
+	}}
+}
+
+// Creates temporary file, calls createBackup and compares content of returned file to original file.
+func TestCreateBackup(t *testing.T) {
+	d, err := ioutil.TempDir("/tmp", "test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(d)
+
+	srcData := []byte{1, 2, 3}
+	srcFile := filepath.Join(d, "src.txt")
+	if err := ioutil.WriteFile(srcFile, srcData, 0644); err != nil {
+		t.Fatalf("failed to write in file %v: %v", srcFile, err)
+	}
+	backup, err := createBackup(srcFile)
+	if err != nil {
+		t.Errorf("createBackup(%v) returned an error: %v", srcFile, err)
+	}
+	backupData, err := ioutil.ReadFile(backup)
+	if err != nil {
+		t.Errorf("error reading file %v: %v", srcFile, err)
+	}
+	if diff := cmp.Diff(srcData, backupData); diff != "" {
+		t.Errorf("backup file does not match source (-want +got):\n%s", diff)
+	}
+}
+
+func TestRotateBackups(t *testing.T) {
+	d, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(d)
+
+	ruleFile := filepath.Join(d, "emitto.rules")
+	for _, f := range []string{"emitto.rules", "emitto.rules.candidate_1", "other.rules_1"} {
+		if err := ioutil.WriteFile(filepath.Join(d, f), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		f := filepath.Join(d, fmt.Sprintf("emitto.rules_190101_%d", i))
+		if err := ioutil.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		mt := now.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(f, mt, mt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rotateBackups(ruleFile, 2); err != nil {
+		t.Fatal(err)
+	}
+	files, err := ioutil.ReadDir(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range files {
+		got = append(got, f.Name())
+	}
+	want := []string{"emitto.rules", "emitto.rules.candidate_1", "emitto.rules_190101_2", "emitto.rules_190101_3", "other.rules_1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+const (
+	// Number of trailing lines of suricata.log collected by default, and at most.
+	defaultLogLines = 100
+	maxLogLines     = 1000
+)
+
+// collectDiagnostics collects troubleshooting information. Failures to collect some of it are
+// reported in the Diagnostics errors.
+func (c *Client) collectDiagnostics(req *pb.CollectDiagnostics) *pb.Diagnostics {
+	d := new(pb.Diagnostics)
+	fail := func(format string, a ...interface{}) {
+		d.Errors = append(d.Errors, fmt.Sprintf(format, a...))
+	}
+	v, err := c.ctrl.Version()
+	if err != nil {
+		fail("failed to get Suricata version: %v", err)
+	}
+	d.SuricataVersion = v
+	if counters, err := c.ctrl.DumpCounters(); err != nil {
+		fail("failed to dump Suricata counters: %v", err)
+	} else if b, err := json.Marshal(counters); err != nil {
+		fail("failed to marshal Suricata counters: %v", err)
+	} else {
+		d.Counters = string(b)
+	}
+	if c.suricataLog != "" {
+		n := int(req.GetLogLines())
+		switch {
+		case n <= 0:
+			n = defaultLogLines
+		case n > maxLogLines:
+			n = maxLogLines
+		}
+		if d.SuricataLog, err = tailFile(c.suricataLog, n); err != nil {
+			fail("failed to read Suricata log: %v", err)
+		}
+	}
+	for _, p := range []string{c.ruleFile, c.suricataLog} {
+		if p == "" {
+			continue
+		}
+		var fs syscall.Statfs_t
+		if err := syscall.Statfs(filepath.Dir(p), &fs); err != nil {
+			fail("failed to get disk space of %q: %v", p, err)
+			continue
+		}
+		d.Disks = append(d.Disks, &pb.DiskSpace{
+			Path:       filepath.Dir(p),
+			TotalBytes: fs.Blocks * uint64(fs.Bsize),
+			FreeBytes:  fs.Bavail * uint64(fs.Bsize),
+		})
+	}
+	if c.configFile != "" {
+		if d.ConfigSha256, err = fileSHA256(c.configFile); err != nil {
+			fail("failed to hash sensor config: %v", err)
+		}
+	}
+	return d
+}
+
+// tailFile returns the last n lines of a file.
+func tailFile(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	// Keep the last n lines in a ring buffer.
+	ring := make([]string, n)
+	var count int
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		ring[count%n] = s.Text()
+		count++
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if count <= n {
+		return ring[:count], nil
+	}
+	i := count % n
+	return append(ring[i:], ring[:i]...), nil
+}
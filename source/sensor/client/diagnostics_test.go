@@ -0,0 +1,145 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/sensor/host"
+	"github.com/google/emitto/source/sensor/ledger"
+	"github.com/google/emitto/source/sensor/suricata"
+	"github.com/google/emitto/source/sensor/suricata/socket"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+)
+
+func TestProcessCollectDiagnostics(t *testing.T) {
+	d, err := ioutil.TempDir("", "diagnostics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	suricataLog := filepath.Join(d, "suricata.log")
+	if err := ioutil.WriteFile(suricataLog, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(d, "sensor.json")
+	config := []byte(`{"org": "a"}`)
+	if err := ioutil.WriteFile(configFile, config, 0644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := ledger.Open("", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeFleetspeakClient{}
+	c := &Client{
+		FSClient: f,
+		ctrl: &fakeSuricataController{
+			health:   &suricata.Health{Version: "4.1.4"},
+			counters: socket.Counters{"capture": map[string]interface{}{"kernel_drops": 3.0}},
+		},
+		host:        &host.Host{},
+		ruleFile:    filepath.Join(d, "missing", "emitto.rules"),
+		suricataLog: suricataLog,
+		configFile:  configFile,
+		ledger:      l,
+	}
+
+	data, err := ptypes.MarshalAny(&pb.SensorRequest{
+		Id:   "1",
+		Type: &pb.SensorRequest_CollectDiagnostics{CollectDiagnostics: &pb.CollectDiagnostics{LogLines: 3}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ProcessMessage(context.Background(), &fspb.Message{Data: data}); err != nil {
+		t.Fatalf("ProcessMessage() failed: %v", err)
+	}
+	if len(f.Msgs) != 1 {
+		t.Fatalf("got %d messages, want a response", len(f.Msgs))
+	}
+	resp := f.Msgs[0].GetResponse()
+	if codes.Code(resp.GetStatus().GetCode()) != codes.OK {
+		t.Errorf("got status %v, want %v", resp.GetStatus(), codes.OK)
+	}
+	got := resp.GetDiagnostics()
+	sum := sha256.Sum256(config)
+	want := &pb.Diagnostics{
+		SuricataVersion: "4.1.4",
+		Counters:        `{"capture":{"kernel_drops":3}}`,
+		SuricataLog:     []string{"line 7", "line 8", "line 9"},
+		Disks:           []*pb.DiskSpace{{Path: d}},
+		ConfigSha256:    sum[:],
+		Errors:          got.GetErrors(),
+	}
+	// Disk space varies; only check that it is reported.
+	for _, disk := range got.GetDisks() {
+		if disk.GetTotalBytes() == 0 {
+			t.Errorf("got no disk space for %q", disk.GetPath())
+		}
+		disk.TotalBytes, disk.FreeBytes = 0, 0
+	}
+	if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	// The rule file directory does not exist.
+	if len(got.GetErrors()) != 1 || !strings.Contains(got.GetErrors()[0], "disk space") {
+		t.Errorf("got errors %q, want a disk space error", got.GetErrors())
+	}
+}
+
+func TestTailFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("a\nb\nc\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	for _, tt := range []struct {
+		n    int
+		want []string
+	}{
+		{n: 1, want: []string{"c"}},
+		{n: 2, want: []string{"b", "c"}},
+		{n: 5, want: []string{"a", "b", "c"}},
+	} {
+		got, err := tailFile(f.Name(), tt.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("tailFile(%d): expectation mismatch (-want +got):\n%s", tt.n, diff)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/sensor/eve"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+const (
+	// windowBuckets is the number of buckets of the alert policy windows.
+	windowBuckets = 60
+	// topContributors is the number of signatures and hosts reported in sensor alerts.
+	topContributors = 5
+)
+
+// AlertForwarding configures the forwarding of sampled Suricata alert events to the server.
+type AlertForwarding struct {
+	// Fraction of alert events to forward, in (0, 1].
+	SampleRate float64
+	// Maximum number of alert events forwarded per second.
+	RateLimit float64
+	// Maximum number of alert events forwarded in a burst above the rate limit.
+	Burst int
+	// Maximum number of alert events per message.
+	BatchSize int
+}
+
+// EVEMonitor follows the Suricata EVE log and sends a sensor alert whenever Suricata alerts cross
+// the threshold of an alert policy rule. Alerts are counted per signature for rule hit reports.
+// Optionally, sampled alert events are forwarded to the server.
+type EVEMonitor struct {
+	client     *Client
+	tailer     *eve.Tailer
+	logFile    string
+	checkpoint string
+	policy     *eve.Policy
+	evaluator  *eve.Evaluator
+	hits       *eve.HitCounter
+	// Start of the current rule hits reporting period.
+	hitsSince time.Time
+
+	forwarding *AlertForwarding
+	sampler    *eve.Sampler
+	batch      []*evepb.EVE
+	// Number of alert events not forwarded since the last batch.
+	dropped int64
+
+	// Guards stats, which are read while the monitor is running.
+	mu    sync.Mutex
+	stats EVEStats
+}
+
+// EVEStats are the counters of an EVEMonitor since its creation.
+type EVEStats struct {
+	// Followed EVE log.
+	LogFile string `json:"log_file"`
+	// Time of the last poll of the EVE log.
+	LastPoll time.Time `json:"last_poll"`
+	// Number of EVE events read, and of EVE lines which could not be parsed.
+	Events      int64 `json:"events"`
+	ParseErrors int64 `json:"parse_errors"`
+	// Number of Suricata alerts.
+	Alerts int64 `json:"alerts"`
+	// Number of sensor alerts sent for alert policy rules.
+	SensorAlerts int64 `json:"sensor_alerts"`
+	// Number of alert events forwarded to the server, and not forwarded due to sampling, rate
+	// limiting or send failures.
+	Forwarded int64 `json:"forwarded"`
+	Dropped   int64 `json:"dropped"`
+}
+
+// Stats returns the counters of the EVEMonitor.
+func (m *EVEMonitor) Stats() EVEStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+// updateStats applies fn to the counters of the EVEMonitor.
+func (m *EVEMonitor) updateStats(fn func(*EVEStats)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(&m.stats)
+}
+
+// NewEVEMonitor creates a new EVEMonitor for the EVE log file. The position in the log file is
+// saved to the checkpoint file, if non-empty. Alert events are not forwarded if forwarding is nil.
+func (c *Client) NewEVEMonitor(logFile, checkpoint string, policy *eve.Policy, forwarding *AlertForwarding) (*EVEMonitor, error) {
+	m := &EVEMonitor{
+		client:    c,
+		hits:      eve.NewHitCounter(),
+		hitsSince: time.Now(),
+	}
+	if err := m.Update(logFile, checkpoint, policy, forwarding); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.monitor = m
+	c.mu.Unlock()
+	return m, nil
+}
+
+// Update changes the settings of the EVEMonitor, which are left unchanged on error. The EVE log is
+// reopened only if its path or checkpoint file changed, and alert counts restart from zero only if
+// the policy changed. Alert events pending forwarding are sent first.
+func (m *EVEMonitor) Update(logFile, checkpoint string, policy *eve.Policy, forwarding *AlertForwarding) error {
+	e := m.evaluator
+	if e == nil || !reflect.DeepEqual(policy, m.policy) {
+		var err error
+		if e, err = eve.NewEvaluator(policy, windowBuckets); err != nil {
+			return err
+		}
+	}
+	var sampler *eve.Sampler
+	if forwarding != nil {
+		if forwarding.SampleRate <= 0 || forwarding.SampleRate > 1 {
+			return fmt.Errorf("invalid alert sample rate %v", forwarding.SampleRate)
+		}
+		if forwarding.BatchSize < 1 {
+			return fmt.Errorf("invalid alert batch size %d", forwarding.BatchSize)
+		}
+		sampler = eve.NewSampler(forwarding.SampleRate, forwarding.RateLimit, forwarding.Burst)
+	}
+	if m.tailer == nil || logFile != m.logFile || checkpoint != m.checkpoint {
+		t, err := eve.NewTailer(logFile, checkpoint)
+		if err != nil {
+			return err
+		}
+		if m.tailer != nil {
+			if err := m.tailer.Close(); err != nil {
+				log.Warningf("Failed to close EVE log %q: %v", m.logFile, err)
+			}
+		}
+		m.tailer, m.logFile, m.checkpoint = t, logFile, checkpoint
+		m.updateStats(func(s *EVEStats) { s.LogFile = logFile })
+	}
+	if len(m.batch) > 0 {
+		m.flush()
+	}
+	m.policy, m.evaluator = policy, e
+	m.forwarding, m.sampler = forwarding, sampler
+	return nil
+}
+
+// Poll reads the events appended to the EVE log since the last poll, checks the alert policy rules
+// and forwards sampled alert events.
+func (m *EVEMonitor) Poll() error {
+	var events, errs, alerts int64
+	if err := m.tailer.Poll(func(line []byte) {
+		events++
+		e, err := parseLogLine(string(line))
+		if err == nil {
+			err = m.evaluator.Add(e)
+		}
+		if err != nil {
+			errs++
+			log.Warning(err)
+			return
+		}
+		if e.GetEventType() == "alert" {
+			alerts++
+		}
+		m.hits.Add(e)
+		m.forward(e)
+	}); err != nil {
+		return fmt.Errorf("failed to read EVE log: %v", err)
+	}
+	if errs > 0 {
+		log.Errorf("Failed to parse %d EVE events", errs)
+	}
+	firings := m.evaluator.Check(time.Now(), topContributors)
+	for _, f := range firings {
+		m.client.sendPolicyAlert(f)
+	}
+	m.updateStats(func(s *EVEStats) {
+		s.LastPoll = time.Now()
+		s.Events += events
+		s.ParseErrors += errs
+		s.Alerts += alerts
+		s.SensorAlerts += int64(len(firings))
+	})
+	if len(m.batch) > 0 {
+		m.flush()
+	}
+	// The events were forwarded and checked against the alert policy: resume after them on restart.
+	if err := m.tailer.Commit(); err != nil {
+		return fmt.Errorf("failed to save EVE log checkpoint: %v", err)
+	}
+	return nil
+}
+
+// ReportRuleHits sends the number of alerts per signature since the last report to the server.
+// Nothing is sent if no alerts were counted.
+func (m *EVEMonitor) ReportRuleHits() {
+	start, end := m.hitsSince, time.Now()
+	m.hitsSince = end
+	hits := m.hits.Reset()
+	if len(hits) == 0 {
+		return
+	}
+	rh := &pb.RuleHits{Host: m.client.getHostInfo()}
+	rh.Start, _ = ptypes.TimestampProto(start)
+	rh.End, _ = ptypes.TimestampProto(end)
+	for _, h := range hits {
+		rh.Hits = append(rh.Hits, &pb.RuleHit{SignatureId: h.ID, Rev: h.Rev, Count: h.Count})
+	}
+	if _, err := m.client.FSClient.SendMessage(&pb.SensorMessage{
+		Id:   uuid.New().String(),
+		Type: &pb.SensorMessage_RuleHits{RuleHits: rh},
+	}); err != nil {
+		log.Errorf("Failed to send rule hits: %v", err)
+	}
+}
+
+// forward adds a sampled alert event to the batch, sending the batch once full.
+func (m *EVEMonitor) forward(e *evepb.EVE) {
+	if m.forwarding == nil || e.GetEventType() != "alert" {
+		return
+	}
+	if !m.sampler.Allow(time.Now()) {
+		m.dropped++
+		m.updateStats(func(s *EVEStats) { s.Dropped++ })
+		return
+	}
+	m.batch = append(m.batch, e)
+	if len(m.batch) >= m.forwarding.BatchSize {
+		m.flush()
+	}
+}
+
+// flush sends the batch of alert events to the server.
+func (m *EVEMonitor) flush() {
+	if _, err := m.client.FSClient.SendMessage(&pb.SensorMessage{
+		Id: uuid.New().String(),
+		Type: &pb.SensorMessage_EveAlerts{
+			EveAlerts: &pb.EVEAlerts{
+				Time:    ptypes.TimestampNow(),
+				Host:    m.client.getHostInfo(),
+				Events:  m.batch,
+				Dropped: m.dropped,
+			},
+		},
+	}); err != nil {
+		log.Errorf("Failed to send %d alert events: %v", len(m.batch), err)
+		m.dropped += int64(len(m.batch))
+		m.updateStats(func(s *EVEStats) { s.Dropped += int64(len(m.batch)) })
+	} else {
+		m.dropped = 0
+		m.updateStats(func(s *EVEStats) { s.Forwarded += int64(len(m.batch)) })
+	}
+	m.batch = nil
+}
+
+// Close closes the EVE log file.
+func (m *EVEMonitor) Close() error {
+	m.client.mu.Lock()
+	if m.client.monitor == m {
+		m.client.monitor = nil
+	}
+	m.client.mu.Unlock()
+	return m.tailer.Close()
+}
+
+// sendPolicyAlert sends a sensor alert for an alert policy rule whose threshold was crossed.
+func (c *Client) sendPolicyAlert(f *eve.Firing) {
+	msg := fmt.Sprintf("Sensor has detected: %d alerts in last: %v (policy %q)", f.Count, f.Window, f.Rule)
+	if f.Group != "" {
+		msg = fmt.Sprintf("Sensor has detected: %d alerts in last: %v for %s (policy %q)", f.Count, f.Window, f.Group, f.Rule)
+	}
+	alert := &pb.SensorAlert{
+		Time:          ptypes.TimestampNow(),
+		Host:          c.getHostInfo(),
+		Status:        status.New(codes.Internal, msg).Proto(),
+		Policy:        f.Rule,
+		AlertCount:    f.Count,
+		WindowSeconds: int64(f.Window / time.Second),
+	}
+	for _, s := range f.Signatures {
+		alert.TopSignatures = append(alert.TopSignatures, &pb.AlertContributor{Key: s.Key, Description: s.Description, Count: s.Count})
+	}
+	for _, h := range f.Hosts {
+		alert.TopHosts = append(alert.TopHosts, &pb.AlertContributor{Key: h.Key, Count: h.Count})
+	}
+	if _, err := c.FSClient.SendMessage(&pb.SensorMessage{
+		Id:   uuid.New().String(),
+		Type: &pb.SensorMessage_Alert{Alert: alert},
+	}); err != nil {
+		log.Errorf("Failed to send sensor alert: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/sha256"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes"
+
+	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+// heartbeat builds a heartbeat containing the sensor and Suricata health. Failures to collect
+// Suricata health are reported in the heartbeat rather than suppressing it.
+func (c *Client) heartbeat() *pb.Heartbeat {
+	if err := c.host.Update(); err != nil {
+		log.Warningf("Failed to update host info: %v", err)
+	}
+	hb := &pb.Heartbeat{
+		Time:          ptypes.TimestampNow(),
+		Host:          c.getHostInfo(),
+		SensorVersion: c.version,
+		Suricata:      c.suricataHealth(),
+	}
+	sum, err := fileSHA256(c.ruleFile)
+	if err != nil {
+		log.Warningf("Failed to hash rule file %q: %v", c.ruleFile, err)
+	}
+	hb.RuleFileSha256 = sum
+	return hb
+}
+
+// suricataHealth collects the Suricata health information.
+func (c *Client) suricataHealth() *pb.SuricataHealth {
+	h, err := c.ctrl.Health()
+	if err != nil {
+		log.Warningf("Failed to collect Suricata health: %v", err)
+		return &pb.SuricataHealth{Error: err.Error()}
+	}
+	sh := &pb.SuricataHealth{
+		Version:       h.Version,
+		UptimeSeconds: int64(h.Uptime.Seconds()),
+		RunningMode:   h.RunningMode,
+		KernelPackets: h.KernelPackets,
+		KernelDrops:   h.KernelDrops,
+		RulesLoaded:   h.RulesLoaded,
+		RulesFailed:   h.RulesFailed,
+	}
+	for name, st := range h.Interfaces {
+		sh.Interfaces = append(sh.Interfaces, &pb.InterfaceStats{
+			Name:             name,
+			Packets:          st.Packets,
+			Drops:            st.Drops,
+			InvalidChecksums: st.InvalidChecksums,
+		})
+	}
+	sort.Slice(sh.Interfaces, func(i, j int) bool {
+		return sh.Interfaces[i].Name < sh.Interfaces[j].Name
+	})
+	return sh
+}
+
+// fileSHA256 returns the SHA-256 digest of a file.
+func fileSHA256(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/emitto/source/sensor/host"
+	"github.com/google/emitto/source/sensor/suricata"
+	"github.com/google/emitto/source/sensor/suricata/socket"
+	"github.com/google/go-cmp/cmp"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+func TestHeartbeat(t *testing.T) {
+	d, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	ruleFile := filepath.Join(d, "emitto.rules")
+	rules := []byte("alert ip any any -> any any (sid:1;)\n")
+	if err := ioutil.WriteFile(ruleFile, rules, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(rules)
+
+	for _, tt := range []struct {
+		desc string
+		ctrl *fakeSuricataController
+		want *pb.SuricataHealth
+	}{
+		{
+			desc: "healthy",
+			ctrl: &fakeSuricataController{
+				health: &suricata.Health{
+					Version:     "4.1.4 RELEASE",
+					Uptime:      time.Hour,
+					RunningMode: "workers",
+					Interfaces: map[string]*socket.InterfaceStats{
+						"eth1": {Packets: 10, Drops: 1},
+						"eth0": {Packets: 100, Drops: 2, InvalidChecksums: 3},
+					},
+					KernelPackets: 110,
+					KernelDrops:   3,
+					RulesLoaded:   10,
+					RulesFailed:   1,
+				},
+			},
+			want: &pb.SuricataHealth{
+				Version:       "4.1.4 RELEASE",
+				UptimeSeconds: 3600,
+				RunningMode:   "workers",
+				Interfaces: []*pb.InterfaceStats{
+					{Name: "eth0", Packets: 100, Drops: 2, InvalidChecksums: 3},
+					{Name: "eth1", Packets: 10, Drops: 1},
+				},
+				KernelPackets: 110,
+				KernelDrops:   3,
+				RulesLoaded:   10,
+				RulesFailed:   1,
+			},
+		},
+		{
+			desc: "suricata unavailable",
+			ctrl: &fakeSuricataController{healthErr: errors.New("connection refused")},
+			want: &pb.SuricataHealth{Error: "connection refused"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := &Client{
+				ctrl:     tt.ctrl,
+				host:     &host.Host{},
+				ruleFile: ruleFile,
+				version:  "1.2.3",
+			}
+			got := c.heartbeat()
+			if got.GetSensorVersion() != "1.2.3" {
+				t.Errorf("got sensor version %q, want %q", got.GetSensorVersion(), "1.2.3")
+			}
+			if diff := cmp.Diff(sum[:], got.GetRuleFileSha256()); diff != "" {
+				t.Errorf("rule file digest mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got.GetSuricata(), cmp.Comparer(proto.Equal)); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/google/emitto/source/sensor/queue"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+// maxRecentRequests is the number of processed requests kept for the sensor status.
+const maxRecentRequests = 20
+
+// RequestRecord describes a processed SensorRequest.
+type RequestRecord struct {
+	ID string `json:"id"`
+	// Request type, e.g. "deploy_rules".
+	Type      string    `json:"type"`
+	Received  time.Time `json:"received"`
+	Completed time.Time `json:"completed"`
+	// Canonical status code name and message of the response.
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// FleetspeakState describes the channel to the Fleetspeak client.
+type FleetspeakState struct {
+	Sent             int64     `json:"sent"`
+	Acknowledged     int64     `json:"acknowledged"`
+	Pending          int64     `json:"pending"`
+	Received         int64     `json:"received"`
+	LastSent         time.Time `json:"last_sent"`
+	LastAcknowledged time.Time `json:"last_acknowledged"`
+	LastReceived     time.Time `json:"last_received"`
+}
+
+// Status is a snapshot of the sensor state, served locally to operators.
+type Status struct {
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+	Host    *pb.Host  `json:"host"`
+	// Installed rule file, its hex SHA-256 digest and modification time, or the error which
+	// prevented reading it.
+	RuleFile          string    `json:"rule_file"`
+	RuleFileSHA256    string    `json:"rule_file_sha256,omitempty"`
+	RuleFileInstalled time.Time `json:"rule_file_installed"`
+	RuleFileError     string    `json:"rule_file_error,omitempty"`
+	// Recently processed requests, most recent first.
+	Requests   []*RequestRecord `json:"requests"`
+	Fleetspeak FleetspeakState  `json:"fleetspeak"`
+	// Counters of the EVE monitor; nil if the EVE log is not monitored.
+	EVE *EVEStats `json:"eve,omitempty"`
+	// Installed deployment generation by location name.
+	Generations map[string]int64 `json:"generations,omitempty"`
+	// Metrics of the outbound queue; nil if messages are sent directly.
+	Queue    *queue.Stats       `json:"queue,omitempty"`
+	Suricata *pb.SuricataHealth `json:"suricata"`
+}
+
+// recordRequest records the outcome of a processed request.
+func (c *Client) recordRequest(id, typ string, received time.Time, s *status.Status) {
+	r := &RequestRecord{
+		ID:        id,
+		Type:      typ,
+		Received:  received,
+		Completed: time.Now(),
+		Code:      s.Code().String(),
+		Message:   s.Message(),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, r)
+	if n := len(c.requests); n > maxRecentRequests {
+		c.requests = append([]*RequestRecord(nil), c.requests[n-maxRecentRequests:]...)
+	}
+}
+
+// Status returns the current sensor status.
+func (c *Client) Status() *Status {
+	st := &Status{
+		Time:     time.Now(),
+		Version:  c.version,
+		Host:     c.getHostInfo(),
+		RuleFile: c.ruleFile,
+		Suricata: c.suricataHealth(),
+	}
+	if fi, err := os.Stat(c.ruleFile); err != nil {
+		st.RuleFileError = err.Error()
+	} else if sum, err := fileSHA256(c.ruleFile); err != nil {
+		st.RuleFileError = err.Error()
+	} else {
+		st.RuleFileSHA256 = hex.EncodeToString(sum)
+		st.RuleFileInstalled = fi.ModTime()
+	}
+	fs := c.FSClient.State()
+	st.Fleetspeak = FleetspeakState{
+		Sent:             fs.Sent,
+		Acknowledged:     fs.Acknowledged,
+		Pending:          fs.Pending(),
+		Received:         fs.Received,
+		LastSent:         fs.LastSent,
+		LastAcknowledged: fs.LastAcknowledged,
+		LastReceived:     fs.LastReceived,
+	}
+
+	c.mu.RLock()
+	for i := len(c.requests) - 1; i >= 0; i-- {
+		st.Requests = append(st.Requests, c.requests[i])
+	}
+	m := c.monitor
+	c.mu.RUnlock()
+	if m != nil {
+		s := m.Stats()
+		st.EVE = &s
+	}
+	if c.ledger != nil {
+		st.Generations = c.ledger.Generations()
+	}
+	if c.queue != nil {
+		s := c.queue.Stats()
+		st.Queue = &s
+	}
+	return st
+}
+
+// RedeployLast deploys again the rules of the last DeployRules request deployed since the sensor
+// started, e.g. after the rule file was modified locally. The request is checked again, so expired
+// requests and requests superseded by a newer generation are refused. The outcome is sent to the
+// server as a response to the original request.
+func (c *Client) RedeployLast(ctx context.Context) (*status.Status, error) {
+	c.mu.RLock()
+	req := c.lastDeploy
+	c.mu.RUnlock()
+	if req == nil {
+		return nil, errors.New("no rules deployed since the sensor started")
+	}
+	log.Infof("Redeploying rules of request %q", req.GetId())
+	received := time.Now()
+	resp := new(pb.SensorResponse)
+	s := c.deploy(ctx, req, resp)
+	c.recordRequest(req.GetId(), "redeploy_rules", received, s)
+	if err := c.sendResponse(req.GetId(), s, resp); err != nil {
+		return s, err
+	}
+	return s, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/sensor/fleetspeak"
+	"github.com/google/emitto/source/sensor/host"
+	"github.com/google/emitto/source/sensor/ledger"
+	"github.com/google/emitto/source/sensor/queue"
+	"github.com/google/emitto/source/sensor/suricata"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+func TestStatus(t *testing.T) {
+	d, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	ruleFile := filepath.Join(d, "emitto.rules")
+	rules := []byte("alert ip any any -> any any (sid:1;)\n")
+	if err := ioutil.WriteFile(ruleFile, rules, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(rules)
+
+	c := &Client{
+		FSClient: &fakeFleetspeakClient{state: fleetspeak.State{Sent: 3, Acknowledged: 2}},
+		ctrl:     &fakeSuricataController{health: &suricata.Health{Version: "4.1.4"}},
+		host:     &host.Host{},
+		ruleFile: ruleFile,
+		version:  "1.2.3",
+	}
+	for i := 0; i < maxRecentRequests+5; i++ {
+		c.recordRequest(fmt.Sprint(i), "reload_rules", time.Now(), status.New(codes.OK, ""))
+	}
+	c.recordRequest("last", "deploy_rules", time.Now(), status.New(codes.NotFound, "no rules"))
+
+	got := c.Status()
+	if got.Version != "1.2.3" {
+		t.Errorf("got version %q, want %q", got.Version, "1.2.3")
+	}
+	if want := hex.EncodeToString(sum[:]); got.RuleFileSHA256 != want || got.RuleFileError != "" {
+		t.Errorf("got rule file digest %q (error %q), want %q", got.RuleFileSHA256, got.RuleFileError, want)
+	}
+	if got.RuleFileInstalled.IsZero() {
+		t.Error("got no rule file install time")
+	}
+	if got.Fleetspeak.Pending != 1 {
+		t.Errorf("got %d pending Fleetspeak messages, want 1", got.Fleetspeak.Pending)
+	}
+	if got.Suricata.GetVersion() != "4.1.4" {
+		t.Errorf("got Suricata health %v, want version %q", got.Suricata, "4.1.4")
+	}
+	if got.EVE != nil {
+		t.Errorf("got EVE stats %+v without an EVE monitor", got.EVE)
+	}
+	if len(got.Requests) != maxRecentRequests {
+		t.Fatalf("got %d requests, want %d", len(got.Requests), maxRecentRequests)
+	}
+	r := *got.Requests[0]
+	if r.Received.IsZero() || r.Completed.Before(r.Received) {
+		t.Errorf("got request times %v and %v", r.Received, r.Completed)
+	}
+	r.Received, r.Completed = time.Time{}, time.Time{}
+	want := RequestRecord{ID: "last", Type: "deploy_rules", Code: "NotFound", Message: "no rules"}
+	if diff := cmp.Diff(want, r); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQueuedStatus(t *testing.T) {
+	d, err := ioutil.TempDir("", "queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	f := &fakeFleetspeakClient{}
+	q, err := queue.New(&queue.Config{Dir: d, MaxMessages: 10, MaxBytes: 1 << 20, MaxAge: time.Hour}, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{
+		FSClient: &queuedClient{FleetspeakClient: f, q: q},
+		ctrl:     &fakeSuricataController{health: &suricata.Health{}},
+		host:     &host.Host{},
+		queue:    q,
+	}
+
+	// Messages are queued, then delivered by the queue.
+	c.SendHeartbeat()
+	if got := c.Status().Queue; got == nil || got.Depth != 1 || got.Enqueued != 1 {
+		t.Fatalf("got queue stats %+v, want 1 queued message", got)
+	}
+	if len(f.Msgs) != 0 {
+		t.Fatalf("got %d messages sent before the queue runs, want none", len(f.Msgs))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+	for i := 0; i < 100 && q.Stats().Delivered == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := c.Status().Queue; got.Depth != 0 || got.Delivered != 1 {
+		t.Errorf("got queue stats %+v, want 1 delivered message", got)
+	}
+	if len(f.Msgs) != 1 || f.Msgs[0].GetHeartbeat() == nil {
+		t.Errorf("got sent messages %v, want a heartbeat", f.Msgs)
+	}
+}
+
+func TestRedeployLast(t *testing.T) {
+	ctx := context.Background()
+	d, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	ruleFile := filepath.Join(d, "emitto.rules")
+	if err := ioutil.WriteFile(ruleFile, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := filestore.NewMemoryFileStore()
+	if err := fs.AddRuleFile(ctx, "a/rules", []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	l, err := ledger.Open("", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeFleetspeakClient{}
+	c := &Client{
+		FSClient:  f,
+		ctrl:      &fakeSuricataController{},
+		host:      &host.Host{},
+		ruleStore: fs,
+		ruleFile:  ruleFile,
+		ledger:    l,
+	}
+	if _, err := c.RedeployLast(ctx); err == nil {
+		t.Error("expected an error without a previous DeployRules request")
+	}
+
+	// Failed deployments are not redeployed.
+	failed := &pb.SensorRequest{Id: "deploy-0", Type: &pb.SensorRequest_DeployRules{DeployRules: &pb.DeployRules{RuleFile: "unknown"}}}
+	if s := c.deploy(ctx, failed, new(pb.SensorResponse)); s.Code() != codes.NotFound {
+		t.Fatalf("deploy() got %v, want code %v", s.Proto(), codes.NotFound)
+	}
+	if c.lastDeploy != nil {
+		t.Errorf("got last deployment %v after a failed deployment, want none", c.lastDeploy)
+	}
+
+	req := &pb.SensorRequest{Id: "deploy-1", Type: &pb.SensorRequest_DeployRules{DeployRules: &pb.DeployRules{RuleFile: "a/rules", Location: "a", Generation: 1}}}
+	if s := c.deploy(ctx, req, new(pb.SensorResponse)); s.Code() != codes.OK {
+		t.Fatalf("deploy() got %v, want code %v", s.Proto(), codes.OK)
+	}
+	if err := ioutil.WriteFile(ruleFile, []byte("modified"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.RedeployLast(ctx)
+	if err != nil {
+		t.Fatalf("RedeployLast() failed: %v", err)
+	}
+	if s.Code() != codes.OK {
+		t.Errorf("RedeployLast() got %v, want code %v", s.Proto(), codes.OK)
+	}
+	b, err := ioutil.ReadFile(ruleFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "new" {
+		t.Errorf("got rule file %q, want %q", b, "new")
+	}
+	if len(f.Msgs) != 1 || f.Msgs[0].GetResponse().GetId() != "deploy-1" {
+		t.Errorf("got messages %v, want a response to %q", f.Msgs, "deploy-1")
+	}
+	if len(c.requests) != 1 || c.requests[0].Type != "redeploy_rules" {
+		t.Errorf("got requests %v, want a recorded redeployment", c.requests)
+	}
+
+	// Deployments superseded by a newer generation are not redeployed.
+	if err := l.SetGeneration("a", 2); err != nil {
+		t.Fatal(err)
+	}
+	if s, err = c.RedeployLast(ctx); err != nil {
+		t.Fatalf("RedeployLast() failed: %v", err)
+	}
+	if s.Code() != codes.FailedPrecondition {
+		t.Errorf("RedeployLast() got %v for a superseded deployment, want code %v", s.Proto(), codes.FailedPrecondition)
+	}
+}
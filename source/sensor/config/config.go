@@ -0,0 +1,383 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config provides the Emitto sensor configuration, loaded from a JSON file which can be
+// reloaded while the sensor is running. YAML is deliberately not supported, so that the sensor
+// does not depend on a YAML parser; YAML configurations can be converted to JSON when deployed.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/emitto/source/sensor/eve"
+)
+
+// Config is the sensor configuration, loaded from a JSON file such as:
+//
+//	{"fleetspeak_socket": "/var/run/fleetspeak.sock",
+//	 "suricata_socket": "/var/run/suricata/suricata-command.socket",
+//	 "rule_file": "/etc/suricata/rules/emitto.rules",
+//	 "storage_bucket": "emitto-rules",
+//	 "org": "example", "zone": "dmz",
+//	 "capture_interfaces": ["eth1"],
+//	 "eve": {"enabled": true, "log": "/var/log/suricata/eve.json", "polling": "1m",
+//	         "policy": "/etc/emitto/alert_policy.json"},
+//	 "heartbeat": {"enabled": true, "period": "10m"},
+//	 "ack_timeout": "1m",
+//	 "request_ledger": "/var/lib/emitto/requests.json", "request_expiry": "24h",
+//	 "queue": {"enabled": true, "dir": "/var/lib/emitto/queue", "max_messages": 10000,
+//	           "max_bytes": 104857600, "max_age": "24h"}}
+//
+// Settings tagged restart:"true" only take effect when the sensor is restarted; all others are
+// applied when the configuration is reloaded.
+type Config struct {
+	// Transport to the server: "fleetspeak" (default) or "grpc".
+	Transport string `json:"transport,omitempty" restart:"true"`
+	// Fleetspeak client socket.
+	FleetspeakSocket string `json:"fleetspeak_socket" restart:"true"`
+	// Address of the Emitto server sensor endpoint, for the gRPC transport.
+	ServerAddr string `json:"server_addr,omitempty" restart:"true"`
+	// Paths of the sensor certificate and key, and of the CA certificates verifying the server,
+	// for the gRPC transport. The subject organization and organizational unit of the certificate
+	// must name the location and zone of the sensor.
+	TLSCert string `json:"tls_cert,omitempty" restart:"true"`
+	TLSKey  string `json:"tls_key,omitempty" restart:"true"`
+	TLSCA   string `json:"tls_ca,omitempty" restart:"true"`
+	// Labels of the sensor for the gRPC transport; derived from the organization and zone if
+	// empty.
+	Labels []string `json:"labels,omitempty" restart:"true"`
+	// Suricata Unix socket.
+	SuricataSocket string `json:"suricata_socket" restart:"true"`
+	// Suricata rule file path.
+	RuleFile string `json:"rule_file" restart:"true"`
+	// Suricata binary used to validate rule files before installing them.
+	SuricataBinary string `json:"suricata_binary,omitempty" restart:"true"`
+	// Suricata configuration file used to validate rule files.
+	SuricataConfig string `json:"suricata_config,omitempty" restart:"true"`
+	// Suricata log file, collected for diagnostics.
+	SuricataLog string `json:"suricata_log,omitempty" restart:"true"`
+	// Suricata socket commands the server is allowed to run.
+	AllowedCommands []string `json:"allowed_commands,omitempty" restart:"true"`
+	// Directory of the Suricata dataset files, written on deployment.
+	DatasetDir string `json:"dataset_dir,omitempty" restart:"true"`
+	// Path of the base64-encoded Ed25519 public key used to verify rule files.
+	RulePublicKey string `json:"rule_public_key,omitempty" restart:"true"`
+	// Use memory filestore.
+	MemoryStorage bool `json:"memory_storage,omitempty" restart:"true"`
+	// Google Cloud project ID.
+	ProjectID string `json:"project_id,omitempty" restart:"true"`
+	// Google Cloud Storage bucket for storing rule files.
+	StorageBucket string `json:"storage_bucket,omitempty" restart:"true"`
+	// Path of the JSON application credential file.
+	CredFile string `json:"cred_file,omitempty" restart:"true"`
+	// Path of the file keeping the sensor UUID.
+	UUIDFile string `json:"uuid_file" restart:"true"`
+	// Local status and control endpoint: "unix:<path>" or a loopback TCP address; disabled if
+	// empty.
+	AdminAddr string `json:"admin_addr,omitempty" restart:"true"`
+	// Path of the file containing the token authenticating local actions; actions are disabled if
+	// empty.
+	AdminTokenFile string `json:"admin_token_file,omitempty" restart:"true"`
+	// Time after which a message unacknowledged by the server is considered lost, e.g. "1m".
+	AckTimeout string `json:"ack_timeout" restart:"true"`
+	// Durable queue of the messages sent to the server.
+	Queue QueueConfig `json:"queue" restart:"true"`
+	// Path of the file recording the processed requests and the installed deployment generations.
+	// They are only kept in memory if empty.
+	RequestLedger string `json:"request_ledger" restart:"true"`
+	// Age after which deployment requests are rejected as stale, e.g. "24h".
+	RequestExpiry string `json:"request_expiry" restart:"true"`
+
+	// Sensor organization.
+	Org string `json:"org"`
+	// Sensor zone.
+	Zone string `json:"zone"`
+	// Names of the interfaces Suricata captures traffic on; identified from the interface state if
+	// empty.
+	CaptureInterfaces []string `json:"capture_interfaces,omitempty"`
+
+	EVE       EVEConfig       `json:"eve"`
+	Heartbeat HeartbeatConfig `json:"heartbeat"`
+}
+
+// EVEConfig configures the monitoring of the Suricata EVE log.
+type EVEConfig struct {
+	// Monitor the EVE log.
+	Enabled bool `json:"enabled"`
+	// Path of the eve.json file.
+	Log string `json:"log"`
+	// Path of the file saving the position in the EVE log across restarts.
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// Polling interval for reading new EVE events, e.g. "1m".
+	Polling string `json:"polling"`
+	// Sliding time window for counting Suricata alerts, without Policy.
+	Window string `json:"window"`
+	// Alerting threshold for Suricata alerts within the window, without Policy.
+	Threshold int `json:"threshold"`
+	// Path of the JSON alert policy setting thresholds per severity, category, signature or host.
+	Policy string `json:"policy,omitempty"`
+	// Forward sampled Suricata alert events to the server.
+	Forward bool `json:"forward"`
+	// Fraction of Suricata alert events to forward.
+	SampleRate float64 `json:"sample_rate"`
+	// Maximum number of Suricata alert events forwarded per second.
+	RateLimit float64 `json:"rate_limit"`
+	// Maximum number of Suricata alert events forwarded in a burst.
+	Burst int `json:"burst"`
+	// Maximum number of Suricata alert events per message.
+	BatchSize int `json:"batch_size"`
+	// Interval for reporting Suricata alert counts per rule.
+	RuleHitsPeriod string `json:"rule_hits_period"`
+}
+
+// HeartbeatConfig configures the heartbeats sent to the server.
+type HeartbeatConfig struct {
+	// Send heartbeats.
+	Enabled bool `json:"enabled"`
+	// Interval between heartbeats, e.g. "10m".
+	Period string `json:"period"`
+}
+
+// QueueConfig configures the durable queue of the messages sent to the server, which keeps them
+// while the server is unreachable and across restarts.
+type QueueConfig struct {
+	// Queue messages; they are sent directly otherwise.
+	Enabled bool `json:"enabled"`
+	// Directory keeping the queued messages.
+	Dir string `json:"dir"`
+	// Maximum number and total size in bytes of the queued messages; the oldest messages are
+	// dropped beyond.
+	MaxMessages int   `json:"max_messages"`
+	MaxBytes    int64 `json:"max_bytes"`
+	// Maximum age of the queued messages, e.g. "24h"; older messages are dropped.
+	MaxAge string `json:"max_age"`
+}
+
+// Load reads a Config from a JSON file. Settings absent from the file keep their value in base,
+// e.g. the defaults or command line flags. The loaded Config is validated.
+func Load(path string, base *Config) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sensor config %q: %v", path, err)
+	}
+	c, err := base.Clone()
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	if err := d.Decode(c); err != nil {
+		return nil, fmt.Errorf("failed to parse sensor config %q: %v", path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sensor config %q: %v", path, err)
+	}
+	return c, nil
+}
+
+// Clone returns a deep copy of the Config.
+func (c *Config) Clone() (*Config, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	n := new(Config)
+	if err := json.Unmarshal(b, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Validate checks that the Config is well formed.
+func (c *Config) Validate() error {
+	if !c.MemoryStorage && c.StorageBucket == "" {
+		return errors.New("no storage bucket")
+	}
+	if c.UUIDFile == "" {
+		return errors.New("no UUID file")
+	}
+	switch c.Transport {
+	case "", "fleetspeak":
+	case "grpc":
+		if c.ServerAddr == "" {
+			return errors.New("no server address for the gRPC transport")
+		}
+		if c.TLSCert == "" || c.TLSKey == "" || c.TLSCA == "" {
+			return errors.New("the gRPC transport requires a TLS certificate, key and CA")
+		}
+	default:
+		return fmt.Errorf("unknown transport %q", c.Transport)
+	}
+	for _, i := range c.CaptureInterfaces {
+		if strings.TrimSpace(i) == "" {
+			return errors.New("empty capture interface name")
+		}
+	}
+	if _, err := parseDuration(c.AckTimeout); err != nil {
+		return fmt.Errorf("ack_timeout: %v", err)
+	}
+	if _, err := parseDuration(c.RequestExpiry); err != nil {
+		return fmt.Errorf("request_expiry: %v", err)
+	}
+	if err := c.Queue.validate(); err != nil {
+		return fmt.Errorf("queue: %v", err)
+	}
+	if err := c.EVE.validate(); err != nil {
+		return fmt.Errorf("eve: %v", err)
+	}
+	if err := c.Heartbeat.validate(); err != nil {
+		return fmt.Errorf("heartbeat: %v", err)
+	}
+	return nil
+}
+
+func (e *EVEConfig) validate() error {
+	if !e.Enabled {
+		return nil
+	}
+	if e.Log == "" {
+		return errors.New("no EVE log")
+	}
+	for name, d := range map[string]string{"polling": e.Polling, "window": e.Window, "rule_hits_period": e.RuleHitsPeriod} {
+		if _, err := parseDuration(d); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	if e.Policy != "" {
+		if _, err := eve.LoadPolicy(e.Policy); err != nil {
+			return err
+		}
+	} else if e.Threshold < 0 {
+		return fmt.Errorf("negative threshold %d", e.Threshold)
+	}
+	if e.Forward {
+		if e.SampleRate <= 0 || e.SampleRate > 1 {
+			return fmt.Errorf("invalid sample rate %v", e.SampleRate)
+		}
+		if e.BatchSize < 1 {
+			return fmt.Errorf("invalid batch size %d", e.BatchSize)
+		}
+	}
+	return nil
+}
+
+func (h *HeartbeatConfig) validate() error {
+	if !h.Enabled {
+		return nil
+	}
+	if _, err := parseDuration(h.Period); err != nil {
+		return fmt.Errorf("period: %v", err)
+	}
+	return nil
+}
+
+func (q *QueueConfig) validate() error {
+	if !q.Enabled {
+		return nil
+	}
+	if q.Dir == "" {
+		return errors.New("queue enabled without a queue directory")
+	}
+	if q.MaxMessages < 1 {
+		return fmt.Errorf("invalid maximum number of messages %d", q.MaxMessages)
+	}
+	if q.MaxBytes < 1 {
+		return fmt.Errorf("invalid maximum size %d", q.MaxBytes)
+	}
+	if _, err := parseDuration(q.MaxAge); err != nil {
+		return fmt.Errorf("max_age: %v", err)
+	}
+	return nil
+}
+
+// parseDuration parses a positive duration.
+func parseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("non-positive duration %q", s)
+	}
+	return d, nil
+}
+
+// PollingInterval returns the EVE polling interval of a validated Config.
+func (e *EVEConfig) PollingInterval() time.Duration {
+	d, _ := parseDuration(e.Polling)
+	return d
+}
+
+// WindowDuration returns the alert window of a validated Config.
+func (e *EVEConfig) WindowDuration() time.Duration {
+	d, _ := parseDuration(e.Window)
+	return d
+}
+
+// RuleHitsInterval returns the rule hits reporting interval of a validated Config.
+func (e *EVEConfig) RuleHitsInterval() time.Duration {
+	d, _ := parseDuration(e.RuleHitsPeriod)
+	return d
+}
+
+// AckTimeoutDuration returns the acknowledgement timeout of a validated Config.
+func (c *Config) AckTimeoutDuration() time.Duration {
+	d, _ := parseDuration(c.AckTimeout)
+	return d
+}
+
+// RequestExpiryDuration returns the request expiry of a validated Config.
+func (c *Config) RequestExpiryDuration() time.Duration {
+	d, _ := parseDuration(c.RequestExpiry)
+	return d
+}
+
+// MaxAgeDuration returns the maximum age of the queued messages of a validated Config.
+func (q *QueueConfig) MaxAgeDuration() time.Duration {
+	d, _ := parseDuration(q.MaxAge)
+	return d
+}
+
+// Interval returns the heartbeat interval of a validated Config.
+func (h *HeartbeatConfig) Interval() time.Duration {
+	d, _ := parseDuration(h.Period)
+	return d
+}
+
+// RestartRequired returns the JSON names of the settings which differ between old and new but only
+// take effect when the sensor is restarted, sorted by name.
+func RestartRequired(old, new *Config) []string {
+	var names []string
+	ov, nv := reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	t := ov.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("restart") != "true" {
+			continue
+		}
+		if !reflect.DeepEqual(ov.Field(i).Interface(), nv.Field(i).Interface()) {
+			names = append(names, strings.Split(f.Tag.Get("json"), ",")[0])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
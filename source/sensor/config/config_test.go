@@ -0,0 +1,228 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func baseConfig() *Config {
+	return &Config{
+		FleetspeakSocket: "/var/run/fleetspeak.sock",
+		MemoryStorage:    true,
+		UUIDFile:         "/var/lib/emitto/sensor_uuid",
+		AckTimeout:       "1m",
+		RequestLedger:    "/var/lib/emitto/requests.json",
+		RequestExpiry:    "24h",
+		Org:              "flags",
+		Queue: QueueConfig{
+			Dir:         "/var/lib/emitto/queue",
+			MaxMessages: 10000,
+			MaxBytes:    100 << 20,
+			MaxAge:      "24h",
+		},
+		EVE: EVEConfig{
+			Log:            "/var/log/suricata/eve.json",
+			Polling:        "1m",
+			Window:         "10m",
+			Threshold:      100,
+			SampleRate:     1,
+			BatchSize:      100,
+			RuleHitsPeriod: "1h",
+		},
+		Heartbeat: HeartbeatConfig{Period: "10m"},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	d, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	policy := filepath.Join(d, "policy.json")
+	if err := ioutil.WriteFile(policy, []byte(`{"rules": [{"name": "a", "threshold": 1, "window": "1m"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		desc    string
+		config  string
+		want    func(*Config)
+		wantErr bool
+	}{
+		{
+			desc:   "empty",
+			config: `{}`,
+			want:   func(*Config) {},
+		},
+		{
+			desc:   "overrides",
+			config: `{"org": "file", "zone": "dmz", "capture_interfaces": ["eth1"], "eve": {"enabled": true, "threshold": 10, "policy": "` + policy + `"}, "heartbeat": {"enabled": true, "period": "5m"}}`,
+			want: func(c *Config) {
+				c.Org, c.Zone, c.CaptureInterfaces = "file", "dmz", []string{"eth1"}
+				c.EVE.Enabled, c.EVE.Threshold, c.EVE.Policy = true, 10, policy
+				c.Heartbeat = HeartbeatConfig{Enabled: true, Period: "5m"}
+			},
+		},
+		{
+			desc:    "unknown setting",
+			config:  `{"eve": {"treshold": 10}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "malformed",
+			config:  `{"org": `,
+			wantErr: true,
+		},
+		{
+			desc:    "no storage bucket",
+			config:  `{"memory_storage": false}`,
+			wantErr: true,
+		},
+		{
+			desc:   "grpc transport",
+			config: `{"transport": "grpc", "server_addr": "emitto:4445", "tls_cert": "sensor.crt", "tls_key": "sensor.key", "tls_ca": "ca.crt"}`,
+			want: func(c *Config) {
+				c.Transport, c.ServerAddr = "grpc", "emitto:4445"
+				c.TLSCert, c.TLSKey, c.TLSCA = "sensor.crt", "sensor.key", "ca.crt"
+			},
+		},
+		{
+			desc:    "grpc transport without TLS",
+			config:  `{"transport": "grpc", "server_addr": "emitto:4445"}`,
+			wantErr: true,
+		},
+		{
+			desc:    "unknown transport",
+			config:  `{"transport": "carrier pigeon"}`,
+			wantErr: true,
+		},
+		{
+			desc:   "queue",
+			config: `{"ack_timeout": "30s", "queue": {"enabled": true, "max_messages": 10}}`,
+			want: func(c *Config) {
+				c.AckTimeout = "30s"
+				c.Queue.Enabled, c.Queue.MaxMessages = true, 10
+			},
+		},
+		{
+			desc:    "invalid request expiry",
+			config:  `{"request_expiry": "soon"}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid ack timeout",
+			config:  `{"ack_timeout": "0s"}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid queue size",
+			config:  `{"queue": {"enabled": true, "max_bytes": 0}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid polling interval",
+			config:  `{"eve": {"enabled": true, "polling": "often"}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "missing alert policy",
+			config:  `{"eve": {"enabled": true, "policy": "` + filepath.Join(d, "missing.json") + `"}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid sample rate",
+			config:  `{"eve": {"enabled": true, "forward": true, "sample_rate": 0}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid heartbeat period",
+			config:  `{"heartbeat": {"enabled": true, "period": "-1m"}}`,
+			wantErr: true,
+		},
+		{
+			desc:   "invalid settings of disabled features",
+			config: `{"eve": {"polling": "often"}, "heartbeat": {"period": "-1m"}}`,
+			want: func(c *Config) {
+				c.EVE.Polling, c.Heartbeat.Period = "often", "-1m"
+			},
+		},
+	} {
+		path := filepath.Join(d, "sensor.json")
+		if err := ioutil.WriteFile(path, []byte(tt.config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		base := baseConfig()
+		got, err := Load(path, base)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+		if diff := cmp.Diff(baseConfig(), base); diff != "" {
+			t.Errorf("%s: base config modified (-want +got):\n%s", tt.desc, diff)
+		}
+		if err != nil {
+			continue
+		}
+		want := baseConfig()
+		tt.want(want)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func TestDurations(t *testing.T) {
+	c := baseConfig()
+	for _, tt := range []struct {
+		got, want time.Duration
+	}{
+		{c.EVE.PollingInterval(), time.Minute},
+		{c.EVE.WindowDuration(), 10 * time.Minute},
+		{c.EVE.RuleHitsInterval(), time.Hour},
+		{c.Heartbeat.Interval(), 10 * time.Minute},
+		{c.AckTimeoutDuration(), time.Minute},
+		{c.RequestExpiryDuration(), 24 * time.Hour},
+		{c.Queue.MaxAgeDuration(), 24 * time.Hour},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("got duration %v, want %v", tt.got, tt.want)
+		}
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	old := baseConfig()
+	c := baseConfig()
+	c.Org = "live"
+	c.CaptureInterfaces = []string{"eth1"}
+	c.EVE.Threshold = 5
+	if got := RestartRequired(old, c); len(got) != 0 {
+		t.Errorf("got settings requiring a restart %v, want none", got)
+	}
+	c.UUIDFile = "/tmp/uuid"
+	c.FleetspeakSocket = "/tmp/fleetspeak.sock"
+	c.Queue.MaxAge = "1h"
+	want := []string{"fleetspeak_socket", "queue", "uuid_file"}
+	if diff := cmp.Diff(want, RestartRequired(old, c)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+
+	log "github.com/golang/glog"
+)
+
+// Watcher reloads a Config file when it changes.
+type Watcher struct {
+	path string
+	base *Config
+	// Config the sensor was started with.
+	initial *Config
+	// Last loaded Config.
+	current *Config
+	// Digest of the last read file content.
+	sum [sha256.Size]byte
+}
+
+// NewWatcher loads the Config file at path over base, and returns a Watcher for it with the
+// loaded Config.
+func NewWatcher(path string, base *Config) (*Watcher, *Config, error) {
+	w := &Watcher{path: path, base: base}
+	c, _, err := w.check(true)
+	if err != nil {
+		return nil, nil, err
+	}
+	w.initial = c
+	return w, c, nil
+}
+
+// check reloads the Config file if its content changed since the last check, or if force is set.
+// It returns the loaded Config and whether it differs from the current one.
+func (w *Watcher) check(force bool) (*Config, bool, error) {
+	b, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read sensor config %q: %v", w.path, err)
+	}
+	sum := sha256.Sum256(b)
+	if !force && sum == w.sum {
+		return w.current, false, nil
+	}
+	// Invalid content is only reported once, until the file changes again.
+	w.sum = sum
+	c, err := Load(w.path, w.base)
+	if err != nil {
+		return nil, false, err
+	}
+	if reflect.DeepEqual(c, w.current) {
+		return c, false, nil
+	}
+	w.current = c
+	return c, true, nil
+}
+
+// Reload reloads the Config file if its content changed since the last reload, or if force is set.
+// If the loaded Config differs from the current one, it is returned with the JSON names of the
+// changed settings which require a restart; otherwise the returned Config is nil.
+func (w *Watcher) Reload(force bool) (*Config, []string, error) {
+	c, changed, err := w.check(force)
+	if err != nil || !changed {
+		return nil, nil, err
+	}
+	return c, RestartRequired(w.initial, c), nil
+}
+
+// Run reloads the Config file whenever the process receives SIGHUP, or the file content changes,
+// checked at every interval. Changed configurations are passed to apply. Settings which cannot be
+// changed without a restart are reported, and configurations which fail to load are ignored. Run
+// returns when ctx is done.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration, apply func(*Config)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		var force bool
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Infof("Received SIGHUP; reloading sensor config %q", w.path)
+			force = true
+		case <-t.C:
+		}
+		c, restart, err := w.Reload(force)
+		if err != nil {
+			log.Errorf("Failed to reload sensor config; keeping the current config: %v", err)
+			continue
+		}
+		if c == nil {
+			continue
+		}
+		for _, name := range restart {
+			log.Warningf("Sensor config setting %q changed; restart the sensor to apply it", name)
+		}
+		log.Infof("Applying reloaded sensor config %q", w.path)
+		apply(c)
+	}
+}
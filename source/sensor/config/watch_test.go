@@ -0,0 +1,118 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWatcher(t *testing.T) {
+	d, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	path := filepath.Join(d, "sensor.json")
+	write := func(config string) {
+		if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"org": "a"}`)
+	w, c, err := NewWatcher(path, baseConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Org != "a" {
+		t.Errorf("got org %q, want %q", c.Org, "a")
+	}
+
+	for _, tt := range []struct {
+		desc        string
+		config      string
+		force       bool
+		wantOrg     string
+		wantRestart []string
+		wantErr     bool
+	}{
+		{
+			desc:   "unchanged file",
+			config: `{"org": "a"}`,
+		},
+		{
+			desc:   "forced reload of an unchanged config",
+			config: `{"org": "a"}`,
+			force:  true,
+		},
+		{
+			desc:   "reformatted file",
+			config: `{ "org": "a" }`,
+		},
+		{
+			desc:    "live setting",
+			config:  `{"org": "b"}`,
+			wantOrg: "b",
+		},
+		{
+			desc:    "invalid config",
+			config:  `{"org": "c", "uuid_file": ""}`,
+			wantErr: true,
+		},
+		{
+			desc:   "invalid config unchanged",
+			config: `{"org": "c", "uuid_file": ""}`,
+		},
+		{
+			desc:        "restart setting",
+			config:      `{"org": "b", "fleetspeak_socket": "/tmp/fs.sock"}`,
+			wantOrg:     "b",
+			wantRestart: []string{"fleetspeak_socket"},
+		},
+		{
+			desc:        "restart setting still pending",
+			config:      `{"org": "d", "fleetspeak_socket": "/tmp/fs.sock"}`,
+			wantOrg:     "d",
+			wantRestart: []string{"fleetspeak_socket"},
+		},
+	} {
+		write(tt.config)
+		got, restart, err := w.Reload(tt.force)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+		if tt.wantOrg == "" {
+			if got != nil {
+				t.Errorf("%s: got a changed config %+v, want none", tt.desc, got)
+			}
+			continue
+		}
+		if got == nil {
+			t.Errorf("%s: got no changed config", tt.desc)
+			continue
+		}
+		if got.Org != tt.wantOrg {
+			t.Errorf("%s: got org %q, want %q", tt.desc, got.Org, tt.wantOrg)
+		}
+		if diff := cmp.Diff(tt.wantRestart, restart); diff != "" {
+			t.Errorf("%s: settings requiring a restart mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+// timeFormats are the accepted EVE timestamp formats, in order:
+//   - Suricata's, with a numeric zone offset, e.g. "2019-05-13T14:12:19.384640+0000";
+//   - RFC 3339, e.g. "2019-05-13T14:12:19.384640Z" or "2019-05-13T16:12:19.384640+02:00", as
+//     rewritten by some log shippers.
+var timeFormats = []string{"2006-01-02T15:04:05.999999999-0700", time.RFC3339Nano}
+
+// localTimeFormat is the format of EVE timestamps without a zone offset, interpreted in a given
+// location.
+const localTimeFormat = "2006-01-02T15:04:05.999999999"
+
+// ParseTimestamp parses an EVE timestamp. Timestamps without a zone offset are interpreted in loc.
+func ParseTimestamp(s string, loc *time.Location) (time.Time, error) {
+	for _, f := range timeFormats {
+		if t, err := time.Parse(f, s); err == nil {
+			return t, nil
+		}
+	}
+	t, err := time.ParseInLocation(localTimeFormat, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse timestamp %q", s)
+	}
+	return t, nil
+}
+
+// Decode unmarshals an EVE json log line. Fields unknown to the EVE schema are ignored, and fields
+// whose type differs from the schema, e.g. as logged by another Suricata version, are left unset;
+// only lines which are not a json object are rejected.
+func Decode(line []byte) (*evepb.EVE, error) {
+	e := new(evepb.EVE)
+	if err := json.Unmarshal(line, e); err != nil {
+		// The remaining fields are still decoded after a type mismatch.
+		if _, ok := err.(*json.UnmarshalTypeError); !ok || !bytes.HasPrefix(bytes.TrimSpace(line), []byte("{")) {
+			return nil, fmt.Errorf("cannot unmarshal json string %q: %v", line, err)
+		}
+	}
+	return e, nil
+}
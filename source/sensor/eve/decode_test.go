@@ -0,0 +1,445 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+// readCorpus returns the EVE lines of a testdata file.
+func readCorpus(t *testing.T, name string) [][]byte {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines [][]byte
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		lines = append(lines, append([]byte(nil), s.Bytes()...))
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+func TestDecodeCorpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "suricata-*.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no EVE corpus files")
+	}
+	for _, f := range files {
+		name := filepath.Base(f)
+		for i, line := range readCorpus(t, name) {
+			// Every field of the corpus must match the type of the schema.
+			if err := json.Unmarshal(line, new(evepb.EVE)); err != nil {
+				t.Errorf("%s:%d: schema mismatch: %v", name, i+1, err)
+			}
+			e, err := Decode(line)
+			if err != nil {
+				t.Errorf("%s:%d: Decode() failed: %v", name, i+1, err)
+				continue
+			}
+			if e.GetEventType() == "" {
+				t.Errorf("%s:%d: no event type", name, i+1)
+			}
+			if _, err := ParseTimestamp(e.GetTimestamp(), time.UTC); err != nil {
+				t.Errorf("%s:%d: %v", name, i+1, err)
+			}
+		}
+	}
+}
+
+func TestDecodeCorpusFields(t *testing.T) {
+	for _, tt := range []struct {
+		file string
+		line int
+		// Returns the checked part of the event.
+		get  func(*evepb.EVE) proto.Message
+		want proto.Message
+	}{
+		{
+			file: "suricata-4.1.jsonl",
+			line: 3,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetFileinfo() },
+			want: &evepb.FileInfo{
+				Filename: "/setup.exe",
+				Magic:    "PE32 executable (GUI) Intel 80386, for MS Windows",
+				State:    "CLOSED",
+				Md5:      "5d41402abc4b2a76b9719d911017c592",
+				Sha1:     "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+				Sha256:   "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+				Size:     48128,
+			},
+		},
+		{
+			file: "suricata-5.0.jsonl",
+			line: 1,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetAlert().GetMetadata() },
+			want: &evepb.Metadata{
+				AffectedProduct:   []string{"Windows_XP_Vista_7_8_10_Server_32_64_Bit"},
+				AttackTarget:      []string{"Client_and_Server"},
+				Deployment:        []string{"Perimeter"},
+				FormerCategory:    []string{"SCAN"},
+				SignatureSeverity: []string{"Minor"},
+				CreatedAt:         []string{"2017_01_23"},
+				UpdatedAt:         []string{"2018_09_28"},
+			},
+		},
+		{
+			file: "suricata-5.0.jsonl",
+			line: 2,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetDns() },
+			want: &evepb.DNS{
+				Version: 2,
+				Type:    "answer",
+				Id:      1234,
+				Flags:   "8180",
+				Qr:      true,
+				Rd:      true,
+				Ra:      true,
+				Rrname:  "www.example.org",
+				Rrtype:  "A",
+				Rcode:   "NOERROR",
+				Answers: []*evepb.DNSAnswer{
+					{Rrname: "www.example.org", Rrtype: "CNAME", Ttl: 300, Rdata: "example.org"},
+					{Rrname: "example.org", Rrtype: "A", Ttl: 300, Rdata: "93.184.216.34"},
+				},
+				Grouped: &evepb.DNSGrouped{Cname: []string{"example.org"}, A: []string{"93.184.216.34"}},
+			},
+		},
+		{
+			file: "suricata-5.0.jsonl",
+			line: 4,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetAnomaly() },
+			want: &evepb.Anomaly{Type: "applayer", Event: "INVALID_RECORD_TYPE", Code: 4, Layer: "proto_parser"},
+		},
+		{
+			file: "suricata-5.0.jsonl",
+			line: 7,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetKrb5() },
+			want: &evepb.KRB5{
+				MsgType:        "KRB_AS_REQ",
+				Cname:          "alice",
+				Realm:          "CORP.EXAMPLE.COM",
+				Sname:          "krbtgt/CORP.EXAMPLE.COM",
+				Encryption:     "rc4-hmac",
+				WeakEncryption: true,
+				FailedRequest:  "KRB_AS_REQ",
+				ErrorCode:      "KDC_ERR_PREAUTH_REQUIRED",
+			},
+		},
+		{
+			file: "suricata-5.0.jsonl",
+			line: 9,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetFlow() },
+			want: &evepb.Flow{
+				PktsToserver:  3045022,
+				PktsToclient:  6122904,
+				BytesToserver: 412004122,
+				BytesToclient: 8123456789,
+				Start:         "2020-03-01T09:00:00.000000+0100",
+				End:           "2020-03-02T09:15:48.000000+0100",
+				Age:           87348,
+				State:         "closed",
+				Reason:        "timeout",
+			},
+		},
+		{
+			file: "suricata-6.0.jsonl",
+			line: 1,
+			get:  func(e *evepb.EVE) proto.Message { return e },
+			want: &evepb.EVE{
+				Timestamp:   "2021-06-10T22:04:11.482331-0400",
+				FlowId:      1086223398711244,
+				InIface:     "ens192",
+				EventType:   "alert",
+				Vlan:        []int32{10, 20},
+				SrcIp:       "10.2.3.4",
+				SrcPort:     443,
+				DestIp:      "10.9.8.7",
+				DestPort:    60123,
+				Proto:       "TCP",
+				CommunityId: "1:Q5nvo3wW6w1tJ0qR1ZQ2bF6Fv0w=",
+				AppProto:    "tls",
+				Direction:   "to_client",
+				Alert: &evepb.Alert{
+					Action:      "blocked",
+					Gid:         1,
+					SignatureId: 2030358,
+					Rev:         2,
+					Signature:   "ET MALWARE Observed Malicious SSL Cert (Cobalt Strike)",
+					Category:    "A Network Trojan was detected",
+					Severity:    1,
+					Metadata: &evepb.Metadata{
+						AffectedProduct:   []string{"Windows_XP_Vista_7_8_10_Server_32_64_Bit"},
+						AttackTarget:      []string{"Client_Endpoint"},
+						CreatedAt:         []string{"2020_06_15"},
+						Deployment:        []string{"Perimeter"},
+						MalwareFamily:     []string{"Cobalt_Strike"},
+						PerformanceImpact: []string{"Low"},
+						SignatureSeverity: []string{"Major"},
+						Tag:               []string{"SSL_Malicious_Cert"},
+						UpdatedAt:         []string{"2020_06_15"},
+					},
+				},
+				Tls: &evepb.TLS{
+					Subject:     "C=US, ST=, L=, O=, OU=, CN=",
+					Issuerdn:    "C=US, ST=, L=, O=, OU=, CN=",
+					Serial:      "7E:E2:C4:9A",
+					Fingerprint: "6e:ce:5e:ce:41:92:68:3d:2d:84:e2:5b:0b:a7:e0:4f:9c:b7:eb:7c",
+					Version:     "TLS 1.2",
+					Notbefore:   "2020-05-01T00:00:00",
+					Notafter:    "2021-05-01T00:00:00",
+					Ja3S:        &evepb.JA3{Hash: "ae4edc6faf64d08308082ad26be60767", String_: "771,49199,65281-0-11-35-23"},
+				},
+				Flow: &evepb.Flow{
+					PktsToserver:  8,
+					PktsToclient:  7,
+					BytesToserver: 1088,
+					BytesToclient: 3621,
+					Start:         "2021-06-10T22:04:11.101234-0400",
+				},
+			},
+		},
+		{
+			file: "suricata-6.0.jsonl",
+			line: 2,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetDrop() },
+			want: &evepb.Drop{Len: 52, Ttl: 128, Ipid: 4242, Tcpseq: 3040591223, Tcpack: 2049128811, Tcpwin: 1024, Ack: true},
+		},
+		{
+			file: "suricata-6.0.jsonl",
+			line: 3,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetHttp() },
+			want: &evepb.HTTP{
+				Hostname:      "www.example.com",
+				Url:           "/",
+				HttpUserAgent: "Mozilla/5.0",
+				HttpMethod:    "GET",
+				Protocol:      "HTTP/2",
+				Status:        200,
+				Version:       "2",
+				RequestHeaders: []*evepb.Header{
+					{Name: ":method", Value: "GET"},
+					{Name: ":path", Value: "/"},
+					{Name: ":authority", Value: "www.example.com"},
+				},
+				ResponseHeaders: []*evepb.Header{
+					{Name: ":status", Value: "200"},
+					{TableSizeUpdate: 4096},
+				},
+				Http2: &evepb.HTTP2{
+					StreamId: 1,
+					Request: &evepb.HTTP2Message{
+						Settings: []*evepb.HTTP2Setting{{SettingsId: "SETTINGS_HEADER_TABLE_SIZE", SettingsValue: 65536}},
+					},
+					Response: &evepb.HTTP2Message{},
+				},
+			},
+		},
+		{
+			file: "suricata-6.0.jsonl",
+			line: 5,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetStats().GetAppLayer() },
+			want: &evepb.AppLayerStats{
+				Flow: map[string]int64{"http": 100000, "tls": 250000, "smb": 120, "krb5_tcp": 30, "dhcp": 50, "rdp": 4, "http2": 800, "failed_tcp": 122},
+				Tx:   map[string]int64{"http": 140000, "tls": 0, "smb": 4000, "dhcp": 120, "rdp": 40, "http2": 2400},
+			},
+		},
+		{
+			file: "suricata-6.0.jsonl",
+			line: 6,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetSmb() },
+			want: &evepb.SMB{
+				Id:             1,
+				Dialect:        "unknown",
+				Command:        "SMB2_COMMAND_NEGOTIATE_PROTOCOL",
+				Status:         "STATUS_SUCCESS",
+				StatusCode:     "0x0",
+				ClientDialects: []string{"2.02", "2.10", "3.00", "3.02", "3.11"},
+				ClientGuid:     "6ab4fa33-2e11-4c0d-9b8e-6a2f1f6e11d2",
+				ServerGuid:     "2c9a0e55-0d76-4b0b-a1ff-3f5e2b9d6a10",
+			},
+		},
+		{
+			file: "suricata-7.0.jsonl",
+			line: 2,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetQuic() },
+			want: &evepb.QUIC{
+				Version: "Q043",
+				Sni:     "www.example.com",
+				Ua:      "Chrome/74.0.3729.169 Linux x86_64",
+				Cyu: []*evepb.CYU{{
+					Hash:    "7b3ceb1adc974ad360cfa634e8d0a730",
+					String_: "46,PAD-SNI-STK-SNO-VER-CCS-NONC-AEAD-UAID-SCID-TCID-PDMD-SMHL-ICSL-NONP-PUBS-MIDS-SCLS-KEXS-XLCT-CSCT-COPT-CCRT-IRTT-CFCW-SFCW",
+				}},
+			},
+		},
+		{
+			file: "suricata-7.0.jsonl",
+			line: 7,
+			get:  func(e *evepb.EVE) proto.Message { return e.GetDhcp() },
+			want: &evepb.DHCP{
+				Type:                  "request",
+				Id:                    1193046,
+				ClientMac:             "aa:bb:cc:dd:ee:ff",
+				AssignedIp:            "0.0.0.0",
+				ClientIp:              "0.0.0.0",
+				DhcpType:              "discover",
+				ClientId:              "01:aa:bb:cc:dd:ee:ff",
+				Hostname:              "laptop",
+				Params:                []string{"subnet_mask", "router", "dns_server", "domain"},
+				VendorClassIdentifier: "MSFT 5.0",
+			},
+		},
+	} {
+		lines := readCorpus(t, tt.file)
+		if tt.line > len(lines) {
+			t.Fatalf("%s has no line %d", tt.file, tt.line)
+		}
+		e, err := Decode(lines[tt.line-1])
+		if err != nil {
+			t.Errorf("%s:%d: Decode() failed: %v", tt.file, tt.line, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, tt.get(e), cmp.Comparer(proto.Equal)); diff != "" {
+			t.Errorf("%s:%d: expectation mismatch (-want +got):\n%s", tt.file, tt.line, diff)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		line    string
+		want    *evepb.EVE
+		wantErr bool
+	}{
+		{
+			desc: "unknown fields",
+			line: `{"timestamp": "2019-05-13T14:12:19.384640+0000", "event_type": "alert", "pkt_src": "wire/pcap", "alert": {"signature_id": 1, "source": {"ip": "1.1.1.1"}}}`,
+			want: &evepb.EVE{Timestamp: "2019-05-13T14:12:19.384640+0000", EventType: "alert", Alert: &evepb.Alert{SignatureId: 1}},
+		},
+		{
+			desc: "mismatched field type",
+			line: ` {"vlan": 10, "event_type": "flow", "src_ip": "1.1.1.1"}`,
+			want: &evepb.EVE{EventType: "flow", SrcIp: "1.1.1.1"},
+		},
+		{
+			desc:    "not an object",
+			line:    `["alert"]`,
+			wantErr: true,
+		},
+		{
+			desc:    "malformed JSON",
+			line:    `{"event_type": "alert"`,
+			wantErr: true,
+		},
+		{
+			desc:    "not JSON",
+			line:    `<Warning> -- [ERRCODE: SC_ERR_EVENT_ENGINE(210)]`,
+			wantErr: true,
+		},
+	} {
+		got, err := Decode([]byte(tt.line))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.Comparer(proto.Equal)); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	for _, tt := range []struct {
+		desc    string
+		ts      string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			desc: "UTC offset",
+			ts:   "2019-05-13T14:12:19.384640+0000",
+			want: time.Date(2019, 5, 13, 14, 12, 19, 384640000, time.UTC),
+		},
+		{
+			desc: "non-UTC offset",
+			ts:   "2021-06-10T22:04:11.482331-0400",
+			want: time.Date(2021, 6, 11, 2, 4, 11, 482331000, time.UTC),
+		},
+		{
+			desc: "no fractional seconds",
+			ts:   "2019-05-13T16:12:19+0200",
+			want: time.Date(2019, 5, 13, 14, 12, 19, 0, time.UTC),
+		},
+		{
+			desc: "RFC 3339 UTC",
+			ts:   "2019-05-13T14:12:19.384640Z",
+			want: time.Date(2019, 5, 13, 14, 12, 19, 384640000, time.UTC),
+		},
+		{
+			desc: "RFC 3339 offset",
+			ts:   "2019-05-13T16:12:19.384640+02:00",
+			want: time.Date(2019, 5, 13, 14, 12, 19, 384640000, time.UTC),
+		},
+		{
+			desc: "no offset",
+			ts:   "2019-05-13T09:12:19.384640",
+			want: time.Date(2019, 5, 13, 14, 12, 19, 384640000, time.UTC),
+		},
+		{
+			desc:    "invalid",
+			ts:      "yesterday",
+			wantErr: true,
+		},
+		{
+			desc:    "empty",
+			wantErr: true,
+		},
+	} {
+		got, err := ParseTimestamp(tt.ts, est)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: ParseTimestamp(%q) = %v, want %v", tt.desc, tt.ts, got, tt.want)
+		}
+	}
+}
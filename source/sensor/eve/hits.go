@@ -0,0 +1,67 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"sort"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+// Signature identifies a revision of a Suricata signature.
+type Signature struct {
+	ID  int64
+	Rev int32
+}
+
+// Hit is the number of alerts of a signature revision.
+type Hit struct {
+	Signature
+	Count int64
+}
+
+// HitCounter counts EVE alerts by signature revision until they are reset.
+type HitCounter struct {
+	hits map[Signature]int64
+}
+
+// NewHitCounter creates a new HitCounter.
+func NewHitCounter() *HitCounter {
+	return &HitCounter{hits: make(map[Signature]int64)}
+}
+
+// Add counts an EVE alert event. Other event types are ignored.
+func (c *HitCounter) Add(e *evepb.EVE) {
+	if e.GetEventType() != "alert" {
+		return
+	}
+	c.hits[Signature{ID: int64(e.GetAlert().GetSignatureId()), Rev: e.GetAlert().GetRev()}]++
+}
+
+// Reset returns the counted hits, ordered by signature ID and revision, and restarts counting.
+func (c *HitCounter) Reset() []Hit {
+	hits := make([]Hit, 0, len(c.hits))
+	for s, n := range c.hits {
+		hits = append(hits, Hit{Signature: s, Count: n})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].ID != hits[j].ID {
+			return hits[i].ID < hits[j].ID
+		}
+		return hits[i].Rev < hits[j].Rev
+	})
+	c.hits = make(map[Signature]int64)
+	return hits
+}
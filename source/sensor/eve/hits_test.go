@@ -0,0 +1,47 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+func TestHitCounter(t *testing.T) {
+	c := NewHitCounter()
+	for _, e := range []*evepb.EVE{
+		{EventType: "alert", Alert: &evepb.Alert{SignatureId: 2, Rev: 1}},
+		{EventType: "alert", Alert: &evepb.Alert{SignatureId: 1, Rev: 3}},
+		{EventType: "alert", Alert: &evepb.Alert{SignatureId: 2, Rev: 1}},
+		{EventType: "alert", Alert: &evepb.Alert{SignatureId: 1, Rev: 2}},
+		{EventType: "flow"},
+	} {
+		c.Add(e)
+	}
+	want := []Hit{
+		{Signature: Signature{ID: 1, Rev: 2}, Count: 1},
+		{Signature: Signature{ID: 1, Rev: 3}, Count: 1},
+		{Signature: Signature{ID: 2, Rev: 1}, Count: 2},
+	}
+	if diff := cmp.Diff(want, c.Reset()); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if got := c.Reset(); len(got) != 0 {
+		t.Errorf("got %v after reset, want no hits", got)
+	}
+}
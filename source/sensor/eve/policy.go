@@ -0,0 +1,337 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+// Supported PolicyRule.GroupBy values.
+const (
+	GroupBySrcIP       = "src_ip"
+	GroupByDestIP      = "dest_ip"
+	GroupBySignatureID = "signature_id"
+)
+
+// Policy is a set of alert thresholds, loaded from a JSON file such as:
+//
+//	{"rules": [
+//	  {"name": "high-severity", "severity": 1, "threshold": 10, "window": "5m"},
+//	  {"name": "scanners", "category": "Attempted Information Leak", "group_by": "src_ip",
+//	   "threshold": 100, "window": "10m"}
+//	]}
+type Policy struct {
+	Rules []*PolicyRule `json:"rules"`
+}
+
+// PolicyRule sets a threshold on the number of Suricata alerts within a window. Only alerts
+// matching all of the set criteria are counted; a rule without criteria counts all alerts.
+type PolicyRule struct {
+	// Unique rule name, reported when the threshold is crossed.
+	Name string `json:"name"`
+	// Alert severity.
+	Severity int32 `json:"severity,omitempty"`
+	// Alert category.
+	Category string `json:"category,omitempty"`
+	// Alert signature ID.
+	SignatureID int32 `json:"signature_id,omitempty"`
+	// Source IP address or CIDR network.
+	SrcIP string `json:"src_ip,omitempty"`
+	// Destination IP address or CIDR network.
+	DestIP string `json:"dest_ip,omitempty"`
+	// Applies the threshold to every distinct value of an alert field, e.g. "src_ip" to alert on
+	// any single source exceeding the threshold.
+	GroupBy string `json:"group_by,omitempty"`
+	// Number of alerts within the window above which the rule fires.
+	Threshold int64 `json:"threshold"`
+	// Window duration, e.g. "10m".
+	Window string `json:"window"`
+}
+
+// LoadPolicy reads and validates a Policy from a JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert policy %q: %v", path, err)
+	}
+	p := new(Policy)
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("failed to parse alert policy %q: %v", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid alert policy %q: %v", path, err)
+	}
+	return p, nil
+}
+
+// DefaultPolicy returns a Policy with a single rule for all alerts.
+func DefaultPolicy(window time.Duration, threshold int) *Policy {
+	return &Policy{Rules: []*PolicyRule{{
+		Name:      "default",
+		Threshold: int64(threshold),
+		Window:    window.String(),
+	}}}
+}
+
+// Validate checks that the Policy rules are well formed.
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return errors.New("no rules")
+	}
+	names := make(map[string]bool)
+	for _, r := range p.Rules {
+		if r.Name == "" {
+			return errors.New("rule without a name")
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate rule %q", r.Name)
+		}
+		names[r.Name] = true
+		if _, err := r.compile(); err != nil {
+			return fmt.Errorf("rule %q: %v", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// compile validates the rule and returns its internal representation.
+func (r *PolicyRule) compile() (*policyRule, error) {
+	if r.Threshold < 0 {
+		return nil, fmt.Errorf("negative threshold %d", r.Threshold)
+	}
+	w, err := time.ParseDuration(r.Window)
+	if err != nil {
+		return nil, fmt.Errorf("invalid window %q: %v", r.Window, err)
+	}
+	if w <= 0 {
+		return nil, fmt.Errorf("invalid window %q", r.Window)
+	}
+	switch r.GroupBy {
+	case "", GroupBySrcIP, GroupByDestIP, GroupBySignatureID:
+	default:
+		return nil, fmt.Errorf("unsupported group_by %q", r.GroupBy)
+	}
+	c := &policyRule{PolicyRule: r, window: w, groups: make(map[string]*ruleGroup)}
+	if c.src, err = parseNet(r.SrcIP); err != nil {
+		return nil, err
+	}
+	if c.dest, err = parseNet(r.DestIP); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseNet parses an IP address or CIDR network. Empty addresses return nil.
+func parseNet(addr string) (*net.IPNet, error) {
+	if addr == "" {
+		return nil, nil
+	}
+	if !strings.Contains(addr, "/") {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", addr)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, n, err := net.ParseCIDR(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network %q: %v", addr, err)
+	}
+	return n, nil
+}
+
+func containsIP(n *net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && n.Contains(ip)
+}
+
+type policyRule struct {
+	*PolicyRule
+	window    time.Duration
+	src, dest *net.IPNet
+	groups    map[string]*ruleGroup
+}
+
+func (r *policyRule) matches(e *evepb.EVE) bool {
+	a := e.GetAlert()
+	switch {
+	case r.Severity != 0 && a.GetSeverity() != r.Severity:
+		return false
+	case r.Category != "" && a.GetCategory() != r.Category:
+		return false
+	case r.SignatureID != 0 && a.GetSignatureId() != r.SignatureID:
+		return false
+	case r.src != nil && !containsIP(r.src, e.GetSrcIp()):
+		return false
+	case r.dest != nil && !containsIP(r.dest, e.GetDestIp()):
+		return false
+	}
+	return true
+}
+
+func (r *policyRule) group(e *evepb.EVE) string {
+	switch r.GroupBy {
+	case GroupBySrcIP:
+		return e.GetSrcIp()
+	case GroupByDestIP:
+		return e.GetDestIp()
+	case GroupBySignatureID:
+		return strconv.Itoa(int(e.GetAlert().GetSignatureId()))
+	}
+	return ""
+}
+
+// ruleGroup holds the alerts counted by a rule for one group value.
+type ruleGroup struct {
+	signatures *KeyedWindow
+	hosts      *KeyedWindow
+	// Signature messages by signature ID.
+	messages map[string]string
+	// Whether the threshold was exceeded on the last check.
+	exceeded bool
+}
+
+// Contributor is a signature or host contributing alerts to a Firing.
+type Contributor struct {
+	// Signature ID or host IP address.
+	Key string
+	// Signature message, for signatures.
+	Description string
+	// Number of alerts within the window.
+	Count int64
+}
+
+// Firing reports that the threshold of a PolicyRule was crossed.
+type Firing struct {
+	// Name of the rule.
+	Rule string
+	// Group value, for rules with GroupBy.
+	Group string
+	// Number of alerts within the window.
+	Count int64
+	// Rule window.
+	Window time.Duration
+	// Signatures and hosts with the most alerts within the window.
+	Signatures []*Contributor
+	Hosts      []*Contributor
+}
+
+// Evaluator counts Suricata alerts against the rules of a Policy.
+type Evaluator struct {
+	rules   []*policyRule
+	buckets int
+}
+
+// NewEvaluator creates a new Evaluator for the policy, with windows divided into the given number
+// of buckets.
+func NewEvaluator(p *Policy, buckets int) (*Evaluator, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	e := &Evaluator{buckets: buckets}
+	for _, r := range p.Rules {
+		c, err := r.compile()
+		if err != nil {
+			return nil, err
+		}
+		e.rules = append(e.rules, c)
+	}
+	return e, nil
+}
+
+// Add counts an EVE alert event against every matching rule. Other event types are ignored.
+func (e *Evaluator) Add(ev *evepb.EVE) error {
+	// https://suricata.readthedocs.io/en/suricata-4.1.4/output/eve/eve-json-format.html#event-type-alert
+	if ev.GetEventType() != "alert" {
+		return nil
+	}
+	t, err := ParseTimestamp(ev.GetTimestamp(), time.Local)
+	if err != nil {
+		return err
+	}
+	sid := strconv.Itoa(int(ev.GetAlert().GetSignatureId()))
+	for _, r := range e.rules {
+		if !r.matches(ev) {
+			continue
+		}
+		key := r.group(ev)
+		g, ok := r.groups[key]
+		if !ok {
+			g = &ruleGroup{
+				signatures: NewKeyedWindow(r.window, e.buckets),
+				hosts:      NewKeyedWindow(r.window, e.buckets),
+				messages:   make(map[string]string),
+			}
+			r.groups[key] = g
+		}
+		g.signatures.Add(t, sid, 1)
+		g.messages[sid] = ev.GetAlert().GetSignature()
+		for _, ip := range []string{ev.GetSrcIp(), ev.GetDestIp()} {
+			if ip != "" {
+				g.hosts.Add(t, ip, 1)
+			}
+		}
+	}
+	return nil
+}
+
+// Check returns the rules whose threshold was crossed since the last check, naming up to top
+// contributing signatures and hosts. A rule fires again only once its count dropped back to the
+// threshold.
+func (e *Evaluator) Check(now time.Time, top int) []*Firing {
+	var firings []*Firing
+	for _, r := range e.rules {
+		keys := make([]string, 0, len(r.groups))
+		for k := range r.groups {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			g := r.groups[k]
+			n := g.signatures.Count(now)
+			if n == 0 {
+				delete(r.groups, k)
+				continue
+			}
+			exceeded := n > r.Threshold
+			if exceeded && !g.exceeded {
+				f := &Firing{Rule: r.Name, Group: k, Count: n, Window: r.window}
+				for _, c := range g.signatures.Top(now, top) {
+					f.Signatures = append(f.Signatures, &Contributor{Key: c.Key, Description: g.messages[c.Key], Count: c.Count})
+				}
+				for _, c := range g.hosts.Top(now, top) {
+					f.Hosts = append(f.Hosts, &Contributor{Key: c.Key, Count: c.Count})
+				}
+				firings = append(firings, f)
+			}
+			g.exceeded = exceeded
+		}
+	}
+	return firings
+}
@@ -0,0 +1,190 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	d, err := ioutil.TempDir("", "policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, tt := range []struct {
+		desc    string
+		policy  string
+		want    *Policy
+		wantErr bool
+	}{
+		{
+			desc:   "valid",
+			policy: `{"rules": [{"name": "a", "severity": 1, "threshold": 10, "window": "5m"}, {"name": "b", "src_ip": "10.0.0.0/8", "group_by": "dest_ip", "threshold": 1, "window": "1h"}]}`,
+			want: &Policy{Rules: []*PolicyRule{
+				{Name: "a", Severity: 1, Threshold: 10, Window: "5m"},
+				{Name: "b", SrcIP: "10.0.0.0/8", GroupBy: "dest_ip", Threshold: 1, Window: "1h"},
+			}},
+		},
+		{
+			desc:    "no rules",
+			policy:  `{"rules": []}`,
+			wantErr: true,
+		},
+		{
+			desc:    "duplicate names",
+			policy:  `{"rules": [{"name": "a", "threshold": 1, "window": "1m"}, {"name": "a", "threshold": 2, "window": "1m"}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid window",
+			policy:  `{"rules": [{"name": "a", "threshold": 1, "window": "soon"}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid IP address",
+			policy:  `{"rules": [{"name": "a", "dest_ip": "10.0.0.256", "threshold": 1, "window": "1m"}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported group_by",
+			policy:  `{"rules": [{"name": "a", "group_by": "proto", "threshold": 1, "window": "1m"}]}`,
+			wantErr: true,
+		},
+	} {
+		path := filepath.Join(d, "policy.json")
+		if err := ioutil.WriteFile(path, []byte(tt.policy), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadPolicy(path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func alert(ts, src, dest string, sid, severity int32, category string) *evepb.EVE {
+	return &evepb.EVE{
+		Timestamp: ts,
+		EventType: "alert",
+		SrcIp:     src,
+		DestIp:    dest,
+		Alert: &evepb.Alert{
+			SignatureId: sid,
+			Signature:   "sig",
+			Severity:    severity,
+			Category:    category,
+		},
+	}
+}
+
+func TestEvaluator(t *testing.T) {
+	e, err := NewEvaluator(&Policy{Rules: []*PolicyRule{
+		{Name: "severe", Severity: 1, Threshold: 2, Window: "1m"},
+		{Name: "scan", Category: "scan", GroupBy: GroupBySrcIP, Threshold: 1, Window: "1m"},
+		{Name: "internal", DestIP: "10.0.0.0/8", SignatureID: 7, Threshold: 0, Window: "1m"},
+	}}, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range []*evepb.EVE{
+		alert("2019-05-13T14:00:01.000000+0000", "1.1.1.1", "2.2.2.2", 1, 1, ""),
+		alert("2019-05-13T14:00:02.000000+0000", "1.1.1.1", "2.2.2.2", 1, 1, ""),
+		alert("2019-05-13T14:00:03.000000+0000", "3.3.3.3", "2.2.2.2", 2, 1, ""),
+		alert("2019-05-13T14:00:04.000000+0000", "4.4.4.4", "2.2.2.2", 3, 2, "scan"),
+		alert("2019-05-13T14:00:05.000000+0000", "4.4.4.4", "2.2.2.3", 3, 2, "scan"),
+		alert("2019-05-13T14:00:06.000000+0000", "5.5.5.5", "2.2.2.3", 3, 2, "scan"),
+		alert("2019-05-13T14:00:07.000000+0000", "5.5.5.5", "192.168.0.1", 7, 3, ""),
+		{Timestamp: "2019-05-13T14:00:08.000000+0000", EventType: "flow"},
+	} {
+		if err := e.Add(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Date(2019, 5, 13, 14, 0, 10, 0, time.UTC)
+	want := []*Firing{
+		{
+			Rule:   "severe",
+			Count:  3,
+			Window: time.Minute,
+			Signatures: []*Contributor{
+				{Key: "1", Description: "sig", Count: 2},
+				{Key: "2", Description: "sig", Count: 1},
+			},
+			Hosts: []*Contributor{
+				{Key: "2.2.2.2", Count: 3},
+				{Key: "1.1.1.1", Count: 2},
+			},
+		},
+		{
+			Rule:       "scan",
+			Group:      "4.4.4.4",
+			Count:      2,
+			Window:     time.Minute,
+			Signatures: []*Contributor{{Key: "3", Description: "sig", Count: 2}},
+			Hosts: []*Contributor{
+				{Key: "4.4.4.4", Count: 2},
+				{Key: "2.2.2.2", Count: 1},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, e.Check(now, 2)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	// Rules fire once while their threshold remains exceeded.
+	if got := e.Check(now, 2); len(got) != 0 {
+		t.Errorf("got %d firings on the second check, want 0", len(got))
+	}
+
+	// Rules fire again once their count dropped back to the threshold.
+	now = now.Add(time.Minute)
+	if got := e.Check(now, 2); len(got) != 0 {
+		t.Errorf("got %d firings after the window, want 0", len(got))
+	}
+	for _, ts := range []string{"2019-05-13T14:01:10.000000+0000", "2019-05-13T14:01:10.000000+0000", "2019-05-13T14:01:10.000000+0000"} {
+		if err := e.Add(alert(ts, "1.1.1.1", "2.2.2.2", 1, 1, "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := e.Check(now, 2)
+	if len(got) != 1 || got[0].Rule != "severe" {
+		t.Errorf("got %+v, want a single severe firing", got)
+	}
+}
+
+func TestEvaluatorInvalidTimestamp(t *testing.T) {
+	e, err := NewEvaluator(DefaultPolicy(time.Minute, 1), 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Add(&evepb.EVE{Timestamp: "yesterday", EventType: "alert"}); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Sampler selects a random fraction of events, limited to a maximum rate by a token bucket.
+type Sampler struct {
+	rate   float64
+	limit  float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	random func() float64
+}
+
+// NewSampler creates a new Sampler selecting the given fraction of events, and at most limit events
+// per second with bursts of up to burst events.
+func NewSampler(rate, limit float64, burst int) *Sampler {
+	return &Sampler{
+		rate:   rate,
+		limit:  limit,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		random: rand.Float64,
+	}
+}
+
+// Allow reports whether an event occurring at now is selected.
+func (s *Sampler) Allow(now time.Time) bool {
+	if !s.last.IsZero() && now.After(s.last) {
+		s.tokens += now.Sub(s.last).Seconds() * s.limit
+		if s.tokens > s.burst {
+			s.tokens = s.burst
+		}
+	}
+	if s.last.IsZero() || now.After(s.last) {
+		s.last = now
+	}
+	if s.rate < 1 && s.random() >= s.rate {
+		return false
+	}
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	start := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+
+	s := NewSampler(1, 2, 3)
+	var allowed int
+	for i := 0; i < 10; i++ {
+		if s.Allow(start) {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("got %d events allowed in a burst, want 3", allowed)
+	}
+	// Tokens refill at the limit rate.
+	if !s.Allow(start.Add(500 * time.Millisecond)) {
+		t.Error("expected an event to be allowed after refilling")
+	}
+	if s.Allow(start.Add(500 * time.Millisecond)) {
+		t.Error("expected an event to be rate limited")
+	}
+
+	// Events are sampled before being rate limited.
+	s = NewSampler(0.5, 100, 100)
+	draws := []float64{0.1, 0.7, 0.4, 0.5}
+	s.random = func() float64 {
+		d := draws[0]
+		draws = draws[1:]
+		return d
+	}
+	var got []bool
+	for range []int{0, 1, 2, 3} {
+		got = append(got, s.Allow(start))
+	}
+	want := []bool{true, false, true, false}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Allow() #%d = %t, want %t", i, got[i], want[i])
+		}
+	}
+}
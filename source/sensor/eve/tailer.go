@@ -0,0 +1,227 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eve contains functionality to follow and aggregate Suricata EVE logs.
+package eve
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	log "github.com/golang/glog"
+)
+
+// Checkpoint records the position of a Tailer in the file it follows.
+type Checkpoint struct {
+	// Inode of the followed file.
+	Inode uint64 `json:"inode"`
+	// Offset following the last complete line read.
+	Offset int64 `json:"offset"`
+}
+
+// Tailer follows a file by offset, one complete line at a time. It detects truncation and
+// rotation of the file, and optionally saves a checkpoint when the caller commits the lines read,
+// so that a restarted Tailer resumes after the last committed line.
+type Tailer struct {
+	path       string
+	checkpoint string
+
+	f      *os.File
+	inode  uint64
+	offset int64
+	// Saved position used when opening the file for the first time.
+	saved *Checkpoint
+}
+
+// NewTailer creates a new Tailer for the file at path. If checkpoint is non-empty, the position is
+// loaded from and saved to the checkpoint file. Without a saved position, the Tailer starts at the
+// end of the file.
+func NewTailer(path, checkpoint string) (*Tailer, error) {
+	t := &Tailer{path: path, checkpoint: checkpoint}
+	if checkpoint == "" {
+		return t, nil
+	}
+	b, err := ioutil.ReadFile(checkpoint)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %q: %v", checkpoint, err)
+	}
+	cp := new(Checkpoint)
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %q: %v", checkpoint, err)
+	}
+	t.saved = cp
+	return t, nil
+}
+
+// Poll calls fn for every complete line appended to the file since the last poll. Incomplete
+// lines are left for the next poll. When the file was rotated, the remainder of the old file is
+// read before following the new file from its start. The position is not saved until Commit is
+// called.
+func (t *Tailer) Poll(fn func(line []byte)) error {
+	if t.f == nil {
+		switch err := t.open(); {
+		case os.IsNotExist(err):
+			// Wait for the file to be created.
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+	if err := t.read(fn); err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(t.path)
+	switch {
+	case os.IsNotExist(err):
+		// Rotated, but the new file was not created yet.
+		return nil
+	case err != nil:
+		return err
+	}
+	switch {
+	case inode(fi) != t.inode:
+		log.Infof("%q was rotated; following the new file", t.path)
+		t.f.Close()
+		t.f = nil
+		t.saved = &Checkpoint{Inode: inode(fi)}
+		if err := t.open(); err != nil {
+			return err
+		}
+		if err := t.read(fn); err != nil {
+			return err
+		}
+	case fi.Size() < t.offset:
+		log.Infof("%q was truncated; following from the start", t.path)
+		t.offset = 0
+		if err := t.read(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit saves the current position to the checkpoint file, once the lines read by Poll were
+// processed. Lines read after the last commit are read again by a restarted Tailer.
+func (t *Tailer) Commit() error {
+	return t.save()
+}
+
+// Checkpoint returns the current position of the Tailer.
+func (t *Tailer) Checkpoint() *Checkpoint {
+	return &Checkpoint{Inode: t.inode, Offset: t.offset}
+}
+
+// Close closes the followed file.
+func (t *Tailer) Close() error {
+	if t.f == nil {
+		return nil
+	}
+	err := t.f.Close()
+	t.f = nil
+	return err
+}
+
+// open opens the file at the saved position if it refers to the same file, at the start if it
+// refers to another file, and at the end if there is no saved position.
+func (t *Tailer) open() error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	t.f = f
+	t.inode = inode(fi)
+	switch {
+	case t.saved == nil:
+		t.offset = fi.Size()
+	case t.saved.Inode == t.inode && t.saved.Offset <= fi.Size():
+		t.offset = t.saved.Offset
+	default:
+		t.offset = 0
+	}
+	t.saved = nil
+	return nil
+}
+
+// read calls fn for every complete line following the current offset.
+func (t *Tailer) read(fn func(line []byte)) error {
+	if _, err := t.f.Seek(t.offset, io.SeekStart); err != nil {
+		return err
+	}
+	r := bufio.NewReader(t.f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// Incomplete lines are read again on the next poll.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		t.offset += int64(len(line))
+		if line = bytes.TrimRight(line, "\r\n"); len(line) > 0 {
+			fn(line)
+		}
+	}
+}
+
+// save atomically writes the current position to the checkpoint file.
+func (t *Tailer) save() error {
+	if t.checkpoint == "" || t.f == nil {
+		return nil
+	}
+	b, err := json.Marshal(t.Checkpoint())
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(t.checkpoint), filepath.Base(t.checkpoint)+".tmp_")
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %v", err)
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), t.checkpoint)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to save checkpoint: %v", err)
+	}
+	return nil
+}
+
+// inode returns the inode number of a file.
+func inode(fi os.FileInfo) uint64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Ino)
+	}
+	return 0
+}
@@ -0,0 +1,187 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func poll(t *testing.T, tl *Tailer) []string {
+	t.Helper()
+	var lines []string
+	if err := tl.Poll(func(line []byte) { lines = append(lines, string(line)) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+func TestTailer(t *testing.T) {
+	d, err := ioutil.TempDir("", "eve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	path := filepath.Join(d, "eve.json")
+	checkpoint := filepath.Join(d, "eve.checkpoint")
+
+	appendFile(t, path, "old1\nold2\n")
+	tl, err := NewTailer(path, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl.Close()
+
+	// Without a checkpoint, existing lines are skipped.
+	if got := poll(t, tl); len(got) != 0 {
+		t.Errorf("got %q, want no lines", got)
+	}
+	for _, tt := range []struct {
+		desc   string
+		update func()
+		want   []string
+	}{
+		{
+			desc:   "appended lines",
+			update: func() { appendFile(t, path, "a\nb\n") },
+			want:   []string{"a", "b"},
+		},
+		{
+			desc:   "incomplete line",
+			update: func() { appendFile(t, path, "c") },
+		},
+		{
+			desc:   "completed line",
+			update: func() { appendFile(t, path, "c\n") },
+			want:   []string{"cc"},
+		},
+		{
+			desc: "truncated",
+			update: func() {
+				if err := os.Truncate(path, 0); err != nil {
+					t.Fatal(err)
+				}
+				appendFile(t, path, "d\n")
+			},
+			want: []string{"d"},
+		},
+		{
+			desc: "rotated",
+			update: func() {
+				appendFile(t, path, "e\n")
+				if err := os.Rename(path, path+".1"); err != nil {
+					t.Fatal(err)
+				}
+				appendFile(t, path, "f\n")
+			},
+			want: []string{"e", "f"},
+		},
+		{
+			desc: "rotated before the new file is created",
+			update: func() {
+				appendFile(t, path, "g\n")
+				if err := os.Rename(path, path+".2"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []string{"g"},
+		},
+		{
+			desc:   "new file created",
+			update: func() { appendFile(t, path, "h\n") },
+			want:   []string{"h"},
+		},
+	} {
+		tt.update()
+		if diff := cmp.Diff(tt.want, poll(t, tl)); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+
+	// A new Tailer resumes from the checkpoint.
+	appendFile(t, path, "i\n")
+	tl2, err := NewTailer(path, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl2.Close()
+	if diff := cmp.Diff([]string{"i"}, poll(t, tl2)); diff != "" {
+		t.Errorf("resume: expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Lines which were read but not committed are read again after a restart.
+	appendFile(t, path, "j\n")
+	var lines []string
+	if err := tl2.Poll(func(line []byte) { lines = append(lines, string(line)) }); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"j"}, lines); diff != "" {
+		t.Errorf("uncommitted: expectation mismatch (-want +got):\n%s", diff)
+	}
+	tl3, err := NewTailer(path, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl3.Close()
+	if diff := cmp.Diff([]string{"j"}, poll(t, tl3)); diff != "" {
+		t.Errorf("resume uncommitted: expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTailerMissingFile(t *testing.T) {
+	d, err := ioutil.TempDir("", "eve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	path := filepath.Join(d, "eve.json")
+
+	tl, err := NewTailer(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tl.Close()
+	if got := poll(t, tl); len(got) != 0 {
+		t.Errorf("got %q, want no lines", got)
+	}
+	appendFile(t, path, "a\n")
+	// The file is opened at its end when it is first seen.
+	if got := poll(t, tl); len(got) != 0 {
+		t.Errorf("got %q, want no lines", got)
+	}
+	appendFile(t, path, "b\n")
+	if diff := cmp.Diff([]string{"b"}, poll(t, tl)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
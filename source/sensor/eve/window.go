@@ -0,0 +1,228 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"sort"
+	"time"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+// ring maps times onto a fixed number of buckets covering a sliding time window.
+type ring struct {
+	bucketSize time.Duration
+	n          int64
+	// Index of the latest bucket since the Unix epoch.
+	head int64
+}
+
+func newRing(size time.Duration, buckets int) ring {
+	if buckets < 1 {
+		buckets = 1
+	}
+	bs := size / time.Duration(buckets)
+	if bs <= 0 {
+		bs = 1
+	}
+	return ring{bucketSize: bs, n: int64(buckets)}
+}
+
+func (r *ring) index(t time.Time) int64 {
+	return t.UnixNano() / int64(r.bucketSize)
+}
+
+func (r *ring) slot(i int64) int {
+	return int(((i % r.n) + r.n) % r.n)
+}
+
+// expired reports whether bucket i fell out of the window.
+func (r *ring) expired(i int64) bool {
+	return i <= r.head-r.n
+}
+
+// advance moves the window forward to bucket i, calling expire for every slot which falls out of
+// it.
+func (r *ring) advance(i int64, expire func(slot int)) {
+	if i <= r.head {
+		return
+	}
+	if i-r.head >= r.n {
+		for s := 0; s < int(r.n); s++ {
+			expire(s)
+		}
+	} else {
+		for j := r.head + 1; j <= i; j++ {
+			expire(r.slot(j))
+		}
+	}
+	r.head = i
+}
+
+// Window counts events within a sliding time window. The window is divided into buckets, so the
+// count is maintained incrementally and expires one bucket at a time.
+type Window struct {
+	ring
+	buckets []int64
+	total   int64
+}
+
+// NewWindow creates a new Window of the given size, divided into the given number of buckets.
+func NewWindow(size time.Duration, buckets int) *Window {
+	r := newRing(size, buckets)
+	return &Window{
+		ring:    r,
+		buckets: make([]int64, r.n),
+	}
+}
+
+// Add counts n events at time t. Events older than the window are ignored.
+func (w *Window) Add(t time.Time, n int64) {
+	i := w.index(t)
+	w.advance(i, w.expire)
+	if w.expired(i) {
+		return
+	}
+	w.buckets[w.slot(i)] += n
+	w.total += n
+}
+
+// Count returns the number of events within the window ending at now.
+func (w *Window) Count(now time.Time) int64 {
+	w.advance(w.index(now), w.expire)
+	return w.total
+}
+
+func (w *Window) expire(slot int) {
+	w.total -= w.buckets[slot]
+	w.buckets[slot] = 0
+}
+
+// KeyedWindow counts events by key within a sliding time window, e.g. alerts by signature.
+type KeyedWindow struct {
+	ring
+	buckets []map[string]int64
+	totals  map[string]int64
+	total   int64
+}
+
+// NewKeyedWindow creates a new KeyedWindow of the given size, divided into the given number of
+// buckets.
+func NewKeyedWindow(size time.Duration, buckets int) *KeyedWindow {
+	r := newRing(size, buckets)
+	return &KeyedWindow{
+		ring:    r,
+		buckets: make([]map[string]int64, r.n),
+		totals:  make(map[string]int64),
+	}
+}
+
+// Add counts n events for key at time t. Events older than the window are ignored.
+func (w *KeyedWindow) Add(t time.Time, key string, n int64) {
+	i := w.index(t)
+	w.advance(i, w.expire)
+	if w.expired(i) {
+		return
+	}
+	s := w.slot(i)
+	if w.buckets[s] == nil {
+		w.buckets[s] = make(map[string]int64)
+	}
+	w.buckets[s][key] += n
+	w.totals[key] += n
+	w.total += n
+}
+
+// Count returns the number of events for all keys within the window ending at now.
+func (w *KeyedWindow) Count(now time.Time) int64 {
+	w.advance(w.index(now), w.expire)
+	return w.total
+}
+
+// KeyCount is the number of events for a key.
+type KeyCount struct {
+	Key   string
+	Count int64
+}
+
+// Top returns up to n keys with the most events within the window ending at now, ordered by
+// decreasing count.
+func (w *KeyedWindow) Top(now time.Time, n int) []KeyCount {
+	w.advance(w.index(now), w.expire)
+	var top []KeyCount
+	for k, c := range w.totals {
+		top = append(top, KeyCount{Key: k, Count: c})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Key < top[j].Key
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+func (w *KeyedWindow) expire(slot int) {
+	for k, c := range w.buckets[slot] {
+		if w.totals[k] -= c; w.totals[k] == 0 {
+			delete(w.totals, k)
+		}
+		w.total -= c
+	}
+	w.buckets[slot] = nil
+}
+
+// Counter counts EVE events by event type within sliding time windows.
+type Counter struct {
+	size    time.Duration
+	buckets int
+	windows map[string]*Window
+}
+
+// NewCounter creates a new Counter with windows of the given size and number of buckets.
+func NewCounter(size time.Duration, buckets int) *Counter {
+	return &Counter{
+		size:    size,
+		buckets: buckets,
+		windows: make(map[string]*Window),
+	}
+}
+
+// Add counts an EVE event at its timestamp.
+func (c *Counter) Add(e *evepb.EVE) error {
+	t, err := ParseTimestamp(e.GetTimestamp(), time.Local)
+	if err != nil {
+		return err
+	}
+	w, ok := c.windows[e.GetEventType()]
+	if !ok {
+		w = NewWindow(c.size, c.buckets)
+		c.windows[e.GetEventType()] = w
+	}
+	w.Add(t, 1)
+	return nil
+}
+
+// Count returns the number of events of the given type within the window ending at now.
+func (c *Counter) Count(eventType string, now time.Time) int64 {
+	w, ok := c.windows[eventType]
+	if !ok {
+		return 0
+	}
+	return w.Count(now)
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eve
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+)
+
+func TestWindow(t *testing.T) {
+	start := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+	w := NewWindow(time.Minute, 6)
+
+	w.Add(start, 1)
+	w.Add(start.Add(15*time.Second), 2)
+	w.Add(start.Add(-2*time.Minute), 5) // Too old.
+	for _, tt := range []struct {
+		now  time.Time
+		want int64
+	}{
+		{now: start.Add(30 * time.Second), want: 3},
+		{now: start.Add(65 * time.Second), want: 2},
+		{now: start.Add(80 * time.Second), want: 0},
+		{now: start.Add(time.Hour), want: 0},
+	} {
+		if got := w.Count(tt.now); got != tt.want {
+			t.Errorf("Count(%v) = %d, want %d", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := NewCounter(time.Minute, 60)
+	for _, e := range []*evepb.EVE{
+		{Timestamp: "2019-05-13T14:12:19.384640+0000", EventType: "alert"},
+		{Timestamp: "2019-05-13T16:12:20.384640+0200", EventType: "alert"},
+		{Timestamp: "2019-05-13T14:12:21.384640+0000", EventType: "flow"},
+	} {
+		if err := c.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Add(&evepb.EVE{Timestamp: "yesterday", EventType: "alert"}); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+	now := time.Date(2019, 5, 13, 14, 12, 30, 0, time.UTC)
+	if got := c.Count("alert", now); got != 2 {
+		t.Errorf("Count(alert) = %d, want 2", got)
+	}
+	if got := c.Count("dns", now); got != 0 {
+		t.Errorf("Count(dns) = %d, want 0", got)
+	}
+	if got := c.Count("alert", now.Add(time.Minute)); got != 0 {
+		t.Errorf("Count(alert) after the window = %d, want 0", got)
+	}
+}
+
+func TestKeyedWindow(t *testing.T) {
+	start := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+	w := NewKeyedWindow(time.Minute, 6)
+
+	w.Add(start, "a", 1)
+	w.Add(start.Add(15*time.Second), "b", 2)
+	w.Add(start.Add(20*time.Second), "a", 2)
+	if got := w.Count(start.Add(30 * time.Second)); got != 5 {
+		t.Errorf("Count() = %d, want 5", got)
+	}
+	want := []KeyCount{{Key: "a", Count: 3}, {Key: "b", Count: 2}}
+	if diff := cmp.Diff(want, w.Top(start.Add(30*time.Second), 3)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	want = []KeyCount{{Key: "a", Count: 2}}
+	if diff := cmp.Diff(want, w.Top(start.Add(65*time.Second), 1)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if got := w.Top(start.Add(time.Hour), 3); len(got) != 0 {
+		t.Errorf("Top() after the window = %v, want none", got)
+	}
+}
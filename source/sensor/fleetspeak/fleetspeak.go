@@ -17,7 +17,9 @@
 package fleetspeak
 
 import (
+	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -41,42 +43,115 @@ const (
 type Client struct {
 	// Channel used for sending messages to the Fleetspeak client.
 	fsChan *channel.RelentlessChannel
-	// Callback for Fleetspeak client send acknowledgements.
-	callbackChan chan string
 	// Messages is used to queue received Fleetspeak client messages for sensor client consumption.
 	messages chan *fspb.Message
+	// Time after which an unacknowledged message is considered lost.
+	ackTimeout time.Duration
+
+	mu    sync.Mutex
+	state State
+	// Acknowledgement channels of the messages awaiting acknowledgement, by message ID.
+	acks map[string]chan struct{}
+}
+
+// State describes the activity of the channel to the Fleetspeak client.
+type State struct {
+	// Number of messages sent to Fleetspeak.
+	Sent int64
+	// Number of sent messages acknowledged by Fleetspeak.
+	Acknowledged int64
+	// Number of messages received from Fleetspeak.
+	Received int64
+	// Times of the last sent, acknowledged and received messages; zero if none.
+	LastSent         time.Time
+	LastAcknowledged time.Time
+	LastReceived     time.Time
+}
+
+// Pending returns the number of sent messages awaiting acknowledgement.
+func (s State) Pending() int64 {
+	return s.Sent - s.Acknowledged
+}
+
+// State returns the current State of the channel.
+func (c *Client) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
 }
 
-// New initializes a Client.
-func New(socket string) *Client {
+// New initializes a Client, whose sends fail if Fleetspeak does not acknowledge them within
+// ackTimeout.
+func New(socket string, ackTimeout time.Duration) *Client {
 	rc := client.OpenChannel(socket, time.Now().Format(time.RFC1123Z))
 
 	return &Client{
-		fsChan:       rc,
-		callbackChan: make(chan string, 5), // To prevent potential locking.
-		messages:     make(chan *fspb.Message, maxMessages),
+		fsChan:     rc,
+		messages:   make(chan *fspb.Message, maxMessages),
+		ackTimeout: ackTimeout,
+		acks:       make(map[string]chan struct{}),
 	}
 }
 
 // SendMessage a message to the Fleetspeak client. This call blocks until Fleetspeak has
-// acknowledged the message.
+// acknowledged the message, or the acknowledgement timeout expires.
 func (c *Client) SendMessage(m *pb.SensorMessage) (string, error) {
 	req, err := c.createRequest(m)
 	if err != nil {
 		return "", err
 	}
-	return c.sendAndWait(req), nil
+	return c.sendAndWait(m.GetId(), req)
 }
 
-// sendAndWait sends a message to the Fleetspeak client and waits indefinitely. Only one sendAndWait
-// should be called by the Client at a time to avoid non-chronological request ID logging from the
-// Fleetspeak client callback channel.
-func (c *Client) sendAndWait(msg *service.AckMessage) string {
-	c.fsChan.Out <- *msg
+// sendAndWait sends a message to the Fleetspeak client and waits for its acknowledgement, matched
+// by message ID, until the acknowledgement timeout expires.
+func (c *Client) sendAndWait(id string, msg *service.AckMessage) (string, error) {
+	ack := make(chan struct{})
+	c.mu.Lock()
+	c.acks[id] = ack
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.acks, id)
+		c.mu.Unlock()
+	}()
+	timeout := time.NewTimer(c.ackTimeout)
+	defer timeout.Stop()
+
+	select {
+	case c.fsChan.Out <- *msg:
+	case <-timeout.C:
+		return "", fmt.Errorf("timed out sending message (%s) to Fleetspeak", id)
+	}
+	c.mu.Lock()
+	c.state.Sent++
+	c.state.LastSent = time.Now()
+	c.mu.Unlock()
 	log.Infof("Sent message (%X) to Fleetspeak; awaiting acknowledgement...", msg.M.GetSourceMessageId())
-	ack := <-c.callbackChan
-	log.Infof("Received ack %q from Fleetspeak", ack)
-	return ack
+	select {
+	case <-ack:
+	case <-timeout.C:
+		return "", fmt.Errorf("timed out waiting for the acknowledgement of message (%s) by Fleetspeak", id)
+	}
+	c.mu.Lock()
+	c.state.Acknowledged++
+	c.state.LastAcknowledged = time.Now()
+	c.mu.Unlock()
+	log.Infof("Received ack %q from Fleetspeak", id)
+	return id, nil
+}
+
+// ack signals the acknowledgement of a message to its sender.
+func (c *Client) ack(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	a, ok := c.acks[id]
+	if !ok {
+		log.Warningf("Received ack %q of a message which is no longer awaited", id)
+		return
+	}
+	close(a)
+	delete(c.acks, id)
 }
 
 // createRequest composes a Fleetspeak AckMessage.
@@ -98,7 +173,7 @@ func (c *Client) createRequest(m *pb.SensorMessage) (*service.AckMessage, error)
 			Background: true,
 		},
 		Ack: func() {
-			c.callbackChan <- m.Id
+			c.ack(m.GetId())
 		},
 	}, nil
 }
@@ -110,6 +185,10 @@ func (c *Client) Receive(done <-chan struct{}) {
 		select {
 		case m := <-c.fsChan.In:
 			log.Infof("Received message (%X) from Fleetspeak", m.GetSourceMessageId())
+			c.mu.Lock()
+			c.state.Received++
+			c.state.LastReceived = time.Now()
+			c.mu.Unlock()
 			c.messages <- m
 		case <-done:
 			log.Warning("Stopped receiving messages from Fleetspeak")
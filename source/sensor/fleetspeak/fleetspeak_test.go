@@ -24,50 +24,69 @@ import (
 	"github.com/google/fleetspeak/fleetspeak/src/client/service"
 	"github.com/google/go-cmp/cmp"
 
-	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
 	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
 )
 
 func TestSendAndWait(t *testing.T) {
-	o := make(chan service.AckMessage)
-	callbackChan := make(chan string)
-	defer close(o)
-
-	// Client with fake relentless channel to Fleetspeak client.
+	o := make(chan service.AckMessage, 2)
 	c := &Client{
 		fsChan: &channel.RelentlessChannel{
 			Out: o,
 		},
-		callbackChan: callbackChan,
+		ackTimeout: time.Second,
+		acks:       make(map[string]chan struct{}),
 	}
-
-	// Fleetspeak client acknowledgement message.
-	ackMsg := &service.AckMessage{
-		Ack: func() {
-			c.callbackChan <- "TEST_OP_ID"
-		},
+	send := func(id string) (string, error) {
+		req, err := c.createRequest(&pb.SensorMessage{Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.sendAndWait(id, req)
 	}
-	go c.sendAndWait(ackMsg)
 
-	// Fake Fleetspeak client message handling.
+	// Fake Fleetspeak client acknowledging the messages in the reverse order.
 	go func() {
-		for {
-			select {
-			case <-o:
-				log.Info("TestSendAndWait() Fleetspeak client received message")
-				time.Sleep(5 * time.Second) // Simulate work.
-				callbackChan <- "TEST_OP_ID"
-				return
-			default:
-				log.Info("No new messages")
-			}
-		}
+		first := <-o
+		second := <-o
+		second.Ack()
+		first.Ack()
 	}()
+	type result struct {
+		ack string
+		err error
+	}
+	results := make(chan result, 2)
+	for _, id := range []string{"a", "b"} {
+		go func(id string) {
+			ack, err := send(id)
+			results <- result{ack, err}
+		}(id)
+		time.Sleep(10 * time.Millisecond) // Send in order.
+	}
+	got := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		r := <-results
+		if r.err != nil {
+			t.Fatalf("sendAndWait() failed: %v", r.err)
+		}
+		got[r.ack] = true
+	}
+	if diff := cmp.Diff(map[string]bool{"a": true, "b": true}, got); diff != "" {
+		t.Errorf("TestSendAndWait() expectation mismatch (-want +got):\n%s", diff)
+	}
 
-	want := "TEST_OP_ID"
-	got := <-c.callbackChan
-	if diff := cmp.Diff(got, want); diff != "" {
-		t.Errorf("TestSendAndWait() expectation mismatch:\n%s", diff)
+	// Unacknowledged messages time out.
+	c.ackTimeout = 10 * time.Millisecond
+	if _, err := send("c"); err == nil {
+		t.Error("expected an error for an unacknowledged message")
+	}
+	<-o
+	// Sends time out if Fleetspeak does not take messages.
+	o <- service.AckMessage{}
+	o <- service.AckMessage{}
+	if _, err := send("d"); err == nil {
+		t.Error("expected an error for a message not taken by Fleetspeak")
 	}
 }
 
@@ -121,3 +140,45 @@ func TestReceive(t *testing.T) {
 		}
 	}
 }
+
+func TestState(t *testing.T) {
+	o := make(chan service.AckMessage, 2)
+	in := make(chan *fspb.Message, 1)
+	c := &Client{
+		fsChan: &channel.RelentlessChannel{
+			In:  in,
+			Out: o,
+		},
+		messages:   make(chan *fspb.Message, maxMessages),
+		ackTimeout: time.Second,
+		acks:       make(map[string]chan struct{}),
+	}
+	if got := c.State(); got.Sent != 0 || !got.LastSent.IsZero() {
+		t.Errorf("got initial state %+v, want zero", got)
+	}
+
+	go func() {
+		m := <-o
+		m.Ack()
+	}()
+	req, err := c.createRequest(&pb.SensorMessage{Id: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.sendAndWait("a", req); err != nil {
+		t.Fatal(err)
+	}
+	in <- &fspb.Message{}
+	done := make(chan struct{})
+	go c.Receive(done)
+	<-c.Messages()
+	close(done)
+
+	got := c.State()
+	if got.Sent != 1 || got.Acknowledged != 1 || got.Received != 1 || got.Pending() != 0 {
+		t.Errorf("got state %+v, want 1 message sent, acknowledged and received", got)
+	}
+	if got.LastSent.IsZero() || got.LastAcknowledged.IsZero() || got.LastReceived.IsZero() {
+		t.Errorf("got state %+v, want times of the last messages", got)
+	}
+}
@@ -16,39 +16,138 @@
 package host
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
 	log "github.com/golang/glog"
 )
 
+// iffPromisc is the Linux IFF_PROMISC interface flag.
+const iffPromisc = 0x100
+
 var (
 	// To facilitate unit testing.
-	netDial    = net.Dial
-	osHostname = os.Hostname
+	osHostname        = os.Hostname
+	listInterfaces    = systemInterfaces
+	osReleaseFile     = "/etc/os-release"
+	kernelReleaseFile = "/proc/sys/kernel/osrelease"
+	sysClassNet       = "/sys/class/net"
 )
 
+// Interface is a network interface of the host.
+type Interface struct {
+	Name string
+	// Hardware address.
+	MAC string
+	// Addresses in CIDR notation.
+	Addresses []string
+	MTU       int
+	Up        bool
+	Loopback  bool
+	// Whether the interface is in promiscuous mode.
+	Promiscuous bool
+	// Whether Suricata captures traffic on the interface.
+	Capture bool
+}
+
+// Config configures a Host.
+type Config struct {
+	// Path of the file keeping the host UUID, created with a new UUID if it does not exist.
+	UUIDFile string
+	// Names of the capture interfaces. If empty, capture interfaces are identified as the
+	// interfaces which are in promiscuous mode, or up without any address.
+	CaptureInterfaces []string
+}
+
 // Host contains basic host information.
 type Host struct {
-	fqdn string
-	ip   net.IP
+	mu         sync.RWMutex
+	uuid       string
+	fqdn       string
+	ip         net.IP
+	os         string
+	kernel     string
+	interfaces []*Interface
+	// Configured capture interfaces.
+	capture map[string]bool
 }
 
 // FQDN returns the Host FQDN.
 func (h *Host) FQDN() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	return h.fqdn
 }
 
-// IP returns the Host IP address.
+// IP returns the primary Host IP address: the first address of the first interface which is up
+// and neither a loopback nor a capture interface, preferring IPv4. It is nil if there is none.
 func (h *Host) IP() net.IP {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	return h.ip
 }
 
-// New performs an initial update and returns a Host.
-func New() (*Host, error) {
-	h := new(Host)
+// UUID returns the Host UUID.
+func (h *Host) UUID() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.uuid
+}
+
+// OS returns the Host operating system name and version.
+func (h *Host) OS() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.os
+}
+
+// KernelVersion returns the Host kernel release.
+func (h *Host) KernelVersion() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.kernel
+}
+
+// Interfaces returns the Host network interfaces, sorted by name.
+func (h *Host) Interfaces() []*Interface {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.interfaces
+}
+
+// SetCaptureInterfaces sets the names of the capture interfaces, taking effect on the next update.
+// If empty, capture interfaces are identified from the interface state.
+func (h *Host) SetCaptureInterfaces(names []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.capture = make(map[string]bool)
+	for _, n := range names {
+		h.capture[n] = true
+	}
+}
+
+// New loads or creates the host UUID, performs an initial update and returns a Host.
+func New(cfg *Config) (*Host, error) {
+	id, err := loadUUID(cfg.UUIDFile)
+	if err != nil {
+		return nil, err
+	}
+	h := &Host{uuid: id}
+	h.SetCaptureInterfaces(cfg.CaptureInterfaces)
 
 	// Wait indefinitely for the host to come online.
 	for {
@@ -62,35 +161,152 @@ func New() (*Host, error) {
 	return h, nil
 }
 
-// Update updates the host.
-func (h *Host) Update() error {
-	if err := h.updateFQDN(); err != nil {
-		return err
+// loadUUID reads the UUID kept in path, creating the file with a new UUID if it does not exist.
+func loadUUID(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("no UUID file")
 	}
-	if err := h.updateIP(); err != nil {
-		return err
+	b, err := ioutil.ReadFile(path)
+	if err == nil {
+		id, err := uuid.Parse(string(bytes.TrimSpace(b)))
+		if err != nil {
+			return "", fmt.Errorf("invalid UUID in %q: %v", path, err)
+		}
+		return id.String(), nil
 	}
-	log.Infof("Host info: hostname: %s, ip: %s", h.fqdn, h.ip)
-	return nil
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read UUID file %q: %v", path, err)
+	}
+	id := uuid.New().String()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create UUID file directory: %v", err)
+	}
+	// Write to a temporary file first so that a crash never leaves a partial UUID behind.
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(id+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to write UUID file: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("failed to write UUID file: %v", err)
+	}
+	log.Infof("Created host UUID %s in %q", id, path)
+	return id, nil
 }
 
-// updateFQDN updates the host fqdn.
-func (h *Host) updateFQDN() error {
+// Update updates the host.
+func (h *Host) Update() error {
 	fqdn, err := osHostname()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve fqdn: %v", err)
 	}
-	h.fqdn = fqdn
+	ifaces, err := listInterfaces()
+	if err != nil {
+		return fmt.Errorf("failed to list network interfaces: %v", err)
+	}
+	osName, kernel := osRelease(), kernelRelease()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, i := range ifaces {
+		if len(h.capture) > 0 {
+			i.Capture = h.capture[i.Name]
+		} else {
+			i.Capture = !i.Loopback && (i.Promiscuous || (i.Up && len(i.Addresses) == 0))
+		}
+	}
+	sort.Slice(ifaces, func(i, j int) bool { return ifaces[i].Name < ifaces[j].Name })
+	h.fqdn, h.os, h.kernel, h.interfaces = fqdn, osName, kernel, ifaces
+	h.ip = primaryIP(ifaces)
+	if h.ip == nil {
+		log.Warning("No IP address found on a non-capture interface")
+	}
+	log.V(1).Infof("Host info: hostname: %s, ip: %s, interfaces: %d", h.fqdn, h.ip, len(h.interfaces))
 	return nil
 }
 
-// updateIP updates the host IP address.
-func (h *Host) updateIP() error {
-	conn, err := netDial("udp", "8.8.8.8:9") // RFC863.
+// primaryIP returns the first global unicast address of the first interface which is up and
+// neither a loopback nor a capture interface, preferring IPv4 addresses.
+func primaryIP(ifaces []*Interface) net.IP {
+	var v6 net.IP
+	for _, i := range ifaces {
+		if !i.Up || i.Loopback || i.Capture {
+			continue
+		}
+		for _, a := range i.Addresses {
+			ip, _, err := net.ParseCIDR(a)
+			if err != nil || !ip.IsGlobalUnicast() {
+				continue
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				return ip4
+			}
+			if v6 == nil {
+				v6 = ip
+			}
+		}
+	}
+	return v6
+}
+
+// systemInterfaces lists the network interfaces of the system.
+func systemInterfaces() ([]*Interface, error) {
+	ifaces, err := net.Interfaces()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve ip address: %v", err)
+		return nil, err
 	}
-	defer conn.Close()
-	h.ip = conn.LocalAddr().(*net.UDPAddr).IP
-	return nil
+	var res []*Interface
+	for _, ni := range ifaces {
+		i := &Interface{
+			Name:        ni.Name,
+			MAC:         ni.HardwareAddr.String(),
+			MTU:         ni.MTU,
+			Up:          ni.Flags&net.FlagUp != 0,
+			Loopback:    ni.Flags&net.FlagLoopback != 0,
+			Promiscuous: promiscuous(ni.Name),
+		}
+		addrs, err := ni.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addresses of %q: %v", ni.Name, err)
+		}
+		for _, a := range addrs {
+			i.Addresses = append(i.Addresses, a.String())
+		}
+		res = append(res, i)
+	}
+	return res, nil
+}
+
+// promiscuous returns whether an interface is in promiscuous mode, as reported by sysfs.
+func promiscuous(name string) bool {
+	b, err := ioutil.ReadFile(filepath.Join(sysClassNet, name, "flags"))
+	if err != nil {
+		return false
+	}
+	flags, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"), 16, 32)
+	return err == nil && flags&iffPromisc != 0
+}
+
+// osRelease returns the pretty name of the operating system from os-release, or the Go operating
+// system name if it is unavailable.
+func osRelease() string {
+	b, err := ioutil.ReadFile(osReleaseFile)
+	if err != nil {
+		return runtime.GOOS
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		if v := strings.TrimPrefix(s.Text(), "PRETTY_NAME="); v != s.Text() {
+			return strings.Trim(v, `"'`)
+		}
+	}
+	return runtime.GOOS
+}
+
+// kernelRelease returns the kernel release, or an empty string if it is unavailable.
+func kernelRelease() string {
+	b, err := ioutil.ReadFile(kernelReleaseFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
 }
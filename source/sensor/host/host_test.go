@@ -15,94 +15,161 @@
 package host
 
 import (
-	"net"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 )
 
-type fakeConn struct {
-	net.Conn
-	localAddr *net.UDPAddr
+// fakeInterfaces returns the interfaces of a sensor with a management and a capture interface.
+func fakeInterfaces() ([]*Interface, error) {
+	return []*Interface{
+		{Name: "lo", Addresses: []string{"127.0.0.1/8", "::1/128"}, MTU: 65536, Up: true, Loopback: true},
+		{Name: "eth1", MAC: "00:11:22:33:44:56", MTU: 9000, Up: true},
+		{Name: "eth0", MAC: "00:11:22:33:44:55", Addresses: []string{"fe80::1/64", "2001:db8::5/64", "100.97.26.27/24"}, MTU: 1500, Up: true},
+		{Name: "eth2", MAC: "00:11:22:33:44:57", MTU: 1500, Promiscuous: true},
+	}, nil
 }
 
-func (c fakeConn) LocalAddr() net.Addr { return c.localAddr }
-func (fakeConn) Close() error          { return nil }
-
-func fakeHost() *Host {
-	return &Host{
-		fqdn: "test_1_host_name",
-		ip:   net.ParseIP("100.97.26.27"),
+func TestUpdate(t *testing.T) {
+	d, err := ioutil.TempDir("", "host")
+	if err != nil {
+		t.Fatal(err)
 	}
-}
+	defer os.RemoveAll(d)
+	osReleaseFile = filepath.Join(d, "os-release")
+	kernelReleaseFile = filepath.Join(d, "osrelease")
+	if err := ioutil.WriteFile(osReleaseFile, []byte("NAME=\"Debian GNU/Linux\"\nPRETTY_NAME=\"Debian GNU/Linux 10 (buster)\"\nID=debian\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(kernelReleaseFile, []byte("4.19.0-6-amd64\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	osHostname = func() (string, error) { return "test_1_host_name", nil }
 
-func TestUpdate(t *testing.T) {
 	for _, tt := range []struct {
-		desc       string
-		osHostname func() (string, error)
-		netDial    func(string, string) (net.Conn, error)
-		want       *Host
+		desc    string
+		capture []string
+		ifaces  func() ([]*Interface, error)
+		wantIP  string
+		// Names of the capture interfaces.
+		wantCapture []string
 	}{
 		{
-			desc:       "no change",
-			osHostname: func() (string, error) { return "test_1_host_name", nil },
-			netDial: func(string, string) (net.Conn, error) {
-				updAddr, _ := net.ResolveUDPAddr("udp", "[100.97.26.27]:5688")
-				return &fakeConn{localAddr: updAddr}, nil
-			},
-			want: &Host{
-				fqdn: "test_1_host_name",
-				ip:   net.ParseIP("100.97.26.27"),
-			},
+			desc:        "identified capture interfaces",
+			ifaces:      fakeInterfaces,
+			wantIP:      "100.97.26.27",
+			wantCapture: []string{"eth1", "eth2"},
 		},
 		{
-			desc:       "fqdn changed",
-			osHostname: func() (string, error) { return "test_2_host_name", nil },
-			netDial:    nil,
-			want: &Host{
-				fqdn: "test_2_host_name",
-				ip:   net.ParseIP("100.97.26.27"),
-			},
+			desc:        "configured capture interfaces",
+			capture:     []string{"eth2"},
+			ifaces:      fakeInterfaces,
+			wantIP:      "100.97.26.27",
+			wantCapture: []string{"eth2"},
 		},
 		{
-			desc:       "ip changed",
-			osHostname: nil,
-			netDial: func(string, string) (net.Conn, error) {
-				updAddr, _ := net.ResolveUDPAddr("udp", "[10.10.10.10]:5688")
-				return &fakeConn{localAddr: updAddr}, nil
-			},
-			want: &Host{
-				fqdn: "test_2_host_name",
-				ip:   net.ParseIP("10.10.10.10"),
+			desc:        "IP address of a capture interface",
+			capture:     []string{"eth0"},
+			ifaces:      fakeInterfaces,
+			wantIP:      "<nil>",
+			wantCapture: []string{"eth0"},
+		},
+		{
+			desc: "IPv6 only",
+			ifaces: func() ([]*Interface, error) {
+				return []*Interface{{Name: "eth0", Addresses: []string{"fe80::1/64", "2001:db8::5/64"}, Up: true}}, nil
 			},
+			wantIP: "2001:db8::5",
 		},
 	} {
-		h := fakeHost()
-
-		// Override functions, if applicable.
-		if tt.osHostname != nil {
-			osHostname = tt.osHostname
+		listInterfaces = tt.ifaces
+		h := new(Host)
+		h.SetCaptureInterfaces(tt.capture)
+		if err := h.Update(); err != nil {
+			t.Errorf("%s: got unexpected error: %v", tt.desc, err)
+			continue
 		}
-		if tt.netDial != nil {
-			netDial = tt.netDial
+		if got := h.IP().String(); got != tt.wantIP {
+			t.Errorf("%s: got IP %s, want %s", tt.desc, got, tt.wantIP)
 		}
-
-		if err := h.Update(); err != nil {
-			t.Errorf("TestUpdate(%s): got unexpected error: %v", tt.desc, err)
+		var names, capture []string
+		for _, i := range h.Interfaces() {
+			names = append(names, i.Name)
+			if i.Capture {
+				capture = append(capture, i.Name)
+			}
+		}
+		if diff := cmp.Diff(tt.wantCapture, capture); diff != "" {
+			t.Errorf("%s: capture interfaces mismatch (-want +got):\n%s", tt.desc, diff)
 		}
-		if diff := cmp.Diff(tt.want, h, cmp.AllowUnexported(*tt.want, *h)); diff != "" {
-			t.Errorf("TestUpdate(%s): expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		if len(names) > 1 && names[0] > names[1] {
+			t.Errorf("%s: interfaces not sorted: %v", tt.desc, names)
+		}
+		if h.FQDN() != "test_1_host_name" {
+			t.Errorf("%s: got FQDN %q, want %q", tt.desc, h.FQDN(), "test_1_host_name")
+		}
+		if h.OS() != "Debian GNU/Linux 10 (buster)" {
+			t.Errorf("%s: got OS %q", tt.desc, h.OS())
+		}
+		if h.KernelVersion() != "4.19.0-6-amd64" {
+			t.Errorf("%s: got kernel version %q", tt.desc, h.KernelVersion())
 		}
 	}
 }
 
-func TestGetters(t *testing.T) {
-	h := fakeHost()
+func TestLoadUUID(t *testing.T) {
+	d, err := ioutil.TempDir("", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
 
-	if diff := cmp.Diff("test_1_host_name", h.FQDN()); diff != "" {
-		t.Errorf("TestGetters: expectation mismatch (-want +got):\n%s", diff)
+	path := filepath.Join(d, "lib", "sensor_uuid")
+	id, err := loadUUID(path)
+	if err != nil {
+		t.Fatalf("loadUUID(%q) failed: %v", path, err)
+	}
+	// The UUID is kept across restarts.
+	again, err := loadUUID(path)
+	if err != nil {
+		t.Fatalf("loadUUID(%q) failed: %v", path, err)
+	}
+	if again != id {
+		t.Errorf("got UUID %s after a restart, want %s", again, id)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("not a UUID"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadUUID(path); err == nil {
+		t.Error("expected an error for an invalid UUID file")
 	}
-	if diff := cmp.Diff("100.97.26.27", h.IP().String()); diff != "" {
-		t.Errorf("TestGetters: expectation mismatch (-want +got):\n%s", diff)
+	if _, err := loadUUID(""); err == nil {
+		t.Error("expected an error without a UUID file")
+	}
+}
+
+func TestPromiscuous(t *testing.T) {
+	d, err := ioutil.TempDir("", "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	sysClassNet = d
+	for name, flags := range map[string]string{"eth0": "0x1003\n", "eth1": "0x1103\n"} {
+		if err := os.Mkdir(filepath.Join(d, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(d, name, "flags"), []byte(flags), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, want := range map[string]bool{"eth0": false, "eth1": true, "eth2": false} {
+		if got := promiscuous(name); got != want {
+			t.Errorf("promiscuous(%q) = %t, want %t", name, got, want)
+		}
 	}
 }
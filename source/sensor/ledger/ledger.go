@@ -0,0 +1,182 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ledger keeps a persistent record of the requests processed by the sensor and of the
+// installed deployment generation of every location, so that redelivered requests are ignored and
+// stale deployments rejected across restarts.
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry records a processed request.
+type Entry struct {
+	ID string `json:"id"`
+	// Status code of the outcome, e.g. "OK".
+	Code string    `json:"code"`
+	Time time.Time `json:"time"`
+}
+
+// record is the content of the ledger file.
+type record struct {
+	// Processed requests, oldest first.
+	Requests []*Entry `json:"requests"`
+	// Installed deployment generation by location name.
+	Generations map[string]int64 `json:"generations"`
+}
+
+// Ledger records the processed requests, up to a maximum number, and the installed deployment
+// generations.
+type Ledger struct {
+	path        string
+	maxRequests int
+
+	mu   sync.Mutex
+	rec  record
+	byID map[string]*Entry
+}
+
+// Open loads the Ledger saved in the file at path, or returns an empty Ledger if the file does not
+// exist. The directory of the file must exist. The Ledger keeps the last maxRequests processed
+// requests. It is only kept in memory if path is empty.
+func Open(path string, maxRequests int) (*Ledger, error) {
+	if maxRequests < 1 {
+		return nil, fmt.Errorf("invalid maximum number of requests %d", maxRequests)
+	}
+	l := &Ledger{
+		path:        path,
+		maxRequests: maxRequests,
+		rec:         record{Generations: make(map[string]int64)},
+		byID:        make(map[string]*Entry),
+	}
+	if path == "" {
+		return l, nil
+	}
+	if fi, err := os.Stat(filepath.Dir(path)); err != nil || !fi.IsDir() {
+		return nil, fmt.Errorf("directory of request ledger %q does not exist", path)
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request ledger %q: %v", path, err)
+	}
+	if err := json.Unmarshal(b, &l.rec); err != nil {
+		return nil, fmt.Errorf("failed to parse request ledger %q: %v", path, err)
+	}
+	if l.rec.Generations == nil {
+		l.rec.Generations = make(map[string]int64)
+	}
+	for _, e := range l.rec.Requests {
+		l.byID[e.ID] = e
+	}
+	return l, nil
+}
+
+// Lookup returns the Entry of a processed request.
+func (l *Ledger) Lookup(id string) (*Entry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.byID[id]
+	if !ok {
+		return nil, false
+	}
+	c := *e
+	return &c, true
+}
+
+// Record records the outcome of a processed request, forgetting the oldest requests beyond the
+// maximum number.
+func (l *Ledger) Record(id, code string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.byID[id]; ok {
+		return nil
+	}
+	e := &Entry{ID: id, Code: code, Time: time.Now()}
+	l.rec.Requests = append(l.rec.Requests, e)
+	l.byID[id] = e
+	if n := len(l.rec.Requests); n > l.maxRequests {
+		for _, old := range l.rec.Requests[:n-l.maxRequests] {
+			delete(l.byID, old.ID)
+		}
+		l.rec.Requests = append([]*Entry(nil), l.rec.Requests[n-l.maxRequests:]...)
+	}
+	return l.save()
+}
+
+// Generation returns the installed deployment generation of a location, 0 if none.
+func (l *Ledger) Generation(location string) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rec.Generations[location]
+}
+
+// Generations returns the installed deployment generations by location name.
+func (l *Ledger) Generations() map[string]int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	g := make(map[string]int64, len(l.rec.Generations))
+	for k, v := range l.rec.Generations {
+		g[k] = v
+	}
+	return g
+}
+
+// SetGeneration records the installed deployment generation of a location. Generations never
+// decrease.
+func (l *Ledger) SetGeneration(location string, generation int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if generation <= l.rec.Generations[location] {
+		return nil
+	}
+	l.rec.Generations[location] = generation
+	return l.save()
+}
+
+// save atomically writes the Ledger to its file. l.mu must be held.
+func (l *Ledger) save() error {
+	if l.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(&l.rec)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(l.path), filepath.Base(l.path)+".tmp_")
+	if err != nil {
+		return fmt.Errorf("failed to save request ledger: %v", err)
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), l.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to save request ledger: %v", err)
+	}
+	return nil
+}
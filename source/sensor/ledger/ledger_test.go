@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLedger(t *testing.T) {
+	d, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	path := filepath.Join(d, "requests.json")
+
+	l, err := Open(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if err := l.Record(id, "OK"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A request is only recorded once.
+	if err := l.Record("3", "Internal"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetGeneration("company1", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetGeneration("company1", 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ledger survives restarts.
+	if l, err = Open(path, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l.Lookup("1"); ok {
+		t.Error("got request 1 beyond the maximum number of requests")
+	}
+	for _, id := range []string{"2", "3"} {
+		e, ok := l.Lookup(id)
+		if !ok {
+			t.Errorf("request %s not found", id)
+			continue
+		}
+		if e.Code != "OK" || e.Time.IsZero() {
+			t.Errorf("got entry %+v for request %s, want code OK", e, id)
+		}
+	}
+	if diff := cmp.Diff(map[string]int64{"company1": 5}, l.Generations()); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if g := l.Generation("company2"); g != 0 {
+		t.Errorf("got generation %d for an unknown location, want 0", g)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	d, err := ioutil.TempDir("", "ledger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	path := filepath.Join(d, "requests.json")
+	if err := ioutil.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, 10); err == nil {
+		t.Error("expected an error for a malformed ledger")
+	}
+	if _, err := Open(filepath.Join(d, "missing", "requests.json"), 10); err == nil {
+		t.Error("expected an error for a missing ledger directory")
+	}
+	if _, err := Open("", 0); err == nil {
+		t.Error("expected an error for an invalid maximum number of requests")
+	}
+	l, err := Open("", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Record("1", "OK"); err != nil {
+		t.Errorf("Record() in memory failed: %v", err)
+	}
+}
@@ -18,25 +18,61 @@ package main
 import (
 	"context"
 	"flag"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/sensor/admin"
 	"github.com/google/emitto/source/sensor/client"
+	"github.com/google/emitto/source/sensor/config"
+	"github.com/google/emitto/source/sensor/eve"
+	"github.com/google/emitto/source/sensor/queue"
+	"github.com/google/emitto/source/sensor/transport"
+	"github.com/google/emitto/source/signing"
+	"golang.org/x/crypto/ed25519"
+	"google.golang.org/grpc/credentials"
 
 	log "github.com/golang/glog"
 )
 
+// version is the sensor version reported in heartbeats. It is set at build time, e.g.
+// go build -ldflags "-X main.version=1.0.0".
+var version = "dev"
+
 var (
+	// Configuration file flags.
+	configFile  = flag.String("config", "", "Path of the JSON sensor configuration file, overriding the flags; reloaded on SIGHUP or when changed")
+	configCheck = flag.Duration("config_check", 30*time.Second, "Interval for checking the sensor configuration file for changes")
+
+	// Transport flags.
+	transportName = flag.String("transport", "fleetspeak", "Transport to the server: fleetspeak, or grpc where Fleetspeak cannot run")
+	serverAddr    = flag.String("server_addr", "", "Address of the Emitto server sensor endpoint, for the gRPC transport")
+	tlsCert       = flag.String("tls_cert", "", "Path of the sensor TLS certificate, for the gRPC transport; its subject organization and organizational unit must name the location and zone of the sensor")
+	tlsKey        = flag.String("tls_key", "", "Path of the sensor TLS key, for the gRPC transport")
+	tlsCA         = flag.String("tls_ca", "", "Path of the CA certificates verifying the server, for the gRPC transport")
+	labels        = flag.String("labels", "", "Comma-separated labels of the sensor for the gRPC transport; derived from --org and --zone if empty")
+
 	// Suricata flags.
-	fsSocket       = flag.String("fleetspeak_socket", "", "Fleetspeak client socket")
-	suricataSocket = flag.String("suricata_socket", "", "Suricata Unix socket")
-	ruleFile       = flag.String("rule_file", "", "Suricata rule file path")
-	memoryStorage  = flag.Bool("memory_storage", false, "Use memory store and filestore")
+	fsSocket        = flag.String("fleetspeak_socket", "", "Fleetspeak client socket")
+	suricataSocket  = flag.String("suricata_socket", "", "Suricata Unix socket")
+	ruleFile        = flag.String("rule_file", "", "Suricata rule file path")
+	suricataBinary  = flag.String("suricata_binary", "", "Suricata binary used to validate rule files before installing them")
+	suricataConfig  = flag.String("suricata_config", "", "Suricata configuration file used to validate rule files")
+	suricataLog     = flag.String("suricata_log", "/var/log/suricata/suricata.log", "Suricata log file, collected for diagnostics")
+	allowedCommands = flag.String("allowed_commands", "uptime,version,running-mode,capture-mode,iface-list,iface-stat,dump-counters,ruleset-stats,ruleset-failed-rules,ruleset-reload-time,memcap-list", "Comma-separated Suricata socket commands the server is allowed to run")
+	datasetDir      = flag.String("dataset_dir", "/var/lib/suricata/data", "Directory of the Suricata dataset files, written on deployment")
+	memoryStorage   = flag.Bool("memory_storage", false, "Use memory store and filestore")
+	rulePublicKey   = flag.String("rule_public_key", "", "Path of the base64-encoded Ed25519 public key used to verify rule files")
 
 	// Sensor identity flags.
-	org  = flag.String("org", "", "Sensor organization")
-	zone = flag.String("zone", "", "Sensor zone")
+	org               = flag.String("org", "", "Sensor organization")
+	zone              = flag.String("zone", "", "Sensor zone")
+	uuidFile          = flag.String("uuid_file", "/var/lib/emitto/sensor_uuid", "Path of the file keeping the sensor UUID, created on first start")
+	captureInterfaces = flag.String("capture_interfaces", "", "Comma-separated names of the interfaces Suricata captures traffic on; identified from the interface state if empty")
 
 	// Google Cloud Project flags.
 	projectID     = flag.String("project_id", "", "Google Cloud project ID")
@@ -45,22 +81,108 @@ var (
 
 	// Suricata EVE monitoring flags.
 	monitorEVE         = flag.Bool("monitor_eve", false, "Monitor EVE logs")
-	alertPollingPeriod = flag.Duration("alerts_polling", 10*time.Minute, "Polling interval for checking alerts")
-	alertThreshold     = flag.Int("alerts_threshold", 100, "Alerting threshold for Suricata alerts")
+	alertPollingPeriod = flag.Duration("alerts_polling", time.Minute, "Polling interval for reading new EVE events")
+	alertWindow        = flag.Duration("alerts_window", 10*time.Minute, "Sliding time window for counting Suricata alerts, without --alerts_policy")
+	alertThreshold     = flag.Int("alerts_threshold", 100, "Alerting threshold for Suricata alerts within the window, without --alerts_policy")
+	alertPolicy        = flag.String("alerts_policy", "", "Path of the JSON alert policy setting thresholds per severity, category, signature or host")
+	forwardAlerts      = flag.Bool("forward_alerts", false, "Forward sampled Suricata alert events to the server")
+	alertSampleRate    = flag.Float64("alerts_sample_rate", 1, "Fraction of Suricata alert events to forward")
+	alertRateLimit     = flag.Float64("alerts_rate_limit", 10, "Maximum number of Suricata alert events forwarded per second")
+	alertBurst         = flag.Int("alerts_burst", 100, "Maximum number of Suricata alert events forwarded in a burst")
+	alertBatchSize     = flag.Int("alerts_batch_size", 100, "Maximum number of Suricata alert events per message")
+	ruleHitsPeriod     = flag.Duration("rule_hits_period", time.Hour, "Interval for reporting Suricata alert counts per rule")
+	eveCheckpoint      = flag.String("eve_checkpoint", "", "Path of the file saving the position in the EVE log across restarts")
 	// https://suricata.readthedocs.io/en/suricata-4.1.4/output/eve/eve-json-output.html
 	suricataEVELog = flag.String("eve_log", "", "Path of the eve.json file")
 
+	// Local status and control endpoint flags.
+	adminAddr      = flag.String("admin_addr", "", `Local status and control endpoint: "unix:<path>" or a loopback TCP address, e.g. "localhost:8081"; disabled if empty`)
+	adminTokenFile = flag.String("admin_token_file", "", "Path of the file containing the bearer token authenticating local actions; actions are disabled if empty")
+
+	// Outbound message flags.
+	ackTimeout       = flag.Duration("ack_timeout", time.Minute, "Time after which a message unacknowledged by the server is considered lost")
+	queueMessages    = flag.Bool("queue", false, "Queue messages to the server on disk while it is unreachable and across restarts; requires queue_dir")
+	queueDir         = flag.String("queue_dir", "", "Directory keeping the queued messages, e.g. /var/lib/emitto/queue; created if its parent directory exists")
+	queueMaxMessages = flag.Int("queue_max_messages", 10000, "Maximum number of queued messages; the oldest messages are dropped beyond")
+	queueMaxBytes    = flag.Int64("queue_max_bytes", 100<<20, "Maximum total size in bytes of the queued messages; the oldest messages are dropped beyond")
+	queueMaxAge      = flag.Duration("queue_max_age", 24*time.Hour, "Maximum age of the queued messages; older messages are dropped")
+
+	// Request processing flags.
+	requestLedger = flag.String("request_ledger", "", "Path of the file recording the processed requests and the installed deployment generations, to ignore duplicates and reject stale deployments across restarts, e.g. /var/lib/emitto/requests.json; only kept in memory if empty")
+	requestExpiry = flag.Duration("request_expiry", 24*time.Hour, "Age after which deployment requests are rejected as stale")
+
 	// Heartbeat flags.
 	heartbeat              = flag.Bool("heartbeat", false, "Send heartbeat to server")
 	heartbeatPollingPeriod = flag.Duration("heartbeat_polling", 10*time.Minute, "Polling interval for sending heartbeats")
 )
 
 func main() {
+	flag.Parse()
 	ctx := context.Background()
 
-	fs, closeFStore := mustGetFileStore(ctx)
+	cfg := flagConfig()
+	var w *config.Watcher
+	if *configFile != "" {
+		var err error
+		if w, cfg, err = config.NewWatcher(*configFile, cfg); err != nil {
+			log.Exit(err)
+		}
+	} else if err := cfg.Validate(); err != nil {
+		log.Exitf("invalid sensor flags: %v", err)
+	}
+
+	fs, closeFStore := mustGetFileStore(ctx, cfg)
 	defer closeFStore()
-	sc, err := client.New(ctx, *fsSocket, *org, *zone, *ruleFile, *suricataSocket, fs)
+	var key ed25519.PublicKey
+	if cfg.RulePublicKey != "" {
+		k, err := signing.LoadPublicKey(cfg.RulePublicKey)
+		if err != nil {
+			log.Exitf("failed to load rule public key: %v", err)
+		}
+		key = k
+	}
+	var creds credentials.TransportCredentials
+	if cfg.Transport == client.TransportGRPC {
+		c, err := transport.ClientCredentials(cfg.TLSCert, cfg.TLSKey, cfg.TLSCA)
+		if err != nil {
+			log.Exit(err)
+		}
+		creds = c
+	}
+	var q *queue.Config
+	if cfg.Queue.Enabled {
+		q = &queue.Config{
+			Dir:         cfg.Queue.Dir,
+			MaxMessages: cfg.Queue.MaxMessages,
+			MaxBytes:    cfg.Queue.MaxBytes,
+			MaxAge:      cfg.Queue.MaxAgeDuration(),
+		}
+	}
+	sc, err := client.New(ctx, &client.Config{
+		Transport:         cfg.Transport,
+		FleetspeakSocket:  cfg.FleetspeakSocket,
+		ServerAddr:        cfg.ServerAddr,
+		ServerCreds:       creds,
+		Labels:            cfg.Labels,
+		AckTimeout:        cfg.AckTimeoutDuration(),
+		Queue:             q,
+		RequestLedger:     cfg.RequestLedger,
+		RequestExpiry:     cfg.RequestExpiryDuration(),
+		SuricataSocket:    cfg.SuricataSocket,
+		SuricataBinary:    cfg.SuricataBinary,
+		SuricataConfig:    cfg.SuricataConfig,
+		SuricataLog:       cfg.SuricataLog,
+		AllowedCommands:   cfg.AllowedCommands,
+		DatasetDir:        cfg.DatasetDir,
+		ConfigFile:        *configFile,
+		RuleFile:          cfg.RuleFile,
+		RuleKey:           key,
+		Org:               cfg.Org,
+		Zone:              cfg.Zone,
+		UUIDFile:          cfg.UUIDFile,
+		CaptureInterfaces: cfg.CaptureInterfaces,
+		Version:           version,
+	}, fs)
 	if err != nil {
 		log.Exitf("failed to create sensor client: %v", err)
 	}
@@ -71,12 +193,20 @@ func main() {
 	}()
 	defer close(done)
 
-	if *monitorEVE {
-		go monitorEVELog(sc)
+	if cfg.AdminAddr != "" {
+		serveAdmin(sc, cfg)
 	}
 
-	if *heartbeat {
-		go heartbeatPolling(sc)
+	s := &sensor{sc: sc}
+	if err := s.apply(ctx, cfg); err != nil {
+		log.Exit(err)
+	}
+	if w != nil {
+		go w.Run(ctx, *configCheck, func(c *config.Config) {
+			if err := s.apply(ctx, c); err != nil {
+				log.Errorf("Failed to apply reloaded sensor config: %v", err)
+			}
+		})
 	}
 
 	for msg := range sc.FSClient.Messages() {
@@ -86,25 +216,284 @@ func main() {
 	}
 }
 
-func mustGetFileStore(ctx context.Context) (filestore.FileStore, func() error) {
-	if *memoryStorage {
+// flagConfig returns the sensor configuration set by the command line flags.
+func flagConfig() *config.Config {
+	return &config.Config{
+		Transport:         *transportName,
+		FleetspeakSocket:  *fsSocket,
+		ServerAddr:        *serverAddr,
+		TLSCert:           *tlsCert,
+		TLSKey:            *tlsKey,
+		TLSCA:             *tlsCA,
+		Labels:            splitList(*labels),
+		SuricataSocket:    *suricataSocket,
+		RuleFile:          *ruleFile,
+		SuricataBinary:    *suricataBinary,
+		SuricataConfig:    *suricataConfig,
+		SuricataLog:       *suricataLog,
+		AllowedCommands:   splitList(*allowedCommands),
+		DatasetDir:        *datasetDir,
+		RulePublicKey:     *rulePublicKey,
+		MemoryStorage:     *memoryStorage,
+		ProjectID:         *projectID,
+		StorageBucket:     *storageBucket,
+		CredFile:          *credFile,
+		UUIDFile:          *uuidFile,
+		AdminAddr:         *adminAddr,
+		AdminTokenFile:    *adminTokenFile,
+		AckTimeout:        ackTimeout.String(),
+		RequestLedger:     *requestLedger,
+		RequestExpiry:     requestExpiry.String(),
+		Org:               *org,
+		Zone:              *zone,
+		CaptureInterfaces: splitList(*captureInterfaces),
+		EVE: config.EVEConfig{
+			Enabled:        *monitorEVE,
+			Log:            *suricataEVELog,
+			Checkpoint:     *eveCheckpoint,
+			Polling:        alertPollingPeriod.String(),
+			Window:         alertWindow.String(),
+			Threshold:      *alertThreshold,
+			Policy:         *alertPolicy,
+			Forward:        *forwardAlerts,
+			SampleRate:     *alertSampleRate,
+			RateLimit:      *alertRateLimit,
+			Burst:          *alertBurst,
+			BatchSize:      *alertBatchSize,
+			RuleHitsPeriod: ruleHitsPeriod.String(),
+		},
+		Queue: config.QueueConfig{
+			Enabled:     *queueMessages,
+			Dir:         *queueDir,
+			MaxMessages: *queueMaxMessages,
+			MaxBytes:    *queueMaxBytes,
+			MaxAge:      queueMaxAge.String(),
+		},
+		Heartbeat: config.HeartbeatConfig{
+			Enabled: *heartbeat,
+			Period:  heartbeatPollingPeriod.String(),
+		},
+	}
+}
+
+// splitList splits a comma-separated flag value, ignoring empty elements.
+func splitList(s string) []string {
+	var l []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			l = append(l, e)
+		}
+	}
+	return l
+}
+
+// serveAdmin serves the local status and control endpoint in the background.
+func serveAdmin(sc *client.Client, cfg *config.Config) {
+	token, err := admin.LoadToken(cfg.AdminTokenFile)
+	if err != nil {
+		log.Exit(err)
+	}
+	l, err := admin.Listen(cfg.AdminAddr)
+	if err != nil {
+		log.Exitf("failed to listen on admin address: %v", err)
+	}
+	if token == "" {
+		log.Warning("No admin token; local actions are disabled")
+	}
+	log.Infof("Serving local status on %s", cfg.AdminAddr)
+	go func() {
+		if err := http.Serve(l, admin.NewHandler(sc, token)); err != nil {
+			log.Errorf("Local status endpoint stopped: %v", err)
+		}
+	}()
+}
+
+func mustGetFileStore(ctx context.Context, cfg *config.Config) (filestore.FileStore, func() error) {
+	if cfg.MemoryStorage {
 		return filestore.NewMemoryFileStore(), func() error { return nil }
 	}
-	c, err := filestore.NewGCSClient(ctx, *credFile, []string{storage.ScopeReadOnly})
+	c, err := filestore.NewGCSClient(ctx, cfg.CredFile, []string{storage.ScopeReadOnly})
 	if err != nil {
 		log.Exitf("failed to create Google Cloud Storage client: %v", err)
 	}
-	return filestore.NewGCSFileStore(*storageBucket, c), c.Close
+	return filestore.NewGCSFileStore(cfg.StorageBucket, c), c.Close
+}
+
+// sensor runs the EVE monitoring and heartbeat loops, applying configuration changes to them
+// without interrupting the Fleetspeak channel.
+type sensor struct {
+	sc *client.Client
+
+	// Guards eve, the EVE monitoring configuration in effect, which the EVE monitoring loop sets
+	// once it applied a change.
+	mu  sync.Mutex
+	eve *config.EVEConfig
+	// Latest EVE monitoring configuration applied, possibly not yet in effect.
+	eveLatest *config.EVEConfig
+	// Stops the EVE monitoring loop.
+	stopEVE context.CancelFunc
+	// Holds the latest configuration change not yet applied by the EVE monitoring loop.
+	eveUpdates chan *eveUpdate
+
+	heartbeat     *config.HeartbeatConfig
+	stopHeartbeat context.CancelFunc
+}
+
+// eveUpdate is a loaded EVE monitoring configuration change.
+type eveUpdate struct {
+	cfg    *config.EVEConfig
+	policy *eve.Policy
 }
 
-func monitorEVELog(sc *client.Client) {
-	for range time.Tick(*alertPollingPeriod) {
-		sc.MonitorSurcataEVELog(*alertPollingPeriod, *alertThreshold, *suricataEVELog)
+// apply applies the settings of a validated configuration which can be changed while the sensor is
+// running. It is not safe for concurrent use.
+func (s *sensor) apply(ctx context.Context, cfg *config.Config) error {
+	s.sc.SetIdentity(cfg.Org, cfg.Zone, cfg.CaptureInterfaces)
+
+	e := cfg.EVE
+	s.mu.Lock()
+	inEffect := s.eve != nil && reflect.DeepEqual(*s.eve, e)
+	s.mu.Unlock()
+	switch {
+	case inEffect && reflect.DeepEqual(*s.eveLatest, e):
+	case !e.Enabled:
+		s.mu.Lock()
+		if s.stopEVE != nil {
+			s.stopEVE()
+			s.stopEVE = nil
+		}
+		s.eve = &e
+		s.mu.Unlock()
+	case s.stopEVE != nil:
+		policy, err := loadAlertPolicy(&e)
+		if err != nil {
+			return err
+		}
+		// Replace any change the loop did not apply yet, without waiting for it.
+		u := &eveUpdate{cfg: &e, policy: policy}
+		select {
+		case <-s.eveUpdates:
+		default:
+		}
+		s.eveUpdates <- u
+	default:
+		policy, err := loadAlertPolicy(&e)
+		if err != nil {
+			return err
+		}
+		m, err := s.sc.NewEVEMonitor(e.Log, e.Checkpoint, policy, alertForwarding(&e))
+		if err != nil {
+			return err
+		}
+		ectx, stop := context.WithCancel(ctx)
+		s.mu.Lock()
+		s.stopEVE, s.eve = stop, &e
+		s.mu.Unlock()
+		s.eveUpdates = make(chan *eveUpdate, 1)
+		go monitorEVELog(ectx, m, &e, s.eveUpdates, func(c *config.EVEConfig) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			// Changes applied after the loop was stopped are not in effect.
+			if ectx.Err() == nil {
+				s.eve = c
+			}
+		})
+	}
+	s.eveLatest = &e
+
+	h := cfg.Heartbeat
+	if s.heartbeat == nil || !reflect.DeepEqual(*s.heartbeat, h) {
+		if s.stopHeartbeat != nil {
+			s.stopHeartbeat()
+			s.stopHeartbeat = nil
+		}
+		if h.Enabled {
+			var hctx context.Context
+			hctx, s.stopHeartbeat = context.WithCancel(ctx)
+			go heartbeatPolling(hctx, s.sc, h.Interval())
+		}
+	}
+	s.heartbeat = &h
+	return nil
+}
+
+// loadAlertPolicy returns the alert policy of the EVE configuration.
+func loadAlertPolicy(e *config.EVEConfig) (*eve.Policy, error) {
+	if e.Policy == "" {
+		return eve.DefaultPolicy(e.WindowDuration(), e.Threshold), nil
+	}
+	return eve.LoadPolicy(e.Policy)
+}
+
+// alertForwarding returns the alert forwarding settings of the EVE configuration, nil if alert
+// events are not forwarded.
+func alertForwarding(e *config.EVEConfig) *client.AlertForwarding {
+	if !e.Forward {
+		return nil
+	}
+	return &client.AlertForwarding{
+		SampleRate: e.SampleRate,
+		RateLimit:  e.RateLimit,
+		Burst:      e.Burst,
+		BatchSize:  e.BatchSize,
 	}
 }
 
-func heartbeatPolling(sc *client.Client) {
-	for range time.Tick(*heartbeatPollingPeriod) {
-		sc.SendHeartbeat()
+// monitorEVELog polls the EVE log and reports rule hits until ctx is done, applying the
+// configuration changes received on updates and calling applied for every change in effect.
+// Changes which fail to apply are retried when they are received again.
+func monitorEVELog(ctx context.Context, m *client.EVEMonitor, cfg *config.EVEConfig, updates <-chan *eveUpdate, applied func(*config.EVEConfig)) {
+	poll := time.NewTicker(cfg.PollingInterval())
+	report := time.NewTicker(cfg.RuleHitsInterval())
+	defer func() {
+		poll.Stop()
+		report.Stop()
+		// Report the alerts counted since the last report before stopping.
+		m.ReportRuleHits()
+		if err := m.Close(); err != nil {
+			log.Error(err)
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			if err := m.Poll(); err != nil {
+				log.Error(err)
+			}
+		case <-report.C:
+			m.ReportRuleHits()
+		case u := <-updates:
+			c := u.cfg
+			if err := m.Update(c.Log, c.Checkpoint, u.policy, alertForwarding(c)); err != nil {
+				log.Errorf("Failed to apply EVE monitoring config: %v", err)
+				continue
+			}
+			if c.Polling != cfg.Polling {
+				poll.Stop()
+				poll = time.NewTicker(c.PollingInterval())
+			}
+			if c.RuleHitsPeriod != cfg.RuleHitsPeriod {
+				report.Stop()
+				report = time.NewTicker(c.RuleHitsInterval())
+			}
+			cfg = c
+			applied(c)
+		}
+	}
+}
+
+// heartbeatPolling sends heartbeats at every interval until ctx is done.
+func heartbeatPolling(ctx context.Context, sc *client.Client, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			sc.SendHeartbeat()
+		}
 	}
 }
@@ -4,10 +4,13 @@
 package emitto_sensor
 
 import (
+	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	proto1 "github.com/google/emitto/source/sensor/suricata/proto"
 	status "google.golang.org/genproto/googleapis/rpc/status"
+	grpc "google.golang.org/grpc"
 	math "math"
 )
 
@@ -23,10 +26,15 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type DeployRules struct {
-	RuleFile             string   `protobuf:"bytes,1,opt,name=rule_file,json=ruleFile,proto3" json:"rule_file,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	RuleFile             string     `protobuf:"bytes,1,opt,name=rule_file,json=ruleFile,proto3" json:"rule_file,omitempty"`
+	Sha256               []byte     `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Signature            []byte     `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Location             string     `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Generation           int64      `protobuf:"varint,5,opt,name=generation,proto3" json:"generation,omitempty"`
+	Datasets             []*Dataset `protobuf:"bytes,6,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *DeployRules) Reset()         { *m = DeployRules{} }
@@ -61,6 +69,159 @@ func (m *DeployRules) GetRuleFile() string {
 	return ""
 }
 
+func (m *DeployRules) GetSha256() []byte {
+	if m != nil {
+		return m.Sha256
+	}
+	return nil
+}
+
+func (m *DeployRules) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *DeployRules) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *DeployRules) GetGeneration() int64 {
+	if m != nil {
+		return m.Generation
+	}
+	return 0
+}
+
+func (m *DeployRules) GetDatasets() []*Dataset {
+	if m != nil {
+		return m.Datasets
+	}
+	return nil
+}
+
+type Dataset struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Members              []string `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dataset) Reset()         { *m = Dataset{} }
+func (m *Dataset) String() string { return proto.CompactTextString(m) }
+func (*Dataset) ProtoMessage()    {}
+func (*Dataset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{1}
+}
+
+func (m *Dataset) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dataset.Unmarshal(m, b)
+}
+func (m *Dataset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dataset.Marshal(b, m, deterministic)
+}
+func (m *Dataset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dataset.Merge(m, src)
+}
+func (m *Dataset) XXX_Size() int {
+	return xxx_messageInfo_Dataset.Size(m)
+}
+func (m *Dataset) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dataset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dataset proto.InternalMessageInfo
+
+func (m *Dataset) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Dataset) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Dataset) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type UpdateDataset struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Add                  []string `protobuf:"bytes,3,rep,name=add,proto3" json:"add,omitempty"`
+	Remove               []string `protobuf:"bytes,4,rep,name=remove,proto3" json:"remove,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateDataset) Reset()         { *m = UpdateDataset{} }
+func (m *UpdateDataset) String() string { return proto.CompactTextString(m) }
+func (*UpdateDataset) ProtoMessage()    {}
+func (*UpdateDataset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{2}
+}
+
+func (m *UpdateDataset) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDataset.Unmarshal(m, b)
+}
+func (m *UpdateDataset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDataset.Marshal(b, m, deterministic)
+}
+func (m *UpdateDataset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDataset.Merge(m, src)
+}
+func (m *UpdateDataset) XXX_Size() int {
+	return xxx_messageInfo_UpdateDataset.Size(m)
+}
+func (m *UpdateDataset) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDataset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDataset proto.InternalMessageInfo
+
+func (m *UpdateDataset) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateDataset) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *UpdateDataset) GetAdd() []string {
+	if m != nil {
+		return m.Add
+	}
+	return nil
+}
+
+func (m *UpdateDataset) GetRemove() []string {
+	if m != nil {
+		return m.Remove
+	}
+	return nil
+}
+
 type ReloadRules struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -71,7 +232,7 @@ func (m *ReloadRules) Reset()         { *m = ReloadRules{} }
 func (m *ReloadRules) String() string { return proto.CompactTextString(m) }
 func (*ReloadRules) ProtoMessage()    {}
 func (*ReloadRules) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8209f5d37db142cb, []int{1}
+	return fileDescriptor_8209f5d37db142cb, []int{3}
 }
 
 func (m *ReloadRules) XXX_Unmarshal(b []byte) error {
@@ -92,12 +253,101 @@ func (m *ReloadRules) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ReloadRules proto.InternalMessageInfo
 
+type CollectDiagnostics struct {
+	LogLines             int32    `protobuf:"varint,1,opt,name=log_lines,json=logLines,proto3" json:"log_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectDiagnostics) Reset()         { *m = CollectDiagnostics{} }
+func (m *CollectDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*CollectDiagnostics) ProtoMessage()    {}
+func (*CollectDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{4}
+}
+
+func (m *CollectDiagnostics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectDiagnostics.Unmarshal(m, b)
+}
+func (m *CollectDiagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectDiagnostics.Marshal(b, m, deterministic)
+}
+func (m *CollectDiagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectDiagnostics.Merge(m, src)
+}
+func (m *CollectDiagnostics) XXX_Size() int {
+	return xxx_messageInfo_CollectDiagnostics.Size(m)
+}
+func (m *CollectDiagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectDiagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectDiagnostics proto.InternalMessageInfo
+
+func (m *CollectDiagnostics) GetLogLines() int32 {
+	if m != nil {
+		return m.LogLines
+	}
+	return 0
+}
+
+type RunCommand struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Args                 map[string]string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RunCommand) Reset()         { *m = RunCommand{} }
+func (m *RunCommand) String() string { return proto.CompactTextString(m) }
+func (*RunCommand) ProtoMessage()    {}
+func (*RunCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{5}
+}
+
+func (m *RunCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunCommand.Unmarshal(m, b)
+}
+func (m *RunCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunCommand.Marshal(b, m, deterministic)
+}
+func (m *RunCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunCommand.Merge(m, src)
+}
+func (m *RunCommand) XXX_Size() int {
+	return xxx_messageInfo_RunCommand.Size(m)
+}
+func (m *RunCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunCommand proto.InternalMessageInfo
+
+func (m *RunCommand) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RunCommand) GetArgs() map[string]string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
 type SensorRequest struct {
 	Id   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Time *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
 	// Types that are valid to be assigned to Type:
 	//	*SensorRequest_DeployRules
 	//	*SensorRequest_ReloadRules
+	//	*SensorRequest_CollectDiagnostics
+	//	*SensorRequest_RunCommand
+	//	*SensorRequest_UpdateDataset
 	Type                 isSensorRequest_Type `protobuf_oneof:"type"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
@@ -108,7 +358,7 @@ func (m *SensorRequest) Reset()         { *m = SensorRequest{} }
 func (m *SensorRequest) String() string { return proto.CompactTextString(m) }
 func (*SensorRequest) ProtoMessage()    {}
 func (*SensorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8209f5d37db142cb, []int{2}
+	return fileDescriptor_8209f5d37db142cb, []int{6}
 }
 
 func (m *SensorRequest) XXX_Unmarshal(b []byte) error {
@@ -155,10 +405,28 @@ type SensorRequest_ReloadRules struct {
 	ReloadRules *ReloadRules `protobuf:"bytes,4,opt,name=reload_rules,json=reloadRules,proto3,oneof"`
 }
 
+type SensorRequest_CollectDiagnostics struct {
+	CollectDiagnostics *CollectDiagnostics `protobuf:"bytes,5,opt,name=collect_diagnostics,json=collectDiagnostics,proto3,oneof"`
+}
+
+type SensorRequest_RunCommand struct {
+	RunCommand *RunCommand `protobuf:"bytes,6,opt,name=run_command,json=runCommand,proto3,oneof"`
+}
+
+type SensorRequest_UpdateDataset struct {
+	UpdateDataset *UpdateDataset `protobuf:"bytes,7,opt,name=update_dataset,json=updateDataset,proto3,oneof"`
+}
+
 func (*SensorRequest_DeployRules) isSensorRequest_Type() {}
 
 func (*SensorRequest_ReloadRules) isSensorRequest_Type() {}
 
+func (*SensorRequest_CollectDiagnostics) isSensorRequest_Type() {}
+
+func (*SensorRequest_RunCommand) isSensorRequest_Type() {}
+
+func (*SensorRequest_UpdateDataset) isSensorRequest_Type() {}
+
 func (m *SensorRequest) GetType() isSensorRequest_Type {
 	if m != nil {
 		return m.Type
@@ -180,30 +448,57 @@ func (m *SensorRequest) GetReloadRules() *ReloadRules {
 	return nil
 }
 
+func (m *SensorRequest) GetCollectDiagnostics() *CollectDiagnostics {
+	if x, ok := m.GetType().(*SensorRequest_CollectDiagnostics); ok {
+		return x.CollectDiagnostics
+	}
+	return nil
+}
+
+func (m *SensorRequest) GetRunCommand() *RunCommand {
+	if x, ok := m.GetType().(*SensorRequest_RunCommand); ok {
+		return x.RunCommand
+	}
+	return nil
+}
+
+func (m *SensorRequest) GetUpdateDataset() *UpdateDataset {
+	if x, ok := m.GetType().(*SensorRequest_UpdateDataset); ok {
+		return x.UpdateDataset
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*SensorRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*SensorRequest_DeployRules)(nil),
 		(*SensorRequest_ReloadRules)(nil),
+		(*SensorRequest_CollectDiagnostics)(nil),
+		(*SensorRequest_RunCommand)(nil),
+		(*SensorRequest_UpdateDataset)(nil),
 	}
 }
 
 type Host struct {
-	Fqdn                 string   `protobuf:"bytes,1,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
-	Ip                   string   `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
-	Uuid                 string   `protobuf:"bytes,3,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Org                  string   `protobuf:"bytes,4,opt,name=org,proto3" json:"org,omitempty"`
-	Zone                 string   `protobuf:"bytes,5,opt,name=zone,proto3" json:"zone,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Fqdn                 string              `protobuf:"bytes,1,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
+	Ip                   string              `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Uuid                 string              `protobuf:"bytes,3,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Org                  string              `protobuf:"bytes,4,opt,name=org,proto3" json:"org,omitempty"`
+	Zone                 string              `protobuf:"bytes,5,opt,name=zone,proto3" json:"zone,omitempty"`
+	Os                   string              `protobuf:"bytes,6,opt,name=os,proto3" json:"os,omitempty"`
+	KernelVersion        string              `protobuf:"bytes,7,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	Interfaces           []*NetworkInterface `protobuf:"bytes,8,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *Host) Reset()         { *m = Host{} }
 func (m *Host) String() string { return proto.CompactTextString(m) }
 func (*Host) ProtoMessage()    {}
 func (*Host) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8209f5d37db142cb, []int{3}
+	return fileDescriptor_8209f5d37db142cb, []int{7}
 }
 
 func (m *Host) XXX_Unmarshal(b []byte) error {
@@ -259,12 +554,114 @@ func (m *Host) GetZone() string {
 	return ""
 }
 
+func (m *Host) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *Host) GetKernelVersion() string {
+	if m != nil {
+		return m.KernelVersion
+	}
+	return ""
+}
+
+func (m *Host) GetInterfaces() []*NetworkInterface {
+	if m != nil {
+		return m.Interfaces
+	}
+	return nil
+}
+
+type NetworkInterface struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mac                  string   `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	Addresses            []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Mtu                  int32    `protobuf:"varint,4,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Up                   bool     `protobuf:"varint,5,opt,name=up,proto3" json:"up,omitempty"`
+	Capture              bool     `protobuf:"varint,6,opt,name=capture,proto3" json:"capture,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkInterface) Reset()         { *m = NetworkInterface{} }
+func (m *NetworkInterface) String() string { return proto.CompactTextString(m) }
+func (*NetworkInterface) ProtoMessage()    {}
+func (*NetworkInterface) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{8}
+}
+
+func (m *NetworkInterface) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkInterface.Unmarshal(m, b)
+}
+func (m *NetworkInterface) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkInterface.Marshal(b, m, deterministic)
+}
+func (m *NetworkInterface) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkInterface.Merge(m, src)
+}
+func (m *NetworkInterface) XXX_Size() int {
+	return xxx_messageInfo_NetworkInterface.Size(m)
+}
+func (m *NetworkInterface) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkInterface.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkInterface proto.InternalMessageInfo
+
+func (m *NetworkInterface) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NetworkInterface) GetMac() string {
+	if m != nil {
+		return m.Mac
+	}
+	return ""
+}
+
+func (m *NetworkInterface) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *NetworkInterface) GetMtu() int32 {
+	if m != nil {
+		return m.Mtu
+	}
+	return 0
+}
+
+func (m *NetworkInterface) GetUp() bool {
+	if m != nil {
+		return m.Up
+	}
+	return false
+}
+
+func (m *NetworkInterface) GetCapture() bool {
+	if m != nil {
+		return m.Capture
+	}
+	return false
+}
+
 type SensorMessage struct {
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// Types that are valid to be assigned to Type:
 	//	*SensorMessage_Response
 	//	*SensorMessage_Alert
 	//	*SensorMessage_Heartbeat
+	//	*SensorMessage_EveAlerts
+	//	*SensorMessage_RuleHits
 	Type                 isSensorMessage_Type `protobuf_oneof:"type"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
@@ -275,7 +672,7 @@ func (m *SensorMessage) Reset()         { *m = SensorMessage{} }
 func (m *SensorMessage) String() string { return proto.CompactTextString(m) }
 func (*SensorMessage) ProtoMessage()    {}
 func (*SensorMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8209f5d37db142cb, []int{4}
+	return fileDescriptor_8209f5d37db142cb, []int{9}
 }
 
 func (m *SensorMessage) XXX_Unmarshal(b []byte) error {
@@ -319,12 +716,24 @@ type SensorMessage_Heartbeat struct {
 	Heartbeat *Heartbeat `protobuf:"bytes,4,opt,name=heartbeat,proto3,oneof"`
 }
 
+type SensorMessage_EveAlerts struct {
+	EveAlerts *EVEAlerts `protobuf:"bytes,5,opt,name=eve_alerts,json=eveAlerts,proto3,oneof"`
+}
+
+type SensorMessage_RuleHits struct {
+	RuleHits *RuleHits `protobuf:"bytes,6,opt,name=rule_hits,json=ruleHits,proto3,oneof"`
+}
+
 func (*SensorMessage_Response) isSensorMessage_Type() {}
 
 func (*SensorMessage_Alert) isSensorMessage_Type() {}
 
 func (*SensorMessage_Heartbeat) isSensorMessage_Type() {}
 
+func (*SensorMessage_EveAlerts) isSensorMessage_Type() {}
+
+func (*SensorMessage_RuleHits) isSensorMessage_Type() {}
+
 func (m *SensorMessage) GetType() isSensorMessage_Type {
 	if m != nil {
 		return m.Type
@@ -353,12 +762,28 @@ func (m *SensorMessage) GetHeartbeat() *Heartbeat {
 	return nil
 }
 
+func (m *SensorMessage) GetEveAlerts() *EVEAlerts {
+	if x, ok := m.GetType().(*SensorMessage_EveAlerts); ok {
+		return x.EveAlerts
+	}
+	return nil
+}
+
+func (m *SensorMessage) GetRuleHits() *RuleHits {
+	if x, ok := m.GetType().(*SensorMessage_RuleHits); ok {
+		return x.RuleHits
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*SensorMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*SensorMessage_Response)(nil),
 		(*SensorMessage_Alert)(nil),
 		(*SensorMessage_Heartbeat)(nil),
+		(*SensorMessage_EveAlerts)(nil),
+		(*SensorMessage_RuleHits)(nil),
 	}
 }
 
@@ -367,6 +792,11 @@ type SensorResponse struct {
 	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
 	Status               *status.Status       `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 	Host                 *Host                `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	RuleErrors           []*RuleError         `protobuf:"bytes,5,rep,name=rule_errors,json=ruleErrors,proto3" json:"rule_errors,omitempty"`
+	RulesetStats         []*RulesetStats      `protobuf:"bytes,6,rep,name=ruleset_stats,json=rulesetStats,proto3" json:"ruleset_stats,omitempty"`
+	FailedRules          []*RuleError         `protobuf:"bytes,7,rep,name=failed_rules,json=failedRules,proto3" json:"failed_rules,omitempty"`
+	Diagnostics          *Diagnostics         `protobuf:"bytes,8,opt,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	CommandResult        string               `protobuf:"bytes,9,opt,name=command_result,json=commandResult,proto3" json:"command_result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -376,7 +806,7 @@ func (m *SensorResponse) Reset()         { *m = SensorResponse{} }
 func (m *SensorResponse) String() string { return proto.CompactTextString(m) }
 func (*SensorResponse) ProtoMessage()    {}
 func (*SensorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8209f5d37db142cb, []int{5}
+	return fileDescriptor_8209f5d37db142cb, []int{10}
 }
 
 func (m *SensorResponse) XXX_Unmarshal(b []byte) error {
@@ -425,64 +855,646 @@ func (m *SensorResponse) GetHost() *Host {
 	return nil
 }
 
-type SensorAlert struct {
-	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
-	Status               *status.Status       `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Host                 *Host                `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *SensorResponse) GetRuleErrors() []*RuleError {
+	if m != nil {
+		return m.RuleErrors
+	}
+	return nil
 }
 
-func (m *SensorAlert) Reset()         { *m = SensorAlert{} }
-func (m *SensorAlert) String() string { return proto.CompactTextString(m) }
-func (*SensorAlert) ProtoMessage()    {}
-func (*SensorAlert) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8209f5d37db142cb, []int{6}
+func (m *SensorResponse) GetRulesetStats() []*RulesetStats {
+	if m != nil {
+		return m.RulesetStats
+	}
+	return nil
 }
 
-func (m *SensorAlert) XXX_Unmarshal(b []byte) error {
+func (m *SensorResponse) GetFailedRules() []*RuleError {
+	if m != nil {
+		return m.FailedRules
+	}
+	return nil
+}
+
+func (m *SensorResponse) GetDiagnostics() *Diagnostics {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+func (m *SensorResponse) GetCommandResult() string {
+	if m != nil {
+		return m.CommandResult
+	}
+	return ""
+}
+
+type Diagnostics struct {
+	SuricataVersion      string       `protobuf:"bytes,1,opt,name=suricata_version,json=suricataVersion,proto3" json:"suricata_version,omitempty"`
+	Counters             string       `protobuf:"bytes,2,opt,name=counters,proto3" json:"counters,omitempty"`
+	SuricataLog          []string     `protobuf:"bytes,3,rep,name=suricata_log,json=suricataLog,proto3" json:"suricata_log,omitempty"`
+	Disks                []*DiskSpace `protobuf:"bytes,4,rep,name=disks,proto3" json:"disks,omitempty"`
+	ConfigSha256         []byte       `protobuf:"bytes,5,opt,name=config_sha256,json=configSha256,proto3" json:"config_sha256,omitempty"`
+	Errors               []string     `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Diagnostics) Reset()         { *m = Diagnostics{} }
+func (m *Diagnostics) String() string { return proto.CompactTextString(m) }
+func (*Diagnostics) ProtoMessage()    {}
+func (*Diagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{11}
+}
+
+func (m *Diagnostics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Diagnostics.Unmarshal(m, b)
+}
+func (m *Diagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Diagnostics.Marshal(b, m, deterministic)
+}
+func (m *Diagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Diagnostics.Merge(m, src)
+}
+func (m *Diagnostics) XXX_Size() int {
+	return xxx_messageInfo_Diagnostics.Size(m)
+}
+func (m *Diagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Diagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Diagnostics proto.InternalMessageInfo
+
+func (m *Diagnostics) GetSuricataVersion() string {
+	if m != nil {
+		return m.SuricataVersion
+	}
+	return ""
+}
+
+func (m *Diagnostics) GetCounters() string {
+	if m != nil {
+		return m.Counters
+	}
+	return ""
+}
+
+func (m *Diagnostics) GetSuricataLog() []string {
+	if m != nil {
+		return m.SuricataLog
+	}
+	return nil
+}
+
+func (m *Diagnostics) GetDisks() []*DiskSpace {
+	if m != nil {
+		return m.Disks
+	}
+	return nil
+}
+
+func (m *Diagnostics) GetConfigSha256() []byte {
+	if m != nil {
+		return m.ConfigSha256
+	}
+	return nil
+}
+
+func (m *Diagnostics) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type DiskSpace struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	TotalBytes           uint64   `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	FreeBytes            uint64   `protobuf:"varint,3,opt,name=free_bytes,json=freeBytes,proto3" json:"free_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskSpace) Reset()         { *m = DiskSpace{} }
+func (m *DiskSpace) String() string { return proto.CompactTextString(m) }
+func (*DiskSpace) ProtoMessage()    {}
+func (*DiskSpace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{12}
+}
+
+func (m *DiskSpace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskSpace.Unmarshal(m, b)
+}
+func (m *DiskSpace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskSpace.Marshal(b, m, deterministic)
+}
+func (m *DiskSpace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskSpace.Merge(m, src)
+}
+func (m *DiskSpace) XXX_Size() int {
+	return xxx_messageInfo_DiskSpace.Size(m)
+}
+func (m *DiskSpace) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskSpace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskSpace proto.InternalMessageInfo
+
+func (m *DiskSpace) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DiskSpace) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *DiskSpace) GetFreeBytes() uint64 {
+	if m != nil {
+		return m.FreeBytes
+	}
+	return 0
+}
+
+type RuleError struct {
+	Line                 int32    `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Sid                  int64    `protobuf:"varint,2,opt,name=sid,proto3" json:"sid,omitempty"`
+	Rule                 string   `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Filename             string   `protobuf:"bytes,5,opt,name=filename,proto3" json:"filename,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RuleError) Reset()         { *m = RuleError{} }
+func (m *RuleError) String() string { return proto.CompactTextString(m) }
+func (*RuleError) ProtoMessage()    {}
+func (*RuleError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{13}
+}
+
+func (m *RuleError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleError.Unmarshal(m, b)
+}
+func (m *RuleError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleError.Marshal(b, m, deterministic)
+}
+func (m *RuleError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleError.Merge(m, src)
+}
+func (m *RuleError) XXX_Size() int {
+	return xxx_messageInfo_RuleError.Size(m)
+}
+func (m *RuleError) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleError proto.InternalMessageInfo
+
+func (m *RuleError) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *RuleError) GetSid() int64 {
+	if m != nil {
+		return m.Sid
+	}
+	return 0
+}
+
+func (m *RuleError) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *RuleError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *RuleError) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+type RulesetStats struct {
+	TenantId             int32    `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	RulesLoaded          int64    `protobuf:"varint,2,opt,name=rules_loaded,json=rulesLoaded,proto3" json:"rules_loaded,omitempty"`
+	RulesFailed          int64    `protobuf:"varint,3,opt,name=rules_failed,json=rulesFailed,proto3" json:"rules_failed,omitempty"`
+	RulesSkipped         int64    `protobuf:"varint,4,opt,name=rules_skipped,json=rulesSkipped,proto3" json:"rules_skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RulesetStats) Reset()         { *m = RulesetStats{} }
+func (m *RulesetStats) String() string { return proto.CompactTextString(m) }
+func (*RulesetStats) ProtoMessage()    {}
+func (*RulesetStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{14}
+}
+
+func (m *RulesetStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RulesetStats.Unmarshal(m, b)
+}
+func (m *RulesetStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RulesetStats.Marshal(b, m, deterministic)
+}
+func (m *RulesetStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RulesetStats.Merge(m, src)
+}
+func (m *RulesetStats) XXX_Size() int {
+	return xxx_messageInfo_RulesetStats.Size(m)
+}
+func (m *RulesetStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_RulesetStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RulesetStats proto.InternalMessageInfo
+
+func (m *RulesetStats) GetTenantId() int32 {
+	if m != nil {
+		return m.TenantId
+	}
+	return 0
+}
+
+func (m *RulesetStats) GetRulesLoaded() int64 {
+	if m != nil {
+		return m.RulesLoaded
+	}
+	return 0
+}
+
+func (m *RulesetStats) GetRulesFailed() int64 {
+	if m != nil {
+		return m.RulesFailed
+	}
+	return 0
+}
+
+func (m *RulesetStats) GetRulesSkipped() int64 {
+	if m != nil {
+		return m.RulesSkipped
+	}
+	return 0
+}
+
+type SensorAlert struct {
+	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Status               *status.Status       `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Host                 *Host                `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Policy               string               `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`
+	AlertCount           int64                `protobuf:"varint,5,opt,name=alert_count,json=alertCount,proto3" json:"alert_count,omitempty"`
+	WindowSeconds        int64                `protobuf:"varint,6,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	TopSignatures        []*AlertContributor  `protobuf:"bytes,7,rep,name=top_signatures,json=topSignatures,proto3" json:"top_signatures,omitempty"`
+	TopHosts             []*AlertContributor  `protobuf:"bytes,8,rep,name=top_hosts,json=topHosts,proto3" json:"top_hosts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SensorAlert) Reset()         { *m = SensorAlert{} }
+func (m *SensorAlert) String() string { return proto.CompactTextString(m) }
+func (*SensorAlert) ProtoMessage()    {}
+func (*SensorAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{15}
+}
+
+func (m *SensorAlert) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SensorAlert.Unmarshal(m, b)
 }
-func (m *SensorAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SensorAlert.Marshal(b, m, deterministic)
+func (m *SensorAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensorAlert.Marshal(b, m, deterministic)
+}
+func (m *SensorAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensorAlert.Merge(m, src)
+}
+func (m *SensorAlert) XXX_Size() int {
+	return xxx_messageInfo_SensorAlert.Size(m)
+}
+func (m *SensorAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensorAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensorAlert proto.InternalMessageInfo
+
+func (m *SensorAlert) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *SensorAlert) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *SensorAlert) GetHost() *Host {
+	if m != nil {
+		return m.Host
+	}
+	return nil
+}
+
+func (m *SensorAlert) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *SensorAlert) GetAlertCount() int64 {
+	if m != nil {
+		return m.AlertCount
+	}
+	return 0
+}
+
+func (m *SensorAlert) GetWindowSeconds() int64 {
+	if m != nil {
+		return m.WindowSeconds
+	}
+	return 0
+}
+
+func (m *SensorAlert) GetTopSignatures() []*AlertContributor {
+	if m != nil {
+		return m.TopSignatures
+	}
+	return nil
+}
+
+func (m *SensorAlert) GetTopHosts() []*AlertContributor {
+	if m != nil {
+		return m.TopHosts
+	}
+	return nil
+}
+
+type AlertContributor struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertContributor) Reset()         { *m = AlertContributor{} }
+func (m *AlertContributor) String() string { return proto.CompactTextString(m) }
+func (*AlertContributor) ProtoMessage()    {}
+func (*AlertContributor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{16}
+}
+
+func (m *AlertContributor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertContributor.Unmarshal(m, b)
+}
+func (m *AlertContributor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertContributor.Marshal(b, m, deterministic)
+}
+func (m *AlertContributor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertContributor.Merge(m, src)
+}
+func (m *AlertContributor) XXX_Size() int {
+	return xxx_messageInfo_AlertContributor.Size(m)
+}
+func (m *AlertContributor) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertContributor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertContributor proto.InternalMessageInfo
+
+func (m *AlertContributor) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *AlertContributor) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *AlertContributor) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type EVEAlerts struct {
+	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Host                 *Host                `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Events               []*proto1.EVE        `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Dropped              int64                `protobuf:"varint,4,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *EVEAlerts) Reset()         { *m = EVEAlerts{} }
+func (m *EVEAlerts) String() string { return proto.CompactTextString(m) }
+func (*EVEAlerts) ProtoMessage()    {}
+func (*EVEAlerts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{17}
+}
+
+func (m *EVEAlerts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EVEAlerts.Unmarshal(m, b)
+}
+func (m *EVEAlerts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EVEAlerts.Marshal(b, m, deterministic)
+}
+func (m *EVEAlerts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EVEAlerts.Merge(m, src)
+}
+func (m *EVEAlerts) XXX_Size() int {
+	return xxx_messageInfo_EVEAlerts.Size(m)
+}
+func (m *EVEAlerts) XXX_DiscardUnknown() {
+	xxx_messageInfo_EVEAlerts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EVEAlerts proto.InternalMessageInfo
+
+func (m *EVEAlerts) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *EVEAlerts) GetHost() *Host {
+	if m != nil {
+		return m.Host
+	}
+	return nil
+}
+
+func (m *EVEAlerts) GetEvents() []*proto1.EVE {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *EVEAlerts) GetDropped() int64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+type RuleHits struct {
+	Start                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Host                 *Host                `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Hits                 []*RuleHit           `protobuf:"bytes,4,rep,name=hits,proto3" json:"hits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RuleHits) Reset()         { *m = RuleHits{} }
+func (m *RuleHits) String() string { return proto.CompactTextString(m) }
+func (*RuleHits) ProtoMessage()    {}
+func (*RuleHits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{18}
+}
+
+func (m *RuleHits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleHits.Unmarshal(m, b)
+}
+func (m *RuleHits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleHits.Marshal(b, m, deterministic)
+}
+func (m *RuleHits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleHits.Merge(m, src)
+}
+func (m *RuleHits) XXX_Size() int {
+	return xxx_messageInfo_RuleHits.Size(m)
+}
+func (m *RuleHits) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleHits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleHits proto.InternalMessageInfo
+
+func (m *RuleHits) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *RuleHits) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *RuleHits) GetHost() *Host {
+	if m != nil {
+		return m.Host
+	}
+	return nil
+}
+
+func (m *RuleHits) GetHits() []*RuleHit {
+	if m != nil {
+		return m.Hits
+	}
+	return nil
+}
+
+type RuleHit struct {
+	SignatureId          int64    `protobuf:"varint,1,opt,name=signature_id,json=signatureId,proto3" json:"signature_id,omitempty"`
+	Rev                  int32    `protobuf:"varint,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RuleHit) Reset()         { *m = RuleHit{} }
+func (m *RuleHit) String() string { return proto.CompactTextString(m) }
+func (*RuleHit) ProtoMessage()    {}
+func (*RuleHit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{19}
+}
+
+func (m *RuleHit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleHit.Unmarshal(m, b)
+}
+func (m *RuleHit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleHit.Marshal(b, m, deterministic)
 }
-func (m *SensorAlert) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SensorAlert.Merge(m, src)
+func (m *RuleHit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleHit.Merge(m, src)
 }
-func (m *SensorAlert) XXX_Size() int {
-	return xxx_messageInfo_SensorAlert.Size(m)
+func (m *RuleHit) XXX_Size() int {
+	return xxx_messageInfo_RuleHit.Size(m)
 }
-func (m *SensorAlert) XXX_DiscardUnknown() {
-	xxx_messageInfo_SensorAlert.DiscardUnknown(m)
+func (m *RuleHit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleHit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SensorAlert proto.InternalMessageInfo
+var xxx_messageInfo_RuleHit proto.InternalMessageInfo
 
-func (m *SensorAlert) GetTime() *timestamp.Timestamp {
+func (m *RuleHit) GetSignatureId() int64 {
 	if m != nil {
-		return m.Time
+		return m.SignatureId
 	}
-	return nil
+	return 0
 }
 
-func (m *SensorAlert) GetStatus() *status.Status {
+func (m *RuleHit) GetRev() int32 {
 	if m != nil {
-		return m.Status
+		return m.Rev
 	}
-	return nil
+	return 0
 }
 
-func (m *SensorAlert) GetHost() *Host {
+func (m *RuleHit) GetCount() int64 {
 	if m != nil {
-		return m.Host
+		return m.Count
 	}
-	return nil
+	return 0
 }
 
 type Heartbeat struct {
 	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
 	Host                 *Host                `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	SensorVersion        string               `protobuf:"bytes,3,opt,name=sensor_version,json=sensorVersion,proto3" json:"sensor_version,omitempty"`
+	RuleFileSha256       []byte               `protobuf:"bytes,4,opt,name=rule_file_sha256,json=ruleFileSha256,proto3" json:"rule_file_sha256,omitempty"`
+	Suricata             *SuricataHealth      `protobuf:"bytes,5,opt,name=suricata,proto3" json:"suricata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -492,7 +1504,7 @@ func (m *Heartbeat) Reset()         { *m = Heartbeat{} }
 func (m *Heartbeat) String() string { return proto.CompactTextString(m) }
 func (*Heartbeat) ProtoMessage()    {}
 func (*Heartbeat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8209f5d37db142cb, []int{7}
+	return fileDescriptor_8209f5d37db142cb, []int{20}
 }
 
 func (m *Heartbeat) XXX_Unmarshal(b []byte) error {
@@ -527,49 +1539,657 @@ func (m *Heartbeat) GetHost() *Host {
 	return nil
 }
 
+func (m *Heartbeat) GetSensorVersion() string {
+	if m != nil {
+		return m.SensorVersion
+	}
+	return ""
+}
+
+func (m *Heartbeat) GetRuleFileSha256() []byte {
+	if m != nil {
+		return m.RuleFileSha256
+	}
+	return nil
+}
+
+func (m *Heartbeat) GetSuricata() *SuricataHealth {
+	if m != nil {
+		return m.Suricata
+	}
+	return nil
+}
+
+type SuricataHealth struct {
+	Version              string            `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	UptimeSeconds        int64             `protobuf:"varint,2,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	RunningMode          string            `protobuf:"bytes,3,opt,name=running_mode,json=runningMode,proto3" json:"running_mode,omitempty"`
+	Interfaces           []*InterfaceStats `protobuf:"bytes,4,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	KernelPackets        int64             `protobuf:"varint,5,opt,name=kernel_packets,json=kernelPackets,proto3" json:"kernel_packets,omitempty"`
+	KernelDrops          int64             `protobuf:"varint,6,opt,name=kernel_drops,json=kernelDrops,proto3" json:"kernel_drops,omitempty"`
+	RulesLoaded          int64             `protobuf:"varint,7,opt,name=rules_loaded,json=rulesLoaded,proto3" json:"rules_loaded,omitempty"`
+	RulesFailed          int64             `protobuf:"varint,8,opt,name=rules_failed,json=rulesFailed,proto3" json:"rules_failed,omitempty"`
+	Error                string            `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SuricataHealth) Reset()         { *m = SuricataHealth{} }
+func (m *SuricataHealth) String() string { return proto.CompactTextString(m) }
+func (*SuricataHealth) ProtoMessage()    {}
+func (*SuricataHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{21}
+}
+
+func (m *SuricataHealth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuricataHealth.Unmarshal(m, b)
+}
+func (m *SuricataHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuricataHealth.Marshal(b, m, deterministic)
+}
+func (m *SuricataHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuricataHealth.Merge(m, src)
+}
+func (m *SuricataHealth) XXX_Size() int {
+	return xxx_messageInfo_SuricataHealth.Size(m)
+}
+func (m *SuricataHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuricataHealth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuricataHealth proto.InternalMessageInfo
+
+func (m *SuricataHealth) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *SuricataHealth) GetUptimeSeconds() int64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
+func (m *SuricataHealth) GetRunningMode() string {
+	if m != nil {
+		return m.RunningMode
+	}
+	return ""
+}
+
+func (m *SuricataHealth) GetInterfaces() []*InterfaceStats {
+	if m != nil {
+		return m.Interfaces
+	}
+	return nil
+}
+
+func (m *SuricataHealth) GetKernelPackets() int64 {
+	if m != nil {
+		return m.KernelPackets
+	}
+	return 0
+}
+
+func (m *SuricataHealth) GetKernelDrops() int64 {
+	if m != nil {
+		return m.KernelDrops
+	}
+	return 0
+}
+
+func (m *SuricataHealth) GetRulesLoaded() int64 {
+	if m != nil {
+		return m.RulesLoaded
+	}
+	return 0
+}
+
+func (m *SuricataHealth) GetRulesFailed() int64 {
+	if m != nil {
+		return m.RulesFailed
+	}
+	return 0
+}
+
+func (m *SuricataHealth) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type InterfaceStats struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Packets              int64    `protobuf:"varint,2,opt,name=packets,proto3" json:"packets,omitempty"`
+	Drops                int64    `protobuf:"varint,3,opt,name=drops,proto3" json:"drops,omitempty"`
+	InvalidChecksums     int64    `protobuf:"varint,4,opt,name=invalid_checksums,json=invalidChecksums,proto3" json:"invalid_checksums,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InterfaceStats) Reset()         { *m = InterfaceStats{} }
+func (m *InterfaceStats) String() string { return proto.CompactTextString(m) }
+func (*InterfaceStats) ProtoMessage()    {}
+func (*InterfaceStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{22}
+}
+
+func (m *InterfaceStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InterfaceStats.Unmarshal(m, b)
+}
+func (m *InterfaceStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InterfaceStats.Marshal(b, m, deterministic)
+}
+func (m *InterfaceStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InterfaceStats.Merge(m, src)
+}
+func (m *InterfaceStats) XXX_Size() int {
+	return xxx_messageInfo_InterfaceStats.Size(m)
+}
+func (m *InterfaceStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_InterfaceStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InterfaceStats proto.InternalMessageInfo
+
+func (m *InterfaceStats) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InterfaceStats) GetPackets() int64 {
+	if m != nil {
+		return m.Packets
+	}
+	return 0
+}
+
+func (m *InterfaceStats) GetDrops() int64 {
+	if m != nil {
+		return m.Drops
+	}
+	return 0
+}
+
+func (m *InterfaceStats) GetInvalidChecksums() int64 {
+	if m != nil {
+		return m.InvalidChecksums
+	}
+	return 0
+}
+
+type Registration struct {
+	ClientId             []byte   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Labels               []string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Registration) Reset()         { *m = Registration{} }
+func (m *Registration) String() string { return proto.CompactTextString(m) }
+func (*Registration) ProtoMessage()    {}
+func (*Registration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{23}
+}
+
+func (m *Registration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Registration.Unmarshal(m, b)
+}
+func (m *Registration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Registration.Marshal(b, m, deterministic)
+}
+func (m *Registration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Registration.Merge(m, src)
+}
+func (m *Registration) XXX_Size() int {
+	return xxx_messageInfo_Registration.Size(m)
+}
+func (m *Registration) XXX_DiscardUnknown() {
+	xxx_messageInfo_Registration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Registration proto.InternalMessageInfo
+
+func (m *Registration) GetClientId() []byte {
+	if m != nil {
+		return m.ClientId
+	}
+	return nil
+}
+
+func (m *Registration) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type TransportUpstream struct {
+	// Types that are valid to be assigned to Type:
+	//	*TransportUpstream_Registration
+	//	*TransportUpstream_Message
+	Type                 isTransportUpstream_Type `protobuf_oneof:"type"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *TransportUpstream) Reset()         { *m = TransportUpstream{} }
+func (m *TransportUpstream) String() string { return proto.CompactTextString(m) }
+func (*TransportUpstream) ProtoMessage()    {}
+func (*TransportUpstream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{24}
+}
+
+func (m *TransportUpstream) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransportUpstream.Unmarshal(m, b)
+}
+func (m *TransportUpstream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransportUpstream.Marshal(b, m, deterministic)
+}
+func (m *TransportUpstream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportUpstream.Merge(m, src)
+}
+func (m *TransportUpstream) XXX_Size() int {
+	return xxx_messageInfo_TransportUpstream.Size(m)
+}
+func (m *TransportUpstream) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportUpstream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportUpstream proto.InternalMessageInfo
+
+type isTransportUpstream_Type interface {
+	isTransportUpstream_Type()
+}
+
+type TransportUpstream_Registration struct {
+	Registration *Registration `protobuf:"bytes,1,opt,name=registration,proto3,oneof"`
+}
+
+type TransportUpstream_Message struct {
+	Message *SensorMessage `protobuf:"bytes,2,opt,name=message,proto3,oneof"`
+}
+
+func (*TransportUpstream_Registration) isTransportUpstream_Type() {}
+
+func (*TransportUpstream_Message) isTransportUpstream_Type() {}
+
+func (m *TransportUpstream) GetType() isTransportUpstream_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *TransportUpstream) GetRegistration() *Registration {
+	if x, ok := m.GetType().(*TransportUpstream_Registration); ok {
+		return x.Registration
+	}
+	return nil
+}
+
+func (m *TransportUpstream) GetMessage() *SensorMessage {
+	if x, ok := m.GetType().(*TransportUpstream_Message); ok {
+		return x.Message
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TransportUpstream) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TransportUpstream_Registration)(nil),
+		(*TransportUpstream_Message)(nil),
+	}
+}
+
+type TransportDownstream struct {
+	// Types that are valid to be assigned to Type:
+	//	*TransportDownstream_Ack
+	//	*TransportDownstream_Request
+	Type                 isTransportDownstream_Type `protobuf_oneof:"type"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *TransportDownstream) Reset()         { *m = TransportDownstream{} }
+func (m *TransportDownstream) String() string { return proto.CompactTextString(m) }
+func (*TransportDownstream) ProtoMessage()    {}
+func (*TransportDownstream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8209f5d37db142cb, []int{25}
+}
+
+func (m *TransportDownstream) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransportDownstream.Unmarshal(m, b)
+}
+func (m *TransportDownstream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransportDownstream.Marshal(b, m, deterministic)
+}
+func (m *TransportDownstream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportDownstream.Merge(m, src)
+}
+func (m *TransportDownstream) XXX_Size() int {
+	return xxx_messageInfo_TransportDownstream.Size(m)
+}
+func (m *TransportDownstream) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportDownstream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportDownstream proto.InternalMessageInfo
+
+type isTransportDownstream_Type interface {
+	isTransportDownstream_Type()
+}
+
+type TransportDownstream_Ack struct {
+	Ack string `protobuf:"bytes,1,opt,name=ack,proto3,oneof"`
+}
+
+type TransportDownstream_Request struct {
+	Request *SensorRequest `protobuf:"bytes,2,opt,name=request,proto3,oneof"`
+}
+
+func (*TransportDownstream_Ack) isTransportDownstream_Type() {}
+
+func (*TransportDownstream_Request) isTransportDownstream_Type() {}
+
+func (m *TransportDownstream) GetType() isTransportDownstream_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *TransportDownstream) GetAck() string {
+	if x, ok := m.GetType().(*TransportDownstream_Ack); ok {
+		return x.Ack
+	}
+	return ""
+}
+
+func (m *TransportDownstream) GetRequest() *SensorRequest {
+	if x, ok := m.GetType().(*TransportDownstream_Request); ok {
+		return x.Request
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TransportDownstream) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TransportDownstream_Ack)(nil),
+		(*TransportDownstream_Request)(nil),
+	}
+}
+
 func init() {
 	proto.RegisterType((*DeployRules)(nil), "emitto.sensor.DeployRules")
+	proto.RegisterType((*Dataset)(nil), "emitto.sensor.Dataset")
+	proto.RegisterType((*UpdateDataset)(nil), "emitto.sensor.UpdateDataset")
 	proto.RegisterType((*ReloadRules)(nil), "emitto.sensor.ReloadRules")
+	proto.RegisterType((*CollectDiagnostics)(nil), "emitto.sensor.CollectDiagnostics")
+	proto.RegisterType((*RunCommand)(nil), "emitto.sensor.RunCommand")
+	proto.RegisterMapType((map[string]string)(nil), "emitto.sensor.RunCommand.ArgsEntry")
 	proto.RegisterType((*SensorRequest)(nil), "emitto.sensor.SensorRequest")
 	proto.RegisterType((*Host)(nil), "emitto.sensor.Host")
+	proto.RegisterType((*NetworkInterface)(nil), "emitto.sensor.NetworkInterface")
 	proto.RegisterType((*SensorMessage)(nil), "emitto.sensor.SensorMessage")
 	proto.RegisterType((*SensorResponse)(nil), "emitto.sensor.SensorResponse")
+	proto.RegisterType((*Diagnostics)(nil), "emitto.sensor.Diagnostics")
+	proto.RegisterType((*DiskSpace)(nil), "emitto.sensor.DiskSpace")
+	proto.RegisterType((*RuleError)(nil), "emitto.sensor.RuleError")
+	proto.RegisterType((*RulesetStats)(nil), "emitto.sensor.RulesetStats")
 	proto.RegisterType((*SensorAlert)(nil), "emitto.sensor.SensorAlert")
+	proto.RegisterType((*AlertContributor)(nil), "emitto.sensor.AlertContributor")
+	proto.RegisterType((*EVEAlerts)(nil), "emitto.sensor.EVEAlerts")
+	proto.RegisterType((*RuleHits)(nil), "emitto.sensor.RuleHits")
+	proto.RegisterType((*RuleHit)(nil), "emitto.sensor.RuleHit")
 	proto.RegisterType((*Heartbeat)(nil), "emitto.sensor.Heartbeat")
+	proto.RegisterType((*SuricataHealth)(nil), "emitto.sensor.SuricataHealth")
+	proto.RegisterType((*InterfaceStats)(nil), "emitto.sensor.InterfaceStats")
+	proto.RegisterType((*Registration)(nil), "emitto.sensor.Registration")
+	proto.RegisterType((*TransportUpstream)(nil), "emitto.sensor.TransportUpstream")
+	proto.RegisterType((*TransportDownstream)(nil), "emitto.sensor.TransportDownstream")
 }
 
 func init() { proto.RegisterFile("source/sensor/proto/sensor.proto", fileDescriptor_8209f5d37db142cb) }
 
 var fileDescriptor_8209f5d37db142cb = []byte{
-	// 467 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x92, 0xcf, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0x63, 0xc7, 0x8d, 0xea, 0x71, 0x53, 0xa1, 0xe5, 0x80, 0x15, 0x84, 0x88, 0x7c, 0xa1,
-	0xea, 0xc1, 0x91, 0xca, 0x05, 0x89, 0x43, 0x05, 0x42, 0xc8, 0x17, 0x2e, 0x5b, 0xee, 0x95, 0x93,
-	0x9d, 0xa4, 0x96, 0x9c, 0xec, 0x76, 0x77, 0x7d, 0x28, 0x8f, 0xc1, 0x3b, 0xf0, 0x42, 0xdc, 0x78,
-	0x1b, 0xb4, 0xff, 0x5c, 0x37, 0x80, 0x4a, 0xd4, 0xdb, 0xac, 0xf7, 0x9b, 0x99, 0xdf, 0xf7, 0x79,
-	0x61, 0xae, 0x78, 0x27, 0x57, 0xb8, 0x50, 0xb8, 0x53, 0x5c, 0x2e, 0x84, 0xe4, 0x9a, 0xfb, 0x43,
-	0x69, 0x0f, 0x64, 0x8a, 0xdb, 0x46, 0x6b, 0x5e, 0xba, 0x8f, 0xb3, 0xd7, 0x1b, 0xce, 0x37, 0x2d,
-	0x3a, 0xe5, 0xb2, 0x5b, 0x2f, 0x74, 0xb3, 0x45, 0xa5, 0xeb, 0xad, 0x70, 0xfa, 0xd9, 0x0b, 0x2f,
-	0x90, 0x62, 0xb5, 0x50, 0xba, 0xd6, 0x9d, 0x72, 0x17, 0xc5, 0x39, 0x64, 0x9f, 0x50, 0xb4, 0xfc,
-	0x8e, 0x76, 0x2d, 0x2a, 0xf2, 0x12, 0x52, 0xd9, 0xb5, 0x78, 0xbd, 0x6e, 0x5a, 0xcc, 0xa3, 0x79,
-	0x74, 0x96, 0xd2, 0x63, 0xf3, 0xe1, 0x73, 0xd3, 0x62, 0x31, 0x85, 0x8c, 0x62, 0xcb, 0x6b, 0x66,
-	0xb5, 0xc5, 0xaf, 0x08, 0xa6, 0x57, 0x76, 0x3f, 0xc5, 0xdb, 0x0e, 0x95, 0x26, 0xa7, 0x10, 0x37,
-	0xcc, 0xb7, 0xc5, 0x0d, 0x23, 0x25, 0x24, 0x06, 0x24, 0x8f, 0xe7, 0xd1, 0x59, 0x76, 0x31, 0x2b,
-	0x1d, 0x44, 0x19, 0x28, 0xcb, 0xaf, 0x81, 0x92, 0x5a, 0x1d, 0xb9, 0x84, 0x13, 0x66, 0x61, 0xae,
-	0xcd, 0x4e, 0x95, 0x8f, 0x7d, 0xdf, 0x03, 0xb3, 0xe5, 0x80, 0xb7, 0x1a, 0xd1, 0x8c, 0x0d, 0xf0,
-	0x2f, 0xe1, 0x44, 0x5a, 0x42, 0x3f, 0x20, 0xf9, 0xeb, 0x80, 0x81, 0x09, 0x33, 0x40, 0xde, 0x1f,
-	0x3f, 0x4e, 0x20, 0xd1, 0x77, 0x02, 0x0b, 0x06, 0x49, 0xc5, 0x95, 0x26, 0x04, 0x92, 0xf5, 0x2d,
-	0xdb, 0x79, 0x4f, 0xb6, 0xb6, 0x2e, 0x85, 0xf5, 0x64, 0x5c, 0x0a, 0xa3, 0xe9, 0xba, 0x86, 0x59,
-	0xda, 0x94, 0xda, 0x9a, 0x3c, 0x83, 0x31, 0x97, 0x1b, 0xbb, 0x3f, 0xa5, 0xa6, 0x34, 0xaa, 0x6f,
-	0x7c, 0x87, 0xf9, 0x91, 0x53, 0x99, 0xba, 0xf8, 0xd9, 0x27, 0xf8, 0x05, 0x95, 0xaa, 0x37, 0xf8,
-	0x47, 0x82, 0xef, 0xe1, 0x58, 0xa2, 0x12, 0x7c, 0xa7, 0x42, 0x8a, 0xaf, 0xf6, 0xcc, 0x84, 0x3f,
-	0xe0, 0x44, 0xd5, 0x88, 0xf6, 0x0d, 0xe4, 0x02, 0x8e, 0xea, 0x16, 0xa5, 0xfe, 0x47, 0x8e, 0xae,
-	0xf3, 0x83, 0x51, 0x54, 0x23, 0xea, 0xa4, 0xe4, 0x1d, 0xa4, 0x37, 0x58, 0x4b, 0xbd, 0xc4, 0x5a,
-	0xfb, 0xf8, 0xf2, 0xbd, 0xbe, 0x2a, 0xdc, 0x57, 0x23, 0x7a, 0x2f, 0xee, 0xa3, 0xfb, 0x11, 0xc1,
-	0xe9, 0x43, 0xa8, 0x27, 0xbf, 0x8b, 0x73, 0x98, 0xb8, 0x47, 0xeb, 0x9d, 0x90, 0xd0, 0x21, 0xc5,
-	0xaa, 0xbc, 0xb2, 0x37, 0xd4, 0x2b, 0xc8, 0x1b, 0x48, 0x6e, 0xb8, 0x0a, 0xec, 0xcf, 0xf7, 0xd9,
-	0xb9, 0xd2, 0xd4, 0x0a, 0x8a, 0xef, 0x11, 0x64, 0x83, 0x08, 0x7a, 0xa8, 0xe8, 0x60, 0xa8, 0xf8,
-	0xbf, 0xa1, 0xc6, 0x8f, 0x41, 0x31, 0x48, 0xfb, 0x78, 0x0f, 0x26, 0x0a, 0x5b, 0xe2, 0x47, 0xb6,
-	0x2c, 0x27, 0x76, 0xc4, 0xdb, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x2f, 0x4e, 0x92, 0x03, 0x68,
-	0x04, 0x00, 0x00,
+	// 1923 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4d, 0x8f, 0x1c, 0x49,
+	0xd1, 0xee, 0xee, 0xea, 0xee, 0xe9, 0x8a, 0x9e, 0x9e, 0x9d, 0x4d, 0xaf, 0xbc, 0xf5, 0x8e, 0x77,
+	0x5f, 0xcf, 0xd6, 0x6a, 0xc5, 0x60, 0x50, 0x7b, 0x19, 0xc4, 0xae, 0xcd, 0x1a, 0x2d, 0xde, 0x19,
+	0x5b, 0x6d, 0xe1, 0x45, 0x28, 0xc7, 0xde, 0x13, 0x52, 0x29, 0xa7, 0x2a, 0xa7, 0xa7, 0xd4, 0xd5,
+	0x95, 0xb5, 0x99, 0x59, 0x63, 0x0d, 0x9c, 0xf8, 0x0b, 0x1c, 0x10, 0xbf, 0x81, 0x33, 0x77, 0x2e,
+	0x70, 0xe7, 0x8a, 0xc4, 0x91, 0x1f, 0xc0, 0x91, 0x23, 0x8a, 0xfc, 0xa8, 0xfe, 0x1c, 0x6c, 0x0b,
+	0x71, 0xcb, 0x78, 0x32, 0x32, 0xf2, 0x23, 0x22, 0x9e, 0x88, 0x2a, 0x38, 0x54, 0xa2, 0x96, 0x29,
+	0xbf, 0xaf, 0x78, 0xa9, 0x84, 0xbc, 0x5f, 0x49, 0xa1, 0x85, 0x13, 0xc6, 0x46, 0x20, 0x23, 0x3e,
+	0xcf, 0xb5, 0x16, 0x63, 0x0b, 0x1e, 0xdc, 0x9d, 0x0a, 0x31, 0x2d, 0xb8, 0xd5, 0x3c, 0xaf, 0x2f,
+	0xee, 0xeb, 0x7c, 0xce, 0x95, 0x66, 0xf3, 0xca, 0xea, 0x1f, 0xbc, 0xef, 0x14, 0x64, 0x95, 0xde,
+	0x57, 0x9a, 0xe9, 0x5a, 0xb9, 0x89, 0xfb, 0xab, 0x5b, 0xa9, 0x5a, 0xe6, 0x29, 0xd3, 0xcc, 0xef,
+	0xe9, 0xc4, 0x84, 0x5f, 0x71, 0xbb, 0x20, 0xfe, 0x6b, 0x1b, 0x86, 0xa7, 0xbc, 0x2a, 0xc4, 0x35,
+	0xad, 0x0b, 0xae, 0xc8, 0x1d, 0x08, 0x65, 0x5d, 0xf0, 0xe4, 0x22, 0x2f, 0x78, 0xd4, 0x3e, 0x6c,
+	0x1f, 0x85, 0x74, 0x80, 0xc0, 0xd3, 0xbc, 0xe0, 0xe4, 0x36, 0xf4, 0xd5, 0x25, 0x3b, 0xfe, 0xd1,
+	0x67, 0x51, 0xe7, 0xb0, 0x7d, 0xb4, 0x4b, 0x9d, 0x44, 0x3e, 0x80, 0x50, 0xe5, 0xd3, 0x92, 0xe9,
+	0x5a, 0xf2, 0x28, 0x30, 0x53, 0x0b, 0x80, 0x1c, 0xc0, 0xa0, 0x10, 0x29, 0xd3, 0xb9, 0x28, 0xa3,
+	0xae, 0xb5, 0xe8, 0x65, 0xf2, 0xff, 0x00, 0x53, 0x5e, 0x72, 0x69, 0x67, 0x7b, 0x87, 0xed, 0xa3,
+	0x80, 0x2e, 0x21, 0xe4, 0x18, 0x06, 0x19, 0xd3, 0x4c, 0x71, 0xad, 0xa2, 0xfe, 0x61, 0x70, 0x34,
+	0x3c, 0xbe, 0x3d, 0x5e, 0x79, 0xab, 0xf1, 0xa9, 0x9d, 0xa6, 0x8d, 0x5e, 0xfc, 0x33, 0xd8, 0x71,
+	0x20, 0x21, 0xd0, 0x2d, 0xd9, 0xdc, 0x5f, 0xc4, 0x8c, 0x11, 0xd3, 0xd7, 0x15, 0x37, 0x57, 0x08,
+	0xa9, 0x19, 0x93, 0x08, 0x76, 0xe6, 0x7c, 0x7e, 0xce, 0xa5, 0x8a, 0x82, 0xc3, 0xe0, 0x28, 0xa4,
+	0x5e, 0x8c, 0x19, 0x8c, 0x5e, 0x56, 0x19, 0xd3, 0xfc, 0x6d, 0x4d, 0xee, 0x43, 0xc0, 0xb2, 0xcc,
+	0x99, 0xc3, 0x21, 0xbe, 0x9e, 0xe4, 0x73, 0x71, 0xc5, 0xa3, 0xae, 0x01, 0x9d, 0x14, 0x8f, 0x60,
+	0x48, 0x79, 0x21, 0x58, 0x66, 0x3c, 0x10, 0xff, 0x00, 0xc8, 0x89, 0x28, 0x0a, 0x9e, 0xea, 0xd3,
+	0x9c, 0x4d, 0x4b, 0xa1, 0x74, 0x9e, 0x1a, 0xbf, 0x14, 0x62, 0x9a, 0x14, 0x79, 0xc9, 0x95, 0xd9,
+	0xbb, 0x87, 0xaf, 0x38, 0x7d, 0x8e, 0x72, 0xfc, 0xdb, 0x36, 0x00, 0xad, 0xcb, 0x13, 0x31, 0x9f,
+	0xb3, 0x32, 0xdb, 0x7a, 0xc4, 0xcf, 0xa1, 0xcb, 0xe4, 0x54, 0x45, 0x1d, 0xf3, 0x88, 0x1f, 0xaf,
+	0x3d, 0xe2, 0x62, 0xf1, 0xf8, 0xb1, 0x9c, 0xaa, 0x27, 0xa5, 0x96, 0xd7, 0xd4, 0x2c, 0x38, 0xf8,
+	0x1c, 0xc2, 0x06, 0xc2, 0x4b, 0xcd, 0xf8, 0xb5, 0x33, 0x8c, 0x43, 0xf2, 0x1e, 0xf4, 0xae, 0x58,
+	0x51, 0xfb, 0xbb, 0x5b, 0xe1, 0xc7, 0x9d, 0x07, 0xed, 0xf8, 0x8f, 0x01, 0x8c, 0xce, 0x8c, 0x79,
+	0xca, 0xbf, 0xad, 0xb9, 0xd2, 0x64, 0x0f, 0x3a, 0x79, 0xe6, 0x16, 0x77, 0xf2, 0x8c, 0x8c, 0xa1,
+	0x8b, 0x81, 0x6d, 0x96, 0x0e, 0x8f, 0x0f, 0xc6, 0x36, 0xa8, 0xc7, 0x3e, 0xea, 0xc7, 0x2f, 0x7c,
+	0xd4, 0x53, 0xa3, 0x47, 0xbe, 0x84, 0xdd, 0xcc, 0x84, 0x6a, 0x82, 0x11, 0xa9, 0xa2, 0xc0, 0xad,
+	0x5b, 0x0b, 0x88, 0x45, 0x34, 0x4f, 0x5a, 0x74, 0x98, 0x2d, 0x44, 0x34, 0x20, 0xcd, 0x4b, 0x3b,
+	0x03, 0xdd, 0xad, 0x06, 0x96, 0x9c, 0x81, 0x06, 0xe4, 0x42, 0x24, 0x2f, 0xe0, 0x56, 0x6a, 0x7d,
+	0x93, 0x64, 0x0b, 0xe7, 0x98, 0xb8, 0x1d, 0x1e, 0x7f, 0xb4, 0x66, 0x67, 0xd3, 0x8b, 0x93, 0x16,
+	0x25, 0xe9, 0xa6, 0x6f, 0x1f, 0xc1, 0x50, 0xd6, 0x65, 0x92, 0x5a, 0x0f, 0x44, 0x7d, 0x63, 0xed,
+	0xff, 0x6e, 0x74, 0xd1, 0xa4, 0x45, 0x41, 0x36, 0x12, 0x79, 0x02, 0x7b, 0xb5, 0x89, 0xd0, 0xc4,
+	0x65, 0x40, 0xb4, 0x63, 0x0c, 0x7c, 0xb0, 0x66, 0x60, 0x25, 0x8c, 0x27, 0x2d, 0x3a, 0xaa, 0x97,
+	0x81, 0xaf, 0xfa, 0x36, 0x86, 0xe3, 0xbf, 0xb7, 0xa1, 0x3b, 0x11, 0xca, 0x04, 0xfa, 0xc5, 0xb7,
+	0x59, 0xe9, 0xa3, 0x08, 0xc7, 0xc6, 0x83, 0x95, 0x73, 0x75, 0x27, 0xaf, 0x50, 0xa7, 0xae, 0xf3,
+	0xcc, 0x78, 0x22, 0xa4, 0x66, 0x8c, 0x31, 0x22, 0xe4, 0xd4, 0x65, 0x3a, 0x0e, 0x51, 0xeb, 0x57,
+	0xa2, 0xe4, 0xe6, 0x99, 0x42, 0x6a, 0xc6, 0x68, 0x49, 0x28, 0x73, 0xd5, 0x90, 0x76, 0x84, 0x22,
+	0x9f, 0xc0, 0xde, 0x8c, 0xcb, 0x92, 0x17, 0xc9, 0x15, 0x97, 0x0a, 0xc9, 0x60, 0xc7, 0xcc, 0x8d,
+	0x2c, 0xfa, 0x8d, 0x05, 0xc9, 0x97, 0x00, 0x79, 0xa9, 0xb9, 0xbc, 0x60, 0x29, 0x57, 0xd1, 0xc0,
+	0x04, 0xf3, 0xdd, 0xb5, 0x8b, 0xfe, 0x9c, 0xeb, 0x57, 0x42, 0xce, 0x9e, 0x79, 0x3d, 0xba, 0xb4,
+	0x04, 0x53, 0x65, 0x7f, 0x5d, 0x61, 0x6b, 0xc2, 0xec, 0x43, 0x30, 0x67, 0xa9, 0xbb, 0x2b, 0x0e,
+	0x91, 0xe5, 0x58, 0x96, 0x49, 0xae, 0x14, 0xf7, 0x34, 0xb1, 0x00, 0x8c, 0xbe, 0xae, 0xcd, 0xb5,
+	0x7b, 0x14, 0x87, 0x78, 0xc5, 0xba, 0x32, 0x97, 0x1e, 0xd0, 0x4e, 0x5d, 0x21, 0xc9, 0xa4, 0xac,
+	0x32, 0x1c, 0xd9, 0x37, 0xa0, 0x17, 0xe3, 0xbf, 0x74, 0x7c, 0xaa, 0x7c, 0xcd, 0x95, 0x62, 0x53,
+	0xbe, 0x91, 0x2a, 0x5f, 0xc0, 0x40, 0x72, 0x55, 0x89, 0x52, 0xf9, 0x74, 0xf9, 0x70, 0xed, 0xd6,
+	0x3e, 0xd5, 0xac, 0xd2, 0xa4, 0x45, 0x9b, 0x05, 0xe4, 0x18, 0x7a, 0xac, 0xe0, 0x52, 0xdf, 0x90,
+	0x30, 0x76, 0xe5, 0x63, 0xd4, 0x98, 0xb4, 0xa8, 0x55, 0x25, 0x0f, 0x20, 0xbc, 0xe4, 0x4c, 0xea,
+	0x73, 0xce, 0xb4, 0xcb, 0x93, 0x68, 0x6d, 0xdd, 0xc4, 0xcf, 0x4f, 0x5a, 0x74, 0xa1, 0x4c, 0x1e,
+	0x02, 0xf0, 0x2b, 0x9e, 0x18, 0x33, 0x3e, 0x35, 0xd6, 0x97, 0x3e, 0xf9, 0xe6, 0x89, 0xd9, 0x0f,
+	0x33, 0x22, 0xe4, 0x57, 0xdc, 0x0a, 0xe4, 0x33, 0x57, 0x7c, 0x2e, 0x73, 0xad, 0x5c, 0x1a, 0xbc,
+	0xbf, 0x91, 0x06, 0x05, 0x9f, 0xe4, 0x66, 0xe1, 0x40, 0xba, 0x71, 0x13, 0xbb, 0x7f, 0x0e, 0x60,
+	0x6f, 0xf5, 0x1d, 0xfe, 0x6b, 0xce, 0xb9, 0x07, 0x7d, 0x5b, 0x60, 0xdd, 0xe3, 0x11, 0xbf, 0x42,
+	0x56, 0xe9, 0xf8, 0xcc, 0xcc, 0x50, 0xa7, 0x41, 0xbe, 0x03, 0xdd, 0x4b, 0xa1, 0xfc, 0x73, 0xdd,
+	0x5a, 0x7f, 0x2e, 0xa1, 0x34, 0x35, 0x0a, 0xe4, 0x21, 0x26, 0x7c, 0xc1, 0x13, 0x2e, 0xa5, 0x90,
+	0xf8, 0x46, 0xc1, 0x96, 0x37, 0xc2, 0x9b, 0x3e, 0x41, 0x05, 0xcc, 0x76, 0x37, 0x54, 0xe4, 0xa7,
+	0x30, 0x42, 0x49, 0x71, 0x9d, 0xe0, 0xae, 0xbe, 0x2a, 0xde, 0xd9, 0xb2, 0x58, 0x71, 0x8d, 0x07,
+	0x54, 0x74, 0x57, 0x2e, 0x49, 0xe4, 0x0b, 0xd8, 0xbd, 0x60, 0x79, 0xc1, 0x3d, 0x09, 0xee, 0xbc,
+	0x66, 0xf7, 0xa1, 0xd5, 0xb6, 0x04, 0xf8, 0x08, 0x86, 0xcb, 0xc4, 0x37, 0xd8, 0xce, 0xc0, 0x0b,
+	0x0d, 0xba, 0xac, 0x8e, 0x49, 0xee, 0x48, 0x2e, 0x91, 0x5c, 0xd5, 0x85, 0x8e, 0x42, 0x9b, 0xe4,
+	0x0e, 0xa5, 0x06, 0x8c, 0xff, 0x81, 0x3d, 0xc9, 0xd2, 0xb2, 0xef, 0xc2, 0x7e, 0xd3, 0xb9, 0x78,
+	0x76, 0xb0, 0x1e, 0x7d, 0xc7, 0xe3, 0x9e, 0x1f, 0x0e, 0x60, 0x90, 0x8a, 0x1a, 0x13, 0x5b, 0xb9,
+	0xd4, 0x6d, 0x64, 0xf2, 0x11, 0xec, 0x36, 0x66, 0x0a, 0x31, 0x75, 0x29, 0x3c, 0xf4, 0xd8, 0x73,
+	0x31, 0x25, 0x63, 0xe8, 0x65, 0xb9, 0x9a, 0xa9, 0xa8, 0xbb, 0xf5, 0x51, 0x4e, 0x73, 0x35, 0x3b,
+	0xab, 0x90, 0x52, 0xac, 0x1a, 0xf9, 0x18, 0x46, 0xa9, 0x28, 0x2f, 0xf2, 0x69, 0xe2, 0xfa, 0xa2,
+	0x9e, 0x69, 0x7e, 0x76, 0x2d, 0x78, 0x66, 0x30, 0xac, 0xfb, 0xce, 0xd1, 0x7d, 0x5b, 0xf7, 0xad,
+	0x14, 0x27, 0x10, 0x36, 0x06, 0x91, 0x82, 0x2a, 0xa6, 0x2f, 0x3d, 0x05, 0xe1, 0x98, 0xdc, 0x85,
+	0xa1, 0x16, 0x9a, 0x15, 0xc9, 0xf9, 0xb5, 0xe6, 0xf6, 0x3e, 0x5d, 0x0a, 0x06, 0xfa, 0x0a, 0x11,
+	0xf2, 0x21, 0xc0, 0x85, 0xe4, 0xdc, 0xcd, 0x07, 0x66, 0x3e, 0x44, 0xc4, 0x4c, 0xc7, 0xbf, 0x86,
+	0xb0, 0x71, 0x23, 0x6e, 0x80, 0xcd, 0x83, 0xeb, 0x1d, 0xcc, 0x18, 0x39, 0x4b, 0xe5, 0x99, 0x31,
+	0x1c, 0x50, 0x1c, 0xa2, 0x16, 0x46, 0x85, 0x27, 0x74, 0x1c, 0xdb, 0xe6, 0xc8, 0xd0, 0x92, 0x23,
+	0x75, 0x2f, 0xe2, 0x6b, 0x63, 0x9f, 0x68, 0xb8, 0xd3, 0x92, 0x7b, 0x23, 0xc7, 0xbf, 0x6b, 0xc3,
+	0xee, 0x72, 0x14, 0x62, 0x07, 0xa3, 0x79, 0xc9, 0x4a, 0x9d, 0xb8, 0x84, 0xec, 0xd1, 0x81, 0x05,
+	0x9e, 0x65, 0xe8, 0x1b, 0x13, 0x8d, 0x09, 0xd6, 0x5a, 0xee, 0x8f, 0x64, 0xb2, 0x44, 0x3d, 0x37,
+	0xd0, 0x42, 0xc5, 0xc6, 0x63, 0x14, 0x2c, 0xa9, 0x3c, 0x35, 0x10, 0xba, 0xc3, 0xaa, 0xa8, 0x59,
+	0x5e, 0x55, 0x3c, 0x33, 0xe7, 0x0d, 0x5c, 0xfc, 0x9f, 0x59, 0x2c, 0xfe, 0x57, 0x07, 0x86, 0x4b,
+	0x94, 0xd7, 0x30, 0x42, 0xfb, 0xad, 0x19, 0xa1, 0xf3, 0xc6, 0x8c, 0x10, 0xbc, 0x8e, 0x11, 0x6e,
+	0x43, 0xbf, 0x12, 0x45, 0x9e, 0x5e, 0xbb, 0x27, 0x76, 0x12, 0x86, 0x80, 0x21, 0xd2, 0xc4, 0x44,
+	0xb1, 0x6f, 0x90, 0x0d, 0x74, 0x82, 0x08, 0xa6, 0xd4, 0xab, 0xbc, 0xcc, 0xc4, 0xab, 0x44, 0xf1,
+	0x54, 0x94, 0x99, 0xe5, 0xcd, 0x80, 0x8e, 0x2c, 0x7a, 0x66, 0x41, 0xf2, 0x14, 0xf6, 0xb4, 0xa8,
+	0x92, 0xa6, 0x29, 0xf7, 0x69, 0xbf, 0x5e, 0x3b, 0x1f, 0x5b, 0xcb, 0xa5, 0x96, 0xf9, 0x79, 0xad,
+	0x85, 0xa4, 0x23, 0x2d, 0xaa, 0xb3, 0x66, 0x15, 0x79, 0x04, 0x21, 0xda, 0xc1, 0x33, 0xdf, 0x54,
+	0x7e, 0x37, 0x4c, 0x0c, 0xb4, 0xa8, 0xf0, 0xaa, 0x2a, 0xfe, 0x25, 0xec, 0xaf, 0xcf, 0x6e, 0x69,
+	0x29, 0x0f, 0x61, 0x98, 0x71, 0x95, 0xca, 0xbc, 0x32, 0x1f, 0x05, 0x36, 0x8d, 0x97, 0x21, 0x6c,
+	0x3a, 0xed, 0x7b, 0xd8, 0x18, 0xb0, 0x42, 0xfc, 0x87, 0x36, 0x84, 0x4d, 0x61, 0x79, 0x6b, 0xb7,
+	0x7a, 0x57, 0x75, 0x5e, 0xe7, 0xaa, 0x7b, 0xd0, 0xe7, 0x57, 0xbc, 0xd4, 0xb6, 0x07, 0x40, 0xff,
+	0x6f, 0xd4, 0x36, 0xea, 0x34, 0x30, 0x75, 0x32, 0x29, 0x96, 0x42, 0xd1, 0x8b, 0xf1, 0x9f, 0xda,
+	0x30, 0xf0, 0xb5, 0x8c, 0x7c, 0x0a, 0x3d, 0xa5, 0x99, 0xd4, 0x6f, 0x70, 0x58, 0xab, 0x48, 0xbe,
+	0x0f, 0x01, 0x2f, 0xb3, 0x37, 0xa8, 0x62, 0xa8, 0xf6, 0xe6, 0x61, 0x78, 0x0f, 0xba, 0xa6, 0xf6,
+	0x76, 0xb7, 0x7e, 0x6a, 0xb9, 0xf3, 0x52, 0xa3, 0x13, 0xbf, 0x80, 0x1d, 0x07, 0x18, 0x66, 0xf5,
+	0x31, 0xe2, 0xb3, 0x3b, 0xa0, 0xc3, 0x06, 0x7b, 0x66, 0xba, 0x42, 0xc9, 0xaf, 0xcc, 0x81, 0x7b,
+	0x14, 0x87, 0x37, 0x38, 0xf1, 0x9f, 0x6d, 0x08, 0x9b, 0xc6, 0xe2, 0x7f, 0xe7, 0xc4, 0x4f, 0x60,
+	0xcf, 0x82, 0x4d, 0x41, 0xb1, 0x8c, 0x37, 0xb2, 0xa8, 0x2f, 0x27, 0x47, 0xb0, 0xdf, 0x7c, 0x0d,
+	0x7b, 0x8a, 0xef, 0x1a, 0x8a, 0xdf, 0xf3, 0x1f, 0xc5, 0x8e, 0xe4, 0x1f, 0xc2, 0xc0, 0x17, 0x92,
+	0xa8, 0xb7, 0xbd, 0x41, 0x73, 0xd3, 0x13, 0xce, 0x0a, 0x7d, 0x49, 0x1b, 0xf5, 0xf8, 0x6f, 0x1d,
+	0xd8, 0x5b, 0x9d, 0xc4, 0xb8, 0x59, 0x2d, 0x74, 0x5e, 0xc4, 0x83, 0xd7, 0x15, 0xde, 0xb5, 0xc9,
+	0x77, 0x4b, 0x95, 0x23, 0x8b, 0xfa, 0x7c, 0x37, 0x64, 0x59, 0x96, 0x79, 0x39, 0x4d, 0xe6, 0x22,
+	0xf3, 0x7c, 0x3e, 0x74, 0xd8, 0xd7, 0x22, 0xe3, 0xe4, 0x27, 0x2b, 0xad, 0xb4, 0xf5, 0xf8, 0xfa,
+	0x99, 0x9b, 0x16, 0xd9, 0x36, 0x12, 0x4b, 0x0b, 0x96, 0x1a, 0xf6, 0x8a, 0xa5, 0x33, 0xee, 0x5a,
+	0xbd, 0xc0, 0x37, 0xec, 0xbf, 0xb0, 0x20, 0x1e, 0xc4, 0xa9, 0x61, 0xe4, 0x7b, 0x76, 0x1a, 0x5a,
+	0xec, 0x14, 0xa1, 0x0d, 0xee, 0xdf, 0x79, 0x3d, 0xf7, 0x0f, 0x36, 0xb9, 0xff, 0x3d, 0xe8, 0x99,
+	0xba, 0xea, 0x5a, 0x0a, 0x2b, 0xc4, 0xbf, 0x69, 0xc3, 0xde, 0xea, 0x25, 0xb6, 0x36, 0xfb, 0x11,
+	0xec, 0xf8, 0x5b, 0xd8, 0xe7, 0xf4, 0x22, 0x9a, 0xb5, 0x07, 0x77, 0x51, 0x6a, 0x04, 0xf2, 0x3d,
+	0x78, 0x37, 0x2f, 0xaf, 0x58, 0x91, 0x67, 0x49, 0x7a, 0xc9, 0xd3, 0x99, 0xaa, 0xe7, 0xca, 0x65,
+	0xf8, 0xbe, 0x9b, 0x38, 0xf1, 0x78, 0x7c, 0x02, 0xbb, 0x94, 0x4f, 0x73, 0xa5, 0xdd, 0x3f, 0x8d,
+	0x3b, 0x10, 0xa6, 0x45, 0xce, 0x17, 0x85, 0x70, 0x97, 0x0e, 0x2c, 0xf0, 0xcc, 0xfc, 0x24, 0x28,
+	0xd8, 0x39, 0x2f, 0xec, 0x97, 0x7a, 0x48, 0x9d, 0x14, 0xff, 0xbe, 0x0d, 0xef, 0xbe, 0x90, 0xac,
+	0x54, 0x95, 0x90, 0xfa, 0x65, 0xa5, 0xb4, 0xe4, 0x6c, 0x4e, 0x1e, 0xe3, 0x07, 0xed, 0xc2, 0xb4,
+	0xcb, 0x93, 0x8d, 0x66, 0x70, 0x49, 0x65, 0xd2, 0xa2, 0x2b, 0x4b, 0xc8, 0x83, 0x45, 0x75, 0xef,
+	0x6c, 0xfd, 0x6e, 0x5c, 0xf9, 0x30, 0x99, 0xb4, 0x9a, 0xea, 0xdf, 0x74, 0xdd, 0x33, 0xb8, 0xd5,
+	0x9c, 0xec, 0x54, 0xbc, 0x2a, 0xdd, 0xd9, 0x08, 0x04, 0x2c, 0x9d, 0xd9, 0x67, 0x9e, 0xb4, 0x28,
+	0x0a, 0xb8, 0x99, 0xb4, 0x3f, 0x03, 0xfe, 0xe3, 0x66, 0xee, 0x87, 0x01, 0x6e, 0xe6, 0xd4, 0xfd,
+	0x66, 0xc7, 0x97, 0xf0, 0x8e, 0xd5, 0x69, 0xb6, 0x24, 0x2f, 0x61, 0xe7, 0x44, 0x94, 0x25, 0x4f,
+	0x35, 0x39, 0x5c, 0x33, 0xb7, 0xf1, 0x62, 0x07, 0xf1, 0x4d, 0x1a, 0x8b, 0x93, 0xc7, 0xad, 0xa3,
+	0xf6, 0xa7, 0xed, 0xf3, 0xbe, 0x61, 0x99, 0x1f, 0xfe, 0x7b, 0x00, 0xd5, 0xb1, 0x08, 0xc8, 0xbe,
+	0x13, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SensorTransportClient is the client API for SensorTransport service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SensorTransportClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (SensorTransport_ConnectClient, error)
+}
+
+type sensorTransportClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSensorTransportClient(cc *grpc.ClientConn) SensorTransportClient {
+	return &sensorTransportClient{cc}
+}
+
+func (c *sensorTransportClient) Connect(ctx context.Context, opts ...grpc.CallOption) (SensorTransport_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SensorTransport_serviceDesc.Streams[0], "/emitto.sensor.SensorTransport/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sensorTransportConnectClient{stream}
+	return x, nil
+}
+
+type SensorTransport_ConnectClient interface {
+	Send(*TransportUpstream) error
+	Recv() (*TransportDownstream, error)
+	grpc.ClientStream
+}
+
+type sensorTransportConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *sensorTransportConnectClient) Send(m *TransportUpstream) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *sensorTransportConnectClient) Recv() (*TransportDownstream, error) {
+	m := new(TransportDownstream)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SensorTransportServer is the server API for SensorTransport service.
+type SensorTransportServer interface {
+	Connect(SensorTransport_ConnectServer) error
+}
+
+func RegisterSensorTransportServer(s *grpc.Server, srv SensorTransportServer) {
+	s.RegisterService(&_SensorTransport_serviceDesc, srv)
+}
+
+func _SensorTransport_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SensorTransportServer).Connect(&sensorTransportConnectServer{stream})
+}
+
+type SensorTransport_ConnectServer interface {
+	Send(*TransportDownstream) error
+	Recv() (*TransportUpstream, error)
+	grpc.ServerStream
+}
+
+type sensorTransportConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *sensorTransportConnectServer) Send(m *TransportDownstream) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sensorTransportConnectServer) Recv() (*TransportUpstream, error) {
+	m := new(TransportUpstream)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _SensorTransport_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "emitto.sensor.SensorTransport",
+	HandlerType: (*SensorTransportServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _SensorTransport_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "source/sensor/proto/sensor.proto",
 }
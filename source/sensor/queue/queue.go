@@ -0,0 +1,356 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package queue provides a durable outbound queue for the messages sent by the sensor to the server.
+// Messages are kept on disk, surviving sensor restarts, until the server acknowledges them.
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+// Extension of the queued message files.
+const ext = ".msg"
+
+var (
+	// Delays between delivery attempts, doubling from minBackoff up to maxBackoff.
+	minBackoff = time.Second
+	maxBackoff = 5 * time.Minute
+)
+
+// Sender sends a message to the server, returning once the server acknowledged it.
+type Sender interface {
+	SendMessage(m *pb.SensorMessage) (string, error)
+}
+
+// Config configures a Queue.
+type Config struct {
+	// Directory keeping the queued messages.
+	Dir string
+	// Maximum number and total size in bytes of the queued messages. The oldest messages are
+	// dropped to respect them.
+	MaxMessages int
+	MaxBytes    int64
+	// Maximum age of the queued messages. Older messages are dropped.
+	MaxAge time.Duration
+}
+
+// Stats are the metrics of a Queue.
+type Stats struct {
+	// Number and total size in bytes of the queued messages.
+	Depth int   `json:"depth"`
+	Bytes int64 `json:"bytes"`
+	// Time the oldest queued message was queued; zero if the queue is empty.
+	Oldest time.Time `json:"oldest"`
+	// Number of messages queued, delivered and dropped since the sensor started, and of failed
+	// delivery attempts.
+	Enqueued  int64 `json:"enqueued"`
+	Delivered int64 `json:"delivered"`
+	Dropped   int64 `json:"dropped"`
+	Failures  int64 `json:"failures"`
+	// Last delivery error, cleared by a successful delivery.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// entry is a queued message.
+type entry struct {
+	seq      uint64
+	size     int64
+	enqueued time.Time
+}
+
+// Queue is a durable FIFO queue of messages, delivered in order by a Sender.
+type Queue struct {
+	cfg    *Config
+	sender Sender
+	// Signals Run that messages were queued.
+	wake chan struct{}
+
+	mu      sync.Mutex
+	entries []*entry
+	next    uint64
+	stats   Stats
+}
+
+// New returns a Queue of the messages kept in the configured directory, creating it if needed.
+// Messages queued before a restart are delivered first.
+func New(cfg *Config, sender Sender) (*Queue, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("no queue directory")
+	}
+	if cfg.MaxMessages < 1 || cfg.MaxBytes < 1 || cfg.MaxAge <= 0 {
+		return nil, errors.New("invalid queue limits")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create queue directory: %v", err)
+	}
+	q := &Queue{cfg: cfg, sender: sender, wake: make(chan struct{}, 1)}
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+	q.mu.Lock()
+	q.enforceLimits(time.Now())
+	q.updateDepth()
+	q.mu.Unlock()
+	if n := len(q.entries); n > 0 {
+		log.Infof("Loaded %d queued messages from %q", n, cfg.Dir)
+	}
+	return q, nil
+}
+
+// load indexes the message files of the queue directory, removing incomplete ones.
+func (q *Queue) load() error {
+	files, err := ioutil.ReadDir(q.cfg.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read queue directory: %v", err)
+	}
+	for _, fi := range files {
+		name := fi.Name()
+		if strings.HasSuffix(name, ".tmp") {
+			os.Remove(filepath.Join(q.cfg.Dir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+		if err != nil || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		q.entries = append(q.entries, &entry{seq: seq, size: fi.Size(), enqueued: fi.ModTime()})
+		if seq >= q.next {
+			q.next = seq + 1
+		}
+	}
+	sort.Slice(q.entries, func(i, j int) bool { return q.entries[i].seq < q.entries[j].seq })
+	return nil
+}
+
+func (q *Queue) path(e *entry) string {
+	return filepath.Join(q.cfg.Dir, fmt.Sprintf("%020d%s", e.seq, ext))
+}
+
+// Enqueue queues a message for delivery. It returns once the message is written and synced to
+// disk.
+func (q *Queue) Enqueue(m *pb.SensorMessage) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message (%s): %v", m.GetId(), err)
+	}
+	if int64(len(b)) > q.cfg.MaxBytes {
+		return fmt.Errorf("message (%s) of %d bytes exceeds the queue size", m.GetId(), len(b))
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	e := &entry{seq: q.next, size: int64(len(b)), enqueued: time.Now()}
+	q.next++
+	// Write to a temporary file first so that a crash never leaves a partial message behind.
+	tmp := q.path(e) + ".tmp"
+	if err := writeFileSync(tmp, b); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to queue message (%s): %v", m.GetId(), err)
+	}
+	if err := os.Rename(tmp, q.path(e)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to queue message (%s): %v", m.GetId(), err)
+	}
+	// The rename is only durable once the directory is synced.
+	if err := syncDir(q.cfg.Dir); err != nil {
+		return fmt.Errorf("failed to queue message (%s): %v", m.GetId(), err)
+	}
+	q.entries = append(q.entries, e)
+	q.stats.Enqueued++
+	q.enforceLimits(e.enqueued)
+	q.updateDepth()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// writeFileSync writes data to a new file and syncs it to disk.
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// syncDir syncs the entries of a directory to disk.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// enforceLimits drops the oldest messages beyond the queue limits. q.mu must be held.
+func (q *Queue) enforceLimits(now time.Time) {
+	var size int64
+	for _, e := range q.entries {
+		size += e.size
+	}
+	for len(q.entries) > 0 {
+		e := q.entries[0]
+		if len(q.entries) <= q.cfg.MaxMessages && size <= q.cfg.MaxBytes && now.Sub(e.enqueued) <= q.cfg.MaxAge {
+			return
+		}
+		log.Warningf("Dropping queued message %d of %v ago, beyond the queue limits", e.seq, now.Sub(e.enqueued))
+		q.drop(e)
+		size -= e.size
+		q.stats.Dropped++
+	}
+}
+
+// drop removes an entry and its file. q.mu must be held.
+func (q *Queue) drop(e *entry) {
+	for i, c := range q.entries {
+		if c == e {
+			q.entries = append(q.entries[:i], q.entries[i+1:]...)
+			break
+		}
+	}
+	if err := os.Remove(q.path(e)); err != nil && !os.IsNotExist(err) {
+		log.Errorf("Failed to remove queued message: %v", err)
+	}
+}
+
+// queued returns true if the entry was not dropped. q.mu must be held.
+func (q *Queue) queued(e *entry) bool {
+	for _, c := range q.entries {
+		if c == e {
+			return true
+		}
+	}
+	return false
+}
+
+// updateDepth updates the depth metrics. q.mu must be held.
+func (q *Queue) updateDepth() {
+	q.stats.Depth, q.stats.Bytes, q.stats.Oldest = len(q.entries), 0, time.Time{}
+	for _, e := range q.entries {
+		q.stats.Bytes += e.size
+	}
+	if len(q.entries) > 0 {
+		q.stats.Oldest = q.entries[0].enqueued
+	}
+}
+
+// head returns the oldest queued message within the age limit, nil if the queue is empty.
+func (q *Queue) head() *entry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.enforceLimits(time.Now())
+	q.updateDepth()
+	if len(q.entries) == 0 {
+		return nil
+	}
+	return q.entries[0]
+}
+
+// done removes a delivered or undeliverable message from the queue. Messages dropped beyond the
+// queue limits while being delivered were already counted as dropped.
+func (q *Queue) done(e *entry, delivered bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.queued(e) {
+		return
+	}
+	q.drop(e)
+	if delivered {
+		q.stats.Delivered++
+		q.stats.LastError = ""
+	} else {
+		q.stats.Dropped++
+	}
+	q.updateDepth()
+}
+
+// Run delivers the queued messages in order until ctx is done, retrying failed deliveries with
+// backoff.
+func (q *Queue) Run(ctx context.Context) {
+	backoff := minBackoff
+	for {
+		e := q.head()
+		if e == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.wake:
+			}
+			continue
+		}
+		b, err := ioutil.ReadFile(q.path(e))
+		m := new(pb.SensorMessage)
+		if err == nil {
+			err = proto.Unmarshal(b, m)
+		}
+		if err != nil {
+			log.Errorf("Dropping unreadable queued message %d: %v", e.seq, err)
+			q.done(e, false)
+			continue
+		}
+		if _, err := q.sender.SendMessage(m); err != nil {
+			q.mu.Lock()
+			q.stats.Failures++
+			q.stats.LastError = err.Error()
+			q.mu.Unlock()
+			log.Warningf("Failed to deliver queued message (%s): %v; retrying in %v", m.GetId(), err, backoff)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		backoff = minBackoff
+		q.done(e, true)
+	}
+}
+
+// Stats returns the metrics of the Queue.
+func (q *Queue) Stats() Stats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.stats
+}
@@ -0,0 +1,239 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+)
+
+// fakeSender records the IDs of the sent messages, failing the first fail attempts. If set,
+// onSend is called while sending every message.
+type fakeSender struct {
+	mu     sync.Mutex
+	fail   int
+	ids    []string
+	sent   chan struct{}
+	onSend func(id string)
+}
+
+func (s *fakeSender) SendMessage(m *pb.SensorMessage) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.onSend != nil {
+		s.onSend(m.GetId())
+	}
+	if s.fail > 0 {
+		s.fail--
+		return "", errors.New("server unreachable")
+	}
+	s.ids = append(s.ids, m.GetId())
+	s.sent <- struct{}{}
+	return m.GetId(), nil
+}
+
+func newConfig(t *testing.T) *Config {
+	t.Helper()
+	d, err := ioutil.TempDir("", "queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Config{Dir: d, MaxMessages: 10, MaxBytes: 1 << 20, MaxAge: time.Hour}
+}
+
+func enqueue(t *testing.T, q *Queue, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if err := q.Enqueue(&pb.SensorMessage{Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	minBackoff, maxBackoff = time.Millisecond, 5*time.Millisecond
+	cfg := newConfig(t)
+	defer os.RemoveAll(cfg.Dir)
+	s := &fakeSender{fail: 3, sent: make(chan struct{}, 10)}
+	q, err := New(cfg, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enqueue(t, q, "1", "2", "3")
+	if st := q.Stats(); st.Depth != 3 || st.Bytes == 0 || st.Oldest.IsZero() {
+		t.Errorf("got stats %+v, want 3 queued messages", st)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+	for i := 0; i < 3; i++ {
+		<-s.sent
+	}
+	// Messages queued while running are delivered too.
+	enqueue(t, q, "4")
+	<-s.sent
+
+	s.mu.Lock()
+	if diff := cmp.Diff([]string{"1", "2", "3", "4"}, s.ids); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	s.mu.Unlock()
+	want := Stats{Enqueued: 4, Delivered: 4, Failures: 3}
+	var got Stats
+	for i := 0; i < 100; i++ {
+		if got = q.Stats(); got == want {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("stats mismatch (-want +got):\n%s", diff)
+	}
+	files, err := ioutil.ReadDir(cfg.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("got %d files left in the queue directory, want none", len(files))
+	}
+}
+
+func TestDropWhileSending(t *testing.T) {
+	cfg := newConfig(t)
+	defer os.RemoveAll(cfg.Dir)
+	cfg.MaxMessages = 1
+	s := &fakeSender{sent: make(chan struct{}, 10)}
+	q, err := New(cfg, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Queuing a message while the first one is being sent drops the first one.
+	s.onSend = func(id string) {
+		if id == "1" {
+			enqueue(t, q, "2")
+		}
+	}
+	enqueue(t, q, "1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+	<-s.sent
+	<-s.sent
+
+	// The first message is counted once, as dropped.
+	want := Stats{Enqueued: 2, Delivered: 1, Dropped: 1}
+	var got Stats
+	for i := 0; i < 100; i++ {
+		if got = q.Stats(); got == want {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("stats mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	cfg := newConfig(t)
+	defer os.RemoveAll(cfg.Dir)
+	q, err := New(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enqueue(t, q, "1", "2")
+	// A message interrupted while being written is discarded.
+	if err := ioutil.WriteFile(filepath.Join(cfg.Dir, "00000000000000000099.msg.tmp"), []byte("partial"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeSender{sent: make(chan struct{}, 10)}
+	if q, err = New(cfg, s); err != nil {
+		t.Fatal(err)
+	}
+	if st := q.Stats(); st.Depth != 2 {
+		t.Errorf("got %d messages after restarting, want 2", st.Depth)
+	}
+	enqueue(t, q, "3")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+	for i := 0; i < 3; i++ {
+		<-s.sent
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if diff := cmp.Diff([]string{"1", "2", "3"}, s.ids); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	cfg := newConfig(t)
+	defer os.RemoveAll(cfg.Dir)
+	cfg.MaxMessages = 2
+	q, err := New(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enqueue(t, q, "1", "2", "3")
+	if st := q.Stats(); st.Depth != 2 || st.Dropped != 1 {
+		t.Errorf("got stats %+v, want 2 queued messages and 1 dropped", st)
+	}
+	if err := q.Enqueue(&pb.SensorMessage{Id: string(make([]byte, 2<<20))}); err == nil {
+		t.Error("expected an error for a message larger than the queue")
+	}
+
+	// Messages older than the maximum age are dropped.
+	files, err := ioutil.ReadDir(cfg.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(cfg.Dir, files[0].Name()), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if q, err = New(cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	if st := q.Stats(); st.Depth != 1 || st.Dropped != 1 {
+		t.Errorf("got stats %+v after restarting, want 1 queued message and 1 dropped", st)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, cfg := range []*Config{
+		{MaxMessages: 1, MaxBytes: 1, MaxAge: time.Hour},
+		{Dir: "/tmp/queue", MaxBytes: 1, MaxAge: time.Hour},
+		{Dir: "/tmp/queue", MaxMessages: 1, MaxAge: time.Hour},
+		{Dir: "/tmp/queue", MaxMessages: 1, MaxBytes: 1},
+	} {
+		if _, err := New(cfg, nil); err == nil {
+			t.Errorf("New(%+v) expected an error", cfg)
+		}
+	}
+}
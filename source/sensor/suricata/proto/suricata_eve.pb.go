@@ -33,7 +33,7 @@ type EVE struct {
 	AppProtoTc           string      `protobuf:"bytes,10,opt,name=app_proto_tc,json=appProtoTc,proto3" json:"app_proto_tc,omitempty"`
 	AppProtoTs           string      `protobuf:"bytes,11,opt,name=app_proto_ts,json=appProtoTs,proto3" json:"app_proto_ts,omitempty"`
 	FlowId               int64       `protobuf:"varint,12,opt,name=flow_id,json=flowId,proto3" json:"flow_id,omitempty"`
-	Vlan                 int32       `protobuf:"varint,13,opt,name=vlan,proto3" json:"vlan,omitempty"`
+	Vlan                 []int32     `protobuf:"varint,13,rep,packed,name=vlan,proto3" json:"vlan,omitempty"`
 	TxId                 int32       `protobuf:"varint,14,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
 	Packet               string      `protobuf:"bytes,15,opt,name=packet,proto3" json:"packet,omitempty"`
 	IcmpType             int32       `protobuf:"varint,16,opt,name=icmp_type,json=icmpType,proto3" json:"icmp_type,omitempty"`
@@ -52,6 +52,21 @@ type EVE struct {
 	Ssh                  *SSH        `protobuf:"bytes,29,opt,name=ssh,proto3" json:"ssh,omitempty"`
 	Smtp                 *SMTP       `protobuf:"bytes,30,opt,name=smtp,proto3" json:"smtp,omitempty"`
 	Email                *Email      `protobuf:"bytes,31,opt,name=email,proto3" json:"email,omitempty"`
+	InIface              string      `protobuf:"bytes,32,opt,name=in_iface,json=inIface,proto3" json:"in_iface,omitempty"`
+	CommunityId          string      `protobuf:"bytes,33,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Host                 string      `protobuf:"bytes,34,opt,name=host,proto3" json:"host,omitempty"`
+	ParentId             int64       `protobuf:"varint,35,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Direction            string      `protobuf:"bytes,36,opt,name=direction,proto3" json:"direction,omitempty"`
+	TxGuessed            bool        `protobuf:"varint,37,opt,name=tx_guessed,json=txGuessed,proto3" json:"tx_guessed,omitempty"`
+	Anomaly              *Anomaly    `protobuf:"bytes,38,opt,name=anomaly,proto3" json:"anomaly,omitempty"`
+	Drop                 *Drop       `protobuf:"bytes,39,opt,name=drop,proto3" json:"drop,omitempty"`
+	Stats                *Stats      `protobuf:"bytes,40,opt,name=stats,proto3" json:"stats,omitempty"`
+	Smb                  *SMB        `protobuf:"bytes,41,opt,name=smb,proto3" json:"smb,omitempty"`
+	Krb5                 *KRB5       `protobuf:"bytes,42,opt,name=krb5,proto3" json:"krb5,omitempty"`
+	Dhcp                 *DHCP       `protobuf:"bytes,43,opt,name=dhcp,proto3" json:"dhcp,omitempty"`
+	Http2                *HTTP2      `protobuf:"bytes,44,opt,name=http2,proto3" json:"http2,omitempty"`
+	Quic                 *QUIC       `protobuf:"bytes,45,opt,name=quic,proto3" json:"quic,omitempty"`
+	Rdp                  *RDP        `protobuf:"bytes,46,opt,name=rdp,proto3" json:"rdp,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -166,11 +181,11 @@ func (m *EVE) GetFlowId() int64 {
 	return 0
 }
 
-func (m *EVE) GetVlan() int32 {
+func (m *EVE) GetVlan() []int32 {
 	if m != nil {
 		return m.Vlan
 	}
-	return 0
+	return nil
 }
 
 func (m *EVE) GetTxId() int32 {
@@ -299,6 +314,111 @@ func (m *EVE) GetEmail() *Email {
 	return nil
 }
 
+func (m *EVE) GetInIface() string {
+	if m != nil {
+		return m.InIface
+	}
+	return ""
+}
+
+func (m *EVE) GetCommunityId() string {
+	if m != nil {
+		return m.CommunityId
+	}
+	return ""
+}
+
+func (m *EVE) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *EVE) GetParentId() int64 {
+	if m != nil {
+		return m.ParentId
+	}
+	return 0
+}
+
+func (m *EVE) GetDirection() string {
+	if m != nil {
+		return m.Direction
+	}
+	return ""
+}
+
+func (m *EVE) GetTxGuessed() bool {
+	if m != nil {
+		return m.TxGuessed
+	}
+	return false
+}
+
+func (m *EVE) GetAnomaly() *Anomaly {
+	if m != nil {
+		return m.Anomaly
+	}
+	return nil
+}
+
+func (m *EVE) GetDrop() *Drop {
+	if m != nil {
+		return m.Drop
+	}
+	return nil
+}
+
+func (m *EVE) GetStats() *Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *EVE) GetSmb() *SMB {
+	if m != nil {
+		return m.Smb
+	}
+	return nil
+}
+
+func (m *EVE) GetKrb5() *KRB5 {
+	if m != nil {
+		return m.Krb5
+	}
+	return nil
+}
+
+func (m *EVE) GetDhcp() *DHCP {
+	if m != nil {
+		return m.Dhcp
+	}
+	return nil
+}
+
+func (m *EVE) GetHttp2() *HTTP2 {
+	if m != nil {
+		return m.Http2
+	}
+	return nil
+}
+
+func (m *EVE) GetQuic() *QUIC {
+	if m != nil {
+		return m.Quic
+	}
+	return nil
+}
+
+func (m *EVE) GetRdp() *RDP {
+	if m != nil {
+		return m.Rdp
+	}
+	return nil
+}
+
 type Vars struct {
 	Flowbits             map[string]bool `protobuf:"bytes,1,rep,name=flowbits,proto3" json:"flowbits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
@@ -348,6 +468,7 @@ type Alert struct {
 	Severity             int32     `protobuf:"varint,7,opt,name=severity,proto3" json:"severity,omitempty"`
 	TenantId             int32     `protobuf:"varint,8,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
 	Metadata             *Metadata `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Rule                 string    `protobuf:"bytes,10,opt,name=rule,proto3" json:"rule,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -441,9 +562,25 @@ func (m *Alert) GetMetadata() *Metadata {
 	return nil
 }
 
+func (m *Alert) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
 type Metadata struct {
 	UpdatedAt            []string `protobuf:"bytes,1,rep,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	CreatedAt            []string `protobuf:"bytes,2,rep,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SignatureSeverity    []string `protobuf:"bytes,3,rep,name=signature_severity,json=signatureSeverity,proto3" json:"signature_severity,omitempty"`
+	AttackTarget         []string `protobuf:"bytes,4,rep,name=attack_target,json=attackTarget,proto3" json:"attack_target,omitempty"`
+	Deployment           []string `protobuf:"bytes,5,rep,name=deployment,proto3" json:"deployment,omitempty"`
+	FormerCategory       []string `protobuf:"bytes,6,rep,name=former_category,json=formerCategory,proto3" json:"former_category,omitempty"`
+	AffectedProduct      []string `protobuf:"bytes,7,rep,name=affected_product,json=affectedProduct,proto3" json:"affected_product,omitempty"`
+	MalwareFamily        []string `protobuf:"bytes,8,rep,name=malware_family,json=malwareFamily,proto3" json:"malware_family,omitempty"`
+	PerformanceImpact    []string `protobuf:"bytes,9,rep,name=performance_impact,json=performanceImpact,proto3" json:"performance_impact,omitempty"`
+	Tag                  []string `protobuf:"bytes,10,rep,name=tag,proto3" json:"tag,omitempty"`
+	Confidence           []string `protobuf:"bytes,11,rep,name=confidence,proto3" json:"confidence,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -488,24 +625,91 @@ func (m *Metadata) GetCreatedAt() []string {
 	return nil
 }
 
+func (m *Metadata) GetSignatureSeverity() []string {
+	if m != nil {
+		return m.SignatureSeverity
+	}
+	return nil
+}
+
+func (m *Metadata) GetAttackTarget() []string {
+	if m != nil {
+		return m.AttackTarget
+	}
+	return nil
+}
+
+func (m *Metadata) GetDeployment() []string {
+	if m != nil {
+		return m.Deployment
+	}
+	return nil
+}
+
+func (m *Metadata) GetFormerCategory() []string {
+	if m != nil {
+		return m.FormerCategory
+	}
+	return nil
+}
+
+func (m *Metadata) GetAffectedProduct() []string {
+	if m != nil {
+		return m.AffectedProduct
+	}
+	return nil
+}
+
+func (m *Metadata) GetMalwareFamily() []string {
+	if m != nil {
+		return m.MalwareFamily
+	}
+	return nil
+}
+
+func (m *Metadata) GetPerformanceImpact() []string {
+	if m != nil {
+		return m.PerformanceImpact
+	}
+	return nil
+}
+
+func (m *Metadata) GetTag() []string {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+func (m *Metadata) GetConfidence() []string {
+	if m != nil {
+		return m.Confidence
+	}
+	return nil
+}
+
 type HTTP struct {
-	Hostname             string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	HttpUserAgent        string   `protobuf:"bytes,3,opt,name=http_user_agent,json=httpUserAgent,proto3" json:"http_user_agent,omitempty"`
-	HttpContentType      string   `protobuf:"bytes,4,opt,name=http_content_type,json=httpContentType,proto3" json:"http_content_type,omitempty"`
-	HttpRefer            string   `protobuf:"bytes,5,opt,name=http_refer,json=httpRefer,proto3" json:"http_refer,omitempty"`
-	HttpMethod           string   `protobuf:"bytes,6,opt,name=http_method,json=httpMethod,proto3" json:"http_method,omitempty"`
-	Protocol             string   `protobuf:"bytes,7,opt,name=protocol,proto3" json:"protocol,omitempty"`
-	Status               int32    `protobuf:"varint,8,opt,name=status,proto3" json:"status,omitempty"`
-	Length               int32    `protobuf:"varint,9,opt,name=length,proto3" json:"length,omitempty"`
-	Redirect             string   `protobuf:"bytes,10,opt,name=redirect,proto3" json:"redirect,omitempty"`
-	Xff                  string   `protobuf:"bytes,11,opt,name=xff,proto3" json:"xff,omitempty"`
-	HttpRequestBody      string   `protobuf:"bytes,12,opt,name=http_request_body,json=httpRequestBody,proto3" json:"http_request_body,omitempty"`
-	HttpResponseBody     string   `protobuf:"bytes,13,opt,name=http_response_body,json=httpResponseBody,proto3" json:"http_response_body,omitempty"`
-	HttpPort             int32    `protobuf:"varint,14,opt,name=http_port,json=httpPort,proto3" json:"http_port,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Hostname             string    `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Url                  string    `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	HttpUserAgent        string    `protobuf:"bytes,3,opt,name=http_user_agent,json=httpUserAgent,proto3" json:"http_user_agent,omitempty"`
+	HttpContentType      string    `protobuf:"bytes,4,opt,name=http_content_type,json=httpContentType,proto3" json:"http_content_type,omitempty"`
+	HttpRefer            string    `protobuf:"bytes,5,opt,name=http_refer,json=httpRefer,proto3" json:"http_refer,omitempty"`
+	HttpMethod           string    `protobuf:"bytes,6,opt,name=http_method,json=httpMethod,proto3" json:"http_method,omitempty"`
+	Protocol             string    `protobuf:"bytes,7,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Status               int32     `protobuf:"varint,8,opt,name=status,proto3" json:"status,omitempty"`
+	Length               int32     `protobuf:"varint,9,opt,name=length,proto3" json:"length,omitempty"`
+	Redirect             string    `protobuf:"bytes,10,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	Xff                  string    `protobuf:"bytes,11,opt,name=xff,proto3" json:"xff,omitempty"`
+	HttpRequestBody      string    `protobuf:"bytes,12,opt,name=http_request_body,json=httpRequestBody,proto3" json:"http_request_body,omitempty"`
+	HttpResponseBody     string    `protobuf:"bytes,13,opt,name=http_response_body,json=httpResponseBody,proto3" json:"http_response_body,omitempty"`
+	HttpPort             int32     `protobuf:"varint,14,opt,name=http_port,json=httpPort,proto3" json:"http_port,omitempty"`
+	Version              string    `protobuf:"bytes,15,opt,name=version,proto3" json:"version,omitempty"`
+	RequestHeaders       []*Header `protobuf:"bytes,16,rep,name=request_headers,json=requestHeaders,proto3" json:"request_headers,omitempty"`
+	ResponseHeaders      []*Header `protobuf:"bytes,17,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty"`
+	Http2                *HTTP2    `protobuf:"bytes,18,opt,name=http2,proto3" json:"http2,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *HTTP) Reset()         { *m = HTTP{} }
@@ -631,159 +835,487 @@ func (m *HTTP) GetHttpPort() int32 {
 	return 0
 }
 
-type FileInfo struct {
-	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	State                string   `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
-	Stored               bool     `protobuf:"varint,3,opt,name=stored,proto3" json:"stored,omitempty"`
-	Size                 int32    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	TxId                 int32    `protobuf:"varint,5,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	Gaps                 bool     `protobuf:"varint,6,opt,name=gaps,proto3" json:"gaps,omitempty"`
+func (m *HTTP) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *HTTP) GetRequestHeaders() []*Header {
+	if m != nil {
+		return m.RequestHeaders
+	}
+	return nil
+}
+
+func (m *HTTP) GetResponseHeaders() []*Header {
+	if m != nil {
+		return m.ResponseHeaders
+	}
+	return nil
+}
+
+func (m *HTTP) GetHttp2() *HTTP2 {
+	if m != nil {
+		return m.Http2
+	}
+	return nil
+}
+
+type Header struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	TableSizeUpdate      int32    `protobuf:"varint,3,opt,name=table_size_update,json=tableSizeUpdate,proto3" json:"table_size_update,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FileInfo) Reset()         { *m = FileInfo{} }
-func (m *FileInfo) String() string { return proto.CompactTextString(m) }
-func (*FileInfo) ProtoMessage()    {}
-func (*FileInfo) Descriptor() ([]byte, []int) {
+func (m *Header) Reset()         { *m = Header{} }
+func (m *Header) String() string { return proto.CompactTextString(m) }
+func (*Header) ProtoMessage()    {}
+func (*Header) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a88655da38237f58, []int{5}
 }
 
-func (m *FileInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FileInfo.Unmarshal(m, b)
+func (m *Header) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Header.Unmarshal(m, b)
 }
-func (m *FileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FileInfo.Marshal(b, m, deterministic)
+func (m *Header) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Header.Marshal(b, m, deterministic)
 }
-func (m *FileInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FileInfo.Merge(m, src)
+func (m *Header) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Header.Merge(m, src)
 }
-func (m *FileInfo) XXX_Size() int {
-	return xxx_messageInfo_FileInfo.Size(m)
+func (m *Header) XXX_Size() int {
+	return xxx_messageInfo_Header.Size(m)
 }
-func (m *FileInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_FileInfo.DiscardUnknown(m)
+func (m *Header) XXX_DiscardUnknown() {
+	xxx_messageInfo_Header.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FileInfo proto.InternalMessageInfo
+var xxx_messageInfo_Header proto.InternalMessageInfo
 
-func (m *FileInfo) GetFilename() string {
+func (m *Header) GetName() string {
 	if m != nil {
-		return m.Filename
+		return m.Name
 	}
 	return ""
 }
 
-func (m *FileInfo) GetState() string {
+func (m *Header) GetValue() string {
 	if m != nil {
-		return m.State
+		return m.Value
 	}
 	return ""
 }
 
-func (m *FileInfo) GetStored() bool {
+func (m *Header) GetTableSizeUpdate() int32 {
 	if m != nil {
-		return m.Stored
+		return m.TableSizeUpdate
 	}
-	return false
+	return 0
+}
+
+type HTTP2 struct {
+	StreamId             int64         `protobuf:"varint,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	Request              *HTTP2Message `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Response             *HTTP2Message `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *HTTP2) Reset()         { *m = HTTP2{} }
+func (m *HTTP2) String() string { return proto.CompactTextString(m) }
+func (*HTTP2) ProtoMessage()    {}
+func (*HTTP2) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{6}
+}
+
+func (m *HTTP2) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HTTP2.Unmarshal(m, b)
+}
+func (m *HTTP2) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HTTP2.Marshal(b, m, deterministic)
+}
+func (m *HTTP2) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HTTP2.Merge(m, src)
+}
+func (m *HTTP2) XXX_Size() int {
+	return xxx_messageInfo_HTTP2.Size(m)
+}
+func (m *HTTP2) XXX_DiscardUnknown() {
+	xxx_messageInfo_HTTP2.DiscardUnknown(m)
 }
 
-func (m *FileInfo) GetSize() int32 {
+var xxx_messageInfo_HTTP2 proto.InternalMessageInfo
+
+func (m *HTTP2) GetStreamId() int64 {
 	if m != nil {
-		return m.Size
+		return m.StreamId
 	}
 	return 0
 }
 
-func (m *FileInfo) GetTxId() int32 {
+func (m *HTTP2) GetRequest() *HTTP2Message {
 	if m != nil {
-		return m.TxId
+		return m.Request
 	}
-	return 0
+	return nil
 }
 
-func (m *FileInfo) GetGaps() bool {
+func (m *HTTP2) GetResponse() *HTTP2Message {
 	if m != nil {
-		return m.Gaps
+		return m.Response
 	}
-	return false
+	return nil
 }
 
-type TCP struct {
-	TcpFlags             string   `protobuf:"bytes,1,opt,name=tcp_flags,json=tcpFlags,proto3" json:"tcp_flags,omitempty"`
-	TcpFlagsTs           string   `protobuf:"bytes,2,opt,name=tcp_flags_ts,json=tcpFlagsTs,proto3" json:"tcp_flags_ts,omitempty"`
-	TcpFlagsTc           string   `protobuf:"bytes,3,opt,name=tcp_flags_tc,json=tcpFlagsTc,proto3" json:"tcp_flags_tc,omitempty"`
-	Syn                  bool     `protobuf:"varint,4,opt,name=syn,proto3" json:"syn,omitempty"`
-	Rst                  bool     `protobuf:"varint,5,opt,name=rst,proto3" json:"rst,omitempty"`
-	Psh                  bool     `protobuf:"varint,6,opt,name=psh,proto3" json:"psh,omitempty"`
-	Ack                  bool     `protobuf:"varint,7,opt,name=ack,proto3" json:"ack,omitempty"`
-	Ecn                  bool     `protobuf:"varint,8,opt,name=ecn,proto3" json:"ecn,omitempty"`
-	Cwr                  bool     `protobuf:"varint,9,opt,name=cwr,proto3" json:"cwr,omitempty"`
-	Fin                  bool     `protobuf:"varint,10,opt,name=fin,proto3" json:"fin,omitempty"`
-	Urg                  bool     `protobuf:"varint,11,opt,name=urg,proto3" json:"urg,omitempty"`
-	State                string   `protobuf:"bytes,12,opt,name=state,proto3" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type HTTP2Message struct {
+	Settings             []*HTTP2Setting `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings,omitempty"`
+	Headers              []*Header       `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	ErrorCode            string          `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Priority             int32           `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Window               int64           `protobuf:"varint,5,opt,name=window,proto3" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *TCP) Reset()         { *m = TCP{} }
-func (m *TCP) String() string { return proto.CompactTextString(m) }
-func (*TCP) ProtoMessage()    {}
-func (*TCP) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{6}
+func (m *HTTP2Message) Reset()         { *m = HTTP2Message{} }
+func (m *HTTP2Message) String() string { return proto.CompactTextString(m) }
+func (*HTTP2Message) ProtoMessage()    {}
+func (*HTTP2Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{7}
 }
 
-func (m *TCP) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TCP.Unmarshal(m, b)
+func (m *HTTP2Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HTTP2Message.Unmarshal(m, b)
 }
-func (m *TCP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TCP.Marshal(b, m, deterministic)
+func (m *HTTP2Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HTTP2Message.Marshal(b, m, deterministic)
 }
-func (m *TCP) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TCP.Merge(m, src)
+func (m *HTTP2Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HTTP2Message.Merge(m, src)
 }
-func (m *TCP) XXX_Size() int {
-	return xxx_messageInfo_TCP.Size(m)
+func (m *HTTP2Message) XXX_Size() int {
+	return xxx_messageInfo_HTTP2Message.Size(m)
 }
-func (m *TCP) XXX_DiscardUnknown() {
-	xxx_messageInfo_TCP.DiscardUnknown(m)
+func (m *HTTP2Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_HTTP2Message.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TCP proto.InternalMessageInfo
+var xxx_messageInfo_HTTP2Message proto.InternalMessageInfo
 
-func (m *TCP) GetTcpFlags() string {
+func (m *HTTP2Message) GetSettings() []*HTTP2Setting {
 	if m != nil {
-		return m.TcpFlags
+		return m.Settings
 	}
-	return ""
+	return nil
 }
 
-func (m *TCP) GetTcpFlagsTs() string {
+func (m *HTTP2Message) GetHeaders() []*Header {
 	if m != nil {
-		return m.TcpFlagsTs
+		return m.Headers
 	}
-	return ""
+	return nil
 }
 
-func (m *TCP) GetTcpFlagsTc() string {
+func (m *HTTP2Message) GetErrorCode() string {
 	if m != nil {
-		return m.TcpFlagsTc
+		return m.ErrorCode
 	}
 	return ""
 }
 
-func (m *TCP) GetSyn() bool {
+func (m *HTTP2Message) GetPriority() int32 {
 	if m != nil {
-		return m.Syn
+		return m.Priority
 	}
-	return false
+	return 0
 }
 
-func (m *TCP) GetRst() bool {
+func (m *HTTP2Message) GetWindow() int64 {
 	if m != nil {
-		return m.Rst
+		return m.Window
+	}
+	return 0
+}
+
+type HTTP2Setting struct {
+	SettingsId           string   `protobuf:"bytes,1,opt,name=settings_id,json=settingsId,proto3" json:"settings_id,omitempty"`
+	SettingsValue        int64    `protobuf:"varint,2,opt,name=settings_value,json=settingsValue,proto3" json:"settings_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HTTP2Setting) Reset()         { *m = HTTP2Setting{} }
+func (m *HTTP2Setting) String() string { return proto.CompactTextString(m) }
+func (*HTTP2Setting) ProtoMessage()    {}
+func (*HTTP2Setting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{8}
+}
+
+func (m *HTTP2Setting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HTTP2Setting.Unmarshal(m, b)
+}
+func (m *HTTP2Setting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HTTP2Setting.Marshal(b, m, deterministic)
+}
+func (m *HTTP2Setting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HTTP2Setting.Merge(m, src)
+}
+func (m *HTTP2Setting) XXX_Size() int {
+	return xxx_messageInfo_HTTP2Setting.Size(m)
+}
+func (m *HTTP2Setting) XXX_DiscardUnknown() {
+	xxx_messageInfo_HTTP2Setting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HTTP2Setting proto.InternalMessageInfo
+
+func (m *HTTP2Setting) GetSettingsId() string {
+	if m != nil {
+		return m.SettingsId
+	}
+	return ""
+}
+
+func (m *HTTP2Setting) GetSettingsValue() int64 {
+	if m != nil {
+		return m.SettingsValue
+	}
+	return 0
+}
+
+type FileInfo struct {
+	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	State                string   `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Stored               bool     `protobuf:"varint,3,opt,name=stored,proto3" json:"stored,omitempty"`
+	Size                 int64    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	TxId                 int32    `protobuf:"varint,5,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Gaps                 bool     `protobuf:"varint,6,opt,name=gaps,proto3" json:"gaps,omitempty"`
+	Md5                  string   `protobuf:"bytes,7,opt,name=md5,proto3" json:"md5,omitempty"`
+	Sha1                 string   `protobuf:"bytes,8,opt,name=sha1,proto3" json:"sha1,omitempty"`
+	Sha256               string   `protobuf:"bytes,9,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Magic                string   `protobuf:"bytes,10,opt,name=magic,proto3" json:"magic,omitempty"`
+	FileId               int64    `protobuf:"varint,11,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Sid                  []int64  `protobuf:"varint,12,rep,packed,name=sid,proto3" json:"sid,omitempty"`
+	Start                int64    `protobuf:"varint,13,opt,name=start,proto3" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,14,opt,name=end,proto3" json:"end,omitempty"`
+	Storing              bool     `protobuf:"varint,15,opt,name=storing,proto3" json:"storing,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileInfo) Reset()         { *m = FileInfo{} }
+func (m *FileInfo) String() string { return proto.CompactTextString(m) }
+func (*FileInfo) ProtoMessage()    {}
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{9}
+}
+
+func (m *FileInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileInfo.Unmarshal(m, b)
+}
+func (m *FileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileInfo.Marshal(b, m, deterministic)
+}
+func (m *FileInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileInfo.Merge(m, src)
+}
+func (m *FileInfo) XXX_Size() int {
+	return xxx_messageInfo_FileInfo.Size(m)
+}
+func (m *FileInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileInfo proto.InternalMessageInfo
+
+func (m *FileInfo) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *FileInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *FileInfo) GetStored() bool {
+	if m != nil {
+		return m.Stored
+	}
+	return false
+}
+
+func (m *FileInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileInfo) GetTxId() int32 {
+	if m != nil {
+		return m.TxId
+	}
+	return 0
+}
+
+func (m *FileInfo) GetGaps() bool {
+	if m != nil {
+		return m.Gaps
+	}
+	return false
+}
+
+func (m *FileInfo) GetMd5() string {
+	if m != nil {
+		return m.Md5
+	}
+	return ""
+}
+
+func (m *FileInfo) GetSha1() string {
+	if m != nil {
+		return m.Sha1
+	}
+	return ""
+}
+
+func (m *FileInfo) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+func (m *FileInfo) GetMagic() string {
+	if m != nil {
+		return m.Magic
+	}
+	return ""
+}
+
+func (m *FileInfo) GetFileId() int64 {
+	if m != nil {
+		return m.FileId
+	}
+	return 0
+}
+
+func (m *FileInfo) GetSid() []int64 {
+	if m != nil {
+		return m.Sid
+	}
+	return nil
+}
+
+func (m *FileInfo) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *FileInfo) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *FileInfo) GetStoring() bool {
+	if m != nil {
+		return m.Storing
+	}
+	return false
+}
+
+type TCP struct {
+	TcpFlags             string   `protobuf:"bytes,1,opt,name=tcp_flags,json=tcpFlags,proto3" json:"tcp_flags,omitempty"`
+	TcpFlagsTs           string   `protobuf:"bytes,2,opt,name=tcp_flags_ts,json=tcpFlagsTs,proto3" json:"tcp_flags_ts,omitempty"`
+	TcpFlagsTc           string   `protobuf:"bytes,3,opt,name=tcp_flags_tc,json=tcpFlagsTc,proto3" json:"tcp_flags_tc,omitempty"`
+	Syn                  bool     `protobuf:"varint,4,opt,name=syn,proto3" json:"syn,omitempty"`
+	Rst                  bool     `protobuf:"varint,5,opt,name=rst,proto3" json:"rst,omitempty"`
+	Psh                  bool     `protobuf:"varint,6,opt,name=psh,proto3" json:"psh,omitempty"`
+	Ack                  bool     `protobuf:"varint,7,opt,name=ack,proto3" json:"ack,omitempty"`
+	Ecn                  bool     `protobuf:"varint,8,opt,name=ecn,proto3" json:"ecn,omitempty"`
+	Cwr                  bool     `protobuf:"varint,9,opt,name=cwr,proto3" json:"cwr,omitempty"`
+	Fin                  bool     `protobuf:"varint,10,opt,name=fin,proto3" json:"fin,omitempty"`
+	Urg                  bool     `protobuf:"varint,11,opt,name=urg,proto3" json:"urg,omitempty"`
+	State                string   `protobuf:"bytes,12,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TCP) Reset()         { *m = TCP{} }
+func (m *TCP) String() string { return proto.CompactTextString(m) }
+func (*TCP) ProtoMessage()    {}
+func (*TCP) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{10}
+}
+
+func (m *TCP) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TCP.Unmarshal(m, b)
+}
+func (m *TCP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TCP.Marshal(b, m, deterministic)
+}
+func (m *TCP) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TCP.Merge(m, src)
+}
+func (m *TCP) XXX_Size() int {
+	return xxx_messageInfo_TCP.Size(m)
+}
+func (m *TCP) XXX_DiscardUnknown() {
+	xxx_messageInfo_TCP.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TCP proto.InternalMessageInfo
+
+func (m *TCP) GetTcpFlags() string {
+	if m != nil {
+		return m.TcpFlags
+	}
+	return ""
+}
+
+func (m *TCP) GetTcpFlagsTs() string {
+	if m != nil {
+		return m.TcpFlagsTs
+	}
+	return ""
+}
+
+func (m *TCP) GetTcpFlagsTc() string {
+	if m != nil {
+		return m.TcpFlagsTc
+	}
+	return ""
+}
+
+func (m *TCP) GetSyn() bool {
+	if m != nil {
+		return m.Syn
+	}
+	return false
+}
+
+func (m *TCP) GetRst() bool {
+	if m != nil {
+		return m.Rst
 	}
 	return false
 }
@@ -838,16 +1370,18 @@ func (m *TCP) GetState() string {
 }
 
 type Flow struct {
-	PktsToserver         int32    `protobuf:"varint,1,opt,name=pkts_toserver,json=pktsToserver,proto3" json:"pkts_toserver,omitempty"`
-	PktsToclient         int32    `protobuf:"varint,2,opt,name=pkts_toclient,json=pktsToclient,proto3" json:"pkts_toclient,omitempty"`
-	BytesToserver        int32    `protobuf:"varint,3,opt,name=bytes_toserver,json=bytesToserver,proto3" json:"bytes_toserver,omitempty"`
-	BytesToclient        int32    `protobuf:"varint,4,opt,name=bytes_toclient,json=bytesToclient,proto3" json:"bytes_toclient,omitempty"`
+	PktsToserver         int64    `protobuf:"varint,1,opt,name=pkts_toserver,json=pktsToserver,proto3" json:"pkts_toserver,omitempty"`
+	PktsToclient         int64    `protobuf:"varint,2,opt,name=pkts_toclient,json=pktsToclient,proto3" json:"pkts_toclient,omitempty"`
+	BytesToserver        int64    `protobuf:"varint,3,opt,name=bytes_toserver,json=bytesToserver,proto3" json:"bytes_toserver,omitempty"`
+	BytesToclient        int64    `protobuf:"varint,4,opt,name=bytes_toclient,json=bytesToclient,proto3" json:"bytes_toclient,omitempty"`
 	Start                string   `protobuf:"bytes,5,opt,name=start,proto3" json:"start,omitempty"`
 	End                  string   `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
-	Age                  int32    `protobuf:"varint,7,opt,name=age,proto3" json:"age,omitempty"`
+	Age                  int64    `protobuf:"varint,7,opt,name=age,proto3" json:"age,omitempty"`
 	State                string   `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
 	Reason               string   `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
 	Alerted              bool     `protobuf:"varint,10,opt,name=alerted,proto3" json:"alerted,omitempty"`
+	Bypass               string   `protobuf:"bytes,11,opt,name=bypass,proto3" json:"bypass,omitempty"`
+	Emergency            bool     `protobuf:"varint,12,opt,name=emergency,proto3" json:"emergency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -857,7 +1391,7 @@ func (m *Flow) Reset()         { *m = Flow{} }
 func (m *Flow) String() string { return proto.CompactTextString(m) }
 func (*Flow) ProtoMessage()    {}
 func (*Flow) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{7}
+	return fileDescriptor_a88655da38237f58, []int{11}
 }
 
 func (m *Flow) XXX_Unmarshal(b []byte) error {
@@ -878,28 +1412,28 @@ func (m *Flow) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Flow proto.InternalMessageInfo
 
-func (m *Flow) GetPktsToserver() int32 {
+func (m *Flow) GetPktsToserver() int64 {
 	if m != nil {
 		return m.PktsToserver
 	}
 	return 0
 }
 
-func (m *Flow) GetPktsToclient() int32 {
+func (m *Flow) GetPktsToclient() int64 {
 	if m != nil {
 		return m.PktsToclient
 	}
 	return 0
 }
 
-func (m *Flow) GetBytesToserver() int32 {
+func (m *Flow) GetBytesToserver() int64 {
 	if m != nil {
 		return m.BytesToserver
 	}
 	return 0
 }
 
-func (m *Flow) GetBytesToclient() int32 {
+func (m *Flow) GetBytesToclient() int64 {
 	if m != nil {
 		return m.BytesToclient
 	}
@@ -920,7 +1454,7 @@ func (m *Flow) GetEnd() string {
 	return ""
 }
 
-func (m *Flow) GetAge() int32 {
+func (m *Flow) GetAge() int64 {
 	if m != nil {
 		return m.Age
 	}
@@ -948,30 +1482,48 @@ func (m *Flow) GetAlerted() bool {
 	return false
 }
 
+func (m *Flow) GetBypass() string {
+	if m != nil {
+		return m.Bypass
+	}
+	return ""
+}
+
+func (m *Flow) GetEmergency() bool {
+	if m != nil {
+		return m.Emergency
+	}
+	return false
+}
+
 type DNS struct {
-	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Id                   int32    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	Rrname               string   `protobuf:"bytes,3,opt,name=rrname,proto3" json:"rrname,omitempty"`
-	Rrtype               string   `protobuf:"bytes,4,opt,name=rrtype,proto3" json:"rrtype,omitempty"`
-	Rdata                string   `protobuf:"bytes,5,opt,name=rdata,proto3" json:"rdata,omitempty"`
-	Rcode                string   `protobuf:"bytes,8,opt,name=rcode,proto3" json:"rcode,omitempty"`
-	Ttl                  int32    `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	TxId                 int32    `protobuf:"varint,7,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	Aa                   bool     `protobuf:"varint,9,opt,name=aa,proto3" json:"aa,omitempty"`
-	Qr                   bool     `protobuf:"varint,10,opt,name=qr,proto3" json:"qr,omitempty"`
-	Rd                   bool     `protobuf:"varint,11,opt,name=rd,proto3" json:"rd,omitempty"`
-	Ra                   bool     `protobuf:"varint,12,opt,name=ra,proto3" json:"ra,omitempty"`
-	Flags                string   `protobuf:"bytes,13,opt,name=flags,proto3" json:"flags,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Type                 string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id                   int32        `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Rrname               string       `protobuf:"bytes,3,opt,name=rrname,proto3" json:"rrname,omitempty"`
+	Rrtype               string       `protobuf:"bytes,4,opt,name=rrtype,proto3" json:"rrtype,omitempty"`
+	Rdata                string       `protobuf:"bytes,5,opt,name=rdata,proto3" json:"rdata,omitempty"`
+	Rcode                string       `protobuf:"bytes,8,opt,name=rcode,proto3" json:"rcode,omitempty"`
+	Ttl                  int32        `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	TxId                 int32        `protobuf:"varint,7,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Aa                   bool         `protobuf:"varint,9,opt,name=aa,proto3" json:"aa,omitempty"`
+	Qr                   bool         `protobuf:"varint,10,opt,name=qr,proto3" json:"qr,omitempty"`
+	Rd                   bool         `protobuf:"varint,11,opt,name=rd,proto3" json:"rd,omitempty"`
+	Ra                   bool         `protobuf:"varint,12,opt,name=ra,proto3" json:"ra,omitempty"`
+	Flags                string       `protobuf:"bytes,13,opt,name=flags,proto3" json:"flags,omitempty"`
+	Version              int32        `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	Opcode               string       `protobuf:"bytes,15,opt,name=opcode,proto3" json:"opcode,omitempty"`
+	Answers              []*DNSAnswer `protobuf:"bytes,16,rep,name=answers,proto3" json:"answers,omitempty"`
+	Grouped              *DNSGrouped  `protobuf:"bytes,17,opt,name=grouped,proto3" json:"grouped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *DNS) Reset()         { *m = DNS{} }
 func (m *DNS) String() string { return proto.CompactTextString(m) }
 func (*DNS) ProtoMessage()    {}
 func (*DNS) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{8}
+	return fileDescriptor_a88655da38237f58, []int{12}
 }
 
 func (m *DNS) XXX_Unmarshal(b []byte) error {
@@ -1083,19 +1635,198 @@ func (m *DNS) GetFlags() string {
 	return ""
 }
 
-type TLS struct {
-	Subject              string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
-	Issuerdn             string   `protobuf:"bytes,2,opt,name=issuerdn,proto3" json:"issuerdn,omitempty"`
-	SessionResumed       bool     `protobuf:"varint,3,opt,name=session_resumed,json=sessionResumed,proto3" json:"session_resumed,omitempty"`
-	Serial               string   `protobuf:"bytes,4,opt,name=serial,proto3" json:"serial,omitempty"`
-	Fingerprint          string   `protobuf:"bytes,5,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
-	Sni                  string   `protobuf:"bytes,6,opt,name=sni,proto3" json:"sni,omitempty"`
-	Version              string   `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
-	Notbefore            string   `protobuf:"bytes,8,opt,name=notbefore,proto3" json:"notbefore,omitempty"`
-	Notafter             string   `protobuf:"bytes,9,opt,name=notafter,proto3" json:"notafter,omitempty"`
-	Certificate          string   `protobuf:"bytes,10,opt,name=certificate,proto3" json:"certificate,omitempty"`
+func (m *DNS) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DNS) GetOpcode() string {
+	if m != nil {
+		return m.Opcode
+	}
+	return ""
+}
+
+func (m *DNS) GetAnswers() []*DNSAnswer {
+	if m != nil {
+		return m.Answers
+	}
+	return nil
+}
+
+func (m *DNS) GetGrouped() *DNSGrouped {
+	if m != nil {
+		return m.Grouped
+	}
+	return nil
+}
+
+type DNSAnswer struct {
+	Rrname               string   `protobuf:"bytes,1,opt,name=rrname,proto3" json:"rrname,omitempty"`
+	Rrtype               string   `protobuf:"bytes,2,opt,name=rrtype,proto3" json:"rrtype,omitempty"`
+	Ttl                  int32    `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Rdata                string   `protobuf:"bytes,4,opt,name=rdata,proto3" json:"rdata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DNSAnswer) Reset()         { *m = DNSAnswer{} }
+func (m *DNSAnswer) String() string { return proto.CompactTextString(m) }
+func (*DNSAnswer) ProtoMessage()    {}
+func (*DNSAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{13}
+}
+
+func (m *DNSAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DNSAnswer.Unmarshal(m, b)
+}
+func (m *DNSAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DNSAnswer.Marshal(b, m, deterministic)
+}
+func (m *DNSAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DNSAnswer.Merge(m, src)
+}
+func (m *DNSAnswer) XXX_Size() int {
+	return xxx_messageInfo_DNSAnswer.Size(m)
+}
+func (m *DNSAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_DNSAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DNSAnswer proto.InternalMessageInfo
+
+func (m *DNSAnswer) GetRrname() string {
+	if m != nil {
+		return m.Rrname
+	}
+	return ""
+}
+
+func (m *DNSAnswer) GetRrtype() string {
+	if m != nil {
+		return m.Rrtype
+	}
+	return ""
+}
+
+func (m *DNSAnswer) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *DNSAnswer) GetRdata() string {
+	if m != nil {
+		return m.Rdata
+	}
+	return ""
+}
+
+type DNSGrouped struct {
+	A                    []string `protobuf:"bytes,1,rep,name=a,proto3" json:"a,omitempty"`
+	Aaaa                 []string `protobuf:"bytes,2,rep,name=aaaa,proto3" json:"aaaa,omitempty"`
+	Cname                []string `protobuf:"bytes,3,rep,name=cname,proto3" json:"cname,omitempty"`
+	Mx                   []string `protobuf:"bytes,4,rep,name=mx,proto3" json:"mx,omitempty"`
+	Ns                   []string `protobuf:"bytes,5,rep,name=ns,proto3" json:"ns,omitempty"`
+	Ptr                  []string `protobuf:"bytes,6,rep,name=ptr,proto3" json:"ptr,omitempty"`
+	Txt                  []string `protobuf:"bytes,7,rep,name=txt,proto3" json:"txt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DNSGrouped) Reset()         { *m = DNSGrouped{} }
+func (m *DNSGrouped) String() string { return proto.CompactTextString(m) }
+func (*DNSGrouped) ProtoMessage()    {}
+func (*DNSGrouped) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{14}
+}
+
+func (m *DNSGrouped) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DNSGrouped.Unmarshal(m, b)
+}
+func (m *DNSGrouped) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DNSGrouped.Marshal(b, m, deterministic)
+}
+func (m *DNSGrouped) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DNSGrouped.Merge(m, src)
+}
+func (m *DNSGrouped) XXX_Size() int {
+	return xxx_messageInfo_DNSGrouped.Size(m)
+}
+func (m *DNSGrouped) XXX_DiscardUnknown() {
+	xxx_messageInfo_DNSGrouped.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DNSGrouped proto.InternalMessageInfo
+
+func (m *DNSGrouped) GetA() []string {
+	if m != nil {
+		return m.A
+	}
+	return nil
+}
+
+func (m *DNSGrouped) GetAaaa() []string {
+	if m != nil {
+		return m.Aaaa
+	}
+	return nil
+}
+
+func (m *DNSGrouped) GetCname() []string {
+	if m != nil {
+		return m.Cname
+	}
+	return nil
+}
+
+func (m *DNSGrouped) GetMx() []string {
+	if m != nil {
+		return m.Mx
+	}
+	return nil
+}
+
+func (m *DNSGrouped) GetNs() []string {
+	if m != nil {
+		return m.Ns
+	}
+	return nil
+}
+
+func (m *DNSGrouped) GetPtr() []string {
+	if m != nil {
+		return m.Ptr
+	}
+	return nil
+}
+
+func (m *DNSGrouped) GetTxt() []string {
+	if m != nil {
+		return m.Txt
+	}
+	return nil
+}
+
+type TLS struct {
+	Subject              string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuerdn             string   `protobuf:"bytes,2,opt,name=issuerdn,proto3" json:"issuerdn,omitempty"`
+	SessionResumed       bool     `protobuf:"varint,3,opt,name=session_resumed,json=sessionResumed,proto3" json:"session_resumed,omitempty"`
+	Serial               string   `protobuf:"bytes,4,opt,name=serial,proto3" json:"serial,omitempty"`
+	Fingerprint          string   `protobuf:"bytes,5,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	Sni                  string   `protobuf:"bytes,6,opt,name=sni,proto3" json:"sni,omitempty"`
+	Version              string   `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
+	Notbefore            string   `protobuf:"bytes,8,opt,name=notbefore,proto3" json:"notbefore,omitempty"`
+	Notafter             string   `protobuf:"bytes,9,opt,name=notafter,proto3" json:"notafter,omitempty"`
+	Certificate          string   `protobuf:"bytes,10,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	Chain                string   `protobuf:"bytes,11,opt,name=chain,proto3" json:"chain,omitempty"`
 	Ja3                  *JA3     `protobuf:"bytes,12,opt,name=ja3,proto3" json:"ja3,omitempty"`
+	Ja3S                 *JA3     `protobuf:"bytes,13,opt,name=ja3s,proto3" json:"ja3s,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1105,7 +1836,7 @@ func (m *TLS) Reset()         { *m = TLS{} }
 func (m *TLS) String() string { return proto.CompactTextString(m) }
 func (*TLS) ProtoMessage()    {}
 func (*TLS) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{9}
+	return fileDescriptor_a88655da38237f58, []int{15}
 }
 
 func (m *TLS) XXX_Unmarshal(b []byte) error {
@@ -1210,6 +1941,13 @@ func (m *TLS) GetJa3() *JA3 {
 	return nil
 }
 
+func (m *TLS) GetJa3S() *JA3 {
+	if m != nil {
+		return m.Ja3S
+	}
+	return nil
+}
+
 type JA3 struct {
 	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Data                 string   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -1223,7 +1961,7 @@ func (m *JA3) Reset()         { *m = JA3{} }
 func (m *JA3) String() string { return proto.CompactTextString(m) }
 func (*JA3) ProtoMessage()    {}
 func (*JA3) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{10}
+	return fileDescriptor_a88655da38237f58, []int{16}
 }
 
 func (m *JA3) XXX_Unmarshal(b []byte) error {
@@ -1276,7 +2014,7 @@ func (m *PacketInfo) Reset()         { *m = PacketInfo{} }
 func (m *PacketInfo) String() string { return proto.CompactTextString(m) }
 func (*PacketInfo) ProtoMessage()    {}
 func (*PacketInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{11}
+	return fileDescriptor_a88655da38237f58, []int{17}
 }
 
 func (m *PacketInfo) XXX_Unmarshal(b []byte) error {
@@ -1316,7 +2054,7 @@ func (m *SSH) Reset()         { *m = SSH{} }
 func (m *SSH) String() string { return proto.CompactTextString(m) }
 func (*SSH) ProtoMessage()    {}
 func (*SSH) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{12}
+	return fileDescriptor_a88655da38237f58, []int{18}
 }
 
 func (m *SSH) XXX_Unmarshal(b []byte) error {
@@ -1363,7 +2101,7 @@ func (m *Client) Reset()         { *m = Client{} }
 func (m *Client) String() string { return proto.CompactTextString(m) }
 func (*Client) ProtoMessage()    {}
 func (*Client) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{13}
+	return fileDescriptor_a88655da38237f58, []int{19}
 }
 
 func (m *Client) XXX_Unmarshal(b []byte) error {
@@ -1410,7 +2148,7 @@ func (m *Server) Reset()         { *m = Server{} }
 func (m *Server) String() string { return proto.CompactTextString(m) }
 func (*Server) ProtoMessage()    {}
 func (*Server) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{14}
+	return fileDescriptor_a88655da38237f58, []int{20}
 }
 
 func (m *Server) XXX_Unmarshal(b []byte) error {
@@ -1458,7 +2196,7 @@ func (m *SMTP) Reset()         { *m = SMTP{} }
 func (m *SMTP) String() string { return proto.CompactTextString(m) }
 func (*SMTP) ProtoMessage()    {}
 func (*SMTP) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{15}
+	return fileDescriptor_a88655da38237f58, []int{21}
 }
 
 func (m *SMTP) XXX_Unmarshal(b []byte) error {
@@ -1511,7 +2249,7 @@ func (m *Email) Reset()         { *m = Email{} }
 func (m *Email) String() string { return proto.CompactTextString(m) }
 func (*Email) ProtoMessage()    {}
 func (*Email) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a88655da38237f58, []int{16}
+	return fileDescriptor_a88655da38237f58, []int{22}
 }
 
 func (m *Email) XXX_Unmarshal(b []byte) error {
@@ -1539,6 +2277,1637 @@ func (m *Email) GetStatus() string {
 	return ""
 }
 
+type Anomaly struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Event                string   `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Code                 int32    `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Layer                string   `protobuf:"bytes,4,opt,name=layer,proto3" json:"layer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Anomaly) Reset()         { *m = Anomaly{} }
+func (m *Anomaly) String() string { return proto.CompactTextString(m) }
+func (*Anomaly) ProtoMessage()    {}
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{23}
+}
+
+func (m *Anomaly) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Anomaly.Unmarshal(m, b)
+}
+func (m *Anomaly) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Anomaly.Marshal(b, m, deterministic)
+}
+func (m *Anomaly) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Anomaly.Merge(m, src)
+}
+func (m *Anomaly) XXX_Size() int {
+	return xxx_messageInfo_Anomaly.Size(m)
+}
+func (m *Anomaly) XXX_DiscardUnknown() {
+	xxx_messageInfo_Anomaly.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Anomaly proto.InternalMessageInfo
+
+func (m *Anomaly) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Anomaly) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *Anomaly) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *Anomaly) GetLayer() string {
+	if m != nil {
+		return m.Layer
+	}
+	return ""
+}
+
+type Drop struct {
+	Len                  int32    `protobuf:"varint,1,opt,name=len,proto3" json:"len,omitempty"`
+	Tos                  int32    `protobuf:"varint,2,opt,name=tos,proto3" json:"tos,omitempty"`
+	Ttl                  int32    `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Ipid                 int32    `protobuf:"varint,4,opt,name=ipid,proto3" json:"ipid,omitempty"`
+	Tcpseq               int64    `protobuf:"varint,5,opt,name=tcpseq,proto3" json:"tcpseq,omitempty"`
+	Tcpack               int64    `protobuf:"varint,6,opt,name=tcpack,proto3" json:"tcpack,omitempty"`
+	Tcpwin               int32    `protobuf:"varint,7,opt,name=tcpwin,proto3" json:"tcpwin,omitempty"`
+	Syn                  bool     `protobuf:"varint,8,opt,name=syn,proto3" json:"syn,omitempty"`
+	Ack                  bool     `protobuf:"varint,9,opt,name=ack,proto3" json:"ack,omitempty"`
+	Psh                  bool     `protobuf:"varint,10,opt,name=psh,proto3" json:"psh,omitempty"`
+	Rst                  bool     `protobuf:"varint,11,opt,name=rst,proto3" json:"rst,omitempty"`
+	Urg                  bool     `protobuf:"varint,12,opt,name=urg,proto3" json:"urg,omitempty"`
+	Fin                  bool     `protobuf:"varint,13,opt,name=fin,proto3" json:"fin,omitempty"`
+	Tcpres               int32    `protobuf:"varint,14,opt,name=tcpres,proto3" json:"tcpres,omitempty"`
+	Tcpurgp              int32    `protobuf:"varint,15,opt,name=tcpurgp,proto3" json:"tcpurgp,omitempty"`
+	Reason               string   `protobuf:"bytes,16,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Drop) Reset()         { *m = Drop{} }
+func (m *Drop) String() string { return proto.CompactTextString(m) }
+func (*Drop) ProtoMessage()    {}
+func (*Drop) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{24}
+}
+
+func (m *Drop) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Drop.Unmarshal(m, b)
+}
+func (m *Drop) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Drop.Marshal(b, m, deterministic)
+}
+func (m *Drop) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Drop.Merge(m, src)
+}
+func (m *Drop) XXX_Size() int {
+	return xxx_messageInfo_Drop.Size(m)
+}
+func (m *Drop) XXX_DiscardUnknown() {
+	xxx_messageInfo_Drop.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Drop proto.InternalMessageInfo
+
+func (m *Drop) GetLen() int32 {
+	if m != nil {
+		return m.Len
+	}
+	return 0
+}
+
+func (m *Drop) GetTos() int32 {
+	if m != nil {
+		return m.Tos
+	}
+	return 0
+}
+
+func (m *Drop) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *Drop) GetIpid() int32 {
+	if m != nil {
+		return m.Ipid
+	}
+	return 0
+}
+
+func (m *Drop) GetTcpseq() int64 {
+	if m != nil {
+		return m.Tcpseq
+	}
+	return 0
+}
+
+func (m *Drop) GetTcpack() int64 {
+	if m != nil {
+		return m.Tcpack
+	}
+	return 0
+}
+
+func (m *Drop) GetTcpwin() int32 {
+	if m != nil {
+		return m.Tcpwin
+	}
+	return 0
+}
+
+func (m *Drop) GetSyn() bool {
+	if m != nil {
+		return m.Syn
+	}
+	return false
+}
+
+func (m *Drop) GetAck() bool {
+	if m != nil {
+		return m.Ack
+	}
+	return false
+}
+
+func (m *Drop) GetPsh() bool {
+	if m != nil {
+		return m.Psh
+	}
+	return false
+}
+
+func (m *Drop) GetRst() bool {
+	if m != nil {
+		return m.Rst
+	}
+	return false
+}
+
+func (m *Drop) GetUrg() bool {
+	if m != nil {
+		return m.Urg
+	}
+	return false
+}
+
+func (m *Drop) GetFin() bool {
+	if m != nil {
+		return m.Fin
+	}
+	return false
+}
+
+func (m *Drop) GetTcpres() int32 {
+	if m != nil {
+		return m.Tcpres
+	}
+	return 0
+}
+
+func (m *Drop) GetTcpurgp() int32 {
+	if m != nil {
+		return m.Tcpurgp
+	}
+	return 0
+}
+
+func (m *Drop) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type Stats struct {
+	Uptime               int64          `protobuf:"varint,1,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Capture              *CaptureStats  `protobuf:"bytes,2,opt,name=capture,proto3" json:"capture,omitempty"`
+	Decoder              *DecoderStats  `protobuf:"bytes,3,opt,name=decoder,proto3" json:"decoder,omitempty"`
+	Flow                 *FlowStats     `protobuf:"bytes,4,opt,name=flow,proto3" json:"flow,omitempty"`
+	Detect               *DetectStats   `protobuf:"bytes,5,opt,name=detect,proto3" json:"detect,omitempty"`
+	Tcp                  *TCPStats      `protobuf:"bytes,6,opt,name=tcp,proto3" json:"tcp,omitempty"`
+	AppLayer             *AppLayerStats `protobuf:"bytes,7,opt,name=app_layer,json=appLayer,proto3" json:"app_layer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Stats) Reset()         { *m = Stats{} }
+func (m *Stats) String() string { return proto.CompactTextString(m) }
+func (*Stats) ProtoMessage()    {}
+func (*Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{25}
+}
+
+func (m *Stats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Stats.Unmarshal(m, b)
+}
+func (m *Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Stats.Marshal(b, m, deterministic)
+}
+func (m *Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Stats.Merge(m, src)
+}
+func (m *Stats) XXX_Size() int {
+	return xxx_messageInfo_Stats.Size(m)
+}
+func (m *Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Stats proto.InternalMessageInfo
+
+func (m *Stats) GetUptime() int64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *Stats) GetCapture() *CaptureStats {
+	if m != nil {
+		return m.Capture
+	}
+	return nil
+}
+
+func (m *Stats) GetDecoder() *DecoderStats {
+	if m != nil {
+		return m.Decoder
+	}
+	return nil
+}
+
+func (m *Stats) GetFlow() *FlowStats {
+	if m != nil {
+		return m.Flow
+	}
+	return nil
+}
+
+func (m *Stats) GetDetect() *DetectStats {
+	if m != nil {
+		return m.Detect
+	}
+	return nil
+}
+
+func (m *Stats) GetTcp() *TCPStats {
+	if m != nil {
+		return m.Tcp
+	}
+	return nil
+}
+
+func (m *Stats) GetAppLayer() *AppLayerStats {
+	if m != nil {
+		return m.AppLayer
+	}
+	return nil
+}
+
+type CaptureStats struct {
+	KernelPackets        int64    `protobuf:"varint,1,opt,name=kernel_packets,json=kernelPackets,proto3" json:"kernel_packets,omitempty"`
+	KernelDrops          int64    `protobuf:"varint,2,opt,name=kernel_drops,json=kernelDrops,proto3" json:"kernel_drops,omitempty"`
+	KernelIfdrops        int64    `protobuf:"varint,3,opt,name=kernel_ifdrops,json=kernelIfdrops,proto3" json:"kernel_ifdrops,omitempty"`
+	Errors               int64    `protobuf:"varint,4,opt,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CaptureStats) Reset()         { *m = CaptureStats{} }
+func (m *CaptureStats) String() string { return proto.CompactTextString(m) }
+func (*CaptureStats) ProtoMessage()    {}
+func (*CaptureStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{26}
+}
+
+func (m *CaptureStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CaptureStats.Unmarshal(m, b)
+}
+func (m *CaptureStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CaptureStats.Marshal(b, m, deterministic)
+}
+func (m *CaptureStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureStats.Merge(m, src)
+}
+func (m *CaptureStats) XXX_Size() int {
+	return xxx_messageInfo_CaptureStats.Size(m)
+}
+func (m *CaptureStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CaptureStats proto.InternalMessageInfo
+
+func (m *CaptureStats) GetKernelPackets() int64 {
+	if m != nil {
+		return m.KernelPackets
+	}
+	return 0
+}
+
+func (m *CaptureStats) GetKernelDrops() int64 {
+	if m != nil {
+		return m.KernelDrops
+	}
+	return 0
+}
+
+func (m *CaptureStats) GetKernelIfdrops() int64 {
+	if m != nil {
+		return m.KernelIfdrops
+	}
+	return 0
+}
+
+func (m *CaptureStats) GetErrors() int64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+type DecoderStats struct {
+	Pkts                 int64    `protobuf:"varint,1,opt,name=pkts,proto3" json:"pkts,omitempty"`
+	Bytes                int64    `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Invalid              int64    `protobuf:"varint,3,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	Ipv4                 int64    `protobuf:"varint,4,opt,name=ipv4,proto3" json:"ipv4,omitempty"`
+	Ipv6                 int64    `protobuf:"varint,5,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	Ethernet             int64    `protobuf:"varint,6,opt,name=ethernet,proto3" json:"ethernet,omitempty"`
+	Tcp                  int64    `protobuf:"varint,7,opt,name=tcp,proto3" json:"tcp,omitempty"`
+	Udp                  int64    `protobuf:"varint,8,opt,name=udp,proto3" json:"udp,omitempty"`
+	Icmpv4               int64    `protobuf:"varint,9,opt,name=icmpv4,proto3" json:"icmpv4,omitempty"`
+	Icmpv6               int64    `protobuf:"varint,10,opt,name=icmpv6,proto3" json:"icmpv6,omitempty"`
+	AvgPktSize           int64    `protobuf:"varint,11,opt,name=avg_pkt_size,json=avgPktSize,proto3" json:"avg_pkt_size,omitempty"`
+	MaxPktSize           int64    `protobuf:"varint,12,opt,name=max_pkt_size,json=maxPktSize,proto3" json:"max_pkt_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecoderStats) Reset()         { *m = DecoderStats{} }
+func (m *DecoderStats) String() string { return proto.CompactTextString(m) }
+func (*DecoderStats) ProtoMessage()    {}
+func (*DecoderStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{27}
+}
+
+func (m *DecoderStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecoderStats.Unmarshal(m, b)
+}
+func (m *DecoderStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecoderStats.Marshal(b, m, deterministic)
+}
+func (m *DecoderStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecoderStats.Merge(m, src)
+}
+func (m *DecoderStats) XXX_Size() int {
+	return xxx_messageInfo_DecoderStats.Size(m)
+}
+func (m *DecoderStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecoderStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecoderStats proto.InternalMessageInfo
+
+func (m *DecoderStats) GetPkts() int64 {
+	if m != nil {
+		return m.Pkts
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetInvalid() int64 {
+	if m != nil {
+		return m.Invalid
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetIpv4() int64 {
+	if m != nil {
+		return m.Ipv4
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetIpv6() int64 {
+	if m != nil {
+		return m.Ipv6
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetEthernet() int64 {
+	if m != nil {
+		return m.Ethernet
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetTcp() int64 {
+	if m != nil {
+		return m.Tcp
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetUdp() int64 {
+	if m != nil {
+		return m.Udp
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetIcmpv4() int64 {
+	if m != nil {
+		return m.Icmpv4
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetIcmpv6() int64 {
+	if m != nil {
+		return m.Icmpv6
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetAvgPktSize() int64 {
+	if m != nil {
+		return m.AvgPktSize
+	}
+	return 0
+}
+
+func (m *DecoderStats) GetMaxPktSize() int64 {
+	if m != nil {
+		return m.MaxPktSize
+	}
+	return 0
+}
+
+type FlowStats struct {
+	Memcap               int64    `protobuf:"varint,1,opt,name=memcap,proto3" json:"memcap,omitempty"`
+	Tcp                  int64    `protobuf:"varint,2,opt,name=tcp,proto3" json:"tcp,omitempty"`
+	Udp                  int64    `protobuf:"varint,3,opt,name=udp,proto3" json:"udp,omitempty"`
+	Icmpv4               int64    `protobuf:"varint,4,opt,name=icmpv4,proto3" json:"icmpv4,omitempty"`
+	Icmpv6               int64    `protobuf:"varint,5,opt,name=icmpv6,proto3" json:"icmpv6,omitempty"`
+	Spare                int64    `protobuf:"varint,6,opt,name=spare,proto3" json:"spare,omitempty"`
+	EmergModeEntered     int64    `protobuf:"varint,7,opt,name=emerg_mode_entered,json=emergModeEntered,proto3" json:"emerg_mode_entered,omitempty"`
+	EmergModeOver        int64    `protobuf:"varint,8,opt,name=emerg_mode_over,json=emergModeOver,proto3" json:"emerg_mode_over,omitempty"`
+	Memuse               int64    `protobuf:"varint,9,opt,name=memuse,proto3" json:"memuse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlowStats) Reset()         { *m = FlowStats{} }
+func (m *FlowStats) String() string { return proto.CompactTextString(m) }
+func (*FlowStats) ProtoMessage()    {}
+func (*FlowStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{28}
+}
+
+func (m *FlowStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlowStats.Unmarshal(m, b)
+}
+func (m *FlowStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlowStats.Marshal(b, m, deterministic)
+}
+func (m *FlowStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowStats.Merge(m, src)
+}
+func (m *FlowStats) XXX_Size() int {
+	return xxx_messageInfo_FlowStats.Size(m)
+}
+func (m *FlowStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowStats proto.InternalMessageInfo
+
+func (m *FlowStats) GetMemcap() int64 {
+	if m != nil {
+		return m.Memcap
+	}
+	return 0
+}
+
+func (m *FlowStats) GetTcp() int64 {
+	if m != nil {
+		return m.Tcp
+	}
+	return 0
+}
+
+func (m *FlowStats) GetUdp() int64 {
+	if m != nil {
+		return m.Udp
+	}
+	return 0
+}
+
+func (m *FlowStats) GetIcmpv4() int64 {
+	if m != nil {
+		return m.Icmpv4
+	}
+	return 0
+}
+
+func (m *FlowStats) GetIcmpv6() int64 {
+	if m != nil {
+		return m.Icmpv6
+	}
+	return 0
+}
+
+func (m *FlowStats) GetSpare() int64 {
+	if m != nil {
+		return m.Spare
+	}
+	return 0
+}
+
+func (m *FlowStats) GetEmergModeEntered() int64 {
+	if m != nil {
+		return m.EmergModeEntered
+	}
+	return 0
+}
+
+func (m *FlowStats) GetEmergModeOver() int64 {
+	if m != nil {
+		return m.EmergModeOver
+	}
+	return 0
+}
+
+func (m *FlowStats) GetMemuse() int64 {
+	if m != nil {
+		return m.Memuse
+	}
+	return 0
+}
+
+type DetectStats struct {
+	Alert                int64    `protobuf:"varint,1,opt,name=alert,proto3" json:"alert,omitempty"`
+	AlertQueueOverflow   int64    `protobuf:"varint,2,opt,name=alert_queue_overflow,json=alertQueueOverflow,proto3" json:"alert_queue_overflow,omitempty"`
+	AlertsSuppressed     int64    `protobuf:"varint,3,opt,name=alerts_suppressed,json=alertsSuppressed,proto3" json:"alerts_suppressed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DetectStats) Reset()         { *m = DetectStats{} }
+func (m *DetectStats) String() string { return proto.CompactTextString(m) }
+func (*DetectStats) ProtoMessage()    {}
+func (*DetectStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{29}
+}
+
+func (m *DetectStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DetectStats.Unmarshal(m, b)
+}
+func (m *DetectStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DetectStats.Marshal(b, m, deterministic)
+}
+func (m *DetectStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DetectStats.Merge(m, src)
+}
+func (m *DetectStats) XXX_Size() int {
+	return xxx_messageInfo_DetectStats.Size(m)
+}
+func (m *DetectStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_DetectStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DetectStats proto.InternalMessageInfo
+
+func (m *DetectStats) GetAlert() int64 {
+	if m != nil {
+		return m.Alert
+	}
+	return 0
+}
+
+func (m *DetectStats) GetAlertQueueOverflow() int64 {
+	if m != nil {
+		return m.AlertQueueOverflow
+	}
+	return 0
+}
+
+func (m *DetectStats) GetAlertsSuppressed() int64 {
+	if m != nil {
+		return m.AlertsSuppressed
+	}
+	return 0
+}
+
+type TCPStats struct {
+	Sessions             int64    `protobuf:"varint,1,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	SsnMemcapDrop        int64    `protobuf:"varint,2,opt,name=ssn_memcap_drop,json=ssnMemcapDrop,proto3" json:"ssn_memcap_drop,omitempty"`
+	Pseudo               int64    `protobuf:"varint,3,opt,name=pseudo,proto3" json:"pseudo,omitempty"`
+	InvalidChecksum      int64    `protobuf:"varint,4,opt,name=invalid_checksum,json=invalidChecksum,proto3" json:"invalid_checksum,omitempty"`
+	Syn                  int64    `protobuf:"varint,5,opt,name=syn,proto3" json:"syn,omitempty"`
+	Synack               int64    `protobuf:"varint,6,opt,name=synack,proto3" json:"synack,omitempty"`
+	Rst                  int64    `protobuf:"varint,7,opt,name=rst,proto3" json:"rst,omitempty"`
+	Memuse               int64    `protobuf:"varint,8,opt,name=memuse,proto3" json:"memuse,omitempty"`
+	ReassemblyMemuse     int64    `protobuf:"varint,9,opt,name=reassembly_memuse,json=reassemblyMemuse,proto3" json:"reassembly_memuse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TCPStats) Reset()         { *m = TCPStats{} }
+func (m *TCPStats) String() string { return proto.CompactTextString(m) }
+func (*TCPStats) ProtoMessage()    {}
+func (*TCPStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{30}
+}
+
+func (m *TCPStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TCPStats.Unmarshal(m, b)
+}
+func (m *TCPStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TCPStats.Marshal(b, m, deterministic)
+}
+func (m *TCPStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TCPStats.Merge(m, src)
+}
+func (m *TCPStats) XXX_Size() int {
+	return xxx_messageInfo_TCPStats.Size(m)
+}
+func (m *TCPStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_TCPStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TCPStats proto.InternalMessageInfo
+
+func (m *TCPStats) GetSessions() int64 {
+	if m != nil {
+		return m.Sessions
+	}
+	return 0
+}
+
+func (m *TCPStats) GetSsnMemcapDrop() int64 {
+	if m != nil {
+		return m.SsnMemcapDrop
+	}
+	return 0
+}
+
+func (m *TCPStats) GetPseudo() int64 {
+	if m != nil {
+		return m.Pseudo
+	}
+	return 0
+}
+
+func (m *TCPStats) GetInvalidChecksum() int64 {
+	if m != nil {
+		return m.InvalidChecksum
+	}
+	return 0
+}
+
+func (m *TCPStats) GetSyn() int64 {
+	if m != nil {
+		return m.Syn
+	}
+	return 0
+}
+
+func (m *TCPStats) GetSynack() int64 {
+	if m != nil {
+		return m.Synack
+	}
+	return 0
+}
+
+func (m *TCPStats) GetRst() int64 {
+	if m != nil {
+		return m.Rst
+	}
+	return 0
+}
+
+func (m *TCPStats) GetMemuse() int64 {
+	if m != nil {
+		return m.Memuse
+	}
+	return 0
+}
+
+func (m *TCPStats) GetReassemblyMemuse() int64 {
+	if m != nil {
+		return m.ReassemblyMemuse
+	}
+	return 0
+}
+
+type AppLayerStats struct {
+	Flow                 map[string]int64 `protobuf:"bytes,1,rep,name=flow,proto3" json:"flow,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Tx                   map[string]int64 `protobuf:"bytes,2,rep,name=tx,proto3" json:"tx,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *AppLayerStats) Reset()         { *m = AppLayerStats{} }
+func (m *AppLayerStats) String() string { return proto.CompactTextString(m) }
+func (*AppLayerStats) ProtoMessage()    {}
+func (*AppLayerStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{31}
+}
+
+func (m *AppLayerStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppLayerStats.Unmarshal(m, b)
+}
+func (m *AppLayerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppLayerStats.Marshal(b, m, deterministic)
+}
+func (m *AppLayerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppLayerStats.Merge(m, src)
+}
+func (m *AppLayerStats) XXX_Size() int {
+	return xxx_messageInfo_AppLayerStats.Size(m)
+}
+func (m *AppLayerStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppLayerStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppLayerStats proto.InternalMessageInfo
+
+func (m *AppLayerStats) GetFlow() map[string]int64 {
+	if m != nil {
+		return m.Flow
+	}
+	return nil
+}
+
+func (m *AppLayerStats) GetTx() map[string]int64 {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+type SMB struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dialect              string   `protobuf:"bytes,2,opt,name=dialect,proto3" json:"dialect,omitempty"`
+	Command              string   `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StatusCode           string   `protobuf:"bytes,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	SessionId            int64    `protobuf:"varint,6,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	TreeId               int64    `protobuf:"varint,7,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	Filename             string   `protobuf:"bytes,8,opt,name=filename,proto3" json:"filename,omitempty"`
+	Share                string   `protobuf:"bytes,9,opt,name=share,proto3" json:"share,omitempty"`
+	ShareType            string   `protobuf:"bytes,10,opt,name=share_type,json=shareType,proto3" json:"share_type,omitempty"`
+	Request              *SMBHost `protobuf:"bytes,11,opt,name=request,proto3" json:"request,omitempty"`
+	Response             *SMBHost `protobuf:"bytes,12,opt,name=response,proto3" json:"response,omitempty"`
+	ClientDialects       []string `protobuf:"bytes,13,rep,name=client_dialects,json=clientDialects,proto3" json:"client_dialects,omitempty"`
+	ClientGuid           string   `protobuf:"bytes,14,opt,name=client_guid,json=clientGuid,proto3" json:"client_guid,omitempty"`
+	ServerGuid           string   `protobuf:"bytes,15,opt,name=server_guid,json=serverGuid,proto3" json:"server_guid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SMB) Reset()         { *m = SMB{} }
+func (m *SMB) String() string { return proto.CompactTextString(m) }
+func (*SMB) ProtoMessage()    {}
+func (*SMB) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{32}
+}
+
+func (m *SMB) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SMB.Unmarshal(m, b)
+}
+func (m *SMB) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SMB.Marshal(b, m, deterministic)
+}
+func (m *SMB) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SMB.Merge(m, src)
+}
+func (m *SMB) XXX_Size() int {
+	return xxx_messageInfo_SMB.Size(m)
+}
+func (m *SMB) XXX_DiscardUnknown() {
+	xxx_messageInfo_SMB.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SMB proto.InternalMessageInfo
+
+func (m *SMB) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SMB) GetDialect() string {
+	if m != nil {
+		return m.Dialect
+	}
+	return ""
+}
+
+func (m *SMB) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *SMB) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SMB) GetStatusCode() string {
+	if m != nil {
+		return m.StatusCode
+	}
+	return ""
+}
+
+func (m *SMB) GetSessionId() int64 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
+}
+
+func (m *SMB) GetTreeId() int64 {
+	if m != nil {
+		return m.TreeId
+	}
+	return 0
+}
+
+func (m *SMB) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *SMB) GetShare() string {
+	if m != nil {
+		return m.Share
+	}
+	return ""
+}
+
+func (m *SMB) GetShareType() string {
+	if m != nil {
+		return m.ShareType
+	}
+	return ""
+}
+
+func (m *SMB) GetRequest() *SMBHost {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SMB) GetResponse() *SMBHost {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SMB) GetClientDialects() []string {
+	if m != nil {
+		return m.ClientDialects
+	}
+	return nil
+}
+
+func (m *SMB) GetClientGuid() string {
+	if m != nil {
+		return m.ClientGuid
+	}
+	return ""
+}
+
+func (m *SMB) GetServerGuid() string {
+	if m != nil {
+		return m.ServerGuid
+	}
+	return ""
+}
+
+type SMBHost struct {
+	NativeOs             string   `protobuf:"bytes,1,opt,name=native_os,json=nativeOs,proto3" json:"native_os,omitempty"`
+	NativeLm             string   `protobuf:"bytes,2,opt,name=native_lm,json=nativeLm,proto3" json:"native_lm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SMBHost) Reset()         { *m = SMBHost{} }
+func (m *SMBHost) String() string { return proto.CompactTextString(m) }
+func (*SMBHost) ProtoMessage()    {}
+func (*SMBHost) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{33}
+}
+
+func (m *SMBHost) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SMBHost.Unmarshal(m, b)
+}
+func (m *SMBHost) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SMBHost.Marshal(b, m, deterministic)
+}
+func (m *SMBHost) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SMBHost.Merge(m, src)
+}
+func (m *SMBHost) XXX_Size() int {
+	return xxx_messageInfo_SMBHost.Size(m)
+}
+func (m *SMBHost) XXX_DiscardUnknown() {
+	xxx_messageInfo_SMBHost.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SMBHost proto.InternalMessageInfo
+
+func (m *SMBHost) GetNativeOs() string {
+	if m != nil {
+		return m.NativeOs
+	}
+	return ""
+}
+
+func (m *SMBHost) GetNativeLm() string {
+	if m != nil {
+		return m.NativeLm
+	}
+	return ""
+}
+
+type KRB5 struct {
+	MsgType              string   `protobuf:"bytes,1,opt,name=msg_type,json=msgType,proto3" json:"msg_type,omitempty"`
+	Cname                string   `protobuf:"bytes,2,opt,name=cname,proto3" json:"cname,omitempty"`
+	Realm                string   `protobuf:"bytes,3,opt,name=realm,proto3" json:"realm,omitempty"`
+	Sname                string   `protobuf:"bytes,4,opt,name=sname,proto3" json:"sname,omitempty"`
+	Encryption           string   `protobuf:"bytes,5,opt,name=encryption,proto3" json:"encryption,omitempty"`
+	WeakEncryption       bool     `protobuf:"varint,6,opt,name=weak_encryption,json=weakEncryption,proto3" json:"weak_encryption,omitempty"`
+	FailedRequest        string   `protobuf:"bytes,7,opt,name=failed_request,json=failedRequest,proto3" json:"failed_request,omitempty"`
+	ErrorCode            string   `protobuf:"bytes,8,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KRB5) Reset()         { *m = KRB5{} }
+func (m *KRB5) String() string { return proto.CompactTextString(m) }
+func (*KRB5) ProtoMessage()    {}
+func (*KRB5) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{34}
+}
+
+func (m *KRB5) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KRB5.Unmarshal(m, b)
+}
+func (m *KRB5) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KRB5.Marshal(b, m, deterministic)
+}
+func (m *KRB5) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KRB5.Merge(m, src)
+}
+func (m *KRB5) XXX_Size() int {
+	return xxx_messageInfo_KRB5.Size(m)
+}
+func (m *KRB5) XXX_DiscardUnknown() {
+	xxx_messageInfo_KRB5.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KRB5 proto.InternalMessageInfo
+
+func (m *KRB5) GetMsgType() string {
+	if m != nil {
+		return m.MsgType
+	}
+	return ""
+}
+
+func (m *KRB5) GetCname() string {
+	if m != nil {
+		return m.Cname
+	}
+	return ""
+}
+
+func (m *KRB5) GetRealm() string {
+	if m != nil {
+		return m.Realm
+	}
+	return ""
+}
+
+func (m *KRB5) GetSname() string {
+	if m != nil {
+		return m.Sname
+	}
+	return ""
+}
+
+func (m *KRB5) GetEncryption() string {
+	if m != nil {
+		return m.Encryption
+	}
+	return ""
+}
+
+func (m *KRB5) GetWeakEncryption() bool {
+	if m != nil {
+		return m.WeakEncryption
+	}
+	return false
+}
+
+func (m *KRB5) GetFailedRequest() string {
+	if m != nil {
+		return m.FailedRequest
+	}
+	return ""
+}
+
+func (m *KRB5) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+type DHCP struct {
+	Type                  string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id                    int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	ClientMac             string   `protobuf:"bytes,3,opt,name=client_mac,json=clientMac,proto3" json:"client_mac,omitempty"`
+	AssignedIp            string   `protobuf:"bytes,4,opt,name=assigned_ip,json=assignedIp,proto3" json:"assigned_ip,omitempty"`
+	ClientIp              string   `protobuf:"bytes,5,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	RelayIp               string   `protobuf:"bytes,6,opt,name=relay_ip,json=relayIp,proto3" json:"relay_ip,omitempty"`
+	NextServerIp          string   `protobuf:"bytes,7,opt,name=next_server_ip,json=nextServerIp,proto3" json:"next_server_ip,omitempty"`
+	DhcpType              string   `protobuf:"bytes,8,opt,name=dhcp_type,json=dhcpType,proto3" json:"dhcp_type,omitempty"`
+	Hostname              string   `protobuf:"bytes,9,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	LeaseTime             int64    `protobuf:"varint,10,opt,name=lease_time,json=leaseTime,proto3" json:"lease_time,omitempty"`
+	RenewalTime           int64    `protobuf:"varint,11,opt,name=renewal_time,json=renewalTime,proto3" json:"renewal_time,omitempty"`
+	RebindingTime         int64    `protobuf:"varint,12,opt,name=rebinding_time,json=rebindingTime,proto3" json:"rebinding_time,omitempty"`
+	SubnetMask            string   `protobuf:"bytes,13,opt,name=subnet_mask,json=subnetMask,proto3" json:"subnet_mask,omitempty"`
+	Routers               []string `protobuf:"bytes,14,rep,name=routers,proto3" json:"routers,omitempty"`
+	DnsServers            []string `protobuf:"bytes,15,rep,name=dns_servers,json=dnsServers,proto3" json:"dns_servers,omitempty"`
+	Params                []string `protobuf:"bytes,16,rep,name=params,proto3" json:"params,omitempty"`
+	ClientId              string   `protobuf:"bytes,17,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	VendorClassIdentifier string   `protobuf:"bytes,18,opt,name=vendor_class_identifier,json=vendorClassIdentifier,proto3" json:"vendor_class_identifier,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *DHCP) Reset()         { *m = DHCP{} }
+func (m *DHCP) String() string { return proto.CompactTextString(m) }
+func (*DHCP) ProtoMessage()    {}
+func (*DHCP) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{35}
+}
+
+func (m *DHCP) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DHCP.Unmarshal(m, b)
+}
+func (m *DHCP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DHCP.Marshal(b, m, deterministic)
+}
+func (m *DHCP) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DHCP.Merge(m, src)
+}
+func (m *DHCP) XXX_Size() int {
+	return xxx_messageInfo_DHCP.Size(m)
+}
+func (m *DHCP) XXX_DiscardUnknown() {
+	xxx_messageInfo_DHCP.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DHCP proto.InternalMessageInfo
+
+func (m *DHCP) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DHCP) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DHCP) GetClientMac() string {
+	if m != nil {
+		return m.ClientMac
+	}
+	return ""
+}
+
+func (m *DHCP) GetAssignedIp() string {
+	if m != nil {
+		return m.AssignedIp
+	}
+	return ""
+}
+
+func (m *DHCP) GetClientIp() string {
+	if m != nil {
+		return m.ClientIp
+	}
+	return ""
+}
+
+func (m *DHCP) GetRelayIp() string {
+	if m != nil {
+		return m.RelayIp
+	}
+	return ""
+}
+
+func (m *DHCP) GetNextServerIp() string {
+	if m != nil {
+		return m.NextServerIp
+	}
+	return ""
+}
+
+func (m *DHCP) GetDhcpType() string {
+	if m != nil {
+		return m.DhcpType
+	}
+	return ""
+}
+
+func (m *DHCP) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *DHCP) GetLeaseTime() int64 {
+	if m != nil {
+		return m.LeaseTime
+	}
+	return 0
+}
+
+func (m *DHCP) GetRenewalTime() int64 {
+	if m != nil {
+		return m.RenewalTime
+	}
+	return 0
+}
+
+func (m *DHCP) GetRebindingTime() int64 {
+	if m != nil {
+		return m.RebindingTime
+	}
+	return 0
+}
+
+func (m *DHCP) GetSubnetMask() string {
+	if m != nil {
+		return m.SubnetMask
+	}
+	return ""
+}
+
+func (m *DHCP) GetRouters() []string {
+	if m != nil {
+		return m.Routers
+	}
+	return nil
+}
+
+func (m *DHCP) GetDnsServers() []string {
+	if m != nil {
+		return m.DnsServers
+	}
+	return nil
+}
+
+func (m *DHCP) GetParams() []string {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *DHCP) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *DHCP) GetVendorClassIdentifier() string {
+	if m != nil {
+		return m.VendorClassIdentifier
+	}
+	return ""
+}
+
+type QUIC struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Sni                  string   `protobuf:"bytes,2,opt,name=sni,proto3" json:"sni,omitempty"`
+	Ua                   string   `protobuf:"bytes,3,opt,name=ua,proto3" json:"ua,omitempty"`
+	Cyu                  []*CYU   `protobuf:"bytes,4,rep,name=cyu,proto3" json:"cyu,omitempty"`
+	Ja3                  *JA3     `protobuf:"bytes,5,opt,name=ja3,proto3" json:"ja3,omitempty"`
+	Ja3S                 *JA3     `protobuf:"bytes,6,opt,name=ja3s,proto3" json:"ja3s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QUIC) Reset()         { *m = QUIC{} }
+func (m *QUIC) String() string { return proto.CompactTextString(m) }
+func (*QUIC) ProtoMessage()    {}
+func (*QUIC) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{36}
+}
+
+func (m *QUIC) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QUIC.Unmarshal(m, b)
+}
+func (m *QUIC) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QUIC.Marshal(b, m, deterministic)
+}
+func (m *QUIC) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QUIC.Merge(m, src)
+}
+func (m *QUIC) XXX_Size() int {
+	return xxx_messageInfo_QUIC.Size(m)
+}
+func (m *QUIC) XXX_DiscardUnknown() {
+	xxx_messageInfo_QUIC.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QUIC proto.InternalMessageInfo
+
+func (m *QUIC) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QUIC) GetSni() string {
+	if m != nil {
+		return m.Sni
+	}
+	return ""
+}
+
+func (m *QUIC) GetUa() string {
+	if m != nil {
+		return m.Ua
+	}
+	return ""
+}
+
+func (m *QUIC) GetCyu() []*CYU {
+	if m != nil {
+		return m.Cyu
+	}
+	return nil
+}
+
+func (m *QUIC) GetJa3() *JA3 {
+	if m != nil {
+		return m.Ja3
+	}
+	return nil
+}
+
+func (m *QUIC) GetJa3S() *JA3 {
+	if m != nil {
+		return m.Ja3S
+	}
+	return nil
+}
+
+type CYU struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	String_              string   `protobuf:"bytes,2,opt,name=string,proto3" json:"string,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CYU) Reset()         { *m = CYU{} }
+func (m *CYU) String() string { return proto.CompactTextString(m) }
+func (*CYU) ProtoMessage()    {}
+func (*CYU) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{37}
+}
+
+func (m *CYU) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CYU.Unmarshal(m, b)
+}
+func (m *CYU) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CYU.Marshal(b, m, deterministic)
+}
+func (m *CYU) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CYU.Merge(m, src)
+}
+func (m *CYU) XXX_Size() int {
+	return xxx_messageInfo_CYU.Size(m)
+}
+func (m *CYU) XXX_DiscardUnknown() {
+	xxx_messageInfo_CYU.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CYU proto.InternalMessageInfo
+
+func (m *CYU) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *CYU) GetString_() string {
+	if m != nil {
+		return m.String_
+	}
+	return ""
+}
+
+type RDP struct {
+	TxId                 int64      `protobuf:"varint,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	EventType            string     `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Client               *RDPClient `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`
+	Channels             []string   `protobuf:"bytes,4,rep,name=channels,proto3" json:"channels,omitempty"`
+	Cookie               string     `protobuf:"bytes,5,opt,name=cookie,proto3" json:"cookie,omitempty"`
+	Protocol             string     `protobuf:"bytes,6,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	ErrorCode            string     `protobuf:"bytes,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ServerSupports       []string   `protobuf:"bytes,8,rep,name=server_supports,json=serverSupports,proto3" json:"server_supports,omitempty"`
+	X509Serials          []string   `protobuf:"bytes,9,rep,name=x509_serials,json=x509Serials,proto3" json:"x509_serials,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RDP) Reset()         { *m = RDP{} }
+func (m *RDP) String() string { return proto.CompactTextString(m) }
+func (*RDP) ProtoMessage()    {}
+func (*RDP) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{38}
+}
+
+func (m *RDP) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RDP.Unmarshal(m, b)
+}
+func (m *RDP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RDP.Marshal(b, m, deterministic)
+}
+func (m *RDP) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RDP.Merge(m, src)
+}
+func (m *RDP) XXX_Size() int {
+	return xxx_messageInfo_RDP.Size(m)
+}
+func (m *RDP) XXX_DiscardUnknown() {
+	xxx_messageInfo_RDP.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RDP proto.InternalMessageInfo
+
+func (m *RDP) GetTxId() int64 {
+	if m != nil {
+		return m.TxId
+	}
+	return 0
+}
+
+func (m *RDP) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *RDP) GetClient() *RDPClient {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+func (m *RDP) GetChannels() []string {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *RDP) GetCookie() string {
+	if m != nil {
+		return m.Cookie
+	}
+	return ""
+}
+
+func (m *RDP) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *RDP) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+func (m *RDP) GetServerSupports() []string {
+	if m != nil {
+		return m.ServerSupports
+	}
+	return nil
+}
+
+func (m *RDP) GetX509Serials() []string {
+	if m != nil {
+		return m.X509Serials
+	}
+	return nil
+}
+
+type RDPClient struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DesktopWidth         int32    `protobuf:"varint,2,opt,name=desktop_width,json=desktopWidth,proto3" json:"desktop_width,omitempty"`
+	DesktopHeight        int32    `protobuf:"varint,3,opt,name=desktop_height,json=desktopHeight,proto3" json:"desktop_height,omitempty"`
+	ColorDepth           int32    `protobuf:"varint,4,opt,name=color_depth,json=colorDepth,proto3" json:"color_depth,omitempty"`
+	KeyboardLayout       string   `protobuf:"bytes,5,opt,name=keyboard_layout,json=keyboardLayout,proto3" json:"keyboard_layout,omitempty"`
+	Build                string   `protobuf:"bytes,6,opt,name=build,proto3" json:"build,omitempty"`
+	ClientName           string   `protobuf:"bytes,7,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	KeyboardType         string   `protobuf:"bytes,8,opt,name=keyboard_type,json=keyboardType,proto3" json:"keyboard_type,omitempty"`
+	FunctionKeys         int32    `protobuf:"varint,9,opt,name=function_keys,json=functionKeys,proto3" json:"function_keys,omitempty"`
+	ProductId            int32    `protobuf:"varint,10,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Capabilities         []string `protobuf:"bytes,11,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Id                   string   `protobuf:"bytes,12,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RDPClient) Reset()         { *m = RDPClient{} }
+func (m *RDPClient) String() string { return proto.CompactTextString(m) }
+func (*RDPClient) ProtoMessage()    {}
+func (*RDPClient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a88655da38237f58, []int{39}
+}
+
+func (m *RDPClient) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RDPClient.Unmarshal(m, b)
+}
+func (m *RDPClient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RDPClient.Marshal(b, m, deterministic)
+}
+func (m *RDPClient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RDPClient.Merge(m, src)
+}
+func (m *RDPClient) XXX_Size() int {
+	return xxx_messageInfo_RDPClient.Size(m)
+}
+func (m *RDPClient) XXX_DiscardUnknown() {
+	xxx_messageInfo_RDPClient.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RDPClient proto.InternalMessageInfo
+
+func (m *RDPClient) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *RDPClient) GetDesktopWidth() int32 {
+	if m != nil {
+		return m.DesktopWidth
+	}
+	return 0
+}
+
+func (m *RDPClient) GetDesktopHeight() int32 {
+	if m != nil {
+		return m.DesktopHeight
+	}
+	return 0
+}
+
+func (m *RDPClient) GetColorDepth() int32 {
+	if m != nil {
+		return m.ColorDepth
+	}
+	return 0
+}
+
+func (m *RDPClient) GetKeyboardLayout() string {
+	if m != nil {
+		return m.KeyboardLayout
+	}
+	return ""
+}
+
+func (m *RDPClient) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *RDPClient) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+func (m *RDPClient) GetKeyboardType() string {
+	if m != nil {
+		return m.KeyboardType
+	}
+	return ""
+}
+
+func (m *RDPClient) GetFunctionKeys() int32 {
+	if m != nil {
+		return m.FunctionKeys
+	}
+	return 0
+}
+
+func (m *RDPClient) GetProductId() int32 {
+	if m != nil {
+		return m.ProductId
+	}
+	return 0
+}
+
+func (m *RDPClient) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *RDPClient) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*EVE)(nil), "emitto.sensor.EVE")
 	proto.RegisterType((*Vars)(nil), "emitto.sensor.Vars")
@@ -1546,10 +3915,16 @@ func init() {
 	proto.RegisterType((*Alert)(nil), "emitto.sensor.Alert")
 	proto.RegisterType((*Metadata)(nil), "emitto.sensor.Metadata")
 	proto.RegisterType((*HTTP)(nil), "emitto.sensor.HTTP")
+	proto.RegisterType((*Header)(nil), "emitto.sensor.Header")
+	proto.RegisterType((*HTTP2)(nil), "emitto.sensor.HTTP2")
+	proto.RegisterType((*HTTP2Message)(nil), "emitto.sensor.HTTP2Message")
+	proto.RegisterType((*HTTP2Setting)(nil), "emitto.sensor.HTTP2Setting")
 	proto.RegisterType((*FileInfo)(nil), "emitto.sensor.FileInfo")
 	proto.RegisterType((*TCP)(nil), "emitto.sensor.TCP")
 	proto.RegisterType((*Flow)(nil), "emitto.sensor.Flow")
 	proto.RegisterType((*DNS)(nil), "emitto.sensor.DNS")
+	proto.RegisterType((*DNSAnswer)(nil), "emitto.sensor.DNSAnswer")
+	proto.RegisterType((*DNSGrouped)(nil), "emitto.sensor.DNSGrouped")
 	proto.RegisterType((*TLS)(nil), "emitto.sensor.TLS")
 	proto.RegisterType((*JA3)(nil), "emitto.sensor.JA3")
 	proto.RegisterType((*PacketInfo)(nil), "emitto.sensor.PacketInfo")
@@ -1558,6 +3933,25 @@ func init() {
 	proto.RegisterType((*Server)(nil), "emitto.sensor.Server")
 	proto.RegisterType((*SMTP)(nil), "emitto.sensor.SMTP")
 	proto.RegisterType((*Email)(nil), "emitto.sensor.Email")
+	proto.RegisterType((*Anomaly)(nil), "emitto.sensor.Anomaly")
+	proto.RegisterType((*Drop)(nil), "emitto.sensor.Drop")
+	proto.RegisterType((*Stats)(nil), "emitto.sensor.Stats")
+	proto.RegisterType((*CaptureStats)(nil), "emitto.sensor.CaptureStats")
+	proto.RegisterType((*DecoderStats)(nil), "emitto.sensor.DecoderStats")
+	proto.RegisterType((*FlowStats)(nil), "emitto.sensor.FlowStats")
+	proto.RegisterType((*DetectStats)(nil), "emitto.sensor.DetectStats")
+	proto.RegisterType((*TCPStats)(nil), "emitto.sensor.TCPStats")
+	proto.RegisterType((*AppLayerStats)(nil), "emitto.sensor.AppLayerStats")
+	proto.RegisterMapType((map[string]int64)(nil), "emitto.sensor.AppLayerStats.FlowEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "emitto.sensor.AppLayerStats.TxEntry")
+	proto.RegisterType((*SMB)(nil), "emitto.sensor.SMB")
+	proto.RegisterType((*SMBHost)(nil), "emitto.sensor.SMBHost")
+	proto.RegisterType((*KRB5)(nil), "emitto.sensor.KRB5")
+	proto.RegisterType((*DHCP)(nil), "emitto.sensor.DHCP")
+	proto.RegisterType((*QUIC)(nil), "emitto.sensor.QUIC")
+	proto.RegisterType((*CYU)(nil), "emitto.sensor.CYU")
+	proto.RegisterType((*RDP)(nil), "emitto.sensor.RDP")
+	proto.RegisterType((*RDPClient)(nil), "emitto.sensor.RDPClient")
 }
 
 func init() {
@@ -1565,111 +3959,272 @@ func init() {
 }
 
 var fileDescriptor_a88655da38237f58 = []byte{
-	// 1695 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcb, 0x6e, 0x1b, 0xbd,
-	0x15, 0x86, 0xae, 0x1e, 0x53, 0xbe, 0x85, 0xb9, 0x8d, 0x73, 0x69, 0x1c, 0xf5, 0x12, 0x37, 0x68,
-	0x6d, 0x20, 0xde, 0x14, 0x29, 0xba, 0x70, 0x1d, 0x1b, 0x56, 0x90, 0x04, 0x06, 0xa5, 0x06, 0xdd,
-	0x0d, 0xe8, 0x19, 0x4a, 0x62, 0x3c, 0x22, 0x27, 0x24, 0x25, 0x47, 0x5d, 0x66, 0x5d, 0x14, 0xe8,
-	0x1b, 0xf4, 0x15, 0xba, 0xec, 0xeb, 0xf4, 0x49, 0x7e, 0x9c, 0x43, 0xce, 0x48, 0x91, 0xf5, 0xef,
-	0xfe, 0xdd, 0x39, 0xdf, 0xf9, 0xc8, 0xe1, 0x39, 0x3c, 0x17, 0x0e, 0x39, 0xb6, 0x7a, 0x6a, 0x52,
-	0x71, 0x6c, 0x85, 0xb2, 0xda, 0x1c, 0xdb, 0xa9, 0x91, 0x29, 0x77, 0xfc, 0xb8, 0x30, 0xda, 0xe9,
-	0x4a, 0x4d, 0xc4, 0x4c, 0x1c, 0x21, 0x44, 0xb7, 0xc5, 0x44, 0x3a, 0xa7, 0x8f, 0xfc, 0x82, 0xee,
-	0x7f, 0x23, 0xd2, 0x38, 0xff, 0x7c, 0x4e, 0x9f, 0x91, 0x4d, 0x27, 0x27, 0xc2, 0x3a, 0x3e, 0x29,
-	0xe2, 0xda, 0x41, 0xed, 0x70, 0x93, 0x2d, 0x00, 0xfa, 0x9c, 0x10, 0x31, 0x13, 0xca, 0x25, 0x6e,
-	0x5e, 0x88, 0xb8, 0xee, 0xcd, 0x88, 0x0c, 0xe6, 0x85, 0xa0, 0x0f, 0x49, 0xdb, 0x9a, 0x34, 0x91,
-	0x45, 0xdc, 0x40, 0x53, 0xcb, 0x9a, 0xb4, 0x57, 0xd0, 0x7d, 0x12, 0x01, 0x5c, 0x68, 0xe3, 0xe2,
-	0xe6, 0x41, 0xed, 0xb0, 0xc5, 0x36, 0xac, 0x49, 0xaf, 0xb4, 0x71, 0xf4, 0x31, 0xd9, 0xc8, 0x84,
-	0x75, 0xb0, 0xa4, 0x85, 0x4b, 0xda, 0xa0, 0xf6, 0x0a, 0xfa, 0x94, 0x6c, 0xa2, 0x01, 0x17, 0xb5,
-	0x71, 0x51, 0x04, 0x00, 0xae, 0x7a, 0x40, 0x5a, 0xe8, 0x44, 0xbc, 0xe1, 0x3f, 0xe3, 0x3d, 0xda,
-	0x27, 0x51, 0x91, 0xf2, 0x22, 0x49, 0x95, 0x8b, 0x23, 0xff, 0x19, 0xd0, 0xcf, 0x94, 0x83, 0xdd,
-	0x78, 0x51, 0x24, 0x7e, 0xd1, 0x26, 0x2e, 0x8a, 0x78, 0x51, 0x5c, 0xe1, 0xba, 0x03, 0xb2, 0x55,
-	0x19, 0x13, 0x97, 0xc6, 0x04, 0xed, 0xa4, 0xb4, 0x0f, 0xd2, 0x15, 0x86, 0x8d, 0x3b, 0x2b, 0x0c,
-	0x0b, 0x7e, 0x0c, 0x73, 0x7d, 0x9b, 0xc8, 0x2c, 0xde, 0x3a, 0xa8, 0x1d, 0x36, 0x58, 0x1b, 0xd4,
-	0x5e, 0x46, 0x29, 0x69, 0xce, 0x72, 0xae, 0xe2, 0x6d, 0x3c, 0x10, 0xca, 0xf4, 0x3e, 0x69, 0xb9,
-	0x6f, 0x40, 0xdd, 0xf1, 0xa0, 0xfb, 0xd6, 0xcb, 0xe8, 0x23, 0xd2, 0x2e, 0x78, 0x7a, 0x23, 0x5c,
-	0xbc, 0xeb, 0x03, 0xe1, 0x35, 0x38, 0xba, 0x4c, 0x27, 0x85, 0x8f, 0xf8, 0x9e, 0x0f, 0x04, 0x00,
-	0x18, 0xf0, 0xd2, 0x98, 0xea, 0x4c, 0xc4, 0xf7, 0x16, 0xc6, 0x33, 0x9d, 0x09, 0xfa, 0x07, 0x42,
-	0x8d, 0xb0, 0x85, 0x56, 0x56, 0x24, 0x0b, 0x16, 0x45, 0xd6, 0x5e, 0x69, 0xe9, 0xfd, 0x2c, 0x1b,
-	0x3f, 0x78, 0xff, 0x2e, 0x1b, 0x3f, 0xfc, 0x8a, 0x34, 0x67, 0xdc, 0xd8, 0xf8, 0xc1, 0x41, 0xed,
-	0xb0, 0xf3, 0xe6, 0xfe, 0xd1, 0x0f, 0xc9, 0x74, 0xf4, 0x99, 0x1b, 0xcb, 0x90, 0x40, 0x5f, 0x93,
-	0x16, 0xcf, 0x85, 0x71, 0xf1, 0x43, 0x64, 0x3e, 0x58, 0x61, 0x9e, 0x82, 0x8d, 0x79, 0x0a, 0x6c,
-	0x3a, 0x76, 0xae, 0x88, 0x1f, 0xad, 0xdd, 0xf4, 0x72, 0x30, 0xb8, 0x62, 0x48, 0xa0, 0x27, 0x24,
-	0x1a, 0xca, 0x5c, 0x48, 0x35, 0xd4, 0xf1, 0x63, 0x24, 0x3f, 0x5e, 0x21, 0x5f, 0xc8, 0x5c, 0xf4,
-	0xd4, 0x50, 0xb3, 0x8a, 0x48, 0x7f, 0x43, 0x1a, 0x2e, 0x2d, 0xe2, 0x18, 0xf9, 0x74, 0x85, 0x3f,
-	0x38, 0xbb, 0x62, 0x60, 0x06, 0x56, 0xa6, 0x6c, 0xbc, 0xbf, 0x96, 0xf5, 0xee, 0x53, 0x9f, 0x81,
-	0x19, 0xf7, 0xca, 0x6d, 0xfc, 0x64, 0xfd, 0x5e, 0x1f, 0xfa, 0x0c, 0xcc, 0xe0, 0x0f, 0x64, 0x41,
-	0xfc, 0x74, 0xad, 0x3f, 0x17, 0xb9, 0xbe, 0x65, 0x48, 0xa0, 0x6f, 0x49, 0xc7, 0xdf, 0x76, 0x82,
-	0x2e, 0x3d, 0x43, 0xfe, 0xfe, 0x0a, 0xff, 0x0a, 0x19, 0xe8, 0x14, 0x29, 0x2a, 0x19, 0x8e, 0x62,
-	0xed, 0x38, 0x7e, 0xbe, 0xf6, 0x28, 0xfd, 0xfe, 0x25, 0x03, 0x33, 0x1c, 0xc5, 0x4e, 0x5c, 0x11,
-	0xff, 0x6a, 0xed, 0x51, 0xfa, 0x1f, 0x21, 0xb4, 0x40, 0x80, 0xfb, 0x12, 0x13, 0x2e, 0xf3, 0xf8,
-	0xc5, 0xda, 0xfb, 0x3a, 0x07, 0x1b, 0xf3, 0x94, 0xee, 0xf7, 0x1a, 0x69, 0xc2, 0x55, 0xd3, 0xbf,
-	0x90, 0x08, 0xfc, 0xb8, 0x96, 0xce, 0xc6, 0xb5, 0x83, 0xc6, 0x61, 0xe7, 0xcd, 0xcb, 0x35, 0x19,
-	0x81, 0x1e, 0x03, 0xe7, 0x5c, 0x39, 0x33, 0x67, 0xd5, 0x92, 0x27, 0x7f, 0x26, 0xdb, 0x3f, 0x98,
-	0xe8, 0x1e, 0x69, 0xdc, 0x88, 0x79, 0x68, 0x3f, 0x20, 0x42, 0xc5, 0xcf, 0x78, 0x3e, 0xf5, 0x3d,
-	0x27, 0x62, 0x5e, 0x79, 0x5b, 0xff, 0x53, 0xad, 0xfb, 0xaf, 0x3a, 0x69, 0x61, 0x16, 0x41, 0x05,
-	0xf1, 0xd4, 0x49, 0xad, 0xc2, 0xc2, 0xa0, 0xc1, 0x6e, 0x23, 0x99, 0xe1, 0xca, 0x16, 0x03, 0x91,
-	0xbe, 0x24, 0x5b, 0x56, 0x8e, 0x14, 0x77, 0x53, 0x23, 0xa0, 0x0e, 0x1b, 0x68, 0xea, 0x54, 0x58,
-	0x2f, 0x83, 0x45, 0x46, 0xcc, 0x42, 0xbb, 0x02, 0x11, 0x3a, 0x63, 0x45, 0x08, 0xcd, 0x6a, 0x01,
-	0xd0, 0x27, 0x24, 0x4a, 0xb9, 0x13, 0x23, 0x6d, 0xe6, 0xd8, 0xae, 0x36, 0x59, 0xa5, 0x83, 0xcd,
-	0x8a, 0x99, 0x30, 0xd2, 0xcd, 0xb1, 0x63, 0xb5, 0x58, 0xa5, 0x43, 0x05, 0x3b, 0xa1, 0xb8, 0x72,
-	0x70, 0x0e, 0xdf, 0xb5, 0x22, 0x0f, 0xf4, 0x32, 0xc8, 0xf3, 0x89, 0x70, 0x3c, 0xe3, 0x8e, 0x63,
-	0xd7, 0xba, 0x9b, 0xe7, 0x1f, 0x83, 0x99, 0x55, 0xc4, 0xee, 0x25, 0x89, 0x4a, 0x14, 0xfa, 0xf5,
-	0xb4, 0xc8, 0xb8, 0x13, 0x59, 0xc2, 0x1d, 0x5e, 0xcd, 0x26, 0xdb, 0x0c, 0xc8, 0xa9, 0x03, 0x73,
-	0x6a, 0x44, 0x69, 0xae, 0x7b, 0x73, 0x40, 0x4e, 0x5d, 0xf7, 0x7f, 0x0d, 0xd2, 0x84, 0xaa, 0x03,
-	0x07, 0xc6, 0xda, 0x3a, 0xc5, 0x27, 0x22, 0xc4, 0xb6, 0xd2, 0x21, 0x50, 0x53, 0x93, 0x87, 0x59,
-	0x00, 0x22, 0xfd, 0x1d, 0xd9, 0x85, 0x2a, 0x4d, 0xa6, 0x56, 0x98, 0x84, 0x8f, 0x84, 0x72, 0x61,
-	0x1c, 0x6c, 0x03, 0xfc, 0x37, 0x2b, 0xcc, 0x29, 0x80, 0xf4, 0x35, 0xb9, 0x87, 0xbc, 0x54, 0x2b,
-	0x57, 0xcd, 0x94, 0x26, 0x32, 0x71, 0x83, 0x33, 0x8f, 0x63, 0xbf, 0x79, 0x4e, 0x08, 0x72, 0x8d,
-	0x18, 0x0a, 0x53, 0x46, 0x1f, 0x10, 0x06, 0x00, 0x7d, 0x41, 0x3a, 0x68, 0x9e, 0x08, 0x37, 0xd6,
-	0x59, 0xb8, 0x00, 0x5c, 0xf1, 0x11, 0x11, 0xf0, 0x00, 0xbb, 0x77, 0xaa, 0xf3, 0x30, 0x34, 0x2a,
-	0x1d, 0xf2, 0xc6, 0x3a, 0xee, 0xa6, 0x36, 0xc4, 0x3f, 0x68, 0x80, 0xe7, 0x42, 0x8d, 0xdc, 0x18,
-	0x63, 0xdf, 0x62, 0x41, 0x83, 0xbd, 0x8c, 0xc8, 0xa4, 0x11, 0xa9, 0x0b, 0xb3, 0xa2, 0xd2, 0x21,
-	0x1a, 0xdf, 0x86, 0xc3, 0x30, 0x20, 0x40, 0xac, 0xbc, 0x34, 0xe2, 0xeb, 0x14, 0x06, 0xda, 0xb5,
-	0xce, 0xe6, 0x38, 0x23, 0x82, 0x97, 0xcc, 0xe3, 0x7f, 0xd5, 0xd9, 0x1c, 0x7a, 0x70, 0xe0, 0x86,
-	0x46, 0x8c, 0xe4, 0x6d, 0x24, 0xef, 0x79, 0xb2, 0x37, 0x20, 0xfb, 0x29, 0xc1, 0x08, 0xf8, 0x11,
-	0xe9, 0x47, 0x49, 0x04, 0x00, 0x8c, 0xc8, 0xee, 0xbf, 0x6b, 0x24, 0x2a, 0x9b, 0x20, 0x9c, 0x18,
-	0xda, 0xe0, 0xf2, 0xfd, 0x95, 0x3a, 0x54, 0x16, 0xf8, 0x5b, 0x4e, 0x73, 0xaf, 0xf8, 0x98, 0x68,
-	0x23, 0x7c, 0x6d, 0x44, 0x2c, 0x68, 0x30, 0xce, 0xac, 0xfc, 0x87, 0x08, 0x75, 0x81, 0xf2, 0x62,
-	0x9c, 0xb5, 0x96, 0xc6, 0x19, 0x25, 0xcd, 0x11, 0x2f, 0x2c, 0x5e, 0x45, 0xc4, 0x50, 0xee, 0xfe,
-	0xb3, 0x4e, 0x1a, 0x83, 0xb3, 0x2b, 0xcc, 0xf9, 0xb4, 0x48, 0x86, 0x39, 0x1f, 0xd9, 0xf2, 0x3c,
-	0x2e, 0x2d, 0x2e, 0x40, 0x87, 0x59, 0x5b, 0x19, 0x61, 0xd6, 0xfa, 0x63, 0x91, 0xd2, 0x3e, 0x58,
-	0x65, 0xa4, 0x21, 0xb9, 0x16, 0x8c, 0x14, 0x6e, 0xc1, 0xce, 0x15, 0x1e, 0x32, 0x62, 0x20, 0x62,
-	0x39, 0x5b, 0x87, 0x27, 0x8c, 0x18, 0x88, 0x80, 0x14, 0x76, 0x1c, 0xce, 0x07, 0x22, 0x20, 0x3c,
-	0xbd, 0xc1, 0xf4, 0x88, 0x18, 0x88, 0x80, 0x88, 0x54, 0x61, 0x5a, 0x44, 0x0c, 0x44, 0x40, 0xd2,
-	0x5b, 0x83, 0x09, 0x11, 0x31, 0x10, 0x01, 0x19, 0x4a, 0x85, 0x89, 0x10, 0x31, 0x10, 0x7d, 0x45,
-	0x8c, 0x30, 0x07, 0x22, 0xa8, 0x88, 0xd1, 0x22, 0xc6, 0x5b, 0x4b, 0x31, 0xee, 0xfe, 0xa7, 0x4e,
-	0x9a, 0xd0, 0xf7, 0xe8, 0xaf, 0xc9, 0x76, 0x71, 0xe3, 0x6c, 0xe2, 0xb4, 0x15, 0x66, 0x26, 0x0c,
-	0xc6, 0xa4, 0xc5, 0xb6, 0x00, 0x1c, 0x04, 0x6c, 0x89, 0x94, 0xe6, 0x12, 0x6a, 0xaa, 0xbe, 0x4c,
-	0xf2, 0x18, 0xfd, 0x2d, 0xd9, 0xb9, 0x9e, 0x3b, 0xb1, 0xb4, 0x95, 0x6f, 0x6d, 0xdb, 0x88, 0x56,
-	0x7b, 0x2d, 0xd1, 0xc2, 0x66, 0xcd, 0x1f, 0x68, 0x61, 0x37, 0x7f, 0x6c, 0xe3, 0x42, 0xbd, 0x79,
-	0x05, 0x83, 0xa2, 0xca, 0x1a, 0x03, 0x11, 0x03, 0x37, 0x12, 0xa1, 0xb5, 0x81, 0xb8, 0x70, 0x38,
-	0x5a, 0x49, 0x2a, 0x23, 0xb8, 0xd5, 0x2a, 0x3c, 0xc1, 0x82, 0x46, 0x63, 0xb2, 0x81, 0x0f, 0x00,
-	0x91, 0x85, 0x30, 0x96, 0x6a, 0xf7, 0x7b, 0x9d, 0x34, 0xde, 0x7d, 0xea, 0x43, 0x36, 0x61, 0x77,
-	0xf0, 0xc9, 0x82, 0x32, 0xdd, 0x21, 0xf5, 0xaa, 0xab, 0xd7, 0x25, 0x3e, 0xa0, 0x8c, 0xc1, 0x14,
-	0x6f, 0x84, 0xdd, 0x51, 0xf3, 0xf8, 0x52, 0x6f, 0x09, 0x1a, 0x9c, 0xd1, 0x60, 0x67, 0x0d, 0xde,
-	0xa1, 0x82, 0x28, 0xbe, 0x93, 0xc2, 0xc9, 0x51, 0x01, 0x0f, 0x9d, 0xcb, 0xc3, 0x3b, 0x14, 0xc4,
-	0x45, 0xd2, 0x6f, 0x2c, 0x25, 0xfd, 0x0e, 0xa9, 0x73, 0x1e, 0x92, 0xa3, 0xce, 0x39, 0xe8, 0x5f,
-	0x4d, 0xf0, 0xa9, 0xfe, 0xd5, 0x80, 0x6e, 0xb2, 0x90, 0x18, 0x75, 0x83, 0x7c, 0xc3, 0x31, 0x29,
-	0x40, 0xc7, 0x8f, 0xfb, 0xa2, 0xf0, 0x25, 0xef, 0x95, 0xee, 0xff, 0xa1, 0x6c, 0x3e, 0xf4, 0x21,
-	0x4c, 0x76, 0x7a, 0xfd, 0x05, 0xda, 0x8e, 0x8f, 0x43, 0xa9, 0x42, 0x7d, 0x4b, 0x6b, 0xa7, 0xc2,
-	0x64, 0x2a, 0xd4, 0x4b, 0xa5, 0xd3, 0x57, 0x64, 0xd7, 0x0a, 0x6b, 0xa5, 0x56, 0xd0, 0x56, 0xa6,
-	0x93, 0xaa, 0xa4, 0x77, 0x02, 0xcc, 0x3c, 0x8a, 0x25, 0x2f, 0x8c, 0xe4, 0x79, 0x19, 0x27, 0xaf,
-	0xd1, 0x03, 0xd2, 0x19, 0x4a, 0x35, 0x12, 0xa6, 0x30, 0x52, 0x95, 0xb9, 0xb0, 0x0c, 0x61, 0xb9,
-	0x29, 0x59, 0x66, 0x84, 0x55, 0x12, 0x8e, 0x3a, 0x13, 0x06, 0x76, 0x0f, 0xdd, 0xb6, 0x54, 0x61,
-	0x8a, 0x2a, 0xed, 0xae, 0xc5, 0x50, 0x9b, 0x32, 0xc6, 0x0b, 0x00, 0x1c, 0x51, 0xda, 0xf1, 0xa1,
-	0x13, 0xa6, 0x7c, 0xa6, 0x97, 0x3a, 0x9c, 0x23, 0x15, 0xc6, 0xc9, 0x21, 0xfc, 0xc9, 0x88, 0xd0,
-	0x79, 0x97, 0x21, 0x08, 0x5f, 0x3a, 0xe6, 0x52, 0x85, 0xf6, 0xeb, 0x15, 0x78, 0x20, 0x7d, 0xe1,
-	0x27, 0x18, 0xe5, 0xbb, 0x0f, 0xa4, 0xf7, 0xa7, 0x27, 0x0c, 0xcc, 0xdd, 0x73, 0xd2, 0x78, 0x7f,
-	0x7a, 0x02, 0x89, 0x36, 0xe6, 0x76, 0x5c, 0x26, 0x1a, 0xc8, 0x80, 0x61, 0x9e, 0xf8, 0xc8, 0xa2,
-	0xec, 0xfb, 0xa3, 0x91, 0x6a, 0x54, 0x26, 0x9b, 0xd7, 0xba, 0x87, 0x84, 0x2c, 0xde, 0x69, 0xe0,
-	0x4e, 0x2e, 0xd5, 0x4d, 0x95, 0xba, 0x2d, 0x56, 0xe9, 0xdd, 0x94, 0x34, 0xfa, 0xfd, 0x4b, 0xfa,
-	0x47, 0x8c, 0x7a, 0x59, 0xf4, 0x9d, 0x37, 0x0f, 0x57, 0x9f, 0x66, 0x68, 0x64, 0x81, 0x04, 0xf4,
-	0xa5, 0xf2, 0xbf, 0x4b, 0x3f, 0x43, 0x23, 0x0b, 0xa4, 0xee, 0xdf, 0x49, 0xdb, 0x23, 0xd8, 0x3e,
-	0xf0, 0xf7, 0xa5, 0xbc, 0x17, 0xef, 0xe1, 0x16, 0x82, 0x9f, 0xc3, 0xe5, 0xfc, 0x9e, 0xec, 0x59,
-	0x3d, 0x74, 0xb7, 0xdc, 0x88, 0x8a, 0xe7, 0xbd, 0xde, 0x2d, 0xf1, 0x40, 0x85, 0x9d, 0xfb, 0x8b,
-	0xc6, 0xf4, 0x4b, 0xee, 0x7c, 0x45, 0x9a, 0xf0, 0x1e, 0xc5, 0xab, 0x10, 0xb9, 0xae, 0xae, 0x42,
-	0xe4, 0x1a, 0x26, 0x07, 0xbc, 0x3c, 0x93, 0xa1, 0xd1, 0x93, 0x32, 0xd3, 0x01, 0xb8, 0x30, 0x7a,
-	0x02, 0xff, 0x60, 0x26, 0x2d, 0x5c, 0xe2, 0x74, 0xdc, 0xc0, 0xa7, 0x4c, 0x1b, 0xd4, 0x81, 0xee,
-	0xbe, 0x20, 0x2d, 0x7c, 0xb7, 0x2e, 0x4d, 0xfa, 0x5a, 0x79, 0x6b, 0xa0, 0x5d, 0xb7, 0xf1, 0xac,
-	0x27, 0x3f, 0x05, 0x00, 0x00, 0xff, 0xff, 0x04, 0x4d, 0xde, 0x0a, 0x45, 0x0f, 0x00, 0x00,
+	// 4271 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x8f, 0x1c, 0x49,
+	0x56, 0x57, 0x55, 0x56, 0x75, 0x55, 0x45, 0x57, 0x7f, 0x38, 0xe7, 0x2b, 0xc7, 0x33, 0xde, 0xe9,
+	0xa9, 0xf9, 0xea, 0x99, 0x9d, 0xf1, 0x78, 0xed, 0xb1, 0x97, 0x1d, 0x04, 0xc2, 0xee, 0xb6, 0xc7,
+	0xbd, 0xeb, 0x9e, 0xe9, 0xcd, 0x6a, 0x1b, 0xf6, 0x00, 0xa9, 0xe8, 0xcc, 0xa8, 0xaa, 0x9c, 0xce,
+	0x2f, 0x47, 0x44, 0x75, 0x77, 0xed, 0x0d, 0x4e, 0x08, 0x21, 0x21, 0x21, 0x24, 0xb8, 0x70, 0xe1,
+	0xc0, 0x91, 0x0b, 0x12, 0xff, 0x04, 0x12, 0x07, 0x24, 0xe0, 0xc2, 0x3f, 0x01, 0x57, 0x2e, 0xe8,
+	0x7d, 0x44, 0x56, 0x76, 0xbb, 0xc6, 0x5e, 0x24, 0x6e, 0xf1, 0x7e, 0xef, 0x17, 0x91, 0x11, 0x2f,
+	0x22, 0x5e, 0xbc, 0x78, 0x91, 0xe2, 0x4b, 0x53, 0xce, 0x75, 0xac, 0xbe, 0x34, 0xaa, 0x30, 0xa5,
+	0xfe, 0xd2, 0xcc, 0x75, 0x1a, 0x4b, 0x2b, 0xbf, 0xac, 0x74, 0x69, 0xcb, 0x5a, 0x8c, 0xd4, 0x99,
+	0xba, 0x89, 0x90, 0xbf, 0xa1, 0xf2, 0xd4, 0xda, 0xf2, 0x26, 0x55, 0x18, 0xfd, 0xc7, 0x50, 0x78,
+	0x0f, 0x9f, 0x3d, 0xf4, 0xdf, 0x15, 0x03, 0x9b, 0xe6, 0xca, 0x58, 0x99, 0x57, 0x41, 0x6b, 0xa7,
+	0xb5, 0x3b, 0x08, 0x97, 0x80, 0x7f, 0x43, 0x08, 0x75, 0xa6, 0x0a, 0x1b, 0xd9, 0x45, 0xa5, 0x82,
+	0x36, 0xa9, 0x11, 0x39, 0x5e, 0x54, 0xca, 0x7f, 0x43, 0xac, 0x19, 0x1d, 0x47, 0x69, 0x15, 0x78,
+	0xa8, 0xea, 0x1a, 0x1d, 0x1f, 0x54, 0xfe, 0xdb, 0xa2, 0x0f, 0x70, 0x55, 0x6a, 0x1b, 0x74, 0x76,
+	0x5a, 0xbb, 0xdd, 0xb0, 0x67, 0x74, 0x7c, 0x54, 0x6a, 0xeb, 0xbf, 0x25, 0x7a, 0x89, 0x32, 0x16,
+	0xaa, 0x74, 0xb1, 0xca, 0x1a, 0x88, 0x07, 0x95, 0xff, 0x8e, 0x18, 0xa0, 0x02, 0x2b, 0xad, 0x61,
+	0xa5, 0x3e, 0x00, 0x58, 0xeb, 0x75, 0xd1, 0xc5, 0x41, 0x04, 0x3d, 0xfa, 0x0c, 0x8d, 0xe8, 0x6d,
+	0xd1, 0xaf, 0x62, 0x59, 0x45, 0x71, 0x61, 0x83, 0x3e, 0x7d, 0x06, 0xe4, 0xbd, 0xc2, 0x42, 0x6b,
+	0xb2, 0xaa, 0x22, 0xaa, 0x34, 0xc0, 0x4a, 0x7d, 0x59, 0x55, 0x47, 0x58, 0x6f, 0x47, 0x0c, 0x6b,
+	0x65, 0x64, 0xe3, 0x40, 0xa0, 0x5e, 0x38, 0xfd, 0x71, 0x7c, 0x85, 0x61, 0x82, 0xf5, 0x2b, 0x0c,
+	0x03, 0xe3, 0x98, 0x64, 0xe5, 0x79, 0x94, 0x26, 0xc1, 0x70, 0xa7, 0xb5, 0xeb, 0x85, 0x6b, 0x20,
+	0x1e, 0x24, 0xbe, 0x2f, 0x3a, 0x67, 0x99, 0x2c, 0x82, 0x8d, 0x1d, 0x6f, 0xb7, 0x1b, 0x62, 0xd9,
+	0x7f, 0x4d, 0x74, 0xed, 0x05, 0x50, 0x37, 0xb1, 0x97, 0x1d, 0x7b, 0x71, 0x90, 0xf8, 0x6f, 0x8a,
+	0xb5, 0x4a, 0xc6, 0xa7, 0xca, 0x06, 0x5b, 0x64, 0x08, 0x92, 0xa0, 0xeb, 0x69, 0x9c, 0x57, 0x64,
+	0xf1, 0x6d, 0x32, 0x04, 0x00, 0x68, 0x70, 0xa7, 0x8c, 0xcb, 0x44, 0x05, 0xd7, 0x96, 0xca, 0xbd,
+	0x32, 0x51, 0xfe, 0xe7, 0xc2, 0xd7, 0xca, 0x54, 0x65, 0x61, 0x54, 0xb4, 0x64, 0xf9, 0xc8, 0xda,
+	0x76, 0x9a, 0x83, 0x1f, 0x64, 0xe3, 0x07, 0x5f, 0x7b, 0x91, 0x8d, 0x1f, 0xfe, 0x44, 0x74, 0xce,
+	0xa4, 0x36, 0xc1, 0xeb, 0x3b, 0xad, 0xdd, 0xf5, 0xdb, 0xaf, 0xdd, 0xbc, 0xb4, 0x98, 0x6e, 0x3e,
+	0x93, 0xda, 0x84, 0x48, 0xf0, 0x3f, 0x13, 0x5d, 0x99, 0x29, 0x6d, 0x83, 0x37, 0x90, 0xf9, 0xfa,
+	0x15, 0xe6, 0x7d, 0xd0, 0x85, 0x44, 0x81, 0x46, 0x67, 0xd6, 0x56, 0xc1, 0x9b, 0x2b, 0x1b, 0x7d,
+	0x7c, 0x7c, 0x7c, 0x14, 0x22, 0xc1, 0xbf, 0x23, 0xfa, 0x93, 0x34, 0x53, 0x69, 0x31, 0x29, 0x83,
+	0xb7, 0x90, 0xfc, 0xd6, 0x15, 0xf2, 0xa3, 0x34, 0x53, 0x07, 0xc5, 0xa4, 0x0c, 0x6b, 0xa2, 0xff,
+	0xa1, 0xf0, 0x6c, 0x5c, 0x05, 0x01, 0xf2, 0xfd, 0x2b, 0xfc, 0xe3, 0xbd, 0xa3, 0x10, 0xd4, 0xc0,
+	0x4a, 0x0a, 0x13, 0xbc, 0xbd, 0x92, 0xb5, 0xff, 0xed, 0x38, 0x04, 0x35, 0xb6, 0x95, 0x99, 0xe0,
+	0xfa, 0xea, 0xb6, 0x9e, 0x8c, 0x43, 0x50, 0xc3, 0x78, 0x60, 0x15, 0x04, 0xef, 0xac, 0x1c, 0xcf,
+	0xa3, 0xac, 0x3c, 0x0f, 0x91, 0xe0, 0x7f, 0x2d, 0xd6, 0x69, 0xb6, 0x23, 0x1c, 0xd2, 0xbb, 0xc8,
+	0x7f, 0xfb, 0x0a, 0xff, 0x08, 0x19, 0x38, 0x28, 0x51, 0xd5, 0x65, 0xe8, 0x8a, 0x31, 0xb3, 0xe0,
+	0xc6, 0xca, 0xae, 0x8c, 0xc7, 0x8f, 0x43, 0x50, 0x43, 0x57, 0x4c, 0x6e, 0xab, 0xe0, 0x47, 0x2b,
+	0xbb, 0x32, 0x3e, 0x04, 0xd3, 0x02, 0x01, 0xe6, 0x4b, 0xe5, 0x32, 0xcd, 0x82, 0xf7, 0x56, 0xce,
+	0xd7, 0x43, 0xd0, 0x85, 0x44, 0x81, 0x0d, 0x97, 0x16, 0x51, 0x3a, 0x91, 0xb1, 0x0a, 0x76, 0x70,
+	0xd1, 0xf6, 0xd2, 0xe2, 0x00, 0x44, 0xff, 0x7d, 0x31, 0x8c, 0xcb, 0x3c, 0x9f, 0x17, 0xa9, 0x5d,
+	0xc0, 0x4a, 0x7f, 0x1f, 0xd5, 0xeb, 0x35, 0x46, 0x3b, 0x63, 0x56, 0x1a, 0x1b, 0x8c, 0x50, 0x85,
+	0x65, 0x58, 0xcf, 0x95, 0xd4, 0xe0, 0x60, 0xd2, 0x24, 0xf8, 0x00, 0x37, 0x52, 0x9f, 0x80, 0x83,
+	0x04, 0x5c, 0x53, 0x92, 0x6a, 0x15, 0xdb, 0xb4, 0x2c, 0x82, 0x0f, 0xc9, 0xf7, 0xd4, 0x00, 0xb8,
+	0x26, 0x7b, 0x11, 0x4d, 0xe7, 0xca, 0x18, 0x95, 0x04, 0x1f, 0xed, 0xb4, 0x76, 0xfb, 0xe1, 0xc0,
+	0x5e, 0x7c, 0x43, 0x80, 0x7f, 0x4b, 0xf4, 0x64, 0x51, 0xe6, 0x32, 0x5b, 0x04, 0x1f, 0xe3, 0xc8,
+	0xde, 0xbc, 0xba, 0x12, 0x49, 0x1b, 0x3a, 0x1a, 0x98, 0x2c, 0xd1, 0x65, 0x15, 0x7c, 0xb2, 0xd2,
+	0x64, 0xfb, 0xba, 0xac, 0x42, 0x24, 0x80, 0xc9, 0x8c, 0x95, 0xd6, 0x04, 0xbb, 0x2b, 0x4d, 0x36,
+	0x06, 0x5d, 0x48, 0x14, 0x9c, 0xad, 0xfc, 0x24, 0xf8, 0x74, 0xf5, 0x6c, 0x1d, 0x3e, 0x08, 0x41,
+	0x0d, 0x9f, 0x3e, 0xd5, 0x27, 0x77, 0x83, 0xcf, 0x56, 0x7e, 0xfa, 0x17, 0xe1, 0x83, 0xbb, 0x21,
+	0x12, 0xb0, 0x8f, 0xb3, 0xb8, 0x0a, 0x7e, 0xbc, 0xba, 0x8f, 0x8f, 0xf7, 0x8e, 0x42, 0x24, 0x40,
+	0x1f, 0x61, 0xe7, 0xdc, 0x0e, 0x3e, 0x5f, 0xd9, 0x47, 0xd8, 0x5b, 0xb7, 0x43, 0xa2, 0x40, 0xa3,
+	0xcf, 0xe7, 0x69, 0x1c, 0x7c, 0xb1, 0xb2, 0xd1, 0x5f, 0x3e, 0x3d, 0xd8, 0x0b, 0x91, 0x00, 0x83,
+	0xd1, 0x49, 0x15, 0xdc, 0x5c, 0x39, 0x98, 0x70, 0xff, 0x28, 0x04, 0xf5, 0xe8, 0x4f, 0x5a, 0xa2,
+	0x03, 0x0e, 0xc1, 0xff, 0x1d, 0xd1, 0x87, 0xd5, 0x7e, 0x92, 0x5a, 0x13, 0xb4, 0x76, 0xbc, 0xdd,
+	0xf5, 0xdb, 0xef, 0xaf, 0xf0, 0x1b, 0x37, 0x1f, 0x31, 0xe7, 0x61, 0x61, 0xf5, 0x22, 0xac, 0xab,
+	0x5c, 0xff, 0x6d, 0xb1, 0x71, 0x49, 0xe5, 0x6f, 0x0b, 0xef, 0x54, 0x2d, 0xf8, 0x90, 0x82, 0x22,
+	0x9c, 0x0b, 0x67, 0x32, 0x9b, 0xd3, 0xc9, 0xd4, 0x0f, 0x49, 0xf8, 0xba, 0xfd, 0x5b, 0xad, 0xd1,
+	0xdf, 0xb5, 0x45, 0x17, 0x7d, 0x0d, 0xf8, 0x59, 0x49, 0x4b, 0x88, 0x2a, 0xb2, 0x04, 0xad, 0x4d,
+	0xd3, 0x04, 0x6b, 0x76, 0x43, 0x28, 0xc2, 0x1a, 0x36, 0xe9, 0xb4, 0x90, 0x76, 0xae, 0x15, 0xac,
+	0x47, 0x0f, 0x55, 0xeb, 0x35, 0x76, 0x90, 0x40, 0x25, 0xad, 0xce, 0xf8, 0x50, 0x83, 0x22, 0x2c,
+	0xd2, 0x9a, 0xc0, 0x47, 0xda, 0x12, 0xf0, 0xaf, 0x8b, 0x7e, 0x2c, 0xad, 0x9a, 0x96, 0x7a, 0x81,
+	0x87, 0xda, 0x20, 0xac, 0x65, 0xd0, 0x19, 0x75, 0xa6, 0x74, 0x6a, 0x17, 0x78, 0xae, 0x75, 0xc3,
+	0x5a, 0x86, 0x7d, 0x61, 0x55, 0x21, 0x69, 0x5f, 0xd0, 0xd9, 0xd6, 0x27, 0xe0, 0x20, 0x01, 0x6f,
+	0x98, 0x2b, 0x2b, 0x13, 0x69, 0x65, 0x30, 0x58, 0xe9, 0x0d, 0x0f, 0x59, 0x1d, 0xd6, 0x44, 0xd8,
+	0x7d, 0x7a, 0x9e, 0x29, 0x3e, 0xec, 0xb0, 0x3c, 0xfa, 0x1b, 0x4f, 0xf4, 0x1d, 0x15, 0xf6, 0xd3,
+	0xbc, 0x4a, 0xa4, 0x55, 0x49, 0x24, 0x2d, 0xce, 0xd7, 0x20, 0x1c, 0x30, 0x72, 0xdf, 0x82, 0x3a,
+	0xd6, 0xca, 0xa9, 0xdb, 0xa4, 0x66, 0xe4, 0xbe, 0xf5, 0xbf, 0x10, 0xfe, 0xd2, 0x76, 0xf5, 0xb0,
+	0x3c, 0xa4, 0x5d, 0xab, 0x35, 0x63, 0x37, 0xbe, 0x0f, 0xc4, 0x86, 0xb4, 0x56, 0xc6, 0xa7, 0x91,
+	0x95, 0x7a, 0xaa, 0x20, 0x4c, 0x00, 0xe6, 0x90, 0xc0, 0x63, 0xc4, 0xfc, 0x1f, 0x09, 0x91, 0xa8,
+	0x2a, 0x2b, 0x17, 0xb9, 0x2a, 0x6c, 0xd0, 0x45, 0x46, 0x03, 0xf1, 0x3f, 0x11, 0x5b, 0x93, 0x52,
+	0xe7, 0x4a, 0x47, 0x0d, 0x1b, 0x03, 0x69, 0x93, 0xe0, 0x3d, 0x67, 0xe9, 0x4f, 0xc5, 0xb6, 0x9c,
+	0x4c, 0x54, 0x0c, 0x9d, 0xaf, 0x74, 0x99, 0xcc, 0x63, 0x1b, 0xf4, 0x90, 0xb9, 0xe5, 0xf0, 0x23,
+	0x82, 0xfd, 0x8f, 0xc4, 0x66, 0x2e, 0xb3, 0x73, 0xa9, 0x55, 0x34, 0x91, 0x79, 0x9a, 0x2d, 0x82,
+	0x3e, 0x12, 0x37, 0x18, 0x7d, 0x84, 0x20, 0x0c, 0xb7, 0x52, 0x1a, 0x3e, 0x23, 0x8b, 0x58, 0x45,
+	0x69, 0x5e, 0xc9, 0xd8, 0x06, 0x03, 0x1a, 0x6e, 0x43, 0x73, 0x80, 0x0a, 0x58, 0x36, 0x56, 0x4e,
+	0x03, 0x81, 0x7a, 0x28, 0xc2, 0xd8, 0xe2, 0xb2, 0x98, 0xa4, 0x89, 0x2a, 0x62, 0x15, 0xac, 0xd3,
+	0xd8, 0x96, 0xc8, 0xe8, 0x8f, 0xbb, 0xa2, 0x03, 0x9b, 0x14, 0x56, 0x09, 0x78, 0xca, 0x42, 0xe6,
+	0x8a, 0x17, 0x70, 0x2d, 0x43, 0xb3, 0x73, 0x9d, 0x71, 0x58, 0x06, 0x45, 0xff, 0x63, 0xb1, 0x05,
+	0x7b, 0x3a, 0x9a, 0x1b, 0xa5, 0x23, 0x39, 0x05, 0xbb, 0x51, 0x64, 0xb6, 0x01, 0xf0, 0x53, 0xa3,
+	0xf4, 0x7d, 0x00, 0xfd, 0xcf, 0xc4, 0x35, 0xe4, 0xc5, 0x65, 0x61, 0xeb, 0xf0, 0xae, 0x83, 0x4c,
+	0x6c, 0x60, 0x8f, 0x70, 0x3c, 0xfa, 0x6f, 0x08, 0x81, 0x5c, 0xad, 0x26, 0x4a, 0xbb, 0x25, 0x0e,
+	0x48, 0x08, 0x80, 0xff, 0x9e, 0x58, 0x47, 0x75, 0xae, 0xec, 0xac, 0x4c, 0x78, 0x95, 0x63, 0x8d,
+	0x43, 0x44, 0x60, 0x04, 0x18, 0x48, 0xc5, 0x65, 0xc6, 0xf1, 0x5b, 0x2d, 0xc3, 0xe6, 0x04, 0x3f,
+	0x39, 0x37, 0xbc, 0xc8, 0x59, 0x02, 0x3c, 0x53, 0xc5, 0xd4, 0xce, 0x70, 0x81, 0x77, 0x43, 0x96,
+	0xa0, 0x2d, 0xad, 0xe8, 0x0c, 0xe0, 0x95, 0x5c, 0xcb, 0x60, 0x8d, 0x8b, 0xc9, 0x84, 0x63, 0x35,
+	0x28, 0xd6, 0xa3, 0xd4, 0xea, 0xf9, 0x1c, 0x62, 0xcb, 0x93, 0x32, 0x59, 0x04, 0xc3, 0xe5, 0x28,
+	0x43, 0xc2, 0x1f, 0x94, 0xc9, 0x02, 0xc2, 0x21, 0xe6, 0x72, 0x4c, 0x84, 0xe4, 0x0d, 0x24, 0x6f,
+	0x13, 0x99, 0x14, 0xc8, 0x7e, 0x47, 0xa0, 0x05, 0x28, 0x5a, 0xa5, 0xa8, 0xae, 0x0f, 0x00, 0x46,
+	0xab, 0x81, 0xe8, 0x9d, 0x29, 0x6d, 0xc0, 0xe5, 0x50, 0x68, 0xe7, 0x44, 0xff, 0x77, 0xc5, 0x96,
+	0xeb, 0xcb, 0x4c, 0xc9, 0x44, 0x69, 0x13, 0x6c, 0xa3, 0x63, 0x7c, 0xe3, 0xaa, 0x7f, 0x46, 0x6d,
+	0xb8, 0xc9, 0x6c, 0x12, 0x8d, 0xff, 0x7b, 0xa2, 0x8e, 0xcc, 0xea, 0x06, 0xae, 0xbd, 0xac, 0x81,
+	0x2d, 0x47, 0x77, 0x2d, 0xd4, 0xe7, 0x82, 0xff, 0xca, 0x73, 0x61, 0xf4, 0x47, 0x62, 0x8d, 0xaa,
+	0x81, 0xf3, 0x68, 0x2c, 0x40, 0x2c, 0x5f, 0xf6, 0xbd, 0x03, 0xf6, 0xbd, 0x60, 0x72, 0x2b, 0x4f,
+	0x32, 0x15, 0x99, 0xf4, 0xd7, 0x2a, 0x22, 0xf7, 0xc1, 0x8e, 0x74, 0x0b, 0x15, 0xe3, 0xf4, 0xd7,
+	0xea, 0x29, 0xc2, 0xa3, 0xbf, 0x6a, 0x89, 0x2e, 0x7e, 0x10, 0xcc, 0x69, 0xac, 0x56, 0x32, 0x07,
+	0x77, 0xd7, 0xa2, 0x30, 0x80, 0x80, 0x83, 0xc4, 0xbf, 0x2b, 0x7a, 0x6c, 0x06, 0xfc, 0xd4, 0xfa,
+	0xed, 0x77, 0x56, 0x75, 0xfa, 0x50, 0x19, 0x23, 0xa7, 0x2a, 0x74, 0x5c, 0xff, 0xa7, 0xa2, 0xef,
+	0x06, 0x1f, 0x78, 0xaf, 0xae, 0x57, 0x93, 0x47, 0xff, 0xdc, 0x12, 0xc3, 0xa6, 0x0a, 0x5a, 0x32,
+	0xca, 0xda, 0xb4, 0x98, 0xba, 0x73, 0x6c, 0x65, 0x4b, 0x63, 0xe2, 0x84, 0x35, 0xd9, 0xff, 0x52,
+	0xf4, 0xdc, 0x2c, 0xb5, 0x5f, 0x36, 0x4b, 0x8e, 0x85, 0xd7, 0x2d, 0xad, 0x4b, 0x4d, 0x91, 0xbb,
+	0xc7, 0xd7, 0x2d, 0x40, 0x30, 0x64, 0xc7, 0x9d, 0x94, 0x96, 0xe8, 0x5a, 0xe9, 0x08, 0xaa, 0x65,
+	0xd8, 0x31, 0xe7, 0x69, 0x91, 0x94, 0xe7, 0xb8, 0x43, 0xbd, 0x90, 0xa5, 0xd1, 0x33, 0x31, 0x6c,
+	0xf6, 0x0e, 0xb6, 0xab, 0xeb, 0x9f, 0x33, 0xf6, 0x20, 0x14, 0x0e, 0x3a, 0x48, 0xc0, 0x03, 0xd6,
+	0x84, 0xe5, 0x04, 0x7b, 0xe1, 0x86, 0x43, 0x9f, 0x01, 0x38, 0xfa, 0xd7, 0xb6, 0xe8, 0xbb, 0xa0,
+	0x1b, 0x3a, 0x06, 0x61, 0x77, 0xd3, 0x49, 0x39, 0x19, 0xd6, 0x09, 0x6c, 0xea, 0x7a, 0x9d, 0xa0,
+	0x40, 0x1b, 0xbf, 0xd4, 0x8a, 0x4e, 0xd9, 0x7e, 0xc8, 0x12, 0xac, 0x34, 0x58, 0x39, 0x38, 0x3c,
+	0x2f, 0xc4, 0xf2, 0xf2, 0xfa, 0xd4, 0x6d, 0x5c, 0x9f, 0x7c, 0xd1, 0x99, 0xca, 0xca, 0xa0, 0xbf,
+	0xe9, 0x87, 0x58, 0x06, 0x0f, 0x90, 0x27, 0x77, 0xd9, 0xc9, 0x40, 0x11, 0x9b, 0x9b, 0xc9, 0x9f,
+	0xa0, 0x77, 0x19, 0x84, 0x58, 0xc6, 0x4f, 0xcf, 0xe4, 0xed, 0xbb, 0xf7, 0xf8, 0x62, 0xc8, 0x12,
+	0x74, 0x34, 0x97, 0xd3, 0xd4, 0xdd, 0x07, 0x49, 0xc0, 0x8b, 0x5e, 0x9a, 0x61, 0x3c, 0xb0, 0xce,
+	0x17, 0xbd, 0x34, 0xe3, 0x50, 0xc0, 0xe0, 0xed, 0xcf, 0xdb, 0xf5, 0x42, 0x28, 0xf2, 0x48, 0xb5,
+	0x45, 0xaf, 0xe1, 0x85, 0x24, 0x00, 0x4f, 0x15, 0x74, 0xf5, 0xf3, 0x42, 0x28, 0x82, 0x7f, 0x80,
+	0xd1, 0xa6, 0xc5, 0x14, 0xfd, 0x43, 0x3f, 0x74, 0xe2, 0xe8, 0xcf, 0xdb, 0xc2, 0x3b, 0xde, 0x3b,
+	0xc2, 0xe3, 0x3f, 0xae, 0xa2, 0x49, 0x26, 0x71, 0xc9, 0xa1, 0x41, 0x6d, 0x5c, 0x3d, 0x02, 0x19,
+	0x2e, 0xa7, 0xb5, 0x12, 0x2e, 0xa7, 0x64, 0x57, 0xe1, 0xf4, 0xc7, 0x57, 0x19, 0x71, 0xe0, 0x5d,
+	0x61, 0xc4, 0xd8, 0xf9, 0x45, 0x81, 0x56, 0xee, 0x87, 0x50, 0x04, 0x44, 0x1b, 0x8b, 0x26, 0xee,
+	0x87, 0x50, 0x04, 0xa4, 0x32, 0x33, 0x36, 0x30, 0x14, 0x01, 0x91, 0xf1, 0x29, 0xda, 0xb7, 0x1f,
+	0x42, 0x11, 0x07, 0x17, 0x17, 0x68, 0xde, 0x7e, 0x08, 0x45, 0x40, 0xe2, 0x73, 0x8d, 0xa6, 0xed,
+	0x87, 0x50, 0x04, 0x64, 0x92, 0x16, 0x68, 0xd5, 0x7e, 0x08, 0x45, 0x3a, 0xb7, 0xa6, 0x68, 0xcf,
+	0x3e, 0x9c, 0x5b, 0xd3, 0xe5, 0x22, 0x19, 0x36, 0x16, 0xc9, 0xe8, 0xdf, 0xdb, 0xa2, 0x03, 0x21,
+	0x20, 0x84, 0x0b, 0xd5, 0xa9, 0x35, 0x91, 0x2d, 0x8d, 0xd2, 0x67, 0x4a, 0xb3, 0x8f, 0x18, 0x02,
+	0x78, 0xcc, 0x58, 0x83, 0x14, 0x67, 0x29, 0x9c, 0x7c, 0xed, 0x26, 0x89, 0x30, 0x58, 0xdd, 0x27,
+	0x0b, 0xab, 0x1a, 0x4d, 0x79, 0xb4, 0xba, 0x11, 0xad, 0xdb, 0x6a, 0xd0, 0xb8, 0xb1, 0xce, 0x25,
+	0x1a, 0xb7, 0x56, 0xcf, 0x78, 0xb7, 0xee, 0xf6, 0x72, 0xc6, 0xe9, 0x24, 0x84, 0x22, 0x1a, 0x6e,
+	0xaa, 0xd0, 0x70, 0x5e, 0x08, 0xc5, 0xe5, 0x80, 0xfb, 0x57, 0x76, 0x85, 0x56, 0xd2, 0x94, 0x85,
+	0x5b, 0x9a, 0x24, 0xc1, 0x8a, 0xc1, 0x1b, 0xb3, 0x4a, 0xd8, 0x8c, 0x4e, 0x84, 0x1a, 0x27, 0x8b,
+	0x4a, 0x1a, 0x97, 0xa3, 0x60, 0x09, 0xc2, 0x52, 0x95, 0x2b, 0x3d, 0x55, 0x45, 0x4c, 0x47, 0x5e,
+	0x3f, 0x5c, 0x02, 0xa3, 0xbf, 0xf5, 0x84, 0xb7, 0xff, 0xed, 0x18, 0xb6, 0x07, 0x9e, 0xfc, 0xec,
+	0xd7, 0xa1, 0xec, 0x6f, 0x8a, 0x76, 0x1d, 0x16, 0xb7, 0x53, 0xfc, 0x82, 0xd6, 0xb8, 0xb3, 0x3d,
+	0xee, 0x13, 0x4a, 0x84, 0x37, 0xe2, 0x06, 0x96, 0x60, 0x64, 0x1a, 0x43, 0x53, 0xb6, 0x09, 0x0a,
+	0x88, 0xa2, 0x53, 0xe3, 0xf1, 0xa2, 0x80, 0x71, 0x91, 0xcd, 0x38, 0xdd, 0x03, 0xc5, 0xe5, 0x5e,
+	0xef, 0x35, 0xf6, 0xfa, 0xa6, 0x68, 0x4b, 0xc9, 0x4b, 0xaa, 0x2d, 0x25, 0xc8, 0xcf, 0x35, 0x5b,
+	0xa2, 0xfd, 0x5c, 0x83, 0xac, 0x13, 0x5e, 0x4e, 0x6d, 0x8d, 0x7c, 0x2d, 0x79, 0xd4, 0x6d, 0x8d,
+	0x1f, 0xa7, 0xad, 0x44, 0xc7, 0x39, 0x09, 0xcd, 0x63, 0x9a, 0x4e, 0x70, 0x27, 0xc2, 0xd0, 0xca,
+	0x0a, 0x7b, 0xcb, 0xa9, 0x19, 0x92, 0xfc, 0xdb, 0x70, 0xa7, 0x34, 0xe7, 0xcb, 0x63, 0x3b, 0x78,
+	0x31, 0x5f, 0x70, 0x1f, 0x09, 0xa1, 0x23, 0xfa, 0x77, 0x44, 0x6f, 0xaa, 0xcb, 0x79, 0xa5, 0x92,
+	0xe0, 0xda, 0xca, 0x6b, 0xfe, 0xfe, 0xb7, 0xe3, 0x6f, 0x88, 0x10, 0x3a, 0xe6, 0x28, 0x16, 0x83,
+	0xba, 0xa9, 0xc6, 0x04, 0xb4, 0x7e, 0x60, 0x02, 0xda, 0x97, 0x26, 0x80, 0x8d, 0xea, 0x2d, 0x8d,
+	0x5a, 0x4f, 0x49, 0xa7, 0x31, 0x25, 0xa3, 0x3f, 0x6d, 0x09, 0xb1, 0xfc, 0xb8, 0x3f, 0x14, 0x2d,
+	0xc9, 0x61, 0x7f, 0x0b, 0xaf, 0x0b, 0x52, 0x4a, 0xc9, 0x81, 0x3e, 0x96, 0xa1, 0x99, 0x98, 0x17,
+	0x02, 0x80, 0x24, 0x80, 0xb1, 0xf3, 0x0b, 0x8e, 0xdf, 0xdb, 0xf9, 0x05, 0xc8, 0x85, 0xe1, 0x68,
+	0xbd, 0x5d, 0xa0, 0x53, 0xae, 0xac, 0xe6, 0xc8, 0x1c, 0x8a, 0xd8, 0xc1, 0x0b, 0x17, 0x81, 0x43,
+	0x71, 0xf4, 0x67, 0x9e, 0xf0, 0x8e, 0x9f, 0x8c, 0xd1, 0x33, 0xce, 0x4f, 0xbe, 0x87, 0xe8, 0x8e,
+	0xc6, 0xea, 0x44, 0x38, 0x61, 0x52, 0x63, 0xe6, 0x4a, 0x27, 0x05, 0x0f, 0xb7, 0x96, 0xe1, 0x1e,
+	0x60, 0x94, 0x81, 0x99, 0x83, 0xe8, 0x6d, 0x9e, 0xd7, 0x87, 0xca, 0x26, 0xc3, 0x21, 0xa1, 0xe8,
+	0xf9, 0x95, 0x4e, 0x65, 0xe6, 0x96, 0x2c, 0x49, 0xfe, 0x8e, 0x58, 0x9f, 0xa4, 0xc5, 0x54, 0xe9,
+	0x4a, 0xa7, 0x85, 0xdb, 0xcc, 0x4d, 0x08, 0xfd, 0x65, 0x91, 0xba, 0x2d, 0x6d, 0x8a, 0xb4, 0xb9,
+	0x7a, 0x7a, 0x97, 0x83, 0xbc, 0x77, 0xc5, 0xa0, 0x28, 0xed, 0x89, 0x9a, 0x94, 0xda, 0x2d, 0xf7,
+	0x25, 0x00, 0x03, 0x29, 0x4a, 0x2b, 0x27, 0x56, 0x69, 0x97, 0x98, 0x74, 0x32, 0xf4, 0x23, 0x56,
+	0xda, 0xa6, 0x13, 0xc8, 0xdd, 0xba, 0xab, 0x5a, 0x13, 0xc2, 0x29, 0x98, 0xc9, 0xb4, 0xe0, 0xdd,
+	0x4e, 0x02, 0xdc, 0xcb, 0xbf, 0x97, 0x77, 0x82, 0xe1, 0xca, 0x7b, 0xf9, 0xcf, 0xef, 0xdf, 0x09,
+	0x41, 0xed, 0x7f, 0x2c, 0x3a, 0xdf, 0xcb, 0x3b, 0xb4, 0x09, 0x56, 0xd3, 0x50, 0x3f, 0x7a, 0x28,
+	0xbc, 0x9f, 0xdf, 0xbf, 0x83, 0xe9, 0x1a, 0x69, 0x66, 0xce, 0x37, 0x40, 0x19, 0x30, 0x5c, 0x47,
+	0x34, 0x03, 0x58, 0xa6, 0x93, 0x1c, 0x0f, 0x33, 0xf6, 0x0f, 0x24, 0x8d, 0x76, 0x85, 0x58, 0x66,
+	0xb0, 0x60, 0xd8, 0x59, 0x5a, 0x9c, 0xd6, 0xde, 0xa6, 0x1b, 0xd6, 0xf2, 0x28, 0x16, 0xde, 0x78,
+	0xfc, 0xd8, 0xff, 0x42, 0xac, 0xb1, 0x4b, 0x6e, 0xed, 0xb4, 0x56, 0x04, 0x4b, 0x63, 0x54, 0x86,
+	0x4c, 0x02, 0x7a, 0xc3, 0xcf, 0xbf, 0x48, 0xdf, 0x43, 0x65, 0xc8, 0xa4, 0xd1, 0x1f, 0x88, 0x35,
+	0x42, 0xf0, 0x9c, 0xc0, 0xc4, 0xae, 0x9b, 0x3f, 0x1a, 0xe1, 0x10, 0xc1, 0x67, 0x3c, 0x89, 0x9f,
+	0x8a, 0x6d, 0x53, 0x4e, 0x2c, 0x5e, 0x04, 0x1d, 0x8f, 0x46, 0xbd, 0xe5, 0x70, 0xa6, 0x42, 0xcb,
+	0xe3, 0xe5, 0x09, 0xf4, 0xff, 0xd9, 0xf2, 0x91, 0xe8, 0x40, 0xa6, 0x0e, 0xa7, 0x42, 0x65, 0x65,
+	0x3d, 0x15, 0x2a, 0x2b, 0x21, 0x44, 0x80, 0x9c, 0x5c, 0x34, 0xd1, 0x65, 0xee, 0x76, 0x04, 0x00,
+	0x8f, 0x74, 0x99, 0x43, 0xd0, 0xa2, 0xe3, 0xca, 0x46, 0xb6, 0xe4, 0xbd, 0xba, 0x06, 0xe2, 0x71,
+	0x39, 0x7a, 0x4f, 0x74, 0x31, 0xa3, 0xd7, 0xb8, 0x78, 0xb5, 0xdc, 0xac, 0x81, 0x34, 0xfa, 0x43,
+	0xd1, 0xe3, 0xc4, 0xd8, 0xca, 0xc3, 0xe1, 0x75, 0xd1, 0xc5, 0xec, 0xbf, 0x0b, 0xe6, 0x50, 0x00,
+	0x66, 0x1d, 0xb0, 0x76, 0x43, 0x2c, 0x03, 0x33, 0x93, 0x0b, 0xa5, 0x9d, 0xcf, 0x41, 0x61, 0xf4,
+	0x2f, 0x6d, 0xd1, 0x81, 0x4c, 0x1a, 0x6c, 0xa8, 0x4c, 0x15, 0xbc, 0x14, 0xa0, 0x08, 0x88, 0x2d,
+	0x8d, 0xcb, 0xc7, 0xd8, 0xd2, 0xac, 0x70, 0x64, 0xbe, 0xe8, 0xa4, 0x55, 0x9a, 0x70, 0xf0, 0x8b,
+	0x65, 0x18, 0x89, 0x8d, 0x2b, 0xa3, 0x9e, 0xbb, 0xc0, 0x97, 0x24, 0xc6, 0x21, 0x5e, 0x59, 0xab,
+	0x71, 0x08, 0x59, 0x08, 0x3f, 0x4f, 0x0b, 0x3e, 0x62, 0x58, 0x72, 0x21, 0x51, 0xff, 0x52, 0x48,
+	0x04, 0xd5, 0x07, 0x97, 0xc2, 0x1d, 0x08, 0x89, 0xc4, 0xa5, 0x90, 0x08, 0xc2, 0xa6, 0xf5, 0x4b,
+	0x61, 0x13, 0x04, 0x37, 0xc3, 0x65, 0x70, 0xc3, 0x01, 0xd0, 0xc6, 0x32, 0x00, 0xa2, 0x3e, 0x68,
+	0x65, 0xf8, 0xe4, 0x61, 0x09, 0x9c, 0x8a, 0x8d, 0xab, 0xb9, 0x9e, 0x56, 0x78, 0xf2, 0x74, 0x43,
+	0x27, 0x36, 0x22, 0x83, 0xed, 0x66, 0x64, 0x30, 0xfa, 0xb7, 0xb6, 0xe8, 0x62, 0xc2, 0x11, 0x18,
+	0xf3, 0x0a, 0x5e, 0x6e, 0x38, 0x38, 0x62, 0x09, 0xae, 0x4f, 0xb1, 0xac, 0x30, 0x3d, 0xb5, 0xfa,
+	0xfa, 0xb4, 0x47, 0x5a, 0x6c, 0x25, 0x74, 0x5c, 0xa8, 0x96, 0x28, 0x98, 0x49, 0xfd, 0x03, 0xb7,
+	0xa7, 0x7d, 0xd2, 0x72, 0x35, 0xe6, 0xfa, 0x9f, 0x73, 0x0a, 0xbc, 0xb3, 0xd3, 0x5a, 0x71, 0x3e,
+	0x42, 0x30, 0x47, 0x15, 0x90, 0xe5, 0xdf, 0x16, 0x6b, 0x89, 0xb2, 0xe0, 0xee, 0xbb, 0xc8, 0xbf,
+	0xfe, 0xc2, 0x37, 0x40, 0x49, 0x35, 0x98, 0xe9, 0x7f, 0x4a, 0x69, 0xfd, 0xb5, 0x95, 0x89, 0xaf,
+	0xe3, 0xbd, 0x23, 0x62, 0x03, 0xc7, 0xff, 0x19, 0xbd, 0x02, 0xd1, 0x3a, 0xec, 0x61, 0x85, 0x77,
+	0xaf, 0x66, 0x81, 0xab, 0xea, 0x09, 0xa8, 0xa9, 0x56, 0x5f, 0xb2, 0x38, 0xfa, 0xeb, 0x96, 0x18,
+	0x36, 0x0d, 0x03, 0x11, 0xe1, 0xa9, 0xd2, 0x85, 0xca, 0x22, 0xca, 0xc5, 0x1b, 0x36, 0xf3, 0x06,
+	0xa1, 0xe4, 0xea, 0x0c, 0xe4, 0x10, 0x99, 0x06, 0xa9, 0x62, 0xc3, 0x31, 0xe8, 0x3a, 0x61, 0xb0,
+	0xf2, 0x9b, 0x2d, 0xa5, 0x13, 0x22, 0x79, 0xcd, 0x96, 0x0e, 0x08, 0x84, 0xf9, 0xc4, 0x9b, 0x9f,
+	0xe1, 0xd0, 0x93, 0xa5, 0xd1, 0x3f, 0xb4, 0xc5, 0xb0, 0x69, 0x7b, 0xd8, 0x14, 0xd5, 0x69, 0xdd,
+	0x1f, 0x2c, 0xc3, 0xee, 0xc3, 0x48, 0x95, 0xbf, 0x4f, 0x02, 0x2c, 0xaf, 0xb4, 0x38, 0x93, 0x19,
+	0xe7, 0x36, 0xbd, 0xd0, 0x89, 0xb4, 0xb1, 0xce, 0xbe, 0x72, 0xd7, 0x2e, 0x28, 0x33, 0x76, 0x8f,
+	0xb7, 0x15, 0x96, 0xc1, 0x8d, 0x2b, 0x3b, 0x83, 0x7e, 0x5a, 0xde, 0x56, 0xb5, 0x8c, 0xdb, 0x35,
+	0xae, 0x5c, 0x90, 0x0b, 0xf6, 0x87, 0xad, 0x90, 0x54, 0xb8, 0xa5, 0xbc, 0x10, 0x8a, 0x30, 0x28,
+	0x78, 0x6b, 0x3a, 0xfb, 0x0a, 0x77, 0x95, 0x17, 0xb2, 0x54, 0xe3, 0xf7, 0x02, 0xd1, 0xc0, 0xef,
+	0xe1, 0x43, 0xdc, 0xd9, 0x34, 0xaa, 0x4e, 0x2d, 0x26, 0x14, 0xf8, 0x0a, 0x26, 0xe4, 0xd9, 0xf4,
+	0xe8, 0xd4, 0x42, 0x2a, 0x01, 0x18, 0xb9, 0xbc, 0x58, 0x32, 0xe8, 0x35, 0x4e, 0xe4, 0xf2, 0x82,
+	0x19, 0xa3, 0xff, 0x69, 0x89, 0x41, 0xbd, 0xf0, 0xe0, 0x4b, 0xb9, 0xca, 0x63, 0x59, 0xb9, 0x6d,
+	0x42, 0x92, 0xeb, 0x7d, 0xfb, 0x85, 0xde, 0x7b, 0xab, 0x7a, 0xdf, 0xf9, 0x81, 0xde, 0x77, 0x2f,
+	0xf5, 0x1e, 0x82, 0x7c, 0x78, 0xcd, 0x60, 0x53, 0x91, 0x00, 0x99, 0x26, 0x8c, 0xc4, 0xa3, 0xbc,
+	0x4c, 0x54, 0xa4, 0x0a, 0xab, 0xe0, 0x1a, 0x4c, 0x66, 0xdb, 0x46, 0xcd, 0x61, 0x99, 0xa8, 0x87,
+	0x84, 0x43, 0x46, 0xaf, 0xc1, 0x2e, 0xe1, 0x78, 0x24, 0x7b, 0x6e, 0xd4, 0xd4, 0xef, 0xce, 0x28,
+	0x4a, 0xcc, 0x55, 0x3e, 0x37, 0xca, 0x59, 0x96, 0x24, 0xc8, 0xc6, 0xaf, 0x37, 0xb6, 0x11, 0xf4,
+	0x89, 0xde, 0xe7, 0x68, 0xf8, 0x24, 0xf8, 0xb7, 0xc4, 0xeb, 0x58, 0x88, 0x9e, 0xcf, 0xd5, 0x9c,
+	0x3e, 0x83, 0xdb, 0x98, 0xcc, 0xe1, 0xa3, 0xee, 0x97, 0xa0, 0xfa, 0x8e, 0x35, 0xfe, 0x8f, 0xc5,
+	0x35, 0x44, 0x4d, 0x64, 0xe6, 0x55, 0xa5, 0xe9, 0x15, 0x86, 0x6c, 0xb5, 0x4d, 0x8a, 0x71, 0x8d,
+	0x8f, 0xfe, 0xb2, 0x2d, 0xfa, 0x6e, 0x6b, 0x52, 0xde, 0x1b, 0xe3, 0x32, 0x53, 0xe7, 0x7a, 0x58,
+	0x86, 0xd1, 0x1a, 0x53, 0x44, 0x34, 0x27, 0xb8, 0x85, 0xea, 0xec, 0x83, 0x29, 0x0e, 0x11, 0xc5,
+	0xe3, 0x03, 0x1e, 0x4f, 0x8d, 0x9a, 0x27, 0x25, 0x7f, 0x92, 0x25, 0x38, 0x5c, 0x79, 0x49, 0x47,
+	0xf1, 0x4c, 0xc5, 0xa7, 0x66, 0x9e, 0xf3, 0x5c, 0x6d, 0x31, 0xbe, 0xc7, 0xb0, 0xf3, 0xf7, 0x34,
+	0x63, 0x50, 0x84, 0x46, 0xcd, 0xa2, 0x68, 0x9c, 0x18, 0x24, 0x39, 0x1f, 0xcf, 0x0b, 0x1b, 0x7c,
+	0xfc, 0xd2, 0xd8, 0xfd, 0xa6, 0xb1, 0xc1, 0x28, 0xe0, 0x97, 0x8d, 0xca, 0x4f, 0xb2, 0x45, 0x74,
+	0x69, 0x3e, 0xb6, 0x97, 0x8a, 0x43, 0x9a, 0x99, 0xff, 0x6a, 0x89, 0x8d, 0x4b, 0xee, 0xc7, 0xff,
+	0x9a, 0x9d, 0x27, 0x25, 0x99, 0x3e, 0x7e, 0x99, 0xab, 0x42, 0x57, 0x4a, 0x2f, 0x26, 0x58, 0xc7,
+	0xff, 0x4a, 0xb4, 0xed, 0x05, 0xa7, 0x99, 0x3e, 0x7c, 0x69, 0xcd, 0xe3, 0x0b, 0xaa, 0xd7, 0xb6,
+	0x17, 0xd7, 0x7f, 0x4a, 0x5b, 0xe3, 0x37, 0x7a, 0x5f, 0xf1, 0x1a, 0xef, 0x2b, 0xd7, 0xef, 0x8a,
+	0xde, 0xf1, 0xc5, 0xff, 0xb9, 0xda, 0xe8, 0x3f, 0x3d, 0xe1, 0x8d, 0x0f, 0x1f, 0xf0, 0x25, 0x93,
+	0x66, 0x1f, 0x2e, 0x99, 0x81, 0xe8, 0x25, 0xa9, 0xcc, 0xe0, 0x24, 0xa0, 0xc8, 0xc2, 0x89, 0xa0,
+	0x81, 0x47, 0x44, 0x59, 0x24, 0x1c, 0x5f, 0x3a, 0xb1, 0x11, 0xc2, 0x74, 0x9a, 0x21, 0x0c, 0x66,
+	0xb8, 0xb0, 0x44, 0x59, 0xb4, 0x2e, 0x67, 0xb8, 0x10, 0xc2, 0x34, 0xda, 0x0d, 0x21, 0xdc, 0x7d,
+	0x21, 0x4d, 0x78, 0xae, 0x07, 0x8c, 0x1c, 0x24, 0x10, 0x3c, 0x59, 0xad, 0x94, 0xbb, 0x84, 0x42,
+	0xe4, 0xa0, 0x15, 0x64, 0x7c, 0x9a, 0x59, 0xae, 0xfe, 0x8a, 0x2c, 0xd7, 0x4c, 0x6a, 0x9a, 0xed,
+	0x41, 0x48, 0x02, 0x7e, 0x09, 0x0a, 0x94, 0x5f, 0x17, 0xfc, 0x3a, 0x04, 0x08, 0x66, 0xd6, 0x6f,
+	0x2d, 0x33, 0x9b, 0xeb, 0x2b, 0xdf, 0x28, 0xc7, 0x87, 0x0f, 0x1e, 0x97, 0xc6, 0x2e, 0x93, 0x9a,
+	0xb7, 0x1b, 0x49, 0xcd, 0xe1, 0x4b, 0xab, 0xd4, 0x3c, 0xb8, 0x1e, 0x51, 0x0c, 0x1c, 0xb1, 0x4d,
+	0x0d, 0xfe, 0x9c, 0x30, 0x08, 0x37, 0x09, 0xde, 0x67, 0x14, 0x0c, 0xc7, 0xc4, 0xe9, 0x9c, 0x7f,
+	0x56, 0x80, 0x47, 0x09, 0x84, 0xbe, 0x99, 0xa7, 0x09, 0x5a, 0x16, 0x23, 0x5d, 0x22, 0x6c, 0xb1,
+	0x65, 0x11, 0x02, 0xc2, 0x68, 0x4f, 0xf4, 0xf8, 0xfb, 0x10, 0x9f, 0x16, 0xd2, 0xa6, 0x67, 0x2a,
+	0x2a, 0xeb, 0x14, 0x16, 0x01, 0xdf, 0x99, 0x86, 0x32, 0xab, 0x83, 0x57, 0x02, 0x9e, 0xe4, 0xa3,
+	0xff, 0x6e, 0x89, 0x0e, 0x3c, 0x79, 0xc2, 0x73, 0x73, 0x6e, 0xa6, 0x51, 0x23, 0x08, 0xed, 0xe5,
+	0x66, 0x7a, 0xcc, 0x71, 0x28, 0x5d, 0x45, 0xdb, 0x7c, 0x0f, 0x72, 0x93, 0xa0, 0x95, 0xcc, 0x72,
+	0xf7, 0x37, 0x0a, 0x0a, 0x80, 0x1a, 0xe4, 0x72, 0x24, 0x8a, 0x02, 0x3c, 0xc0, 0xa8, 0x22, 0xd6,
+	0x8b, 0x0a, 0x9f, 0x06, 0x79, 0x91, 0x2c, 0x11, 0xb0, 0xda, 0xb9, 0x92, 0xa7, 0x51, 0x83, 0x44,
+	0x99, 0xb0, 0x4d, 0x80, 0x1f, 0x2e, 0x89, 0x1f, 0x89, 0xcd, 0x89, 0x4c, 0x33, 0x95, 0xb8, 0x67,
+	0x06, 0xbe, 0x0f, 0x6e, 0x10, 0xca, 0x6f, 0x0c, 0x57, 0x52, 0xbb, 0xfd, 0x2b, 0xa9, 0xdd, 0xd1,
+	0x3f, 0x76, 0x44, 0x07, 0x9e, 0x6f, 0x5f, 0x91, 0x92, 0xa1, 0xdd, 0x72, 0x43, 0xf0, 0xac, 0x44,
+	0xb9, 0x74, 0xd9, 0xbd, 0x01, 0x21, 0x87, 0x32, 0x86, 0x69, 0x92, 0x06, 0xde, 0xdc, 0x54, 0x02,
+	0xff, 0xd9, 0xd0, 0xb0, 0x85, 0x83, 0xe8, 0x5f, 0x1b, 0xae, 0x5f, 0xff, 0x86, 0xd3, 0x27, 0x80,
+	0x7e, 0xde, 0xd1, 0x2a, 0x93, 0x0b, 0xd0, 0xd1, 0x7d, 0xb7, 0x87, 0xf2, 0x01, 0xfc, 0x2b, 0xb1,
+	0x59, 0xa8, 0x0b, 0x1b, 0xf1, 0x22, 0x48, 0x2b, 0x1e, 0xea, 0x10, 0x50, 0xba, 0x03, 0x51, 0xeb,
+	0xf0, 0x04, 0x4d, 0xf3, 0xc6, 0xfb, 0x04, 0x00, 0x9c, 0xb8, 0xe6, 0x73, 0xd6, 0xe0, 0xca, 0x73,
+	0xd6, 0x0d, 0x21, 0x32, 0x25, 0x8d, 0x8a, 0x30, 0x8a, 0xa5, 0x40, 0x60, 0x80, 0xc8, 0x31, 0x04,
+	0xb2, 0xef, 0x8b, 0xa1, 0x56, 0x85, 0x3a, 0x97, 0x19, 0x11, 0x28, 0x16, 0x58, 0x67, 0x0c, 0x29,
+	0x1f, 0x89, 0x4d, 0xad, 0x4e, 0xd2, 0x22, 0x49, 0x8b, 0x29, 0x91, 0x28, 0x1c, 0xd8, 0xa8, 0x51,
+	0xa4, 0xc1, 0x3a, 0x9e, 0x9f, 0x14, 0x0a, 0xec, 0x67, 0x4e, 0x39, 0x2b, 0x24, 0x08, 0x3a, 0x94,
+	0xe6, 0x14, 0x9c, 0x8e, 0x2e, 0xe7, 0x56, 0x69, 0x08, 0xd0, 0x3d, 0x34, 0x01, 0x89, 0x50, 0x35,
+	0x29, 0x0c, 0x5b, 0xc0, 0x04, 0x5b, 0xfc, 0x28, 0x59, 0x18, 0x1a, 0xbe, 0xa1, 0xdf, 0x7a, 0xb4,
+	0xcc, 0x29, 0x45, 0x34, 0x08, 0x59, 0x6a, 0xda, 0x9c, 0x32, 0x41, 0x4b, 0x9b, 0x27, 0xfe, 0x3d,
+	0xf1, 0xd6, 0x99, 0x2a, 0x12, 0x58, 0x1d, 0x99, 0x34, 0x90, 0x98, 0x57, 0x05, 0xdc, 0xf9, 0x95,
+	0xc6, 0x77, 0x9a, 0x41, 0xf8, 0x06, 0xa9, 0xf7, 0x40, 0x7b, 0x50, 0x2b, 0x47, 0xff, 0xd4, 0x12,
+	0x1d, 0x78, 0x9f, 0x6f, 0x66, 0x23, 0x5a, 0x97, 0xb3, 0x11, 0x9c, 0xb9, 0x68, 0x2f, 0x33, 0x17,
+	0x9b, 0xa2, 0x3d, 0x97, 0xbc, 0x6a, 0xda, 0x73, 0x09, 0xd9, 0x83, 0x78, 0x31, 0xc7, 0x0c, 0xce,
+	0x8b, 0x69, 0x81, 0xbd, 0x5f, 0x3d, 0x0d, 0x41, 0xed, 0x72, 0x0c, 0xdd, 0xdf, 0x2c, 0xc7, 0xb0,
+	0xf6, 0x8a, 0x1c, 0xc3, 0x4f, 0x84, 0xb7, 0xf7, 0xab, 0xa7, 0x2b, 0x73, 0x0c, 0xcb, 0x7c, 0x42,
+	0xfb, 0x52, 0x3e, 0xe1, 0xef, 0xdb, 0xc2, 0x0b, 0xf7, 0x8f, 0x96, 0x19, 0x42, 0x8e, 0x77, 0x31,
+	0x43, 0xf8, 0x8a, 0xff, 0xd4, 0x6e, 0xd5, 0xb9, 0x02, 0x6f, 0xe5, 0xbd, 0x24, 0xdc, 0x3f, 0xba,
+	0x9c, 0x2e, 0xc0, 0x87, 0xfb, 0x99, 0x2c, 0x0a, 0x95, 0x19, 0xce, 0x6d, 0xd5, 0x32, 0xf4, 0x30,
+	0x2e, 0xcb, 0xd3, 0xd4, 0x9d, 0x2d, 0x2c, 0x5d, 0x7a, 0xe8, 0x5c, 0xbb, 0xf2, 0xd0, 0x79, 0x79,
+	0xfb, 0xf7, 0xae, 0xbe, 0xec, 0x60, 0x0a, 0x0b, 0x37, 0x15, 0x44, 0x53, 0xa5, 0xb6, 0x86, 0xdf,
+	0x9d, 0x37, 0x09, 0x1e, 0x33, 0x0a, 0x9b, 0xe0, 0xe2, 0xee, 0xad, 0x9f, 0x45, 0x94, 0xb9, 0x32,
+	0xfc, 0xe4, 0xbc, 0x0e, 0xd8, 0x98, 0xa0, 0xd1, 0x5f, 0x78, 0x62, 0x50, 0x0f, 0xe8, 0x25, 0x2b,
+	0xe3, 0x03, 0xb1, 0x91, 0x28, 0x73, 0x6a, 0xcb, 0x2a, 0x3a, 0x4f, 0x13, 0x3b, 0xe3, 0xab, 0xf7,
+	0x90, 0xc1, 0xdf, 0x07, 0x0c, 0x76, 0x94, 0x23, 0xcd, 0x54, 0x3a, 0x9d, 0x59, 0xbe, 0x8e, 0xbb,
+	0xaa, 0x8f, 0x11, 0xc4, 0xa3, 0xa3, 0xcc, 0x4a, 0x1d, 0x25, 0xaa, 0xb2, 0x33, 0xbe, 0x9f, 0x0b,
+	0x84, 0xf6, 0x01, 0x81, 0x01, 0x9e, 0xaa, 0xc5, 0x49, 0x29, 0x75, 0x02, 0xf7, 0xb1, 0x72, 0xee,
+	0xd2, 0x6c, 0x9b, 0x0e, 0x7e, 0x82, 0x28, 0xde, 0x5c, 0xe6, 0x69, 0xe6, 0xd2, 0xe7, 0x24, 0x34,
+	0x8e, 0x26, 0xf4, 0x1c, 0xbd, 0xe6, 0xd1, 0xf4, 0x2d, 0xf8, 0x8e, 0x0f, 0xc4, 0x46, 0xdd, 0x7e,
+	0xc3, 0xf1, 0x0c, 0x1d, 0x88, 0xcb, 0xe0, 0x03, 0xb1, 0x31, 0x99, 0x17, 0xf8, 0xfb, 0x47, 0x74,
+	0xaa, 0x16, 0x86, 0x1f, 0x97, 0x87, 0x0e, 0xfc, 0x85, 0x5a, 0xe0, 0x1b, 0x1c, 0xff, 0x23, 0x00,
+	0x8b, 0x4c, 0x20, 0x63, 0xc0, 0xc8, 0x41, 0xe2, 0x8f, 0xc4, 0x30, 0x96, 0x95, 0x3c, 0x49, 0xb3,
+	0xd4, 0xa6, 0xca, 0xf0, 0xd3, 0xfd, 0x25, 0x8c, 0xfd, 0x35, 0x3d, 0x65, 0xb4, 0xd3, 0xe4, 0x64,
+	0x0d, 0x97, 0xc1, 0x9d, 0xff, 0x1d, 0x00, 0x56, 0xb5, 0xff, 0x64, 0xb4, 0x29, 0x00, 0x00,
 }
@@ -0,0 +1,257 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeFormat is the Suricata timestamp format, e.g. "2019-07-24T13:37:18.105357+0000".
+const TimeFormat = "2006-01-02T15:04:05.999999-0700"
+
+// Interfaces is the response to the iface-list command.
+type Interfaces struct {
+	Count  int      `json:"count"`
+	Ifaces []string `json:"ifaces"`
+}
+
+// InterfaceStats is the response to the iface-stat command.
+type InterfaceStats struct {
+	Packets          int64 `json:"pkts"`
+	Drops            int64 `json:"drop"`
+	InvalidChecksums int64 `json:"invalid-checksums"`
+	Bypassed         int64 `json:"bypassed"`
+}
+
+// Counters is the response to the dump-counters command. It maps module names, e.g. "capture",
+// to their counters.
+type Counters map[string]interface{}
+
+// Int returns the value of a counter identified by its dotted path, e.g. "capture.kernel_packets".
+func (c Counters) Int(path string) (int64, bool) {
+	var v interface{} = map[string]interface{}(c)
+	for _, p := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return 0, false
+		}
+		if v, ok = m[p]; !ok {
+			return 0, false
+		}
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// RuleStats is a per-tenant element of the ruleset-stats response.
+type RuleStats struct {
+	ID           int   `json:"id"`
+	RulesLoaded  int64 `json:"rules_loaded"`
+	RulesFailed  int64 `json:"rules_failed"`
+	RulesSkipped int64 `json:"rules_skipped"`
+}
+
+// FailedRule is an element of the ruleset-failed-rules response.
+type FailedRule struct {
+	TenantID int    `json:"tenant_id"`
+	Rule     string `json:"rule"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+}
+
+// ReloadTime is a per-tenant element of the ruleset-reload-time response.
+type ReloadTime struct {
+	ID         int    `json:"id"`
+	LastReload string `json:"last_reload"`
+}
+
+// Time parses the last reload time.
+func (r *ReloadTime) Time() (time.Time, error) {
+	return time.Parse(TimeFormat, r.LastReload)
+}
+
+// Memcap is an element of the memcap-list response.
+type Memcap struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// UnmarshalJSON accepts the memcap value as either a string or a number, since the encoding
+// differs across Suricata versions.
+func (m *Memcap) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Name  string          `json:"name"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	m.Name = raw.Name
+	if err := json.Unmarshal(raw.Value, &m.Value); err == nil {
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw.Value, &n); err != nil {
+		return fmt.Errorf("invalid memcap value for %q: %s", raw.Name, raw.Value)
+	}
+	m.Value = n.String()
+	return nil
+}
+
+// Decode unmarshals the response message into v.
+func (r *Response) Decode(v interface{}) error {
+	if err := json.Unmarshal(r.Message, v); err != nil {
+		return fmt.Errorf("failed to parse response message (%s): %v", r.Message, err)
+	}
+	return nil
+}
+
+// call sends a command and decodes the response message into v, if v is non-nil.
+func (s *Socket) call(cmd *Command, v interface{}) error {
+	r, err := s.Send(cmd)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	if err := r.Decode(v); err != nil {
+		return fmt.Errorf("%s: %v", cmd.Name, err)
+	}
+	return nil
+}
+
+// Uptime returns the time Suricata has been running.
+func (s *Socket) Uptime() (time.Duration, error) {
+	var secs int64
+	if err := s.call(&Command{Name: Uptime}, &secs); err != nil {
+		return 0, err
+	}
+	return time.Duration(secs) * time.Second, nil
+}
+
+// Version returns the Suricata version.
+func (s *Socket) Version() (string, error) {
+	var v string
+	return v, s.call(&Command{Name: SuricataVersion}, &v)
+}
+
+// RunningMode returns the Suricata running mode, e.g. "autofp" or "workers".
+func (s *Socket) RunningMode() (string, error) {
+	var v string
+	return v, s.call(&Command{Name: RunningMode}, &v)
+}
+
+// CaptureMode returns the Suricata capture mode, e.g. "AF_PACKET_DEV" or "PCAP_DEV".
+func (s *Socket) CaptureMode() (string, error) {
+	var v string
+	return v, s.call(&Command{Name: CaptureMode}, &v)
+}
+
+// Interfaces returns the interfaces Suricata is capturing on.
+func (s *Socket) Interfaces() (*Interfaces, error) {
+	v := new(Interfaces)
+	if err := s.call(&Command{Name: IfaceList}, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// InterfaceStats returns the capture statistics of an interface.
+func (s *Socket) InterfaceStats(iface string) (*InterfaceStats, error) {
+	v := new(InterfaceStats)
+	if err := s.call(&Command{Name: IfaceStat, Args: map[string]string{"iface": iface}}, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DumpCounters returns the Suricata performance counters.
+func (s *Socket) DumpCounters() (Counters, error) {
+	var v Counters
+	if err := s.call(&Command{Name: DumpCounters}, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ReloadRules reloads the ruleset and blocks until the reload completes.
+func (s *Socket) ReloadRules() error {
+	return s.call(&Command{Name: ReloadRules}, nil)
+}
+
+// ReloadRulesNonblocking starts a ruleset reload and returns immediately.
+func (s *Socket) ReloadRulesNonblocking() error {
+	return s.call(&Command{Name: RulesetReloadNonblocking}, nil)
+}
+
+// RulesetStats returns the number of loaded and failed rules per tenant.
+func (s *Socket) RulesetStats() ([]*RuleStats, error) {
+	var v []*RuleStats
+	return v, s.call(&Command{Name: RulesetStats}, &v)
+}
+
+// FailedRules returns the rules which failed to load.
+func (s *Socket) FailedRules() ([]*FailedRule, error) {
+	var v []*FailedRule
+	return v, s.call(&Command{Name: RulesetFailedRules}, &v)
+}
+
+// ReloadTimes returns the time of the last successful ruleset reload per tenant.
+func (s *Socket) ReloadTimes() ([]*ReloadTime, error) {
+	var v []*ReloadTime
+	return v, s.call(&Command{Name: RulesetReloadTime}, &v)
+}
+
+// Memcaps returns the configured memcaps.
+func (s *Socket) Memcaps() ([]*Memcap, error) {
+	var v []*Memcap
+	return v, s.call(&Command{Name: MemcapList}, &v)
+}
+
+// SetMemcap updates a memcap, e.g. SetMemcap("stream", "1gb").
+func (s *Socket) SetMemcap(name, value string) error {
+	return s.call(&Command{Name: MemcapSet, Args: map[string]string{"config": name, "memcap": value}}, nil)
+}
+
+// datasetArgs returns the arguments of the dataset commands. Values are sent as is, including
+// strings, which are only base64-encoded in dataset files.
+func datasetArgs(name, typ, value string) map[string]string {
+	return map[string]string{"setname": name, "settype": typ, "datavalue": value}
+}
+
+// DatasetAdd adds a value to a dataset, e.g. DatasetAdd("bad_ips", "ipv4", "10.0.0.1").
+func (s *Socket) DatasetAdd(name, typ, value string) error {
+	return s.call(&Command{Name: DatasetAdd, Args: datasetArgs(name, typ, value)}, nil)
+}
+
+// DatasetRemove removes a value from a dataset.
+func (s *Socket) DatasetRemove(name, typ, value string) error {
+	return s.call(&Command{Name: DatasetRemove, Args: datasetArgs(name, typ, value)}, nil)
+}
+
+// Shutdown stops Suricata. The connection is closed afterwards.
+func (s *Socket) Shutdown() error {
+	if err := s.call(&Command{Name: Shutdown}, nil); err != nil {
+		return err
+	}
+	return s.Close()
+}
@@ -14,17 +14,17 @@
 
 // Package socket contains functionality to send commands to Suricata via its Unix socket.
 //
-// Proper usage of the socket:
-//   1. Connect()
-//   2. Send()
-//   3. Close()
+// A Socket holds a single persistent connection, which is established on the first command and
+// re-established on the next command after a connection error. Messages in both directions are
+// newline-delimited JSON objects.
 package socket
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net"
+	"sync"
 	"time"
 
 	log "github.com/golang/glog"
@@ -33,9 +33,13 @@ import (
 var (
 	retryAttempts = 3
 	retryInterval = 5 * time.Second
+	// commandTimeout bounds each message exchange, so that a hung Suricata does not block the
+	// sensor. Blocking ruleset reloads may take minutes with large rulesets.
+	commandTimeout = 5 * time.Minute
 )
 
-const versionID = "1.0"
+// versionID is the Suricata Unix socket protocol version.
+const versionID = "0.2"
 
 // Version represents a version message, which must be sent and return "OK" before sending commands.
 type Version struct {
@@ -44,17 +48,49 @@ type Version struct {
 
 // CommandName represents a Suricata Unix socket command name.
 //
-// https://suricata.readthedocs.io/en/suricata-4.0.5/unix-socket.html
+// https://suricata.readthedocs.io/en/latest/unix-socket.html
 type CommandName string
 
 // Suricata socket commands.
 const (
-	ReloadRules CommandName = "reload-rules"
+	Uptime                   CommandName = "uptime"
+	SuricataVersion          CommandName = "version"
+	RunningMode              CommandName = "running-mode"
+	CaptureMode              CommandName = "capture-mode"
+	IfaceList                CommandName = "iface-list"
+	IfaceStat                CommandName = "iface-stat"
+	DumpCounters             CommandName = "dump-counters"
+	ReloadRules              CommandName = "reload-rules"
+	RulesetStats             CommandName = "ruleset-stats"
+	RulesetFailedRules       CommandName = "ruleset-failed-rules"
+	RulesetReloadNonblocking CommandName = "ruleset-reload-nonblocking"
+	RulesetReloadTime        CommandName = "ruleset-reload-time"
+	MemcapList               CommandName = "memcap-list"
+	MemcapSet                CommandName = "memcap-set"
+	DatasetAdd               CommandName = "dataset-add"
+	DatasetRemove            CommandName = "dataset-remove"
+	Shutdown                 CommandName = "shutdown"
 )
 
 // validCommands contains the currently supported socket commands.
 var validCommands = map[CommandName]bool{
-	ReloadRules: true,
+	Uptime:                   true,
+	SuricataVersion:          true,
+	RunningMode:              true,
+	CaptureMode:              true,
+	IfaceList:                true,
+	IfaceStat:                true,
+	DumpCounters:             true,
+	ReloadRules:              true,
+	RulesetStats:             true,
+	RulesetFailedRules:       true,
+	RulesetReloadNonblocking: true,
+	RulesetReloadTime:        true,
+	MemcapList:               true,
+	MemcapSet:                true,
+	DatasetAdd:               true,
+	DatasetRemove:            true,
+	Shutdown:                 true,
 }
 
 // Command represents a Suricata Unix socket command.
@@ -65,16 +101,20 @@ type Command struct {
 	Args map[string]string `json:"arguments,omitempty"`
 }
 
-// Response represents a Suricata Unix socket command response.
+// Response represents a Suricata Unix socket command response. Message is either a string or a
+// command specific JSON object.
 type Response struct {
-	Return  string `json:"return,"`
-	Message string `json:"message,string"`
+	Return  string          `json:"return,"`
+	Message json.RawMessage `json:"message,omitempty"`
 }
 
 // Socket represents a Suricata Unix socket server connection.
 type Socket struct {
 	addr string
+
+	mu   sync.Mutex // Serializes commands over the connection.
 	conn net.Conn
+	r    *bufio.Reader
 }
 
 // New creates a new Socket.
@@ -83,24 +123,62 @@ func New(addr string) *Socket {
 }
 
 // Connect dials the Suricata Unix socket and prepares the connection for receiving commands.
+// It is a no-op if the Socket is already connected.
 func (s *Socket) Connect() error {
-	if err := retry(retryAttempts, retryInterval, func() error {
-		c, err := net.Dial("unix", s.addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connect()
+}
+
+// connect must be called with s.mu held. The mutex is released while dialing, so that the retries
+// do not block other callers.
+func (s *Socket) connect() error {
+	if s.conn != nil {
+		return nil
+	}
+	s.mu.Unlock()
+	var conn net.Conn
+	err := retry(retryAttempts, retryInterval, func() error {
+		c, err := net.DialTimeout("unix", s.addr, commandTimeout)
 		if err != nil {
 			return fmt.Errorf("failed to connect to Suricata socket (%s): %v", s.addr, err)
 		}
-		s.conn = c
+		conn = c
 		return nil
-	}); err != nil {
+	})
+	s.mu.Lock()
+	if err != nil {
 		return err
 	}
-
-	return s.version()
+	if s.conn != nil {
+		// Connected by another caller in the meantime.
+		conn.Close()
+		return nil
+	}
+	s.conn = conn
+	s.r = bufio.NewReader(conn)
+	if err := s.version(); err != nil {
+		s.close()
+		return err
+	}
+	return nil
 }
 
 // Close closes the Suricata Unix socket connection.
 func (s *Socket) Close() error {
-	return s.conn.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.close()
+}
+
+func (s *Socket) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	s.r = nil
+	return err
 }
 
 // version sends a Suricata version message to establish the communication protocol.
@@ -109,55 +187,60 @@ func (s *Socket) Close() error {
 //  2. Client sends a version message: { "version": "$VERSION_ID" }.
 //  3. Server answers with { "return": "OK|NOK" }.
 func (s *Socket) version() error {
-	buf, err := json.Marshal(&Version{versionID})
-	if err != nil {
-		return err
-	}
-	if _, err := s.conn.Write(buf); err != nil {
-		return err
-	}
-
-	resp, err := ioutil.ReadAll(s.conn)
+	r, err := s.roundTrip(&Version{versionID})
 	if err != nil {
 		return err
 	}
-	r := new(Response)
-	if err := json.Unmarshal(resp, r); err != nil {
-		return err
-	}
 	if r.Return != "OK" {
 		return fmt.Errorf("failed to establish communication protocol with Suricata: %s: %s", r.Return, r.Message)
 	}
 	return nil
 }
 
-// Send sends a command to Suricata and returns its response.
+// Send sends a command to Suricata and returns its response. The connection is established if
+// needed, and dropped if the command fails to be sent or its response cannot be read.
 func (s *Socket) Send(cmd *Command) (*Response, error) {
 	if _, ok := validCommands[cmd.Name]; !ok {
 		return nil, fmt.Errorf("unsupported command type: %s", cmd.Name)
 	}
 
-	buf, err := json.Marshal(cmd)
-	if err != nil {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.connect(); err != nil {
 		return nil, err
 	}
-	if _, err := s.conn.Write(buf); err != nil {
+	r, err := s.roundTrip(cmd)
+	if err != nil {
+		s.close()
 		return nil, err
 	}
+	if r.Return != "OK" {
+		return nil, fmt.Errorf("received an error response from command (%+v): %s: %s", cmd, r.Return, r.Message)
+	}
+	return r, nil
+}
 
-	// The read blocks until it receives an EOF or returns an error.
-	resp, err := ioutil.ReadAll(s.conn)
+// roundTrip writes a newline-delimited JSON message and reads the newline-delimited response,
+// failing if the exchange takes longer than commandTimeout.
+func (s *Socket) roundTrip(msg interface{}) (*Response, error) {
+	buf, err := json.Marshal(msg)
 	if err != nil {
 		return nil, err
 	}
-	r := new(Response)
-	if err := json.Unmarshal(resp, r); err != nil {
+	if err := s.conn.SetDeadline(time.Now().Add(commandTimeout)); err != nil {
 		return nil, err
 	}
-	if r.Return != "OK" {
-		return nil, fmt.Errorf("received an error response from command (%+v): %s: %s", cmd, r.Return, r.Message)
+	if _, err := s.conn.Write(append(buf, '\n')); err != nil {
+		return nil, err
+	}
+	line, err := s.r.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	r := new(Response)
+	if err := json.Unmarshal(line, r); err != nil {
+		return nil, err
 	}
-
 	return r, nil
 }
 
@@ -15,90 +15,394 @@
 package socket
 
 import (
+	"bufio"
 	"encoding/json"
-	"io"
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
-// fakeSocketServer mimiks a Suricata Unix socket server. It handles receiving a version message
-// and a subsequent command.
-// cmdSize is the expected Command message size for exiting the read loop.
-func fakeSocketServer(t *testing.T, conn net.Conn, resp *Response, cmdSize int) {
-	// Receive command.
-	in := make([]byte, 0, 128)
-	for len(in) < cmdSize {
-		tmp := make([]byte, 64)
-		n, err := conn.Read(tmp)
-		if err != nil && err != io.EOF {
-			t.Fatal(err)
+// fakeServer mimics a Suricata Unix socket server. Each connection must start with a version
+// message, after which commands are answered from the responses map.
+type fakeServer struct {
+	l         net.Listener
+	addr      string
+	dir       string
+	responses map[CommandName]*Response
+
+	mu       sync.Mutex
+	conns    int
+	commands []*Command
+}
+
+func newFakeServer(t *testing.T, responses map[CommandName]*Response) *fakeServer {
+	t.Helper()
+	d, err := ioutil.TempDir("", "socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := filepath.Join(d, "suricata.socket")
+	l, err := net.Listen("unix", addr)
+	if err != nil {
+		os.RemoveAll(d)
+		t.Fatal(err)
+	}
+	s := &fakeServer{l: l, addr: addr, dir: d, responses: responses}
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) close() {
+	s.l.Close()
+	os.RemoveAll(s.dir)
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			return
 		}
-		in = append(in, tmp[:n]...)
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.handle(conn)
 	}
+}
 
-	// Send response.
-	out, err := json.Marshal(resp)
+func (s *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	write := func(resp *Response) bool {
+		b, err := json.Marshal(resp)
+		if err != nil {
+			return false
+		}
+		_, err = conn.Write(append(b, '\n'))
+		return err == nil
+	}
+
+	line, err := r.ReadBytes('\n')
 	if err != nil {
-		t.Fatal(err)
+		return
+	}
+	var v Version
+	if err := json.Unmarshal(line, &v); err != nil || v.ID != versionID {
+		write(&Response{Return: "NOK", Message: json.RawMessage(`"unsupported version"`)})
+		return
+	}
+	if !write(&Response{Return: "OK"}) {
+		return
+	}
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		cmd := new(Command)
+		if err := json.Unmarshal(line, cmd); err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, cmd)
+		s.mu.Unlock()
+
+		resp, ok := s.responses[cmd.Name]
+		if !ok {
+			resp = &Response{Return: "NOK", Message: json.RawMessage(`"Unknown command"`)}
+		}
+		if !write(resp) {
+			return
+		}
 	}
-	conn.Write(out)
-	conn.Close()
 }
 
-func TestVersion(t *testing.T) {
-	buf, err := json.Marshal(&Version{versionID})
+func okResponse(msg string) *Response {
+	return &Response{Return: "OK", Message: json.RawMessage(msg)}
+}
+
+func TestCommands(t *testing.T) {
+	srv := newFakeServer(t, map[CommandName]*Response{
+		Uptime:          okResponse(`3600`),
+		SuricataVersion: okResponse(`"4.1.4 RELEASE"`),
+		RunningMode:     okResponse(`"workers"`),
+		CaptureMode:     okResponse(`"AF_PACKET_DEV"`),
+		IfaceList:       okResponse(`{"count": 1, "ifaces": ["eth0"]}`),
+		IfaceStat:       okResponse(`{"pkts": 100, "invalid-checksums": 1, "drop": 2, "bypassed": 3}`),
+		DumpCounters:    okResponse(`{"uptime": 3600, "capture": {"kernel_packets": 100, "kernel_drops": 2}}`),
+		ReloadRules:     okResponse(`"done"`),
+		RulesetStats:    okResponse(`[{"id": 0, "rules_loaded": 10, "rules_failed": 1}]`),
+		RulesetFailedRules: okResponse(
+			`[{"tenant_id": 0, "rule": "alert ip any any -> any any (foo; sid:1;)", "filename": "/etc/suricata/rules/emitto.rules", "line": 3}]`),
+		RulesetReloadNonblocking: okResponse(`"done"`),
+		RulesetReloadTime:        okResponse(`[{"id": 0, "last_reload": "2019-07-24T13:37:18.105357+0000"}]`),
+		MemcapList:               okResponse(`[{"name": "stream", "value": "64mb"}, {"name": "defrag", "value": 33554432}]`),
+		MemcapSet:                okResponse(`"memcap value for 'stream' updated: 1gb"`),
+		DatasetAdd:               okResponse(`"data added"`),
+		DatasetRemove:            okResponse(`"data removed"`),
+		Shutdown:                 okResponse(`"Closing Suricata"`),
+	})
+	defer srv.close()
+
+	s := New(srv.addr)
+	defer s.Close()
+
+	uptime, err := s.Uptime()
 	if err != nil {
 		t.Fatal(err)
 	}
+	if uptime != time.Hour {
+		t.Errorf("Uptime() = %v, want %v", uptime, time.Hour)
+	}
 
-	server, client := net.Pipe()
-	defer client.Close()
-	go fakeSocketServer(t, server, &Response{
-		Return:  "OK",
-		Message: "1.0",
-	}, len(buf))
+	for _, tt := range []struct {
+		desc string
+		call func() (string, error)
+		want string
+	}{
+		{desc: "version", call: s.Version, want: "4.1.4 RELEASE"},
+		{desc: "running mode", call: s.RunningMode, want: "workers"},
+		{desc: "capture mode", call: s.CaptureMode, want: "AF_PACKET_DEV"},
+	} {
+		got, err := tt.call()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.desc, got, tt.want)
+		}
+	}
 
-	s := &Socket{conn: client}
-	if err := s.version(); err != nil {
+	ifaces, err := s.Interfaces()
+	if err != nil {
 		t.Fatal(err)
 	}
-}
+	if diff := cmp.Diff(&Interfaces{Count: 1, Ifaces: []string{"eth0"}}, ifaces); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
 
-func TestInvalidCommandName(t *testing.T) {
-	_, client := net.Pipe()
-	defer client.Close()
-	s := &Socket{conn: client}
-	_, err := s.Send(&Command{Name: "list-iface"})
-	if err == nil {
+	istats, err := s.InterfaceStats("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&InterfaceStats{Packets: 100, Drops: 2, InvalidChecksums: 1, Bypassed: 3}, istats); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	counters, err := s.DumpCounters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := counters.Int("capture.kernel_drops"); !ok || n != 2 {
+		t.Errorf("Counters.Int(capture.kernel_drops) = %d, %t, want 2, true", n, ok)
+	}
+	if _, ok := counters.Int("capture.missing"); ok {
+		t.Error("Counters.Int(capture.missing) returned ok for a missing counter")
+	}
+
+	if err := s.ReloadRules(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ReloadRulesNonblocking(); err != nil {
 		t.Fatal(err)
 	}
-}
 
-func TestInvalidResponse(t *testing.T) {
-	cmd := &Command{Name: ReloadRules}
-	buf, err := json.Marshal(cmd)
+	rstats, err := s.RulesetStats()
 	if err != nil {
 		t.Fatal(err)
 	}
+	if diff := cmp.Diff([]*RuleStats{{RulesLoaded: 10, RulesFailed: 1}}, rstats); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
 
-	server, client := net.Pipe()
-	defer client.Close()
-	go fakeSocketServer(t, server, &Response{
-		Return:  "NOK",
-		Message: "error",
-	}, len(buf))
+	failed, err := s.FailedRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*FailedRule{{
+		Rule:     "alert ip any any -> any any (foo; sid:1;)",
+		Filename: "/etc/suricata/rules/emitto.rules",
+		Line:     3,
+	}}, failed); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
 
-	s := &Socket{conn: client}
-	if _, err := s.Send(cmd); err == nil {
+	reloads, err := s.ReloadTimes()
+	if err != nil {
 		t.Fatal(err)
 	}
+	if len(reloads) != 1 {
+		t.Fatalf("got %d reload times, want 1", len(reloads))
+	}
+	rt, err := reloads[0].Time()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2019, 7, 24, 13, 37, 18, 105357000, time.UTC); !rt.Equal(want) {
+		t.Errorf("ReloadTime.Time() = %v, want %v", rt, want)
+	}
+
+	memcaps, err := s.Memcaps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*Memcap{
+		{Name: "stream", Value: "64mb"},
+		{Name: "defrag", Value: "33554432"},
+	}, memcaps); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if err := s.SetMemcap("stream", "1gb"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DatasetAdd("bad_ips", "ipv4", "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DatasetRemove("bad_ips", "ipv4", "10.0.0.2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.conns != 1 {
+		t.Errorf("got %d connections, want a single persistent connection", srv.conns)
+	}
+	wantArgs := map[CommandName]map[string]string{
+		IfaceStat:     {"iface": "eth0"},
+		MemcapSet:     {"config": "stream", "memcap": "1gb"},
+		DatasetAdd:    {"setname": "bad_ips", "settype": "ipv4", "datavalue": "10.0.0.1"},
+		DatasetRemove: {"setname": "bad_ips", "settype": "ipv4", "datavalue": "10.0.0.2"},
+	}
+	for _, cmd := range srv.commands {
+		if diff := cmp.Diff(wantArgs[cmd.Name], cmd.Args); diff != "" {
+			t.Errorf("%s arguments mismatch (-want +got):\n%s", cmd.Name, diff)
+		}
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	srv := newFakeServer(t, map[CommandName]*Response{
+		SuricataVersion: okResponse(`"4.1.4 RELEASE"`),
+	})
+	defer srv.close()
+
+	s := New(srv.addr)
+	defer s.Close()
+	if _, err := s.Version(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the connection from the client side, as if Suricata restarted.
+	s.mu.Lock()
+	s.conn.Close()
+	s.mu.Unlock()
+	if _, err := s.Version(); err == nil {
+		t.Fatal("expected an error on a closed connection")
+	}
+	if _, err := s.Version(); err != nil {
+		t.Fatalf("expected the connection to be re-established: %v", err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.conns != 2 {
+		t.Errorf("got %d connections, want 2", srv.conns)
+	}
+}
+
+func TestInvalidCommandName(t *testing.T) {
+	s := New("/tmp/nonexistent.socket")
+	if _, err := s.Send(&Command{Name: "list-iface"}); err == nil {
+		t.Fatal("expected an error for an unsupported command")
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	srv := newFakeServer(t, map[CommandName]*Response{
+		ReloadRules: {Return: "NOK", Message: json.RawMessage(`"error"`)},
+	})
+	defer srv.close()
+
+	s := New(srv.addr)
+	defer s.Close()
+	if err := s.ReloadRules(); err == nil {
+		t.Fatal("expected an error for a NOK response")
+	}
 }
 
 func TestConnectTimeout(t *testing.T) {
+	defer func(d time.Duration) { retryInterval = d }(retryInterval)
+	retryInterval = time.Millisecond
+
 	p := "/tmp/nonexistent.socket"
 	s := New(p)
-	if err := s.Connect(); !strings.Contains(err.Error(), "connect: no such file or directory") {
+	if err := s.Connect(); err == nil || !strings.Contains(err.Error(), "connect: no such file or directory") {
 		t.Fatalf("expected connection timeout from: %s", p)
 	}
 }
+
+func TestCommandTimeout(t *testing.T) {
+	defer func(d time.Duration) { commandTimeout = d }(commandTimeout)
+	commandTimeout = 10 * time.Millisecond
+
+	// The server accepts connections but never answers.
+	d, err := ioutil.TempDir("", "socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	addr := filepath.Join(d, "suricata.socket")
+	l, err := net.Listen("unix", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	s := New(addr)
+	defer s.Close()
+	if err := s.Connect(); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("got err=%v, want a timeout", err)
+	}
+}
+
+func TestConnectReleasesLock(t *testing.T) {
+	defer func(d time.Duration) { retryInterval = d }(retryInterval)
+	retryInterval = 200 * time.Millisecond
+
+	s := New("/tmp/nonexistent.socket")
+	done := make(chan error)
+	go func() { done <- s.Connect() }()
+	time.Sleep(10 * time.Millisecond)
+	// Close takes the mutex, which must not be held while waiting between connection attempts.
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+		t.Error("Close() waited for the connection attempts")
+	default:
+	}
+	if err := <-done; err == nil {
+		t.Error("expected a connection error")
+	}
+}
@@ -16,20 +16,64 @@
 package suricata
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/emitto/source/sensor/suricata/socket"
 )
 
-// Socket represents a Suricata unix socket connection.
+// Socket represents a Suricata Unix socket connection.
 type Socket interface {
-	// Connect to socket service.
-	Connect() error
-	// Send command over socket connection.
-	Send(*socket.Command) (*socket.Response, error)
-	// Close socket connection.
+	// ReloadRules reloads the ruleset.
+	ReloadRules() error
+	// ReloadTimes returns the time of the last successful ruleset reload per tenant.
+	ReloadTimes() ([]*socket.ReloadTime, error)
+	// RulesetStats returns the number of loaded and failed rules per tenant.
+	RulesetStats() ([]*socket.RuleStats, error)
+	// FailedRules returns the rules which failed to load.
+	FailedRules() ([]*socket.FailedRule, error)
+	// Version returns the Suricata version.
+	Version() (string, error)
+	// Uptime returns the time Suricata has been running.
+	Uptime() (time.Duration, error)
+	// RunningMode returns the Suricata running mode.
+	RunningMode() (string, error)
+	// Interfaces returns the interfaces Suricata is capturing on.
+	Interfaces() (*socket.Interfaces, error)
+	// InterfaceStats returns the capture statistics of an interface.
+	InterfaceStats(iface string) (*socket.InterfaceStats, error)
+	// DumpCounters returns the Suricata performance counters.
+	DumpCounters() (socket.Counters, error)
+	// DatasetAdd adds a value to a dataset.
+	DatasetAdd(name, typ, value string) error
+	// DatasetRemove removes a value from a dataset.
+	DatasetRemove(name, typ, value string) error
+	// Send sends a command and returns its response.
+	Send(cmd *socket.Command) (*socket.Response, error)
+	// Close the socket connection.
 	Close() error
 }
 
-// Controller controls Suricata via its Unix socket service.
+// Health contains Suricata health information.
+type Health struct {
+	// Suricata version.
+	Version string
+	// Time Suricata has been running.
+	Uptime time.Duration
+	// Suricata running mode.
+	RunningMode string
+	// Capture statistics by interface name.
+	Interfaces map[string]*socket.InterfaceStats
+	// Kernel capture counters.
+	KernelPackets int64
+	KernelDrops   int64
+	// Ruleset statistics, summed across tenants.
+	RulesLoaded int64
+	RulesFailed int64
+}
+
+// Controller controls Suricata via its Unix socket service. The socket connection is kept open
+// across commands.
 type Controller struct {
 	sock Socket
 }
@@ -41,17 +85,105 @@ func NewController(sockAddr string) *Controller {
 
 // ReloadRules issues a command to Suricata to reload the rules engine with updated rules.
 func (c *Controller) ReloadRules() error {
-	if err := c.sock.Connect(); err != nil {
-		return err
+	return c.sock.ReloadRules()
+}
+
+// LastReloadTime returns the time of the last successful ruleset reload.
+func (c *Controller) LastReloadTime() (time.Time, error) {
+	reloads, err := c.sock.ReloadTimes()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(reloads) == 0 {
+		return time.Time{}, errors.New("no ruleset reload time reported")
+	}
+	return reloads[0].Time()
+}
+
+// RulesetStats returns the number of loaded and failed rules per tenant.
+func (c *Controller) RulesetStats() ([]*socket.RuleStats, error) {
+	return c.sock.RulesetStats()
+}
+
+// FailedRules returns the rules which failed to load during the last ruleset reload.
+func (c *Controller) FailedRules() ([]*socket.FailedRule, error) {
+	return c.sock.FailedRules()
+}
+
+// Version returns the Suricata version.
+func (c *Controller) Version() (string, error) {
+	return c.sock.Version()
+}
+
+// DumpCounters returns the Suricata performance counters.
+func (c *Controller) DumpCounters() (socket.Counters, error) {
+	return c.sock.DumpCounters()
+}
+
+// DatasetAdd adds a value to a loaded dataset, without a ruleset reload.
+func (c *Controller) DatasetAdd(name, typ, value string) error {
+	return c.sock.DatasetAdd(name, typ, value)
+}
+
+// DatasetRemove removes a value from a loaded dataset, without a ruleset reload.
+func (c *Controller) DatasetRemove(name, typ, value string) error {
+	return c.sock.DatasetRemove(name, typ, value)
+}
+
+// RunCommand runs a socket command and returns its raw JSON response message.
+func (c *Controller) RunCommand(name string, args map[string]string) ([]byte, error) {
+	r, err := c.sock.Send(&socket.Command{Name: socket.CommandName(name), Args: args})
+	if err != nil {
+		return nil, err
 	}
-	defer c.sock.Close()
+	return r.Message, nil
+}
 
-	_, err := c.sock.Send(&socket.Command{
-		Name: socket.ReloadRules,
-	})
+// Health collects Suricata health information.
+func (c *Controller) Health() (*Health, error) {
+	var (
+		h   = &Health{Interfaces: make(map[string]*socket.InterfaceStats)}
+		err error
+	)
+	if h.Version, err = c.sock.Version(); err != nil {
+		return nil, err
+	}
+	if h.Uptime, err = c.sock.Uptime(); err != nil {
+		return nil, err
+	}
+	if h.RunningMode, err = c.sock.RunningMode(); err != nil {
+		return nil, err
+	}
+	ifaces, err := c.sock.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range ifaces.Ifaces {
+		st, err := c.sock.InterfaceStats(i)
+		if err != nil {
+			return nil, err
+		}
+		h.Interfaces[i] = st
+	}
+	counters, err := c.sock.DumpCounters()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	// Kernel counters are only reported by some capture methods, e.g. AF_PACKET.
+	h.KernelPackets, _ = counters.Int("capture.kernel_packets")
+	h.KernelDrops, _ = counters.Int("capture.kernel_drops")
+	stats, err := c.sock.RulesetStats()
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range stats {
+		h.RulesLoaded += st.RulesLoaded
+		h.RulesFailed += st.RulesFailed
+	}
+	return h, nil
+}
 
-	return nil
+// Close closes the Suricata socket connection.
+func (c *Controller) Close() error {
+	return c.sock.Close()
 }
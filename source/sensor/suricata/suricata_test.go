@@ -15,33 +15,161 @@
 package suricata
 
 import (
-	"net"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/emitto/source/sensor/suricata/socket"
+	"github.com/google/go-cmp/cmp"
 )
 
 type fakeSocket struct {
-	conn net.Conn
+	Socket // Panics for unimplemented methods.
 
-	sendResponse *socket.Response
+	reloadErr error
+	reloads   []*socket.ReloadTime
 }
 
-func (s *fakeSocket) Connect() error { return nil }
-func (s *fakeSocket) Close() error   { return nil }
+func (s *fakeSocket) ReloadRules() error { return s.reloadErr }
+func (s *fakeSocket) Close() error       { return nil }
 
-func (s *fakeSocket) Send(*socket.Command) (*socket.Response, error) {
-	return s.sendResponse, nil
+func (s *fakeSocket) ReloadTimes() ([]*socket.ReloadTime, error) {
+	return s.reloads, nil
 }
 
 func TestReloadRules(t *testing.T) {
-	fs := new(fakeSocket)
-	fs.sendResponse = &socket.Response{
-		Return: "OK",
+	ctrl := &Controller{&fakeSocket{}}
+	if err := ctrl.ReloadRules(); err != nil {
+		t.Fatal(err)
+	}
+	ctrl = &Controller{&fakeSocket{reloadErr: errors.New("NOK")}}
+	if err := ctrl.ReloadRules(); err == nil {
+		t.Fatal("expected a reload error")
+	}
+}
+
+func TestLastReloadTime(t *testing.T) {
+	fs := &fakeSocket{
+		reloads: []*socket.ReloadTime{{LastReload: "2019-07-24T13:37:18.105357+0000"}},
 	}
 
 	ctrl := &Controller{fs}
-	if err := ctrl.ReloadRules(); err != nil {
+	got, err := ctrl.LastReloadTime()
+	if err != nil {
 		t.Fatal(err)
 	}
+	if want := time.Date(2019, 7, 24, 13, 37, 18, 105357000, time.UTC); !got.Equal(want) {
+		t.Errorf("LastReloadTime() = %v, want %v", got, want)
+	}
+
+	if _, err := (&Controller{&fakeSocket{}}).LastReloadTime(); err == nil {
+		t.Error("expected an error when no reload time is reported")
+	}
+}
+
+type fakeHealthSocket struct {
+	fakeSocket
+}
+
+func (s *fakeHealthSocket) Version() (string, error)       { return "4.1.4 RELEASE", nil }
+func (s *fakeHealthSocket) Uptime() (time.Duration, error) { return time.Hour, nil }
+func (s *fakeHealthSocket) RunningMode() (string, error)   { return "workers", nil }
+func (s *fakeHealthSocket) Interfaces() (*socket.Interfaces, error) {
+	return &socket.Interfaces{Count: 1, Ifaces: []string{"eth0"}}, nil
+}
+func (s *fakeHealthSocket) InterfaceStats(string) (*socket.InterfaceStats, error) {
+	return &socket.InterfaceStats{Packets: 100, Drops: 2}, nil
+}
+func (s *fakeHealthSocket) DumpCounters() (socket.Counters, error) {
+	return socket.Counters{"capture": map[string]interface{}{"kernel_packets": 100.0, "kernel_drops": 2.0}}, nil
+}
+func (s *fakeHealthSocket) RulesetStats() ([]*socket.RuleStats, error) {
+	return []*socket.RuleStats{{RulesLoaded: 10, RulesFailed: 1}}, nil
+}
+
+func TestHealth(t *testing.T) {
+	ctrl := &Controller{new(fakeHealthSocket)}
+	got, err := ctrl.Health()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Health{
+		Version:       "4.1.4 RELEASE",
+		Uptime:        time.Hour,
+		RunningMode:   "workers",
+		Interfaces:    map[string]*socket.InterfaceStats{"eth0": {Packets: 100, Drops: 2}},
+		KernelPackets: 100,
+		KernelDrops:   2,
+		RulesLoaded:   10,
+		RulesFailed:   1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type fakeCommandSocket struct {
+	fakeSocket
+
+	cmd *socket.Command
+}
+
+func (s *fakeCommandSocket) Send(cmd *socket.Command) (*socket.Response, error) {
+	s.cmd = cmd
+	if cmd.Name == socket.Shutdown {
+		return nil, errors.New("NOK")
+	}
+	return &socket.Response{Return: "OK", Message: []byte(`{"pkts":10}`)}, nil
+}
+
+func TestRunCommand(t *testing.T) {
+	fs := new(fakeCommandSocket)
+	ctrl := &Controller{fs}
+	args := map[string]string{"iface": "eth0"}
+	got, err := ctrl.RunCommand("iface-stat", args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `{"pkts":10}` {
+		t.Errorf("got result %s, want %s", got, `{"pkts":10}`)
+	}
+	if diff := cmp.Diff(&socket.Command{Name: socket.IfaceStat, Args: args}, fs.cmd); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := ctrl.RunCommand("shutdown", nil); err == nil {
+		t.Error("expected an error for a failed command")
+	}
+}
+
+type fakeDatasetSocket struct {
+	fakeSocket
+
+	added, removed []string
+}
+
+func (s *fakeDatasetSocket) DatasetAdd(name, typ, value string) error {
+	s.added = append(s.added, name+"/"+typ+"/"+value)
+	return nil
+}
+
+func (s *fakeDatasetSocket) DatasetRemove(name, typ, value string) error {
+	s.removed = append(s.removed, name+"/"+typ+"/"+value)
+	return nil
+}
+
+func TestDatasets(t *testing.T) {
+	fs := new(fakeDatasetSocket)
+	ctrl := &Controller{fs}
+	if err := ctrl.DatasetAdd("bad_ips", "ipv4", "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctrl.DatasetRemove("bad_ips", "ipv4", "10.0.0.2"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"bad_ips/ipv4/10.0.0.1"}, fs.added); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"bad_ips/ipv4/10.0.0.2"}, fs.removed); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
 }
@@ -0,0 +1,157 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suricata
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// Matches the per-rule parsing failure, e.g.
+	// error parsing signature "alert ..." from file /etc/suricata/rules/emitto.rules at line 3
+	sigErrorRE = regexp.MustCompile(`error parsing signature "(.*)" from file (.*) at line (\d+)`)
+	// Matches Suricata error messages across versions, e.g.
+	// [ERRCODE: SC_ERR_INVALID_SIGNATURE(39)] - unknown rule keyword 'foo'.
+	// Error: detect-parse: unknown rule keyword 'foo'.
+	errCodeRE = regexp.MustCompile(`\[ERRCODE: [^\]]+\] - (.*)$|(?:^|\s)(?:Error|E): (?:[\w-]+: )?(.*)$`)
+	// Matches the rule SID option, which starts the options or follows another option.
+	sidRE = regexp.MustCompile(`(?:^|;|\()\s*sid\s*:\s*(\d+)\s*;`)
+	// validationTimeout bounds a Suricata test run, after which Suricata is killed.
+	validationTimeout = 5 * time.Minute
+)
+
+// RuleError describes a rule which Suricata failed to load.
+type RuleError struct {
+	// Line number of the rule in the rule file.
+	Line int
+	// SID of the rule, if it could be determined.
+	SID int64
+	// The rule itself.
+	Rule string
+	// Error messages reported by Suricata for the rule.
+	Message string
+}
+
+// ValidationError is returned when Suricata rejects a rule file.
+type ValidationError struct {
+	// Errors for individual rules.
+	RuleErrors []*RuleError
+	// Suricata output, used when no individual rule errors could be parsed.
+	Output string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.RuleErrors) == 0 {
+		return fmt.Sprintf("suricata rejected the rule file: %s", e.Output)
+	}
+	return fmt.Sprintf("suricata rejected %d rule(s)", len(e.RuleErrors))
+}
+
+// Validator validates rule files by running the Suricata binary in test mode.
+type Validator struct {
+	binary string
+	config string
+}
+
+// NewValidator creates a new Validator for the provided Suricata binary and configuration file.
+func NewValidator(binary, config string) *Validator {
+	return &Validator{binary: binary, config: config}
+}
+
+// Validate tests the rule file with the Suricata configuration. A *ValidationError is returned if
+// Suricata rejects the rule file, and an error if it does not complete within the timeout.
+func (v *Validator) Validate(ruleFile string) error {
+	logDir, err := ioutil.TempDir("", "suricata-test")
+	if err != nil {
+		return fmt.Errorf("failed to create log directory: %v", err)
+	}
+	defer os.RemoveAll(logDir)
+
+	args := []string{"-T", "-S", ruleFile, "-l", logDir}
+	if v.config != "" {
+		args = append(args, "-c", v.config)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), validationTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, v.binary, args...).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%q did not complete within %v", v.binary, validationTimeout)
+	}
+	if _, ok := err.(*exec.ExitError); !ok {
+		return fmt.Errorf("failed to run %q: %v", v.binary, err)
+	}
+	return &ValidationError{
+		RuleErrors: parseTestOutput(out),
+		Output:     strings.TrimSpace(string(out)),
+	}
+}
+
+// parseTestOutput extracts the rule errors from Suricata test mode output. Error messages
+// preceding a signature parsing failure are attributed to that signature.
+func parseTestOutput(out []byte) []*RuleError {
+	var (
+		errs []*RuleError
+		msgs []string
+	)
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		line := s.Text()
+		if m := sigErrorRE.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.Atoi(m[3])
+			errs = append(errs, &RuleError{
+				Line:    n,
+				SID:     ParseSID(m[1]),
+				Rule:    m[1],
+				Message: strings.Join(msgs, "; "),
+			})
+			msgs = nil
+			continue
+		}
+		if m := errCodeRE.FindStringSubmatch(line); m != nil {
+			msg := m[1]
+			if msg == "" {
+				msg = m[2]
+			}
+			msgs = append(msgs, strings.TrimSpace(msg))
+		}
+	}
+	return errs
+}
+
+// ParseSID returns the SID of a rule, or 0 if it cannot be determined.
+func ParseSID(rule string) int64 {
+	m := sidRE.FindStringSubmatch(rule)
+	if m == nil {
+		return 0
+	}
+	sid, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return sid
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suricata
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeSuricata writes a shell script which mimics Suricata test mode output.
+func fakeSuricata(t *testing.T, dir, output string, exitCode int) string {
+	t.Helper()
+	p := filepath.Join(dir, "suricata")
+	script := fmt.Sprintf("#!/bin/sh\ncat <<'EOF'\n%s\nEOF\nexit %d\n", output, exitCode)
+	if err := ioutil.WriteFile(p, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestValidate(t *testing.T) {
+	d, err := ioutil.TempDir("", "validator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, tt := range []struct {
+		desc     string
+		output   string
+		exitCode int
+		want     []*RuleError
+		wantErr  bool
+	}{
+		{
+			desc:   "valid rules",
+			output: `<Notice> - Configuration provided was successfully loaded. Exiting.`,
+		},
+		{
+			desc: "suricata 4 rule errors",
+			output: `[ERRCODE: SC_ERR_INVALID_SIGNATURE(39)] - unknown rule keyword 'foo'.
+[ERRCODE: SC_ERR_INVALID_SIGNATURE(39)] - error parsing signature "alert tcp any any -> any any (foo; sid:100;)" from file /tmp/emitto.rules at line 2
+[ERRCODE: SC_ERR_NO_RULES_LOADED(43)] - 1 rule files specified, but no rules were loaded!`,
+			exitCode: 1,
+			want: []*RuleError{
+				{
+					Line:    2,
+					SID:     100,
+					Rule:    "alert tcp any any -> any any (foo; sid:100;)",
+					Message: "unknown rule keyword 'foo'.",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "suricata 6 rule errors",
+			output: `E: detect-parse: unknown rule keyword 'foo'.
+E: detect: error parsing signature "alert tcp any any -> any any (foo; sid:100;)" from file /tmp/emitto.rules at line 2
+E: detect-parse: duplicated rule sid
+E: detect: error parsing signature "alert ip any any -> any any (sid:100;)" from file /tmp/emitto.rules at line 3`,
+			exitCode: 1,
+			want: []*RuleError{
+				{
+					Line:    2,
+					SID:     100,
+					Rule:    "alert tcp any any -> any any (foo; sid:100;)",
+					Message: "unknown rule keyword 'foo'.",
+				},
+				{
+					Line:    3,
+					SID:     100,
+					Rule:    "alert ip any any -> any any (sid:100;)",
+					Message: "duplicated rule sid",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "config error",
+			output:   `<Error> - [ERRCODE: SC_ERR_FOPEN(44)] - failed to open file: /etc/suricata/suricata.yaml`,
+			exitCode: 1,
+			wantErr:  true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			v := NewValidator(fakeSuricata(t, d, tt.output, tt.exitCode), "/etc/suricata/suricata.yaml")
+			err := v.Validate(filepath.Join(d, "emitto.rules"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got err=%v, wantErr=%t", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			verr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("got error of type %T, want *ValidationError", err)
+			}
+			if diff := cmp.Diff(tt.want, verr.RuleErrors); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMissingBinary(t *testing.T) {
+	v := NewValidator("/nonexistent/suricata", "")
+	err := v.Validate("/tmp/emitto.rules")
+	if err == nil {
+		t.Fatal("expected an error for a missing binary")
+	}
+	if _, ok := err.(*ValidationError); ok {
+		t.Errorf("got a *ValidationError for a missing binary: %v", err)
+	}
+}
+
+func TestValidateTimeout(t *testing.T) {
+	defer func(d time.Duration) { validationTimeout = d }(validationTimeout)
+	validationTimeout = 10 * time.Millisecond
+
+	d, err := ioutil.TempDir("", "validator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	p := filepath.Join(d, "suricata")
+	if err := ioutil.WriteFile(p, []byte("#!/bin/sh\nexec sleep 10\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	err = NewValidator(p, "").Validate(filepath.Join(d, "emitto.rules"))
+	if err == nil {
+		t.Fatal("expected an error for a hung Suricata")
+	}
+	if _, ok := err.(*ValidationError); ok {
+		t.Errorf("got a *ValidationError for a hung Suricata: %v", err)
+	}
+}
+
+func TestParseSID(t *testing.T) {
+	for _, tt := range []struct {
+		rule string
+		want int64
+	}{
+		{rule: `alert ip any any -> any any (msg:"test"; sid:1234; rev:1;)`, want: 1234},
+		{rule: `alert ip any any -> any any (msg:"test"; sid: 99 ;)`, want: 99},
+		{rule: `alert ip any any -> any any (msg:"test";)`, want: 0},
+		{rule: `alert ip any any -> any any (sid:5;)`, want: 5},
+		{rule: `alert ip any any -> any any (msg:"fsid:5;"; sid:10;)`, want: 10},
+		{rule: `alert ip any any -> any any (msg:"test"; xsid:5;)`, want: 0},
+	} {
+		if got := ParseSID(tt.rule); got != tt.want {
+			t.Errorf("ParseSID(%q) = %d, want %d", tt.rule, got, tt.want)
+		}
+	}
+}
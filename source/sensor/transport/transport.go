@@ -0,0 +1,352 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transport provides the sensor side of the direct gRPC transport to the Emitto server,
+// used instead of Fleetspeak where it cannot run.
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/sensor/fleetspeak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	log "github.com/golang/glog"
+	pb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+)
+
+const (
+	// Service name set on received messages, as for Fleetspeak messages.
+	serviceName = "Emitto"
+	// Maximum number of received messages awaiting processing.
+	maxMessages = 5
+)
+
+var (
+	// Delays between reconnection attempts, doubling from minBackoff up to maxBackoff.
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// Config configures a Client.
+type Config struct {
+	// Address of the Emitto server sensor endpoint, e.g. "emitto.example.com:4445".
+	Addr string
+	// Transport credentials; the connection is not encrypted if nil, which is only suitable for
+	// testing.
+	Creds credentials.TransportCredentials
+	// Client ID of the sensor.
+	ClientID []byte
+	// Labels of the sensor, used by the server to target requests.
+	Labels []string
+	// Time after which an unacknowledged message is considered lost.
+	AckTimeout time.Duration
+}
+
+// stream is an established stream to the server.
+type stream struct {
+	s pb.SensorTransport_ConnectClient
+	// Serializes sends on the stream.
+	sendMu sync.Mutex
+	// Closed when the stream fails.
+	broken chan struct{}
+	// Acknowledgement channels of the messages awaiting acknowledgement, by message ID.
+	acks map[string]chan struct{}
+}
+
+// Client is a sensor client of the gRPC transport, implementing the same interface as the
+// Fleetspeak client. It keeps a stream open to the server, reconnecting with backoff.
+type Client struct {
+	cfg      *Config
+	conn     *grpc.ClientConn
+	messages chan *fspb.Message
+
+	mu     sync.Mutex
+	labels []string
+	// Current stream; nil while disconnected.
+	cur *stream
+	// Closed when a stream is established.
+	ready chan struct{}
+	state fleetspeak.State
+}
+
+// New returns a Client connecting to the server; the connection is established by Receive.
+func New(cfg *Config) (*Client, error) {
+	if cfg.Addr == "" {
+		return nil, errors.New("no server address")
+	}
+	if len(cfg.ClientID) == 0 {
+		return nil, errors.New("no client ID")
+	}
+	if cfg.AckTimeout <= 0 {
+		return nil, errors.New("no acknowledgement timeout")
+	}
+	opt := grpc.WithInsecure()
+	if cfg.Creds != nil {
+		opt = grpc.WithTransportCredentials(cfg.Creds)
+	}
+	conn, err := grpc.Dial(cfg.Addr, opt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %v", cfg.Addr, err)
+	}
+	return &Client{
+		cfg:      cfg,
+		conn:     conn,
+		messages: make(chan *fspb.Message, maxMessages),
+		labels:   cfg.Labels,
+		ready:    make(chan struct{}),
+	}, nil
+}
+
+// SendMessage sends a message to the server, and blocks until the server has acknowledged it,
+// sending it again on a new stream if the current one fails in the meantime, or until the
+// acknowledgement timeout expires.
+func (c *Client) SendMessage(m *pb.SensorMessage) (string, error) {
+	up := &pb.TransportUpstream{Type: &pb.TransportUpstream_Message{Message: m}}
+	timeout := time.NewTimer(c.cfg.AckTimeout)
+	defer timeout.Stop()
+	for {
+		st, err := c.waitStream(timeout.C)
+		if err != nil {
+			return "", fmt.Errorf("failed to send message (%s): %v", m.GetId(), err)
+		}
+		ack := make(chan struct{})
+		st.sendMu.Lock()
+		st.acks[m.GetId()] = ack
+		err = st.s.Send(up)
+		st.sendMu.Unlock()
+		if err != nil {
+			log.Warningf("Failed to send message (%s): %v; retrying", m.GetId(), err)
+			c.disconnect(st)
+			continue
+		}
+		c.update(func(s *fleetspeak.State) {
+			s.Sent++
+			s.LastSent = time.Now()
+		})
+		log.Infof("Sent message (%s) to the server; awaiting acknowledgement...", m.GetId())
+		select {
+		case <-ack:
+			c.update(func(s *fleetspeak.State) {
+				s.Acknowledged++
+				s.LastAcknowledged = time.Now()
+			})
+			return m.GetId(), nil
+		case <-st.broken:
+			log.Warningf("Stream failed before message (%s) was acknowledged; retrying", m.GetId())
+		case <-timeout.C:
+			st.sendMu.Lock()
+			delete(st.acks, m.GetId())
+			st.sendMu.Unlock()
+			return "", fmt.Errorf("timed out waiting for the acknowledgement of message (%s)", m.GetId())
+		}
+	}
+}
+
+// SetLabels changes the labels of the sensor, registering them again on the current stream.
+func (c *Client) SetLabels(labels []string) {
+	c.mu.Lock()
+	c.labels = labels
+	st := c.cur
+	c.mu.Unlock()
+	if st == nil {
+		return
+	}
+	st.sendMu.Lock()
+	defer st.sendMu.Unlock()
+	if err := st.s.Send(c.registration(labels)); err != nil {
+		log.Warningf("Failed to register new labels: %v", err)
+	}
+}
+
+func (c *Client) registration(labels []string) *pb.TransportUpstream {
+	return &pb.TransportUpstream{Type: &pb.TransportUpstream_Registration{
+		Registration: &pb.Registration{ClientId: c.cfg.ClientID, Labels: labels},
+	}}
+}
+
+// Receive keeps a stream open to the server, reconnecting with backoff, and passes the received
+// requests to the Messages channel until done is closed.
+func (c *Client) Receive(done <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-done
+		cancel()
+	}()
+	backoff := minBackoff
+	for {
+		if err := c.connect(ctx, func() { backoff = minBackoff }); err != nil {
+			log.Warningf("Stream to the server at %s failed: %v", c.cfg.Addr, err)
+		}
+		select {
+		case <-ctx.Done():
+			log.Warning("Stopped receiving messages from the server")
+			close(c.messages)
+			c.conn.Close()
+			return
+		case <-time.After(jitter(backoff)):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// connect opens a stream, registers the sensor and receives messages until the stream fails.
+// registered is called once the sensor is registered.
+func (c *Client) connect(ctx context.Context, registered func()) error {
+	s, err := pb.NewSensorTransportClient(c.conn).Connect(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	labels := c.labels
+	c.mu.Unlock()
+	if err := s.Send(c.registration(labels)); err != nil {
+		return err
+	}
+	st := &stream{s: s, broken: make(chan struct{}), acks: make(map[string]chan struct{})}
+	c.mu.Lock()
+	c.cur = st
+	close(c.ready)
+	c.mu.Unlock()
+	defer c.disconnect(st)
+	registered()
+	log.Infof("Connected to the server at %s", c.cfg.Addr)
+
+	for {
+		down, err := s.Recv()
+		if err != nil {
+			return err
+		}
+		switch t := down.Type.(type) {
+		case *pb.TransportDownstream_Ack:
+			st.sendMu.Lock()
+			if ack, ok := st.acks[t.Ack]; ok {
+				close(ack)
+				delete(st.acks, t.Ack)
+			}
+			st.sendMu.Unlock()
+		case *pb.TransportDownstream_Request:
+			data, err := ptypes.MarshalAny(t.Request)
+			if err != nil {
+				log.Errorf("Failed to marshal request (%s): %v", t.Request.GetId(), err)
+				continue
+			}
+			log.Infof("Received request (%s) from the server", t.Request.GetId())
+			c.update(func(s *fleetspeak.State) {
+				s.Received++
+				s.LastReceived = time.Now()
+			})
+			m := &fspb.Message{
+				SourceMessageId: []byte(t.Request.GetId()),
+				Source:          &fspb.Address{ServiceName: serviceName},
+				Destination:     &fspb.Address{ServiceName: serviceName, ClientId: c.cfg.ClientID},
+				Data:            data,
+			}
+			select {
+			case c.messages <- m:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		default:
+			log.Errorf("Unknown downstream message type (%T)", t)
+		}
+	}
+}
+
+// waitStream returns the current stream, waiting for one to be established until timeout.
+func (c *Client) waitStream(timeout <-chan time.Time) (*stream, error) {
+	for {
+		c.mu.Lock()
+		st, ready := c.cur, c.ready
+		c.mu.Unlock()
+		if st != nil {
+			return st, nil
+		}
+		select {
+		case <-ready:
+		case <-timeout:
+			return nil, errors.New("timed out waiting for a connection to the server")
+		}
+	}
+}
+
+// disconnect marks a stream as failed, if it is still the current one.
+func (c *Client) disconnect(st *stream) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cur != st {
+		return
+	}
+	c.cur = nil
+	c.ready = make(chan struct{})
+	close(st.broken)
+}
+
+func (c *Client) update(fn func(*fleetspeak.State)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn(&c.state)
+}
+
+// Messages returns the channel containing the requests received from the server.
+func (c *Client) Messages() chan *fspb.Message {
+	return c.messages
+}
+
+// State returns the current State of the transport.
+func (c *Client) State() fleetspeak.State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// jitter returns a random duration between d/2 and d.
+func jitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// ClientCredentials returns the TLS credentials of a sensor, presenting its certificate and
+// verifying the server certificate against the CAs in caFile.
+func ClientCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sensor certificate: %v", err)
+	}
+	b, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server CA file %q: %v", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificate in server CA file %q", caFile)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/server/transport"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+)
+
+type fakeProcessor struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (p *fakeProcessor) Process(ctx context.Context, m *fspb.Message) (*fspb.EmptyMessage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ids = append(p.ids, string(m.GetSourceMessageId()))
+	return &fspb.EmptyMessage{}, nil
+}
+
+// serve serves the SensorTransport service of s on l.
+func serve(s *transport.Server, creds credentials.TransportCredentials, l net.Listener) *grpc.Server {
+	gs := grpc.NewServer(grpc.Creds(creds))
+	pb.RegisterSensorTransportServer(gs, s)
+	go gs.Serve(l)
+	return gs
+}
+
+// waitLabels waits until the sensor known to s has the wanted labels.
+func waitLabels(t *testing.T, s *transport.Server, want []string) {
+	t.Helper()
+	var got []string
+	for i := 0; i < 200; i++ {
+		got = nil
+		if clients, err := s.ListClients(context.Background()); err == nil {
+			for _, l := range clients[0].GetLabels() {
+				got = append(got, l.GetLabel())
+			}
+		}
+		if cmp.Equal(want, got) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("got labels %v, want %v", got, want)
+}
+
+func TestClient(t *testing.T) {
+	minBackoff, maxBackoff = 10*time.Millisecond, 50*time.Millisecond
+	ctx := context.Background()
+	p := &fakeProcessor{}
+	s := transport.NewServer()
+	s.SetProcessor(p)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	pki, err := transport.NewTestPKI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverCreds, err := pki.ServerCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := serve(s, serverCreds, l)
+
+	// The server identifies the sensor by its certificate.
+	creds, id, err := pki.SensorCredentials("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(&Config{Addr: addr, Creds: creds, ClientID: []byte{1, 2, 3, 4}, Labels: []string{"a"}, AckTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go c.Receive(done)
+
+	// Messages are sent once connected, and acknowledged.
+	ack, err := c.SendMessage(&pb.SensorMessage{Id: "m1"})
+	if err != nil || ack != "m1" {
+		t.Errorf("SendMessage() = %q, %v; want %q", ack, err, "m1")
+	}
+	waitLabels(t, s, []string{"a"})
+
+	// Requests are received as Fleetspeak messages.
+	if err := s.InsertMessage(ctx, &pb.SensorRequest{Id: "r1"}, id); err != nil {
+		t.Fatal(err)
+	}
+	m := <-c.Messages()
+	req := new(pb.SensorRequest)
+	if err := ptypes.UnmarshalAny(m.GetData(), req); err != nil {
+		t.Fatal(err)
+	}
+	if req.GetId() != "r1" {
+		t.Errorf("got request %q, want %q", req.GetId(), "r1")
+	}
+
+	c.SetLabels([]string{"b", "c"})
+	waitLabels(t, s, []string{"b", "c"})
+
+	// The client reconnects after the server restarts.
+	gs.Stop()
+	if l, err = net.Listen("tcp", addr); err != nil {
+		t.Fatal(err)
+	}
+	gs = serve(s, serverCreds, l)
+	if ack, err := c.SendMessage(&pb.SensorMessage{Id: "m2"}); err != nil || ack != "m2" {
+		t.Errorf("SendMessage() after reconnecting = %q, %v; want %q", ack, err, "m2")
+	}
+
+	st := c.State()
+	if st.Sent < 2 || st.Acknowledged != 2 || st.Received != 1 {
+		t.Errorf("got state %+v, want 2 acknowledged messages and 1 received", st)
+	}
+	p.mu.Lock()
+	if diff := cmp.Diff([]string{"m1", "m2"}, p.ids); diff != "" {
+		t.Errorf("processed messages mismatch (-want +got):\n%s", diff)
+	}
+	p.mu.Unlock()
+
+	// Sends time out while the server is down.
+	gs.Stop()
+	c.cfg.AckTimeout = 100 * time.Millisecond
+	if _, err := c.SendMessage(&pb.SensorMessage{Id: "m3"}); err == nil {
+		t.Error("expected an error while the server is down")
+	}
+
+	close(done)
+	for range c.Messages() {
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, cfg := range []*Config{
+		{ClientID: []byte{1}, AckTimeout: time.Second},
+		{Addr: "localhost:4445", AckTimeout: time.Second},
+		{Addr: "localhost:4445", ClientID: []byte{1}},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) expected an error", cfg)
+		}
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"path/filepath"
 	"regexp"
 	"strconv"
 
@@ -77,6 +79,28 @@ func (c *Client) DeployRules(ctx context.Context, loc *pb.Location) ([]*pb.Deplo
 	return responses, nil
 }
 
+// ImportIndicators imports the indicators of a local file in the given format, e.g. "csv",
+// generating rules enabled in the location zones. The file name is recorded as the indicator
+// source in the rules.
+func (c *Client) ImportIndicators(ctx context.Context, path, format string, locZones []string) (*pb.ImportIndicatorsResponse, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indicator file: %v", err)
+	}
+	resp, err := c.emitto.ImportIndicators(ctx, &pb.ImportIndicatorsRequest{
+		Format:        format,
+		Data:          data,
+		Source:        filepath.Base(path),
+		LocationZones: locZones,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("imported indicators from %q: %d rule(s) added, %d updated, %d unchanged, %d indicator(s) skipped",
+		path, len(resp.GetAddedRuleIds()), len(resp.GetUpdatedRuleIds()), len(resp.GetUnchangedRuleIds()), resp.GetSkipped())
+	return resp, nil
+}
+
 // getSID extracts the SID from a Suricata rule and casts it to an int64.
 func getSID(rule string) (int64, error) {
 	matches := suricataSIDRE.FindStringSubmatch(rule)
@@ -18,6 +18,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -66,18 +69,54 @@ func TestDeployRules(t *testing.T) {
 	}
 }
 
+func TestImportIndicators(t *testing.T) {
+	d, err := ioutil.TempDir("", "indicators")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	path := filepath.Join(d, "phishing.csv")
+	data := []byte("type,value\ndomain,evil.example.com\n")
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	fc := &fakeEmittoClient{importResp: &pb.ImportIndicatorsResponse{AddedRuleIds: []int64{1}}}
+	c := Client{emitto: fc}
+	got, err := c.ImportIndicators(context.Background(), path, "csv", []string{"a:dmz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(fc.importResp, got, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("ImportIndicators() expectation mismatch (-want +got):\n%s", diff)
+	}
+	want := &pb.ImportIndicatorsRequest{Format: "csv", Data: data, Source: "phishing.csv", LocationZones: []string{"a:dmz"}}
+	if diff := cmp.Diff(want, fc.importReq, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("ImportIndicators() expectation mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := c.ImportIndicators(context.Background(), filepath.Join(d, "missing.csv"), "csv", []string{"a:dmz"}); err == nil {
+		t.Error("ImportIndicators() expected an error for a missing file")
+	}
+}
+
 // fakeEmittoClient fakes emitto client, field of Client struct object.
 // This does not fake the implementation of DeployRules method of an actual
 // Client struct, rather it only fakes the stream to the service.
 type fakeEmittoClient struct {
 	pb.EmittoClient
-	stream *fakeEmittoDeployRulesClient
+	stream     *fakeEmittoDeployRulesClient
+	importReq  *pb.ImportIndicatorsRequest
+	importResp *pb.ImportIndicatorsResponse
 }
 
 func (c *fakeEmittoClient) DeployRules(ctx context.Context, in *pb.DeployRulesRequest, opts ...grpc.CallOption) (pb.Emitto_DeployRulesClient, error) {
 	return c.stream, nil
 }
 
+func (c *fakeEmittoClient) ImportIndicators(ctx context.Context, in *pb.ImportIndicatorsRequest, opts ...grpc.CallOption) (*pb.ImportIndicatorsResponse, error) {
+	c.importReq = in
+	return c.importResp, nil
+}
+
 type fakeEmittoDeployRulesClient struct {
 	grpc.ClientStream
 	responses []*pb.DeployRulesResponse
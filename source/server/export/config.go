@@ -0,0 +1,160 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// Config configures an Exporter, loaded from a JSON file such as:
+//
+//	{"format": "cef",
+//	 "kinds": ["sensor_alert", "alert_event"],
+//	 "fields": {"src": "src_ip", "dst": "dest_ip", "cs1Label": "=location", "cs1": "location"},
+//	 "output": {"type": "syslog", "network": "tls", "addr": "siem.example.com:6514",
+//	            "ca_file": "/etc/emitto/siem-ca.pem"},
+//	 "checkpoint": "/var/lib/emitto/export.json",
+//	 "interval": "30s",
+//	 "batch_size": 500}
+type Config struct {
+	// Format: "cef", "leef", "rfc5424" or "json".
+	Format string `json:"format"`
+	// Exported record kinds, all if empty.
+	Kinds []string `json:"kinds,omitempty"`
+	// Field mapping, replacing the default mapping of the format.
+	Fields Mapping       `json:"fields,omitempty"`
+	Output *OutputConfig `json:"output"`
+	// Path of the checkpoint file.
+	Checkpoint string `json:"checkpoint"`
+	// Export interval, e.g. "30s".
+	Interval string `json:"interval"`
+	// Maximum number of records per delivery; 500 if zero.
+	BatchSize int `json:"batch_size,omitempty"`
+}
+
+// OutputConfig configures an Output.
+type OutputConfig struct {
+	// Output type: "syslog" or "file".
+	Type string `json:"type"`
+	// Syslog network ("udp", "tcp" or "tls") and address.
+	Network string `json:"network,omitempty"`
+	Addr    string `json:"addr,omitempty"`
+	// PEM files of the CA certificates verifying the TLS syslog receiver, and of the client
+	// certificate and key, if required by the receiver. System CAs are used if CAFile is empty.
+	CAFile   string `json:"ca_file,omitempty"`
+	CertFile string `json:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty"`
+	// Path of the output file.
+	Path string `json:"path,omitempty"`
+}
+
+const defaultBatchSize = 500
+
+// LoadConfig reads a Config from a JSON file.
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export config %q: %v", path, err)
+	}
+	c := new(Config)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse export config %q: %v", path, err)
+	}
+	return c, nil
+}
+
+// Exporter creates the Exporter of the Config, returning it with the export interval.
+func (c *Config) Exporter(source Source) (*Exporter, time.Duration, error) {
+	interval, err := time.ParseDuration(c.Interval)
+	if err != nil || interval <= 0 {
+		return nil, 0, fmt.Errorf("invalid export interval %q", c.Interval)
+	}
+	f, err := NewFormatter(c.Format, c.Fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	kinds := c.Kinds
+	if len(kinds) == 0 {
+		kinds = []string{SensorAlert, AlertEvent}
+	}
+	batchSize := c.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+	if c.Output == nil {
+		return nil, 0, errors.New("no output")
+	}
+	o, err := c.Output.output()
+	if err != nil {
+		return nil, 0, err
+	}
+	e, err := NewExporter(source, kinds, f, o, c.Checkpoint, batchSize)
+	if err != nil {
+		o.Close()
+		return nil, 0, err
+	}
+	return e, interval, nil
+}
+
+func (c *OutputConfig) output() (Output, error) {
+	switch c.Type {
+	case "syslog":
+		if c.Addr == "" {
+			return nil, errors.New("no syslog address")
+		}
+		var config *tls.Config
+		if c.Network == "tls" {
+			var err error
+			if config, err = c.tlsConfig(); err != nil {
+				return nil, err
+			}
+		}
+		return NewSyslog(c.Network, c.Addr, config)
+	case "file":
+		if c.Path == "" {
+			return nil, errors.New("no output file")
+		}
+		return NewFile(c.Path)
+	}
+	return nil, fmt.Errorf("unsupported output type %q", c.Type)
+}
+
+func (c *OutputConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{}
+	if c.CAFile != "" {
+		b, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificates in %q", c.CAFile)
+		}
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
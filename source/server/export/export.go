@@ -0,0 +1,280 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export streams stored sensor alerts and alert events to SIEMs, as CEF, LEEF, RFC 5424
+// syslog or JSON Lines. Delivery is at-least-once: the position of every source is checkpointed
+// after each delivered batch. Positions are reception times assigned by the server, so records
+// reported late by sensors, e.g. replayed after an outage, are still exported.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/emitto/source/resources"
+
+	log "github.com/golang/glog"
+)
+
+// Source lists the stored records. It is implemented by store.Store.
+type Source interface {
+	ListSensorMessages(ctx context.Context, f *resources.SensorMessageFilter) ([]*resources.SensorMessage, error)
+	ListAlertEvents(ctx context.Context, f *resources.AlertEventFilter) ([]*resources.AlertEvent, error)
+}
+
+// receptionLag bounds the delay between the reception time of a record and the time it can be
+// listed from the store. Records received up to receptionLag before the cursor are listed again.
+const receptionLag = time.Minute
+
+// Cursor is the export position of a record kind: the latest reception time of the exported
+// records, and the reception times of the records exported within receptionLag of it, by ID.
+type Cursor struct {
+	Time   time.Time            `json:"time"`
+	Recent map[string]time.Time `json:"recent,omitempty"`
+}
+
+// since returns the reception time from which records are listed.
+func (c *Cursor) since() time.Time {
+	if c.Time.IsZero() {
+		return c.Time
+	}
+	return c.Time.Add(-receptionLag)
+}
+
+// exported returns true if the record was exported before the cursor position.
+func (c *Cursor) exported(r *Record) bool {
+	if r.Received.Before(c.since()) {
+		return true
+	}
+	_, ok := c.Recent[r.ID]
+	return ok
+}
+
+// advance moves the cursor past the record.
+func (c *Cursor) advance(r *Record) {
+	if r.Received.After(c.Time) {
+		c.Time = r.Received
+	}
+	if c.Recent == nil {
+		c.Recent = make(map[string]time.Time)
+	}
+	c.Recent[r.ID] = r.Received
+}
+
+// prune forgets the records which are no longer listed.
+func (c *Cursor) prune() {
+	since := c.since()
+	for id, t := range c.Recent {
+		if t.Before(since) {
+			delete(c.Recent, id)
+		}
+	}
+}
+
+// Checkpoint is the export position of every record kind.
+type Checkpoint map[string]*Cursor
+
+// loadCheckpoint reads a checkpoint file. A missing file is an empty checkpoint.
+func loadCheckpoint(path string) (Checkpoint, error) {
+	c := make(Checkpoint)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %q: %v", path, err)
+	}
+	return c, nil
+}
+
+// save atomically writes the checkpoint file.
+func (c Checkpoint) save(path string) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Exporter exports records from a Source.
+type Exporter struct {
+	source     Source
+	kinds      []string
+	formatter  Formatter
+	output     Output
+	checkpoint string
+	batchSize  int
+}
+
+// NewExporter creates an Exporter of the record kinds, SensorAlert and AlertEvent. The export
+// position is saved to the checkpoint file.
+func NewExporter(source Source, kinds []string, formatter Formatter, output Output, checkpoint string, batchSize int) (*Exporter, error) {
+	if len(kinds) == 0 {
+		return nil, fmt.Errorf("no record kinds")
+	}
+	for _, k := range kinds {
+		if k != SensorAlert && k != AlertEvent {
+			return nil, fmt.Errorf("unsupported record kind %q", k)
+		}
+	}
+	if checkpoint == "" {
+		return nil, fmt.Errorf("no checkpoint file")
+	}
+	if batchSize < 1 {
+		return nil, fmt.Errorf("invalid batch size %d", batchSize)
+	}
+	return &Exporter{
+		source:     source,
+		kinds:      kinds,
+		formatter:  formatter,
+		output:     output,
+		checkpoint: checkpoint,
+		batchSize:  batchSize,
+	}, nil
+}
+
+// records lists the records of a kind received at or after the time, in order of reception.
+func (e *Exporter) records(ctx context.Context, kind string, since time.Time) ([]*Record, error) {
+	var records []*Record
+	switch kind {
+	case SensorAlert:
+		msgs, err := e.source.ListSensorMessages(ctx, &resources.SensorMessageFilter{Type: resources.Alert, ReceivedSince: since})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			r, err := FromSensorMessage(m)
+			if err != nil {
+				log.Errorf("Skipping export of sensor alert: %v", err)
+				continue
+			}
+			records = append(records, r)
+		}
+	case AlertEvent:
+		events, err := e.source.ListAlertEvents(ctx, &resources.AlertEventFilter{ReceivedSince: since})
+		if err != nil {
+			return nil, err
+		}
+		for _, ev := range events {
+			records = append(records, FromAlertEvent(ev))
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if !records[i].Received.Equal(records[j].Received) {
+			return records[i].Received.Before(records[j].Received)
+		}
+		return records[i].ID < records[j].ID
+	})
+	return records, nil
+}
+
+// Export sends the records stored since the last checkpoint, returning the number of exported
+// records. The checkpoint is saved after every delivered batch, so records are exported again
+// only if delivery or checkpointing fails.
+func (e *Exporter) Export(ctx context.Context) (int, error) {
+	ckpt, err := loadCheckpoint(e.checkpoint)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for _, kind := range e.kinds {
+		cur, ok := ckpt[kind]
+		if !ok {
+			cur = &Cursor{}
+			ckpt[kind] = cur
+		}
+		records, err := e.records(ctx, kind, cur.since())
+		if err != nil {
+			return n, fmt.Errorf("failed to list %s records: %v", kind, err)
+		}
+		var batch [][]byte
+		var pending []*Record
+		flush := func() error {
+			if len(batch) > 0 {
+				if err := e.output.Send(batch); err != nil {
+					return err
+				}
+				n += len(batch)
+			}
+			for _, r := range pending {
+				cur.advance(r)
+			}
+			cur.prune()
+			batch, pending = nil, nil
+			return ckpt.save(e.checkpoint)
+		}
+		for _, r := range records {
+			if cur.exported(r) {
+				continue
+			}
+			pending = append(pending, r)
+			b, err := e.formatter.Format(r)
+			if err != nil {
+				log.Errorf("Skipping export of %s %q: %v", kind, r.ID, err)
+				continue
+			}
+			batch = append(batch, b)
+			if len(batch) >= e.batchSize {
+				if err := flush(); err != nil {
+					return n, err
+				}
+			}
+		}
+		if len(pending) > 0 {
+			if err := flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Run exports records every interval until the context is done.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if n, err := e.Export(ctx); err != nil {
+			log.Errorf("Failed to export records: %v", err)
+		} else if n > 0 {
+			log.Infof("Exported %d records", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
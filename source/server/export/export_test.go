@@ -0,0 +1,235 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeOutput records delivered messages, failing while err is set.
+type fakeOutput struct {
+	err  error
+	msgs []string
+}
+
+func (o *fakeOutput) Send(msgs [][]byte) error {
+	if o.err != nil {
+		return o.err
+	}
+	for _, m := range msgs {
+		o.msgs = append(o.msgs, string(m))
+	}
+	return nil
+}
+
+func (o *fakeOutput) Close() error {
+	return nil
+}
+
+// idFormatter formats records as their ID.
+type idFormatter struct{}
+
+func (idFormatter) Format(r *Record) ([]byte, error) {
+	return []byte(r.ID), nil
+}
+
+func alertEvent(id string, t time.Time) *resources.AlertEvent {
+	return &resources.AlertEvent{ID: id, Time: t, SignatureID: 1}
+}
+
+func sensorAlert(id string, t time.Time) *resources.SensorMessage {
+	return &resources.SensorMessage{ID: id, Time: t.Format(time.RFC1123Z), Type: resources.Alert}
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	d, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	checkpoint := filepath.Join(d, "checkpoint.json")
+
+	st := store.NewMemoryStore()
+	t0 := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+	// Records are received by the server one second after they were created, unless set otherwise.
+	received := t0.Add(time.Second)
+	store.TimeNow = func() time.Time { return received }
+	defer func() { store.TimeNow = time.Now }()
+	if err := st.AddAlertEvents(ctx, []*resources.AlertEvent{
+		alertEvent("e2", t0.Add(time.Second)),
+		alertEvent("e1", t0),
+		alertEvent("e3", t0.Add(time.Second)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*resources.SensorMessage{
+		sensorAlert("a1", t0),
+		{ID: "h1", Time: t0.Format(time.RFC1123Z), Type: resources.Heartbeat},
+	} {
+		if err := st.AddSensorMessage(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := &fakeOutput{}
+	e, err := NewExporter(st, []string{SensorAlert, AlertEvent}, idFormatter{}, out, checkpoint, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	export := func(want int) {
+		t.Helper()
+		n, err := e.Export(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != want {
+			t.Errorf("got %d exported records, want %d", n, want)
+		}
+	}
+	export(4)
+	if diff := cmp.Diff([]string{"a1", "e1", "e2", "e3"}, out.msgs); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	// Nothing new to export.
+	export(0)
+
+	// Records received at the checkpoint time or later are exported, once delivery succeeds.
+	if err := st.AddAlertEvents(ctx, []*resources.AlertEvent{
+		alertEvent("e4", t0.Add(time.Second)),
+		alertEvent("e5", t0.Add(time.Minute)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	out.err = errors.New("unavailable")
+	if _, err := e.Export(ctx); err == nil {
+		t.Fatal("expected an error for a failed delivery")
+	}
+	out.err = nil
+	out.msgs = nil
+	export(2)
+	if diff := cmp.Diff([]string{"e4", "e5"}, out.msgs); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Records received late, e.g. replayed by a sensor after an outage, are exported although
+	// they are older than the exported records. So are records listed late by the store.
+	out.msgs = nil
+	received = t0.Add(time.Hour)
+	if err := st.AddAlertEvents(ctx, []*resources.AlertEvent{alertEvent("e0", t0.Add(-time.Hour))}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.AddSensorMessage(ctx, sensorAlert("a0", t0.Add(-time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	export(2)
+	received = t0.Add(time.Hour - receptionLag/2)
+	if err := st.AddAlertEvents(ctx, []*resources.AlertEvent{alertEvent("e00", t0.Add(-time.Hour))}); err != nil {
+		t.Fatal(err)
+	}
+	export(1)
+	if diff := cmp.Diff([]string{"a0", "e0", "e00"}, out.msgs); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// A new Exporter resumes from the checkpoint.
+	out = &fakeOutput{}
+	e, err = NewExporter(st, []string{AlertEvent}, idFormatter{}, out, checkpoint, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received = t0.Add(2 * time.Hour)
+	if err := st.AddAlertEvents(ctx, []*resources.AlertEvent{alertEvent("e6", t0.Add(time.Hour))}); err != nil {
+		t.Fatal(err)
+	}
+	export(1)
+	if diff := cmp.Diff([]string{"e6"}, out.msgs); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	d, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, tt := range []struct {
+		desc    string
+		config  string
+		wantErr bool
+	}{
+		{
+			desc:   "file output",
+			config: `{"format": "json", "output": {"type": "file", "path": "` + filepath.Join(d, "alerts.jsonl") + `"}, "checkpoint": "` + filepath.Join(d, "ckpt") + `", "interval": "1m"}`,
+		},
+		{
+			desc:   "syslog output",
+			config: `{"format": "cef", "kinds": ["alert_event"], "output": {"type": "syslog", "network": "udp", "addr": "localhost:514"}, "checkpoint": "ckpt", "interval": "1m"}`,
+		},
+		{
+			desc:    "unsupported format",
+			config:  `{"format": "xml", "output": {"type": "file", "path": "out"}, "checkpoint": "ckpt", "interval": "1m"}`,
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported kind",
+			config:  `{"format": "cef", "kinds": ["heartbeat"], "output": {"type": "syslog", "network": "udp", "addr": "localhost:514"}, "checkpoint": "ckpt", "interval": "1m"}`,
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported network",
+			config:  `{"format": "cef", "output": {"type": "syslog", "network": "sctp", "addr": "localhost:514"}, "checkpoint": "ckpt", "interval": "1m"}`,
+			wantErr: true,
+		},
+		{
+			desc:    "no checkpoint",
+			config:  `{"format": "cef", "output": {"type": "syslog", "network": "udp", "addr": "localhost:514"}, "interval": "1m"}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid interval",
+			config:  `{"format": "cef", "output": {"type": "syslog", "network": "udp", "addr": "localhost:514"}, "checkpoint": "ckpt"}`,
+			wantErr: true,
+		},
+	} {
+		path := filepath.Join(d, "export.json")
+		if err := ioutil.WriteFile(path, []byte(tt.config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		c, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		e, _, err := c.Exporter(store.NewMemoryStore())
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+		if err == nil {
+			e.output.Close()
+		}
+	}
+}
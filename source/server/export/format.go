@@ -0,0 +1,301 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported formats.
+const (
+	// ArcSight Common Event Format.
+	CEF = "cef"
+	// IBM QRadar Log Event Extended Format.
+	LEEF = "leef"
+	// RFC 5424 syslog messages, with the fields as structured data.
+	RFC5424 = "rfc5424"
+	// JSON objects.
+	JSON = "json"
+)
+
+// Vendor, product and version reported in CEF and LEEF headers and as syslog app name.
+const (
+	vendor  = "Google"
+	product = "Emitto"
+	version = "1.0"
+	appName = "emitto"
+	// sdID is the RFC 5424 structured data ID, using the Google private enterprise number.
+	sdID = "emitto@11129"
+)
+
+// Mapping maps output field names to Record field names. Values prefixed with "=" are literals,
+// e.g. {"cs1Label": "=location", "cs1": "location"}.
+type Mapping map[string]string
+
+// Default mappings of the formats.
+var (
+	defaultCEFMapping = Mapping{
+		"externalId": "id",
+		"rt":         "epoch_ms",
+		"dvchost":    "host",
+		"dvc":        "ip",
+		"src":        "src_ip",
+		"spt":        "src_port",
+		"dst":        "dest_ip",
+		"dpt":        "dest_port",
+		"proto":      "proto",
+		"app":        "app_proto",
+		"cat":        "category",
+		"act":        "action",
+		"msg":        "message",
+		"cs1Label":   "=location",
+		"cs1":        "location",
+		"cs2Label":   "=zone",
+		"cs2":        "zone",
+		"cn1Label":   "=rule_id",
+		"cn1":        "rule_id",
+	}
+	defaultLEEFMapping = Mapping{
+		"devTime":       "epoch_ms",
+		"identHostName": "host",
+		"src":           "src_ip",
+		"srcPort":       "src_port",
+		"dst":           "dest_ip",
+		"dstPort":       "dest_port",
+		"proto":         "proto",
+		"sev":           "severity",
+		"cat":           "category",
+		"msg":           "message",
+		"externalId":    "id",
+		"location":      "location",
+		"zone":          "zone",
+		"ruleId":        "rule_id",
+	}
+)
+
+// Formatter formats Records for export.
+type Formatter interface {
+	Format(r *Record) ([]byte, error)
+}
+
+// NewFormatter creates a Formatter for a format. The default field mapping of the format is used
+// if mapping is empty; the default JSON and RFC 5424 mapping includes all Record fields.
+func NewFormatter(format string, mapping Mapping) (Formatter, error) {
+	switch format {
+	case CEF:
+		if len(mapping) == 0 {
+			mapping = defaultCEFMapping
+		}
+		return &cefFormatter{mapping}, nil
+	case LEEF:
+		if len(mapping) == 0 {
+			mapping = defaultLEEFMapping
+		}
+		return &leefFormatter{mapping}, nil
+	case RFC5424:
+		return &syslogFormatter{mapping}, nil
+	case JSON:
+		return &jsonFormatter{mapping}, nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// field is a mapped output field.
+type field struct {
+	name, value string
+}
+
+// apply returns the non-empty mapped fields of a record, sorted by name. All record fields are
+// returned if the mapping is empty.
+func (m Mapping) apply(r *Record) []field {
+	var fields []field
+	if len(m) == 0 {
+		for k, v := range r.Fields {
+			fields = append(fields, field{k, v})
+		}
+	}
+	for k, src := range m {
+		v := r.Fields[src]
+		if strings.HasPrefix(src, "=") {
+			v = src[1:]
+		}
+		if v != "" {
+			fields = append(fields, field{k, v})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	return fields
+}
+
+// eventName returns the signature of alert events and the message of other records.
+func eventName(r *Record) string {
+	if s := r.Fields["signature"]; s != "" {
+		return s
+	}
+	return r.Fields["message"]
+}
+
+// eventID returns the signature ID of alert events and the kind of other records.
+func eventID(r *Record) string {
+	if s := r.Fields["signature_id"]; s != "" {
+		return s
+	}
+	return r.Kind
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+type cefFormatter struct {
+	mapping Mapping
+}
+
+func (f *cefFormatter) Format(r *Record) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|", vendor, product, version,
+		cefHeaderEscaper.Replace(eventID(r)), cefHeaderEscaper.Replace(eventName(r)), r.Severity())
+	fields := f.mapping.apply(r)
+	present := make(map[string]bool)
+	for _, fl := range fields {
+		present[fl.name] = true
+	}
+	sep := ""
+	for _, fl := range fields {
+		// Omit the labels of custom fields without a value, e.g. cs1Label without cs1.
+		if base := strings.TrimSuffix(fl.name, "Label"); base != fl.name && !present[base] {
+			continue
+		}
+		fmt.Fprintf(&b, "%s%s=%s", sep, fl.name, cefExtensionEscaper.Replace(fl.value))
+		sep = " "
+	}
+	return b.Bytes(), nil
+}
+
+var (
+	leefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	leefAttributeEscaper = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+)
+
+type leefFormatter struct {
+	mapping Mapping
+}
+
+func (f *leefFormatter) Format(r *Record) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "LEEF:1.0|%s|%s|%s|%s|", vendor, product, version, leefHeaderEscaper.Replace(eventID(r)))
+	for i, fl := range f.mapping.apply(r) {
+		if i > 0 {
+			b.WriteByte('\t')
+		}
+		fmt.Fprintf(&b, "%s=%s", fl.name, leefAttributeEscaper.Replace(fl.value))
+	}
+	return b.Bytes(), nil
+}
+
+// syslogSeverity maps a record severity to an RFC 5424 severity.
+func syslogSeverity(s int) int {
+	switch {
+	case s >= 8:
+		return 2 // Critical.
+	case s >= 6:
+		return 3 // Error.
+	case s >= 4:
+		return 4 // Warning.
+	}
+	return 5 // Notice.
+}
+
+// syslogFacility is the local0 facility.
+const syslogFacility = 16
+
+var sdParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// sdName returns a valid RFC 5424 SD-NAME: printable US-ASCII except '=', ' ', ']' and '"', up to
+// 32 characters.
+func sdName(s string) string {
+	n := []byte(s)
+	for i, c := range n {
+		if c <= ' ' || c >= 127 || c == '=' || c == ']' || c == '"' {
+			n[i] = '_'
+		}
+	}
+	if len(n) > 32 {
+		n = n[:32]
+	}
+	return string(n)
+}
+
+// syslogHeaderField returns an RFC 5424 header field, or the NILVALUE if empty.
+func syslogHeaderField(s string, max int) string {
+	n := []byte(s)
+	for i, c := range n {
+		if c <= ' ' || c >= 127 {
+			n[i] = '_'
+		}
+	}
+	if len(n) == 0 {
+		return "-"
+	}
+	if len(n) > max {
+		n = n[:max]
+	}
+	return string(n)
+}
+
+type syslogFormatter struct {
+	mapping Mapping
+}
+
+func (f *syslogFormatter) Format(r *Record) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s - %s ",
+		syslogFacility*8+syslogSeverity(r.Severity()),
+		r.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(r.Fields["host"], 255),
+		appName,
+		syslogHeaderField(r.Kind, 32))
+	fields := f.mapping.apply(r)
+	if len(fields) == 0 {
+		b.WriteByte('-')
+	} else {
+		fmt.Fprintf(&b, "[%s", sdID)
+		for _, fl := range fields {
+			fmt.Fprintf(&b, ` %s="%s"`, sdName(fl.name), sdParamEscaper.Replace(fl.value))
+		}
+		b.WriteByte(']')
+	}
+	if msg := eventName(r); msg != "" {
+		fmt.Fprintf(&b, " %s", strings.Replace(msg, "\n", " ", -1))
+	}
+	return b.Bytes(), nil
+}
+
+type jsonFormatter struct {
+	mapping Mapping
+}
+
+func (f *jsonFormatter) Format(r *Record) ([]byte, error) {
+	m := make(map[string]string)
+	for _, fl := range f.mapping.apply(r) {
+		m[fl.name] = fl.value
+	}
+	return json.Marshal(m)
+}
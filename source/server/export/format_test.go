@@ -0,0 +1,176 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/emitto/source/resources"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	testAlertEvent = &resources.AlertEvent{
+		ID:          "msg1-0",
+		ClientID:    "0A",
+		Time:        time.Date(2019, 5, 13, 14, 0, 0, 500000000, time.UTC),
+		Host:        "sensor1",
+		Location:    "a",
+		Zone:        "dmz",
+		RuleID:      1,
+		SignatureID: 1001,
+		Signature:   `ET POLICY "curl" | User-Agent`,
+		Category:    "Potential Corporate Privacy Violation",
+		Severity:    1,
+		Action:      "allowed",
+		SrcIP:       "10.0.0.1",
+		SrcPort:     1234,
+		DestIP:      "10.0.0.2",
+		DestPort:    80,
+		Proto:       "TCP",
+		AppProto:    "http",
+	}
+	testSensorMessage = &resources.SensorMessage{
+		ID:       "msg2",
+		Time:     "Mon, 13 May 2019 14:00:01 +0000",
+		ClientID: "0A",
+		Type:     resources.Alert,
+		Host:     `fqdn:"sensor1" ip:"10.0.0.10" org:"a" zone:"dmz" `,
+		Status:   `code:13 message:"Sensor has detected: 100 alerts in last: 1m0s" `,
+	}
+)
+
+func TestFromSensorMessage(t *testing.T) {
+	got, err := FromSensorMessage(testSensorMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Record{
+		Kind: SensorAlert,
+		ID:   "msg2",
+		Time: time.Date(2019, 5, 13, 14, 0, 1, 0, time.UTC),
+		Fields: map[string]string{
+			"kind":        "sensor_alert",
+			"id":          "msg2",
+			"time":        "2019-05-13T14:00:01Z",
+			"epoch_ms":    "1557756001000",
+			"client_id":   "0A",
+			"host":        "sensor1",
+			"ip":          "10.0.0.10",
+			"location":    "a",
+			"zone":        "dmz",
+			"severity":    "7",
+			"message":     "Sensor has detected: 100 alerts in last: 1m0s",
+			"status_code": "13",
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	invalid := *testSensorMessage
+	invalid.Time = "yesterday"
+	if _, err := FromSensorMessage(&invalid); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	event := FromAlertEvent(testAlertEvent)
+	alert, err := FromSensorMessage(testSensorMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		desc    string
+		format  string
+		mapping Mapping
+		record  *Record
+		want    string
+	}{
+		{
+			desc:   "CEF alert event",
+			format: CEF,
+			record: event,
+			want: `CEF:0|Google|Emitto|1.0|1001|ET POLICY "curl" \| User-Agent|9|` +
+				`act=allowed app=http cat=Potential Corporate Privacy Violation cn1=1 cn1Label=rule_id ` +
+				`cs1=a cs1Label=location cs2=dmz cs2Label=zone dpt=80 dst=10.0.0.2 dvchost=sensor1 ` +
+				`externalId=msg1-0 msg=ET POLICY "curl" | User-Agent proto=TCP rt=1557756000500 spt=1234 src=10.0.0.1`,
+		},
+		{
+			desc:   "CEF sensor alert",
+			format: CEF,
+			record: alert,
+			want: `CEF:0|Google|Emitto|1.0|sensor_alert|Sensor has detected: 100 alerts in last: 1m0s|7|` +
+				`cs1=a cs1Label=location cs2=dmz cs2Label=zone dvc=10.0.0.10 dvchost=sensor1 externalId=msg2 ` +
+				`msg=Sensor has detected: 100 alerts in last: 1m0s rt=1557756001000`,
+		},
+		{
+			desc:    "CEF custom mapping",
+			format:  CEF,
+			mapping: Mapping{"src": "src_ip", "cs1": "signature", "cs1Label": "=sig=nature"},
+			record:  event,
+			want:    `CEF:0|Google|Emitto|1.0|1001|ET POLICY "curl" \| User-Agent|9|cs1=ET POLICY "curl" | User-Agent cs1Label=sig\=nature src=10.0.0.1`,
+		},
+		{
+			desc:   "LEEF alert event",
+			format: LEEF,
+			record: event,
+			want: "LEEF:1.0|Google|Emitto|1.0|1001|cat=Potential Corporate Privacy Violation\tdevTime=1557756000500\t" +
+				"dst=10.0.0.2\tdstPort=80\texternalId=msg1-0\tidentHostName=sensor1\tlocation=a\t" +
+				"msg=ET POLICY \"curl\" | User-Agent\tproto=TCP\truleId=1\tsev=9\tsrc=10.0.0.1\tsrcPort=1234\tzone=dmz",
+		},
+		{
+			desc:    "RFC 5424 alert event",
+			format:  RFC5424,
+			mapping: Mapping{"sig": "signature", "src": "src_ip"},
+			record:  event,
+			want:    `<130>1 2019-05-13T14:00:00.500000Z sensor1 emitto - alert_event [emitto@11129 sig="ET POLICY \"curl\" | User-Agent" src="10.0.0.1"] ET POLICY "curl" | User-Agent`,
+		},
+		{
+			desc:   "RFC 5424 sensor alert",
+			format: RFC5424,
+			record: alert,
+			want: `<131>1 2019-05-13T14:00:01.000000Z sensor1 emitto - sensor_alert [emitto@11129 client_id="0A" ` +
+				`epoch_ms="1557756001000" host="sensor1" id="msg2" ip="10.0.0.10" kind="sensor_alert" location="a" ` +
+				`message="Sensor has detected: 100 alerts in last: 1m0s" severity="7" status_code="13" ` +
+				`time="2019-05-13T14:00:01Z" zone="dmz"] Sensor has detected: 100 alerts in last: 1m0s`,
+		},
+		{
+			desc:    "JSON",
+			format:  JSON,
+			mapping: Mapping{"src": "src_ip", "sid": "signature_id", "source": "=emitto"},
+			record:  event,
+			want:    `{"sid":"1001","source":"emitto","src":"10.0.0.1"}`,
+		},
+	} {
+		f, err := NewFormatter(tt.format, tt.mapping)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		got, err := f.Format(tt.record)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+
+	if _, err := NewFormatter("xml", nil); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// Output delivers formatted records.
+type Output interface {
+	// Send delivers a batch of formatted records. Records may be delivered again after an error.
+	Send(msgs [][]byte) error
+	// Close releases the resources of the Output.
+	Close() error
+}
+
+// dialTimeout bounds the time to connect to a syslog receiver.
+const dialTimeout = 30 * time.Second
+
+// Syslog is an Output sending records to a syslog receiver over UDP, TCP or TLS. Over TCP and TLS,
+// messages are framed by octet counting (RFC 6587 and RFC 5425); over UDP, every message is a
+// datagram (RFC 5426).
+type Syslog struct {
+	network, addr string
+	config        *tls.Config
+	conn          net.Conn
+}
+
+// NewSyslog creates a Syslog Output for the "udp", "tcp" or "tls" network. The TLS config is only
+// used for the "tls" network. The connection is established on the first Send.
+func NewSyslog(network, addr string, config *tls.Config) (*Syslog, error) {
+	switch network {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q", network)
+	}
+	return &Syslog{network: network, addr: addr, config: config}, nil
+}
+
+func (s *Syslog) dial() (net.Conn, error) {
+	d := &net.Dialer{Timeout: dialTimeout}
+	if s.network == "tls" {
+		return tls.DialWithDialer(d, "tcp", s.addr, s.config)
+	}
+	return d.Dial(s.network, s.addr)
+}
+
+// Send sends the messages, reconnecting if the previous Send failed.
+func (s *Syslog) Send(msgs [][]byte) error {
+	if s.conn == nil {
+		c, err := s.dial()
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog receiver %s: %v", s.addr, err)
+		}
+		s.conn = c
+	}
+	var err error
+	if s.network == "udp" {
+		for _, m := range msgs {
+			if _, err = s.conn.Write(m); err != nil {
+				break
+			}
+		}
+	} else {
+		w := bufio.NewWriter(s.conn)
+		for _, m := range msgs {
+			if _, err = fmt.Fprintf(w, "%d %s", len(m), m); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+	}
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("failed to send to syslog receiver %s: %v", s.addr, err)
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (s *Syslog) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// File is an Output appending records to a file, one per line, e.g. as JSON Lines.
+type File struct {
+	f *os.File
+}
+
+// NewFile opens a File Output, creating the file if needed.
+func NewFile(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return &File{f: f}, nil
+}
+
+// Send appends the messages and syncs the file.
+func (o *File) Send(msgs [][]byte) error {
+	w := bufio.NewWriter(o.f)
+	for _, m := range msgs {
+		w.Write(m)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return o.f.Sync()
+}
+
+// Close closes the file.
+func (o *File) Close() error {
+	return o.f.Close()
+}
@@ -0,0 +1,147 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/emitto/source/resources"
+
+	spb "github.com/google/emitto/source/sensor/proto"
+	rpb "google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// Record kinds.
+const (
+	// A sensor alert, stored as a SensorMessage.
+	SensorAlert = "sensor_alert"
+	// A Suricata alert event forwarded by a sensor.
+	AlertEvent = "alert_event"
+)
+
+// Record is an exported event, with its fields named as follows:
+//
+//	kind, id, time (RFC 3339), epoch_ms, client_id, host, ip, location, zone, severity (1-10),
+//	message, status_code, rule_id, signature_id, signature, category, suricata_severity, action,
+//	src_ip, src_port, dest_ip, dest_port, proto, app_proto
+//
+// Empty fields are omitted.
+type Record struct {
+	Kind string
+	ID   string
+	Time time.Time
+	// Time the record was stored by the server, which orders the export.
+	Received time.Time
+	Fields   map[string]string
+}
+
+func newRecord(kind, id string, t, received time.Time) *Record {
+	r := &Record{Kind: kind, ID: id, Time: t, Received: received, Fields: make(map[string]string)}
+	r.set("kind", kind)
+	r.set("id", id)
+	r.set("time", t.UTC().Format(time.RFC3339Nano))
+	r.set("epoch_ms", strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10))
+	return r
+}
+
+func (r *Record) set(field, value string) {
+	if value != "" {
+		r.Fields[field] = value
+	}
+}
+
+func (r *Record) setInt(field string, value int64) {
+	if value != 0 {
+		r.Fields[field] = strconv.FormatInt(value, 10)
+	}
+}
+
+// Severity returns the record severity on a scale from 1 (lowest) to 10 (highest).
+func (r *Record) Severity() int {
+	s, err := strconv.Atoi(r.Fields["severity"])
+	if err != nil {
+		return 5
+	}
+	return s
+}
+
+// suricataSeverity maps Suricata alert severities, 1 being the highest, to a scale from 1 to 10.
+func suricataSeverity(s int64) int64 {
+	switch s {
+	case 1:
+		return 9
+	case 2:
+		return 6
+	case 3:
+		return 3
+	}
+	return 5
+}
+
+// sensorAlertSeverity is the severity of sensor alerts, which report alert policy thresholds.
+const sensorAlertSeverity = 7
+
+// FromSensorMessage converts a stored sensor alert to a Record.
+func FromSensorMessage(m *resources.SensorMessage) (*Record, error) {
+	t, err := time.Parse(time.RFC1123Z, m.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time of sensor message %q: %v", m.ID, err)
+	}
+	var h spb.Host
+	if err := proto.UnmarshalText(m.Host, &h); err != nil {
+		return nil, fmt.Errorf("invalid host of sensor message %q: %v", m.ID, err)
+	}
+	var st rpb.Status
+	if err := proto.UnmarshalText(m.Status, &st); err != nil {
+		return nil, fmt.Errorf("invalid status of sensor message %q: %v", m.ID, err)
+	}
+	r := newRecord(SensorAlert, m.ID, t, m.Received)
+	r.set("client_id", m.ClientID)
+	r.set("host", h.GetFqdn())
+	r.set("ip", h.GetIp())
+	r.set("location", h.GetOrg())
+	r.set("zone", h.GetZone())
+	r.setInt("severity", sensorAlertSeverity)
+	r.set("message", st.GetMessage())
+	r.setInt("status_code", int64(st.GetCode()))
+	return r, nil
+}
+
+// FromAlertEvent converts a forwarded alert event to a Record.
+func FromAlertEvent(e *resources.AlertEvent) *Record {
+	r := newRecord(AlertEvent, e.ID, e.Time, e.Received)
+	r.set("client_id", e.ClientID)
+	r.set("host", e.Host)
+	r.set("location", e.Location)
+	r.set("zone", e.Zone)
+	r.setInt("severity", suricataSeverity(e.Severity))
+	r.set("message", e.Signature)
+	r.setInt("rule_id", e.RuleID)
+	r.setInt("signature_id", e.SignatureID)
+	r.set("signature", e.Signature)
+	r.set("category", e.Category)
+	r.setInt("suricata_severity", e.Severity)
+	r.set("action", e.Action)
+	r.set("src_ip", e.SrcIP)
+	r.setInt("src_port", e.SrcPort)
+	r.set("dest_ip", e.DestIP)
+	r.setInt("dest_port", e.DestPort)
+	r.set("proto", e.Proto)
+	r.set("app_proto", e.AppProto)
+	return r
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ioc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Config configures a Generator, loaded from a JSON file such as:
+//
+//	{"sid_min": 9100000,
+//	 "sid_max": 9199999,
+//	 "templates": [
+//	   {"name": "dns_query", "type": "domain",
+//	    "rule": "alert dns any any -> any any (msg:\"IOC {{.Value}}\"; dns.query; content:\"{{.Value}}\"; nocase; sid:{{.SID}}; rev:{{.Rev}}; metadata:{{.Metadata}};)"}]}
+type Config struct {
+	// Range of the SIDs allocated to generated rules, inclusive.
+	SIDMin int64 `json:"sid_min"`
+	SIDMax int64 `json:"sid_max"`
+	// Rule templates, replacing DefaultTemplates if set.
+	Templates []*Template `json:"templates,omitempty"`
+}
+
+// Template is the text/template of the rules generated for the indicators of a type. Rules are
+// rendered with the fields of RuleData, and must set the SID, rev and metadata options from it.
+type Template struct {
+	// Unique name of the template, identifying the generated rules across imports.
+	Name string `json:"name"`
+	// Type of the indicators the template applies to.
+	Type IndicatorType `json:"type"`
+	// Template of the rule body.
+	Rule string `json:"rule"`
+}
+
+// DefaultTemplates match DNS queries, TLS SNIs and HTTP hosts of domains and their subdomains,
+// and traffic with IP addresses.
+var DefaultTemplates = []*Template{
+	{
+		Name: "dns_query",
+		Type: Domain,
+		Rule: `alert dns $HOME_NET any -> any any (msg:"IOC DNS query for {{.Value}}"; dns.query; dotprefix; content:".{{.Value}}"; nocase; endswith; sid:{{.SID}}; rev:{{.Rev}}; metadata:{{.Metadata}};)`,
+	},
+	{
+		Name: "tls_sni",
+		Type: Domain,
+		Rule: `alert tls $HOME_NET any -> any any (msg:"IOC TLS SNI {{.Value}}"; tls.sni; dotprefix; content:".{{.Value}}"; nocase; endswith; sid:{{.SID}}; rev:{{.Rev}}; metadata:{{.Metadata}};)`,
+	},
+	{
+		Name: "http_host",
+		Type: Domain,
+		Rule: `alert http $HOME_NET any -> any any (msg:"IOC HTTP host {{.Value}}"; http.host; dotprefix; content:".{{.Value}}"; endswith; sid:{{.SID}}; rev:{{.Rev}}; metadata:{{.Metadata}};)`,
+	},
+	{
+		Name: "ip_reputation",
+		Type: IP,
+		Rule: `alert ip $HOME_NET any <> {{.Value}} any (msg:"IOC IP {{.Value}}"; sid:{{.SID}}; rev:{{.Rev}}; metadata:{{.Metadata}};)`,
+	},
+}
+
+// LoadConfig reads a Config from a JSON file.
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indicator config %q: %v", path, err)
+	}
+	c := new(Config)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse indicator config %q: %v", path, err)
+	}
+	return c, nil
+}
+
+// Generator creates the Generator of the Config, storing rules in s.
+func (c *Config) Generator(s Store) (*Generator, error) {
+	templates := c.Templates
+	if len(templates) == 0 {
+		templates = DefaultTemplates
+	}
+	return NewGenerator(s, c.SIDMin, c.SIDMax, templates)
+}
@@ -0,0 +1,310 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ioc generates Suricata rules from indicators of compromise, e.g. domains and IP
+// addresses exported as CSV, STIX or MISP JSON.
+//
+// Every generated rule carries an "emitto_ioc" metadata key derived from its template and
+// indicator, so that importing the same indicators again updates the existing rules, bumping
+// their revision when their body changes, instead of adding duplicates.
+package ioc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/sensor/suricata"
+
+	log "github.com/golang/glog"
+)
+
+// ErrSIDsExhausted is returned when no SID of the configured range is available.
+var ErrSIDsExhausted = errors.New("no SID available in the configured range")
+
+// Store stores the generated rules. It is implemented by store.Store.
+type Store interface {
+	AddRule(ctx context.Context, r *resources.Rule) error
+	ModifyRule(ctx context.Context, r *resources.Rule) error
+	ListRules(ctx context.Context, ids []int64) ([]*resources.Rule, error)
+	ListQuarantines(ctx context.Context) ([]*resources.Quarantine, error)
+}
+
+// RuleData is the data rule templates are rendered with.
+type RuleData struct {
+	// Normalized indicator value.
+	Value string
+	// Name of the indicator source, and reference of the indicator in the source.
+	Source string
+	Ref    string
+	// SID and revision of the rule.
+	SID int64
+	Rev int64
+	// Value of the metadata option, identifying the rule and pointing back to the source.
+	Metadata string
+}
+
+// Result lists the IDs of the rules added, updated and left unchanged by an import.
+type Result struct {
+	Added     []int64
+	Updated   []int64
+	Unchanged []int64
+}
+
+type ruleTemplate struct {
+	name string
+	typ  IndicatorType
+	tmpl *template.Template
+}
+
+// Generator generates rules from indicators and stores them.
+type Generator struct {
+	store     Store
+	sidMin    int64
+	sidMax    int64
+	templates []*ruleTemplate
+
+	mu sync.Mutex // Serializes imports, which allocate SIDs.
+}
+
+var templateNameRE = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// NewGenerator creates a new Generator allocating SIDs in [sidMin, sidMax].
+func NewGenerator(s Store, sidMin, sidMax int64, templates []*Template) (*Generator, error) {
+	if sidMin <= 0 || sidMax < sidMin {
+		return nil, fmt.Errorf("invalid SID range [%d, %d]", sidMin, sidMax)
+	}
+	g := &Generator{store: s, sidMin: sidMin, sidMax: sidMax}
+	names := make(map[string]bool)
+	for _, t := range templates {
+		if !templateNameRE.MatchString(t.Name) || names[t.Name] {
+			return nil, fmt.Errorf("invalid or duplicate template name %q", t.Name)
+		}
+		names[t.Name] = true
+		if t.Type != Domain && t.Type != IP {
+			return nil, fmt.Errorf("template %q: unsupported indicator type %q", t.Name, t.Type)
+		}
+		tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.Rule)
+		if err != nil {
+			return nil, fmt.Errorf("template %q: %v", t.Name, err)
+		}
+		g.templates = append(g.templates, &ruleTemplate{name: t.Name, typ: t.Type, tmpl: tmpl})
+	}
+	if len(g.templates) == 0 {
+		return nil, errors.New("no rule templates")
+	}
+	return g, nil
+}
+
+var (
+	// iocKeyRE matches the metadata key identifying generated rules.
+	iocKeyRE = regexp.MustCompile(`emitto_ioc ([a-z0-9_]+)`)
+	// revRE matches the rev option of a rule.
+	revRE = regexp.MustCompile(`rev\s*:\s*(\d+)\s*;`)
+	// metadataUnsafeRE matches characters which cannot appear in metadata values.
+	metadataUnsafeRE = regexp.MustCompile(`[^A-Za-z0-9._:/@+-]`)
+)
+
+// ruleKey identifies the rule generated by a template for an indicator.
+func ruleKey(template string, ind *Indicator) string {
+	sum := sha256.Sum256([]byte(string(ind.Type) + ":" + ind.Value))
+	return fmt.Sprintf("%s_%x", template, sum[:8])
+}
+
+// ruleRev returns the revision of a rule body, or 0 if it has none.
+func ruleRev(body string) int64 {
+	m := revRE.FindStringSubmatch(body)
+	if m == nil {
+		return 0
+	}
+	rev, _ := strconv.ParseInt(m[1], 10, 64)
+	return rev
+}
+
+// render renders the rule of a template for an indicator, checking that it carries its SID and key.
+func (t *ruleTemplate) render(key, source string, ind *Indicator, sid, rev int64) (string, error) {
+	d := &RuleData{
+		Value:  ind.Value,
+		Source: source,
+		Ref:    ind.Ref,
+		SID:    sid,
+		Rev:    rev,
+		Metadata: fmt.Sprintf("emitto_ioc %s, emitto_ioc_source %s, emitto_ioc_ref %s", key,
+			metadataUnsafeRE.ReplaceAllString(source, "_"), metadataUnsafeRE.ReplaceAllString(ind.Ref, "_")),
+	}
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, d); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %v", t.name, err)
+	}
+	body := strings.TrimSpace(buf.String())
+	if suricata.ParseSID(body) != sid || ruleRev(body) != rev || !strings.Contains(body, "emitto_ioc "+key) {
+		return "", fmt.Errorf("template %q does not set the SID, rev and metadata of the rule", t.name)
+	}
+	return body, nil
+}
+
+// Import generates the rules of indicators from a source and stores them, enabled in the location
+// zones. Rules generated by earlier imports for the same indicators are updated, and enabled in the
+// location zones in addition to their current ones. On error, the rules stored so far are kept, and
+// importing again completes the import.
+func (g *Generator) Import(ctx context.Context, source string, indicators []*Indicator, locZones []string) (*Result, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	rules, err := g.store.ListRules(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %v", err)
+	}
+	existing := make(map[string]*resources.Rule)
+	used := make(map[int64]bool)
+	for _, r := range rules {
+		used[r.ID] = true
+		used[suricata.ParseSID(r.Body)] = true
+		if m := iocKeyRE.FindStringSubmatch(r.Body); m != nil {
+			existing[m[1]] = r
+		}
+	}
+	// Location zones the rules were removed from by active quarantines, by rule ID.
+	quarantines, err := g.store.ListQuarantines(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantines: %v", err)
+	}
+	quarantined := make(map[int64]map[string]bool)
+	for _, q := range quarantines {
+		if !q.Active || q.Action != resources.QuarantineRemove {
+			continue
+		}
+		if quarantined[q.RuleID] == nil {
+			quarantined[q.RuleID] = make(map[string]bool)
+		}
+		quarantined[q.RuleID][q.Location+":"+q.Zone] = true
+	}
+	next := g.sidMin
+	res := new(Result)
+	for _, ind := range indicators {
+		for _, t := range g.templates {
+			if t.typ != ind.Type {
+				continue
+			}
+			key := ruleKey(t.name, ind)
+			if r, ok := existing[key]; ok {
+				updated, err := g.update(ctx, t, key, source, ind, r, mergeLocZones(r.LocZones, locZones, quarantined[r.ID]))
+				if err != nil {
+					return res, err
+				}
+				if updated {
+					res.Updated = append(res.Updated, r.ID)
+				} else {
+					res.Unchanged = append(res.Unchanged, r.ID)
+				}
+				continue
+			}
+			for ; next <= g.sidMax && used[next]; next++ {
+			}
+			if next > g.sidMax {
+				return res, ErrSIDsExhausted
+			}
+			body, err := t.render(key, source, ind, next, 1)
+			if err != nil {
+				return res, err
+			}
+			r := &resources.Rule{ID: next, Body: body, LocZones: locZones}
+			if err := g.store.AddRule(ctx, r); err != nil {
+				return res, fmt.Errorf("failed to add rule %d: %v", r.ID, err)
+			}
+			used[next] = true
+			existing[key] = r
+			res.Added = append(res.Added, r.ID)
+		}
+	}
+	log.Infof("Imported %d indicator(s) from %q: %d rule(s) added, %d updated, %d unchanged", len(indicators), source, len(res.Added), len(res.Updated), len(res.Unchanged))
+	return res, nil
+}
+
+// update updates a rule generated by an earlier import, bumping its revision if its body changes.
+// It returns false if the rule is unchanged.
+func (g *Generator) update(ctx context.Context, t *ruleTemplate, key, source string, ind *Indicator, r *resources.Rule, locZones []string) (bool, error) {
+	sid := suricata.ParseSID(r.Body)
+	if sid == 0 {
+		sid = r.ID
+	}
+	rev := ruleRev(r.Body)
+	body, err := t.render(key, source, ind, sid, rev)
+	if err != nil {
+		return false, err
+	}
+	m := &resources.Rule{ID: r.ID}
+	if body != r.Body {
+		if m.Body, err = t.render(key, source, ind, sid, rev+1); err != nil {
+			return false, err
+		}
+	}
+	if !equalStrings(locZones, r.LocZones) {
+		m.LocZones = locZones
+	}
+	if m.Body == "" && m.LocZones == nil {
+		return false, nil
+	}
+	if err := g.store.ModifyRule(ctx, m); err != nil {
+		return false, fmt.Errorf("failed to modify rule %d: %v", r.ID, err)
+	}
+	if m.Body != "" {
+		r.Body = m.Body
+	}
+	if m.LocZones != nil {
+		r.LocZones = m.LocZones
+	}
+	return true, nil
+}
+
+// mergeLocZones returns the location zones of a rule with the imported ones added, except those the
+// rule was removed from by a quarantine, which undoing the quarantine restores.
+func mergeLocZones(current, imported []string, quarantined map[string]bool) []string {
+	res := append([]string(nil), current...)
+	for _, lz := range imported {
+		if !quarantined[lz] && !contains(res, lz) {
+			res = append(res, lz)
+		}
+	}
+	return res
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
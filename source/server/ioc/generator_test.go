@@ -0,0 +1,164 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ioc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	// SID 101 is used by an existing rule, and rule ID 102 by a rule with another SID.
+	for _, r := range []*resources.Rule{
+		{ID: 101, Body: "alert ip any any -> any any (sid:101; rev:1;)"},
+		{ID: 102, Body: "alert ip any any -> any any (sid:5000; rev:1;)"},
+	} {
+		if err := s.AddRule(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g, err := (&Config{SIDMin: 100, SIDMax: 110}).Generator(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indicators := []*Indicator{
+		{Type: Domain, Value: "evil.example.com", Ref: "ioc-1"},
+		{Type: IP, Value: "10.0.0.1", Ref: "ioc-2"},
+	}
+	res, err := g.Import(ctx, "phishing.csv", indicators, []string{"a:dmz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Result{Added: []int64{100, 103, 104, 105}}, res); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	rules, err := s.ListRules(ctx, []int64{100, 105})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`alert dns $HOME_NET any -> any any (msg:"IOC DNS query for evil.example.com"; dns.query; dotprefix; content:".evil.example.com"; nocase; endswith; sid:100; rev:1; metadata:emitto_ioc ` + ruleKey("dns_query", indicators[0]) + `, emitto_ioc_source phishing.csv, emitto_ioc_ref ioc-1;)`,
+		`alert ip $HOME_NET any <> 10.0.0.1 any (msg:"IOC IP 10.0.0.1"; sid:105; rev:1; metadata:emitto_ioc ` + ruleKey("ip_reputation", indicators[1]) + `, emitto_ioc_source phishing.csv, emitto_ioc_ref ioc-2;)`,
+	}
+	var got []string
+	for _, r := range rules {
+		got = append(got, r.Body)
+		if diff := cmp.Diff([]string{"a:dmz"}, r.LocZones); diff != "" {
+			t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Importing the same indicators again leaves the rules unchanged.
+	if res, err = g.Import(ctx, "phishing.csv", indicators, []string{"a:dmz"}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Result{Unchanged: []int64{100, 103, 104, 105}}, res); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Changed rules are updated with the next revision, and new indicators get the next free SIDs.
+	indicators = append(indicators, &Indicator{Type: IP, Value: "10.0.0.2", Ref: "ioc-3"})
+	if res, err = g.Import(ctx, "phishing-v2.csv", indicators, []string{"a:dmz", "b:dmz"}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Result{Added: []int64{106}, Updated: []int64{100, 103, 104, 105}}, res); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	rules, err = s.ListRules(ctx, []int64{100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := rules[0].Body; !strings.Contains(b, "sid:100; rev:2;") || !strings.Contains(b, "emitto_ioc_source phishing-v2.csv") {
+		t.Errorf("got rule %q, want revision 2 from phishing-v2.csv", b)
+	}
+	if diff := cmp.Diff([]string{"a:dmz", "b:dmz"}, rules[0].LocZones); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	all, err := s.ListRules(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 7 {
+		t.Errorf("got %d rules, want 7", len(all))
+	}
+
+	// Location zones are added to those of the rules, except where a rule is quarantined.
+	if err := s.ModifyRule(ctx, &resources.Rule{ID: 100, LocZones: []string{"b:dmz"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutQuarantine(ctx, &resources.Quarantine{ID: "q", RuleID: 100, Location: "a", Zone: "dmz", Action: resources.QuarantineRemove, Active: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = g.Import(ctx, "phishing-v2.csv", indicators, []string{"a:dmz", "c:dmz"}); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = s.ListRules(ctx, []int64{100, 103})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"b:dmz", "c:dmz"}, rules[0].LocZones); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"a:dmz", "b:dmz", "c:dmz"}, rules[1].LocZones); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// The SID range is exhausted.
+	var many []*Indicator
+	for _, v := range []string{"10.0.1.1", "10.0.1.2", "10.0.1.3", "10.0.1.4", "10.0.1.5"} {
+		many = append(many, &Indicator{Type: IP, Value: v})
+	}
+	if _, err := g.Import(ctx, "many.csv", many, []string{"a:dmz"}); err != ErrSIDsExhausted {
+		t.Errorf("got err=%v, want %v", err, ErrSIDsExhausted)
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	s := store.NewMemoryStore()
+	for _, tt := range []struct {
+		desc           string
+		sidMin, sidMax int64
+		templates      []*Template
+	}{
+		{desc: "invalid SID range", sidMin: 200, sidMax: 100, templates: DefaultTemplates},
+		{desc: "no templates", sidMin: 100, sidMax: 200},
+		{desc: "invalid name", sidMin: 100, sidMax: 200, templates: []*Template{{Name: "DNS query", Type: Domain, Rule: "x"}}},
+		{desc: "unsupported type", sidMin: 100, sidMax: 200, templates: []*Template{{Name: "url", Type: "url", Rule: "x"}}},
+		{desc: "invalid template", sidMin: 100, sidMax: 200, templates: []*Template{{Name: "dns", Type: Domain, Rule: "{{.Value"}}},
+	} {
+		if _, err := NewGenerator(s, tt.sidMin, tt.sidMax, tt.templates); err == nil {
+			t.Errorf("%s: expected an error", tt.desc)
+		}
+	}
+
+	// Templates must set the SID, rev and metadata of the rules.
+	g, err := NewGenerator(s, 100, 200, []*Template{{Name: "dns", Type: Domain, Rule: `alert dns any any -> any any (sid:{{.SID}};)`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Import(context.Background(), "a.csv", []*Indicator{{Type: Domain, Value: "evil.example.com"}}, []string{"a:dmz"}); err == nil {
+		t.Error("expected an error for a template without rev and metadata")
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ioc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// IndicatorType is the type of an indicator of compromise.
+type IndicatorType string
+
+// Indicator types for which rules are generated.
+const (
+	Domain IndicatorType = "domain"
+	IP     IndicatorType = "ip"
+)
+
+// Indicator formats.
+const (
+	FormatCSV  = "csv"
+	FormatSTIX = "stix"
+	FormatMISP = "misp"
+)
+
+// Indicator is an indicator of compromise.
+type Indicator struct {
+	Type IndicatorType
+	// Normalized value: a lowercase domain, or an IP address or network.
+	Value string
+	// Reference of the indicator in its source, e.g. a STIX indicator ID or a CSV record number.
+	Ref string
+}
+
+// indicatorTypes maps the indicator types of the supported formats to the generated types.
+var indicatorTypes = map[string]IndicatorType{
+	"domain":      Domain,
+	"hostname":    Domain,
+	"fqdn":        Domain,
+	"domain-name": Domain,
+	"ip":          IP,
+	"ip-dst":      IP,
+	"ip-src":      IP,
+	"ipv4":        IP,
+	"ipv6":        IP,
+	"ipv4-addr":   IP,
+	"ipv6-addr":   IP,
+}
+
+var domainRE = regexp.MustCompile(`^([a-z0-9_]([a-z0-9_-]*[a-z0-9])?\.)+[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// normalize validates an indicator value and returns its canonical form. Defanged values, e.g.
+// "evil[.]com", are accepted.
+func normalize(t IndicatorType, v string) (string, error) {
+	v = strings.NewReplacer("[.]", ".", "(.)", ".", "[:]", ":").Replace(strings.TrimSpace(v))
+	switch t {
+	case Domain:
+		v = strings.TrimSuffix(strings.ToLower(v), ".")
+		if !domainRE.MatchString(v) {
+			return "", fmt.Errorf("invalid domain %q", v)
+		}
+		return v, nil
+	case IP:
+		if ip := net.ParseIP(v); ip != nil {
+			return ip.String(), nil
+		}
+		if _, n, err := net.ParseCIDR(v); err == nil {
+			return n.String(), nil
+		}
+		return "", fmt.Errorf("invalid IP address %q", v)
+	}
+	return "", fmt.Errorf("unsupported indicator type %q", t)
+}
+
+// indicators accumulates the parsed indicators, dropping duplicates and counting the skipped ones.
+type indicators struct {
+	list    []*Indicator
+	seen    map[Indicator]bool
+	skipped int
+}
+
+// add adds an indicator of a source type. Indicators of unsupported types are skipped.
+func (s *indicators) add(typ, value, ref string) error {
+	t, ok := indicatorTypes[strings.ToLower(strings.TrimSpace(typ))]
+	if !ok {
+		s.skipped++
+		return nil
+	}
+	v, err := normalize(t, value)
+	if err != nil {
+		return fmt.Errorf("%s: %v", ref, err)
+	}
+	k := Indicator{Type: t, Value: v}
+	if s.seen[k] {
+		return nil
+	}
+	if s.seen == nil {
+		s.seen = make(map[Indicator]bool)
+	}
+	s.seen[k] = true
+	s.list = append(s.list, &Indicator{Type: t, Value: v, Ref: ref})
+	return nil
+}
+
+// Parse parses indicators in the given format, returning the supported indicators without
+// duplicates and the number of indicators skipped because their type is unsupported.
+func Parse(format string, data []byte) ([]*Indicator, int, error) {
+	var (
+		s   indicators
+		err error
+	)
+	switch format {
+	case FormatCSV:
+		err = parseCSV(data, &s)
+	case FormatSTIX:
+		err = parseSTIX(data, &s)
+	case FormatMISP:
+		err = parseMISP(data, &s)
+	default:
+		err = fmt.Errorf("unsupported indicator format %q", format)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.list, s.skipped, nil
+}
+
+// parseCSV parses a CSV file with a header naming its "type" and "value" columns, and optionally
+// an "id" column referencing the indicators.
+func parseCSV(data []byte, s *indicators) error {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %v", err)
+	}
+	cols := map[string]int{"id": -1, "type": -1, "value": -1}
+	for i, h := range header {
+		if _, ok := cols[strings.ToLower(strings.TrimSpace(h))]; ok {
+			cols[strings.ToLower(strings.TrimSpace(h))] = i
+		}
+	}
+	if cols["type"] < 0 || cols["value"] < 0 {
+		return errors.New(`CSV header has no "type" and "value" columns`)
+	}
+	for n := 1; ; n++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV: %v", err)
+		}
+		ref := fmt.Sprintf("record %d", n)
+		if len(rec) <= cols["type"] || len(rec) <= cols["value"] {
+			return fmt.Errorf("%s: missing columns", ref)
+		}
+		if i := cols["id"]; i >= 0 && i < len(rec) && rec[i] != "" {
+			ref = rec[i]
+		}
+		if err := s.add(rec[cols["type"]], rec[cols["value"]], ref); err != nil {
+			return err
+		}
+	}
+}
+
+// stixComparisonRE matches the equality comparisons of a STIX pattern, e.g.
+// "[domain-name:value = 'evil.com']".
+var stixComparisonRE = regexp.MustCompile(`([a-z0-9-]+):value\s*=\s*'((?:[^'\\]|\\.)*)'`)
+
+// parseSTIX parses the indicators of a STIX 2 bundle. Every equality comparison of an indicator
+// pattern is an indicator.
+func parseSTIX(data []byte, s *indicators) error {
+	var bundle struct {
+		Objects []struct {
+			Type    string `json:"type"`
+			ID      string `json:"id"`
+			Pattern string `json:"pattern"`
+		} `json:"objects"`
+	}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("failed to parse STIX bundle: %v", err)
+	}
+	for _, o := range bundle.Objects {
+		if o.Type != "indicator" {
+			continue
+		}
+		for _, m := range stixComparisonRE.FindAllStringSubmatch(o.Pattern, -1) {
+			if err := s.add(m[1], strings.Replace(m[2], `\'`, `'`, -1), o.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type mispAttribute struct {
+	UUID  string `json:"uuid"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	ToIDS bool   `json:"to_ids"`
+}
+
+type mispEvent struct {
+	Attribute []*mispAttribute `json:"Attribute"`
+	Object    []struct {
+		Attribute []*mispAttribute `json:"Attribute"`
+	} `json:"Object"`
+}
+
+// parseMISP parses the attributes of a MISP JSON event export, either a single event or a
+// search response. Only attributes flagged for IDS are indicators. The parts of composite
+// attributes, e.g. "domain|ip", are separate indicators.
+func parseMISP(data []byte, s *indicators) error {
+	var export struct {
+		Event    *mispEvent `json:"Event"`
+		Response []struct {
+			Event *mispEvent `json:"Event"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("failed to parse MISP export: %v", err)
+	}
+	events := []*mispEvent{export.Event}
+	for _, r := range export.Response {
+		events = append(events, r.Event)
+	}
+	var attrs []*mispAttribute
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		attrs = append(attrs, e.Attribute...)
+		for _, o := range e.Object {
+			attrs = append(attrs, o.Attribute...)
+		}
+	}
+	for _, a := range attrs {
+		if !a.ToIDS {
+			continue
+		}
+		types, values := strings.Split(a.Type, "|"), strings.Split(a.Value, "|")
+		if len(types) != len(values) {
+			return fmt.Errorf("%s: value %q does not match type %q", a.UUID, a.Value, a.Type)
+		}
+		for i := range types {
+			if err := s.add(types[i], values[i], a.UUID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
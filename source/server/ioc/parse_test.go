@@ -0,0 +1,126 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ioc
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc        string
+		format      string
+		data        string
+		want        []*Indicator
+		wantSkipped int
+		wantErr     bool
+	}{
+		{
+			desc:   "CSV",
+			format: FormatCSV,
+			data: `# Exported indicators
+type,value,id
+domain,Evil[.]example.com.,ioc-1
+ip,10.0.0.1,
+url,http://evil.example.com/a,ioc-3
+hostname,evil.example.com,ioc-4
+ipv6,2001:DB8::1,ioc-5
+ip,192.0.2.0/24,ioc-6
+`,
+			want: []*Indicator{
+				{Type: Domain, Value: "evil.example.com", Ref: "ioc-1"},
+				{Type: IP, Value: "10.0.0.1", Ref: "record 2"},
+				{Type: IP, Value: "2001:db8::1", Ref: "ioc-5"},
+				{Type: IP, Value: "192.0.2.0/24", Ref: "ioc-6"},
+			},
+			wantSkipped: 1,
+		},
+		{
+			desc:    "CSV without value column",
+			format:  FormatCSV,
+			data:    "type,indicator\ndomain,evil.example.com\n",
+			wantErr: true,
+		},
+		{
+			desc:    "CSV with invalid domain",
+			format:  FormatCSV,
+			data:    "type,value\ndomain,\"evil.example.com\"\"; sid:1;\"\n",
+			wantErr: true,
+		},
+		{
+			desc:   "STIX",
+			format: FormatSTIX,
+			data: `{"type": "bundle", "objects": [
+				{"type": "identity", "id": "identity--1", "name": "CERT"},
+				{"type": "indicator", "id": "indicator--1",
+				 "pattern": "[domain-name:value = 'evil.example.com'] OR [ipv4-addr:value = '10.0.0.1']"},
+				{"type": "indicator", "id": "indicator--2", "pattern": "[url:value = 'http://evil.example.com/a']"}]}`,
+			want: []*Indicator{
+				{Type: Domain, Value: "evil.example.com", Ref: "indicator--1"},
+				{Type: IP, Value: "10.0.0.1", Ref: "indicator--1"},
+			},
+			wantSkipped: 1,
+		},
+		{
+			desc:   "MISP event",
+			format: FormatMISP,
+			data: `{"Event": {"info": "Phishing", "Attribute": [
+				{"uuid": "a1", "type": "domain", "value": "evil.example.com", "to_ids": true},
+				{"uuid": "a2", "type": "ip-dst", "value": "10.0.0.1", "to_ids": false},
+				{"uuid": "a3", "type": "domain|ip", "value": "bad.example.com|10.0.0.2", "to_ids": true}],
+				"Object": [{"Attribute": [{"uuid": "a4", "type": "ip-src", "value": "10.0.0.3", "to_ids": true}]}]}}`,
+			want: []*Indicator{
+				{Type: Domain, Value: "evil.example.com", Ref: "a1"},
+				{Type: Domain, Value: "bad.example.com", Ref: "a3"},
+				{Type: IP, Value: "10.0.0.2", Ref: "a3"},
+				{Type: IP, Value: "10.0.0.3", Ref: "a4"},
+			},
+		},
+		{
+			desc:   "MISP search response",
+			format: FormatMISP,
+			data: `{"response": [
+				{"Event": {"Attribute": [{"uuid": "a1", "type": "hostname", "value": "evil.example.com", "to_ids": true}]}},
+				{"Event": {"Attribute": [{"uuid": "a2", "type": "ip-dst|port", "value": "10.0.0.1|443", "to_ids": true}]}}]}`,
+			want: []*Indicator{
+				{Type: Domain, Value: "evil.example.com", Ref: "a1"},
+				{Type: IP, Value: "10.0.0.1", Ref: "a2"},
+			},
+			wantSkipped: 1,
+		},
+		{
+			desc:    "unsupported format",
+			format:  "openioc",
+			data:    "<ioc/>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, skipped, err := Parse(tt.format, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got err=%v, wantErr=%t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			if skipped != tt.wantSkipped {
+				t.Errorf("got %d skipped indicators, want %d", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
@@ -20,15 +20,24 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/export"
 	"github.com/google/emitto/source/server/fleetspeak"
+	"github.com/google/emitto/source/server/ioc"
+	"github.com/google/emitto/source/server/notify"
 	"github.com/google/emitto/source/server/service"
 	"github.com/google/emitto/source/server/store"
+	"github.com/google/emitto/source/server/transport"
+	"github.com/google/emitto/source/signing"
+	"golang.org/x/crypto/ed25519"
 	"google.golang.org/grpc"
 
 	log "github.com/golang/glog"
+	spb "github.com/google/emitto/source/sensor/proto"
 	pb "github.com/google/emitto/source/server/proto"
 	fspb "github.com/google/fleetspeak/fleetspeak/src/server/grpcservice/proto/fleetspeak_grpcservice"
 )
@@ -46,14 +55,50 @@ var (
 
 	// Fleetspeak flags.
 	certFile = flag.String("cert_file", "", "Path of the Fleetspeak certificate file")
+
+	// Sensor transport flags.
+	sensorTransport = flag.String("sensor_transport", "fleetspeak", "Transport to the sensors: fleetspeak, or grpc where Fleetspeak cannot run")
+	sensorPort      = flag.Int("sensor_port", 4445, "Port of the sensor endpoint, for the gRPC transport")
+	tlsCert         = flag.String("tls_cert", "", "Path of the server TLS certificate of the sensor endpoint, for the gRPC transport")
+	tlsKey          = flag.String("tls_key", "", "Path of the server TLS key of the sensor endpoint, for the gRPC transport")
+	sensorCA        = flag.String("sensor_ca", "", "Path of the CA certificates verifying sensor certificates, for the gRPC transport")
+
+	// Rule file signing flags.
+	signingKey = flag.String("signing_key", "", "Path of the base64-encoded Ed25519 private key used to sign rule files")
+
+	// Rule quarantine flags.
+	quarantineThreshold = flag.Int64("quarantine_threshold", 0, "Number of rule hits in a location zone within the quarantine window above which the rule is quarantined; 0 disables quarantines")
+	quarantineWindow    = flag.Duration("quarantine_window", time.Hour, "Window of the rule hits counted against the quarantine threshold")
+	quarantineAction    = flag.String("quarantine_action", "remove", "Quarantine action: remove the rule from the location zone, or suppress it with a threshold (remove|suppress)")
+
+	// Notification flags.
+	notifyConfig = flag.String("notify_config", "", "Path of the JSON notification sink and routing config; notifications are logged if empty")
+
+	// Indicator import flags.
+	iocConfig = flag.String("ioc_config", "", "Path of the JSON config for generating rules from imported indicator files, with the SID range to allocate; indicators cannot be imported if empty")
+
+	// SIEM export flags.
+	exportConfig = flag.String("export_config", "", "Path of the JSON config for exporting sensor alerts and alert events to a SIEM; nothing is exported if empty")
 )
 
 func main() {
 	ctx := context.Background()
 
-	a, err := fleetspeak.New(*fsAdminAddr, *certFile)
-	if err != nil {
-		log.Fatalf("unable to connect to the Fleetspeak admin server: %v", err)
+	var (
+		a   service.FleetspeakAdminClient
+		t   *transport.Server
+		err error
+	)
+	switch *sensorTransport {
+	case "fleetspeak":
+		if a, err = fleetspeak.New(*fsAdminAddr, *certFile); err != nil {
+			log.Fatalf("unable to connect to the Fleetspeak admin server: %v", err)
+		}
+	case "grpc":
+		t = transport.NewServer()
+		a = t
+	default:
+		log.Exitf("unsupported sensor transport %q", *sensorTransport)
 	}
 	defer a.Close()
 
@@ -63,10 +108,63 @@ func main() {
 	s, closeStore := mustGetStore(ctx)
 	defer closeStore()
 
+	var key ed25519.PrivateKey
+	if *signingKey != "" {
+		if key, err = signing.LoadPrivateKey(*signingKey); err != nil {
+			log.Exitf("failed to load rule file signing key: %v", err)
+		}
+	}
+
+	if *exportConfig != "" {
+		c, err := export.LoadConfig(*exportConfig)
+		if err != nil {
+			log.Exitf("failed to load export config: %v", err)
+		}
+		e, interval, err := c.Exporter(s)
+		if err != nil {
+			log.Exitf("failed to create exporter: %v", err)
+		}
+		go e.Run(ctx, interval)
+	}
+
 	server := grpc.NewServer()
-	svc := service.New(s, fs, a)
+	svc := service.New(s, fs, a, key)
+	defer svc.Close()
+	if *notifyConfig != "" {
+		n, err := notify.LoadConfig(*notifyConfig)
+		if err != nil {
+			log.Exitf("failed to load notification config: %v", err)
+		}
+		svc.SetNotifier(n)
+	}
+	if *iocConfig != "" {
+		c, err := ioc.LoadConfig(*iocConfig)
+		if err != nil {
+			log.Exitf("failed to load indicator config: %v", err)
+		}
+		g, err := c.Generator(s)
+		if err != nil {
+			log.Exitf("failed to create indicator rule generator: %v", err)
+		}
+		svc.SetIndicatorGenerator(g)
+	}
+	if *quarantineThreshold > 0 {
+		action := resources.QuarantineAction(*quarantineAction)
+		if action != resources.QuarantineRemove && action != resources.QuarantineSuppress {
+			log.Exitf("unsupported quarantine action %q", *quarantineAction)
+		}
+		svc.SetQuarantinePolicy(&service.QuarantinePolicy{
+			Threshold: *quarantineThreshold,
+			Window:    *quarantineWindow,
+			Action:    action,
+		})
+	}
 	pb.RegisterEmittoServer(server, svc)
 	fspb.RegisterProcessorServer(server, svc)
+	if t != nil {
+		t.SetProcessor(svc)
+		serveSensors(t)
+	}
 
 	l, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
 	if err != nil {
@@ -76,6 +174,25 @@ func main() {
 	server.Serve(l)
 }
 
+// serveSensors serves the gRPC transport to the sensors in the background.
+func serveSensors(t *transport.Server) {
+	creds, err := transport.ServerCredentials(*tlsCert, *tlsKey, *sensorCA)
+	if err != nil {
+		log.Exitf("failed to load sensor endpoint credentials: %v", err)
+	}
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", *sensorPort))
+	if err != nil {
+		log.Exitf("sensor endpoint failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.Creds(creds))
+	spb.RegisterSensorTransportServer(server, t)
+	go func() {
+		if err := server.Serve(l); err != nil {
+			log.Errorf("Sensor endpoint stopped: %v", err)
+		}
+	}()
+}
+
 func mustGetFileStore(ctx context.Context) (filestore.FileStore, func() error) {
 	if *memoryStorage {
 		return filestore.NewMemoryFileStore(), func() error { return nil }
@@ -0,0 +1,73 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/golang/glog"
+)
+
+// Async is a Notifier queueing notifications and sending them in the background, so that callers
+// are not delayed by slow sinks. The queue is bounded: notifications are dropped while it is full.
+type Async struct {
+	notifier Notifier
+	timeout  time.Duration
+	queue    chan *Notification
+	done     chan struct{}
+}
+
+// NewAsync creates an Async sending notifications to n. At most size notifications are queued, and
+// sending a notification, including its retries, is canceled after timeout.
+func NewAsync(n Notifier, size int, timeout time.Duration) *Async {
+	a := &Async{
+		notifier: n,
+		timeout:  timeout,
+		queue:    make(chan *Notification, size),
+		done:     make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Notify queues the notification, failing if the queue is full. Notify must not be called after
+// Close.
+func (a *Async) Notify(ctx context.Context, n *Notification) error {
+	select {
+	case a.queue <- n:
+		return nil
+	default:
+		return fmt.Errorf("notification queue full, dropped %s notification (%s)", n.Type, n.Subject)
+	}
+}
+
+// Close waits for the queued notifications to be sent.
+func (a *Async) Close() {
+	close(a.queue)
+	<-a.done
+}
+
+func (a *Async) run() {
+	defer close(a.done)
+	for n := range a.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+		if err := a.notifier.Notify(ctx, n); err != nil {
+			log.Errorf("Failed to send %s notification (%s): %v", n.Type, n.Subject, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// Config configures notification sinks and routes, loaded from a JSON file such as:
+//
+//	{"sinks": [
+//	  {"name": "soc", "type": "webhook", "url": "https://soc.example.com/emitto", "secret": "s3cr3t"},
+//	  {"name": "chat", "type": "slack", "url": "https://hooks.slack.com/services/..."},
+//	  {"name": "mail", "type": "smtp", "addr": "mail.example.com:25", "from": "emitto@example.com",
+//	   "to": ["oncall@example.com"]},
+//	  {"name": "syslog", "type": "syslog", "network": "udp", "addr": "localhost:514", "timeout": "10s"}
+//	 ],
+//	 "routes": [
+//	  {"types": ["sensor_alert"], "min_severity": "warning", "sinks": ["soc", "chat"]},
+//	  {"types": ["deploy_result"], "locations": ["nyc"], "min_severity": "critical", "sinks": ["mail"]},
+//	  {"sinks": ["syslog"]}
+//	 ],
+//	 "retry": {"attempts": 3, "backoff": "1s"}}
+type Config struct {
+	Sinks  []*SinkConfig  `json:"sinks"`
+	Routes []*RouteConfig `json:"routes"`
+	Retry  *RetryConfig   `json:"retry,omitempty"`
+}
+
+// SinkConfig configures a notification sink. Only the fields of the sink type are used.
+type SinkConfig struct {
+	// Unique sink name, referenced by routes.
+	Name string `json:"name"`
+	// Sink type: "webhook", "slack", "smtp" or "syslog".
+	Type string `json:"type"`
+	// Webhook and Slack URL.
+	URL string `json:"url,omitempty"`
+	// Webhook HMAC secret.
+	Secret string `json:"secret,omitempty"`
+	// SMTP and syslog server address.
+	Addr string `json:"addr,omitempty"`
+	// SMTP sender, recipients and credentials.
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	// Syslog network and tag.
+	Network string `json:"network,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+	// Maximum time to send a notification to the sink, including retries, e.g. "30s";
+	// defaultSinkTimeout if empty.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// RouteConfig configures a Route.
+type RouteConfig struct {
+	Types     []string `json:"types,omitempty"`
+	Locations []string `json:"locations,omitempty"`
+	// Minimum severity name, "info" if empty.
+	MinSeverity string   `json:"min_severity,omitempty"`
+	Sinks       []string `json:"sinks"`
+}
+
+// RetryConfig configures the retries of every sink.
+type RetryConfig struct {
+	Attempts int `json:"attempts"`
+	// Delay before the first retry, e.g. "1s".
+	Backoff string `json:"backoff"`
+}
+
+// Default retries and sink timeout, if not configured.
+const (
+	defaultAttempts    = 3
+	defaultBackoff     = time.Second
+	defaultSinkTimeout = time.Minute
+)
+
+// LoadConfig reads a Config from a JSON file and creates its Router.
+func LoadConfig(path string) (*Router, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification config %q: %v", path, err)
+	}
+	c := new(Config)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse notification config %q: %v", path, err)
+	}
+	r, err := c.Router()
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification config %q: %v", path, err)
+	}
+	return r, nil
+}
+
+// Router creates the Router of the Config, with every sink retrying failed notifications within its
+// timeout.
+func (c *Config) Router() (*Router, error) {
+	retry := &Retry{Attempts: defaultAttempts, Backoff: defaultBackoff}
+	if c.Retry != nil {
+		if c.Retry.Attempts < 1 {
+			return nil, fmt.Errorf("invalid retry attempts %d", c.Retry.Attempts)
+		}
+		b, err := time.ParseDuration(c.Retry.Backoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry backoff %q: %v", c.Retry.Backoff, err)
+		}
+		retry.Attempts, retry.Backoff = c.Retry.Attempts, b
+	}
+	sinks := make(map[string]Notifier)
+	for _, sc := range c.Sinks {
+		if sc.Name == "" {
+			return nil, errors.New("sink without a name")
+		}
+		if _, ok := sinks[sc.Name]; ok {
+			return nil, fmt.Errorf("duplicate sink %q", sc.Name)
+		}
+		n, err := sc.notifier()
+		if err != nil {
+			return nil, fmt.Errorf("sink %q: %v", sc.Name, err)
+		}
+		timeout := defaultSinkTimeout
+		if sc.Timeout != "" {
+			if timeout, err = time.ParseDuration(sc.Timeout); err != nil || timeout <= 0 {
+				return nil, fmt.Errorf("sink %q: invalid timeout %q", sc.Name, sc.Timeout)
+			}
+		}
+		sinks[sc.Name] = &Timeout{
+			Notifier: &Retry{Notifier: n, Attempts: retry.Attempts, Backoff: retry.Backoff},
+			Timeout:  timeout,
+		}
+	}
+	var routes []*Route
+	for _, rc := range c.Routes {
+		r := &Route{Types: rc.Types, Locations: rc.Locations, Sinks: rc.Sinks}
+		if rc.MinSeverity != "" {
+			s, err := ParseSeverity(rc.MinSeverity)
+			if err != nil {
+				return nil, err
+			}
+			r.MinSeverity = s
+		}
+		routes = append(routes, r)
+	}
+	return NewRouter(sinks, routes)
+}
+
+func (c *SinkConfig) notifier() (Notifier, error) {
+	switch c.Type {
+	case "webhook":
+		if c.URL == "" {
+			return nil, errors.New("missing url")
+		}
+		return &Webhook{URL: c.URL, Secret: c.Secret}, nil
+	case "slack":
+		if c.URL == "" {
+			return nil, errors.New("missing url")
+		}
+		return &Slack{URL: c.URL}, nil
+	case "smtp":
+		if c.Addr == "" || c.From == "" || len(c.To) == 0 {
+			return nil, errors.New("missing addr, from or to")
+		}
+		return &SMTP{Addr: c.Addr, From: c.From, To: c.To, Username: c.Username, Password: c.Password}, nil
+	case "syslog":
+		return &Syslog{Network: c.Network, Addr: c.Addr, Tag: c.Tag}, nil
+	}
+	return nil, fmt.Errorf("unsupported sink type %q", c.Type)
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify contains functionality to notify operators of server events. Notifications are
+// routed to sinks, such as webhooks, email or syslog, by event type, location and severity.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/golang/glog"
+)
+
+// Notified event types.
+const (
+	// A sensor alert policy threshold was crossed.
+	SensorAlert = "sensor_alert"
+	// A sensor reported the result of a rule deployment.
+	DeployResult = "deploy_result"
+	// A noisy rule was quarantined.
+	QuarantineRule = "quarantine_rule"
+	// A rule quarantine was undone.
+	UndoQuarantine = "undo_quarantine"
+)
+
+// Severity is the severity of a notification.
+type Severity int
+
+// Notification severities, in increasing order.
+const (
+	Info Severity = iota
+	Warning
+	Critical
+)
+
+var severityNames = []string{"info", "warning", "critical"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", s)
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity parses a severity name, e.g. "warning".
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// Notification is a message for operators.
+type Notification struct {
+	// Time of the notified event.
+	Time time.Time
+	// Event type, e.g. SensorAlert.
+	Type     string
+	Severity Severity
+	// Location and zone of the event, if any.
+	Location string
+	Zone     string
+	// Fleetspeak client ID of the sensor, if any.
+	ClientID string
+	// Short summary.
+	Subject string
+	// Details.
+	Body string
+}
+
+// DefaultTimeout is the timeout of a notification attempt by a sink without a configured timeout.
+const DefaultTimeout = 10 * time.Second
+
+// deadline returns the deadline of a notification attempt with the provided timeout, or
+// DefaultTimeout if zero, shortened to the deadline of ctx.
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	d := time.Now().Add(timeout)
+	if cd, ok := ctx.Deadline(); ok && cd.Before(d) {
+		return cd
+	}
+	return d
+}
+
+// Notifier sends notifications.
+type Notifier interface {
+	// Notify sends a notification.
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// Log is a Notifier writing notifications to the server log.
+type Log struct{}
+
+// Notify logs the notification.
+func (Log) Notify(ctx context.Context, n *Notification) error {
+	switch n.Severity {
+	case Info:
+		log.Infof("%s: %s", n.Subject, n.Body)
+	case Warning:
+		log.Warningf("%s: %s", n.Subject, n.Body)
+	default:
+		log.Errorf("%s: %s", n.Subject, n.Body)
+	}
+	return nil
+}
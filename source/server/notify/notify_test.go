@@ -0,0 +1,265 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recorder is a Notifier recording notifications, failing the first fail notifications.
+type recorder struct {
+	fail     int
+	attempts int
+	subjects []string
+}
+
+func (r *recorder) Notify(_ context.Context, n *Notification) error {
+	r.attempts++
+	if r.attempts <= r.fail {
+		return errors.New("unavailable")
+	}
+	r.subjects = append(r.subjects, n.Subject)
+	return nil
+}
+
+func TestRouter(t *testing.T) {
+	alerts, deploys, all := &recorder{}, &recorder{}, &recorder{}
+	r, err := NewRouter(map[string]Notifier{"alerts": alerts, "deploys": deploys, "all": all}, []*Route{
+		{Types: []string{SensorAlert}, MinSeverity: Warning, Sinks: []string{"alerts", "all"}},
+		{Types: []string{DeployResult}, Locations: []string{"a"}, Sinks: []string{"deploys"}},
+		{Sinks: []string{"all"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []*Notification{
+		{Type: SensorAlert, Severity: Critical, Location: "a", Subject: "critical alert"},
+		{Type: SensorAlert, Severity: Info, Location: "a", Subject: "info alert"},
+		{Type: DeployResult, Severity: Info, Location: "a", Subject: "deploy a"},
+		{Type: DeployResult, Severity: Critical, Location: "b", Subject: "deploy b"},
+	} {
+		if err := r.Notify(context.Background(), n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tt := range []struct {
+		desc string
+		sink *recorder
+		want []string
+	}{
+		{desc: "alerts", sink: alerts, want: []string{"critical alert"}},
+		{desc: "deploys", sink: deploys, want: []string{"deploy a"}},
+		{desc: "all", sink: all, want: []string{"critical alert", "info alert", "deploy a", "deploy b"}},
+	} {
+		if diff := cmp.Diff(tt.want, tt.sink.subjects); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+
+	if _, err := NewRouter(nil, []*Route{{Sinks: []string{"unknown"}}}); err == nil {
+		t.Error("expected an error for an unknown sink")
+	}
+}
+
+func TestRouterSlowSink(t *testing.T) {
+	slow, fast := &blocker{release: make(chan struct{})}, &recorder{}
+	r, err := NewRouter(map[string]Notifier{
+		"slow": &Timeout{Notifier: slow, Timeout: 10 * time.Millisecond},
+		"fast": fast,
+	}, []*Route{{Sinks: []string{"slow", "fast"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Notify(context.Background(), &Notification{Subject: "test"}); err == nil {
+		t.Error("expected an error for the slow sink")
+	}
+	// The slow sink timed out on its own, without preventing the fast sink from being notified.
+	if slow.canceled != 1 {
+		t.Errorf("got %d canceled notifications of the slow sink, want 1", slow.canceled)
+	}
+	if diff := cmp.Diff([]string{"test"}, fast.subjects); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		fail         int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{desc: "success", wantAttempts: 1},
+		{desc: "success after retries", fail: 2, wantAttempts: 3},
+		{desc: "failure", fail: 5, wantAttempts: 3, wantErr: true},
+	} {
+		rec := &recorder{fail: tt.fail}
+		r := &Retry{Notifier: rec, Attempts: 3, Backoff: time.Millisecond}
+		err := r.Notify(context.Background(), &Notification{Subject: "test"})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+		if rec.attempts != tt.wantAttempts {
+			t.Errorf("%s: got %d attempts, want %d", tt.desc, rec.attempts, tt.wantAttempts)
+		}
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := &recorder{fail: 5}
+	r := &Retry{Notifier: rec, Attempts: 3, Backoff: time.Hour}
+	if err := r.Notify(ctx, &Notification{Subject: "test"}); err == nil {
+		t.Error("expected an error")
+	}
+	if rec.attempts != 1 {
+		t.Errorf("got %d attempts, want 1", rec.attempts)
+	}
+}
+
+// blocker is a Notifier recording notifications once released, or failing when its context is done.
+type blocker struct {
+	release  chan struct{}
+	subjects []string
+	canceled int
+}
+
+func (b *blocker) Notify(ctx context.Context, n *Notification) error {
+	select {
+	case <-b.release:
+		b.subjects = append(b.subjects, n.Subject)
+		return nil
+	case <-ctx.Done():
+		b.canceled++
+		return ctx.Err()
+	}
+}
+
+func TestAsync(t *testing.T) {
+	b := &blocker{release: make(chan struct{})}
+	a := NewAsync(b, 1, time.Minute)
+	// The first notification is being sent, the second is queued and the third is dropped.
+	if err := a.Notify(context.Background(), &Notification{Subject: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	var err error
+	for i := 0; i < 100; i++ {
+		if err = a.Notify(context.Background(), &Notification{Subject: "2"}); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Notify(context.Background(), &Notification{Subject: "3"}); err == nil {
+		t.Error("expected an error for a full queue")
+	}
+	close(b.release)
+	a.Close()
+	if diff := cmp.Diff([]string{"1", "2"}, b.subjects); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Sending is canceled after the timeout.
+	b = &blocker{release: make(chan struct{})}
+	a = NewAsync(b, 1, time.Millisecond)
+	if err := a.Notify(context.Background(), &Notification{Subject: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	a.Close()
+	if b.canceled != 1 {
+		t.Errorf("got %d canceled notifications, want 1", b.canceled)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	d, err := ioutil.TempDir("", "notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, tt := range []struct {
+		desc    string
+		config  string
+		wantErr bool
+	}{
+		{
+			desc: "valid",
+			config: `{"sinks": [
+				{"name": "soc", "type": "webhook", "url": "http://localhost/hook", "secret": "s"},
+				{"name": "chat", "type": "slack", "url": "http://localhost/slack"},
+				{"name": "mail", "type": "smtp", "addr": "localhost:25", "from": "a@example.com", "to": ["b@example.com"]},
+				{"name": "syslog", "type": "syslog", "network": "udp", "addr": "localhost:514", "timeout": "10s"}
+			],
+			"routes": [{"types": ["sensor_alert"], "min_severity": "warning", "sinks": ["soc", "chat", "mail", "syslog"]}],
+			"retry": {"attempts": 5, "backoff": "2s"}}`,
+		},
+		{
+			desc:    "unknown sink",
+			config:  `{"routes": [{"sinks": ["soc"]}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "duplicate sink",
+			config:  `{"sinks": [{"name": "a", "type": "syslog"}, {"name": "a", "type": "syslog"}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported sink type",
+			config:  `{"sinks": [{"name": "a", "type": "pager"}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "missing webhook URL",
+			config:  `{"sinks": [{"name": "a", "type": "webhook"}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "unknown severity",
+			config:  `{"sinks": [{"name": "a", "type": "syslog"}], "routes": [{"min_severity": "urgent", "sinks": ["a"]}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid sink timeout",
+			config:  `{"sinks": [{"name": "a", "type": "syslog", "timeout": "-1s"}]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "invalid backoff",
+			config:  `{"retry": {"attempts": 1, "backoff": "soon"}}`,
+			wantErr: true,
+		},
+	} {
+		path := filepath.Join(d, "notify.json")
+		if err := ioutil.WriteFile(path, []byte(tt.config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadConfig(path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got err=%v, wantErr=%t", tt.desc, err, tt.wantErr)
+		}
+	}
+}
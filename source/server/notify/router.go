@@ -0,0 +1,156 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Route sends the notifications matching all of its criteria to its sinks. Empty criteria match
+// all notifications.
+type Route struct {
+	// Event types.
+	Types []string
+	// Locations.
+	Locations []string
+	// Minimum severity.
+	MinSeverity Severity
+	// Names of the sinks.
+	Sinks []string
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Route) matches(n *Notification) bool {
+	switch {
+	case len(r.Types) > 0 && !contains(r.Types, n.Type):
+		return false
+	case len(r.Locations) > 0 && !contains(r.Locations, n.Location):
+		return false
+	}
+	return n.Severity >= r.MinSeverity
+}
+
+// Router is a Notifier sending notifications to named sinks according to routes.
+type Router struct {
+	sinks  map[string]Notifier
+	routes []*Route
+}
+
+// NewRouter creates a new Router. All route sinks must be defined.
+func NewRouter(sinks map[string]Notifier, routes []*Route) (*Router, error) {
+	for _, r := range routes {
+		for _, name := range r.Sinks {
+			if _, ok := sinks[name]; !ok {
+				return nil, fmt.Errorf("unknown sink %q", name)
+			}
+		}
+	}
+	return &Router{sinks: sinks, routes: routes}, nil
+}
+
+// Notify sends the notification to the sinks of every matching route. A sink receives the
+// notification at most once, even if several routes match. The sinks are notified concurrently, so
+// that a slow sink does not delay the others.
+func (r *Router) Notify(ctx context.Context, n *Notification) error {
+	sent := make(map[string]bool)
+	var names []string
+	for _, route := range r.routes {
+		if !route.matches(n) {
+			continue
+		}
+		for _, name := range route.Sinks {
+			if !sent[name] {
+				sent[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			errs[i] = r.sinks[name].Notify(ctx, n)
+		}(i, name)
+	}
+	wg.Wait()
+	var failed []string
+	var lastErr error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, names[i])
+			lastErr = err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to notify sinks %q: %v", failed, lastErr)
+	}
+	return nil
+}
+
+// Timeout is a Notifier bounding the time to send a notification, including retries.
+type Timeout struct {
+	Notifier Notifier
+	Timeout  time.Duration
+}
+
+// Notify sends the notification, failing once the timeout expires.
+func (t *Timeout) Notify(ctx context.Context, n *Notification) error {
+	ctx, cancel := context.WithTimeout(ctx, t.Timeout)
+	defer cancel()
+	return t.Notifier.Notify(ctx, n)
+}
+
+// Retry is a Notifier retrying failed notifications with exponential backoff.
+type Retry struct {
+	Notifier Notifier
+	// Maximum number of attempts.
+	Attempts int
+	// Delay before the first retry, doubled on every further retry.
+	Backoff time.Duration
+}
+
+// Notify sends the notification, retrying until it succeeds, the attempts are exhausted or the
+// context is done.
+func (r *Retry) Notify(ctx context.Context, n *Notification) error {
+	backoff := r.Backoff
+	var err error
+	for i := 0; i < r.Attempts || i == 0; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%v (retries aborted: %v)", err, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		if err = r.Notifier.Notify(ctx, n); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v (after %d attempts)", err, r.Attempts)
+}
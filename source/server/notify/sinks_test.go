@@ -0,0 +1,255 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testNotification = &Notification{
+	Time:     time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC),
+	Type:     SensorAlert,
+	Severity: Warning,
+	Location: "a",
+	Zone:     "dmz",
+	ClientID: "0A",
+	Subject:  "Sensor alert",
+	Body:     "100 alerts in 1m0s",
+}
+
+// httpStandIn records the requests of an HTTP sink, responding with status code.
+func httpStandIn(t *testing.T, code int) (*httptest.Server, chan *http.Request, chan []byte) {
+	reqs, bodies := make(chan *http.Request, 10), make(chan []byte, 10)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		reqs <- r
+		bodies <- b
+		w.WriteHeader(code)
+	})), reqs, bodies
+}
+
+func TestWebhook(t *testing.T) {
+	srv, reqs, bodies := httpStandIn(t, http.StatusOK)
+	defer srv.Close()
+
+	w := &Webhook{URL: srv.URL, Secret: "s3cr3t"}
+	if err := w.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	r, b := <-reqs, <-bodies
+	if got, want := r.Header.Get(SignatureHeader), Sign("s3cr3t", b); got != want {
+		t.Errorf("got signature %q, want %q", got, want)
+	}
+	var got WebhookPayload
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := WebhookPayload{
+		Time:     "2019-05-13T14:00:00Z",
+		Type:     "sensor_alert",
+		Severity: "warning",
+		Location: "a",
+		Zone:     "dmz",
+		ClientID: "0A",
+		Subject:  "Sensor alert",
+		Body:     "100 alerts in 1m0s",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhookError(t *testing.T) {
+	srv, _, _ := httpStandIn(t, http.StatusInternalServerError)
+	defer srv.Close()
+
+	w := &Webhook{URL: srv.URL}
+	if err := w.Notify(context.Background(), testNotification); err == nil {
+		t.Error("expected an error for a failed request")
+	}
+}
+
+func TestSlack(t *testing.T) {
+	srv, _, bodies := httpStandIn(t, http.StatusOK)
+	defer srv.Close()
+
+	s := &Slack{URL: srv.URL}
+	if err := s.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(<-bodies, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"text": "[warning] *Sensor alert*\n100 alerts in 1m0s"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// smtpStandIn accepts a single SMTP session, sending the recipients and message to the returned
+// channels.
+func smtpStandIn(t *testing.T) (string, chan []string, chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcpts, msgs := make(chan []string, 1), make(chan string, 1)
+	go func() {
+		defer l.Close()
+		c, err := l.Accept()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer c.Close()
+		tc := textproto.NewConn(c)
+		tc.PrintfLine("220 localhost ESMTP")
+		var to []string
+		for {
+			line, err := tc.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				tc.PrintfLine("250 localhost")
+			case "MAIL":
+				tc.PrintfLine("250 OK")
+			case "RCPT":
+				to = append(to, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+				tc.PrintfLine("250 OK")
+			case "DATA":
+				tc.PrintfLine("354 Go ahead")
+				b, err := ioutil.ReadAll(tc.DotReader())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				rcpts <- to
+				msgs <- string(b)
+				tc.PrintfLine("250 OK")
+			case "QUIT":
+				tc.PrintfLine("221 Bye")
+				return
+			default:
+				tc.PrintfLine("502 Not implemented")
+			}
+		}
+	}()
+	return l.Addr().String(), rcpts, msgs
+}
+
+func TestSMTP(t *testing.T) {
+	addr, rcpts, msgs := smtpStandIn(t)
+	s := &SMTP{Addr: addr, From: "emitto@example.com", To: []string{"oncall@example.com", "soc@example.com"}}
+	if err := s.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"oncall@example.com", "soc@example.com"}, <-rcpts); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	msg := <-msgs
+	for _, want := range []string{
+		"From: emitto@example.com\n",
+		"To: oncall@example.com, soc@example.com\n",
+		"Subject: [emitto warning] Sensor alert\n",
+		"Location: a:dmz\n",
+		"\n100 alerts in 1m0s\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message %q does not contain %q", msg, want)
+		}
+	}
+}
+
+func TestSyslog(t *testing.T) {
+	c, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s := &Syslog{Network: "udp", Addr: c.LocalAddr().String(), Tag: "emitto"}
+	if err := s.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	c.SetReadDeadline(time.Now().Add(5 * time.Second))
+	b := make([]byte, 1024)
+	n, _, err := c.ReadFrom(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := bufio.NewReader(strings.NewReader(string(b[:n]))).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	// LOG_DAEMON|LOG_WARNING.
+	if !strings.HasPrefix(msg, "<28>") {
+		t.Errorf("got priority of %q, want <28>", msg)
+	}
+	if want := "emitto["; !strings.Contains(msg, want) {
+		t.Errorf("message %q does not contain %q", msg, want)
+	}
+	if want := "type=sensor_alert location=a zone=dmz client=0A: Sensor alert: 100 alerts in 1m0s"; !strings.Contains(msg, want) {
+		t.Errorf("message %q does not contain %q", msg, want)
+	}
+}
+
+func TestSMTPTimeout(t *testing.T) {
+	// The server accepts connections but never greets.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer c.Close()
+		}
+	}()
+
+	s := &SMTP{Addr: l.Addr().String(), From: "emitto@example.com", To: []string{"oncall@example.com"}, Timeout: 100 * time.Millisecond}
+	errc := make(chan error, 1)
+	go func() { errc <- s.Notify(context.Background(), testNotification) }()
+	select {
+	case err := <-errc:
+		if err == nil {
+			t.Error("expected an error for an unresponsive server")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Notify() did not time out")
+	}
+}
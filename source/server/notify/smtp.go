@@ -0,0 +1,115 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTP is a Notifier sending notifications by email.
+type SMTP struct {
+	// Address of the SMTP server, as host:port.
+	Addr string
+	From string
+	To   []string
+	// Credentials for PLAIN authentication. No authentication is used if Username is empty.
+	Username string
+	Password string
+	// Timeout of a notification attempt. DefaultTimeout is used if zero.
+	Timeout time.Duration
+}
+
+// Notify mails the notification.
+func (s *SMTP) Notify(ctx context.Context, n *Notification) error {
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return err
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: [emitto %s] %s\r\n", n.Severity, n.Subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", n.Time.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "Type: %s\r\n", n.Type)
+	if n.Location != "" {
+		fmt.Fprintf(&msg, "Location: %s:%s\r\n", n.Location, n.Zone)
+	}
+	if n.ClientID != "" {
+		fmt.Fprintf(&msg, "Client: %s\r\n", n.ClientID)
+	}
+	fmt.Fprintf(&msg, "\r\n%s\r\n", strings.Replace(n.Body, "\n", "\r\n", -1))
+	return s.send(ctx, host, msg.Bytes())
+}
+
+// send sends the message like smtp.SendMail, over a connection which fails at the deadline of the
+// attempt.
+func (s *SMTP) send(ctx context.Context, host string, msg []byte) error {
+	d := deadline(ctx, s.Timeout)
+	conn, err := (&net.Dialer{Deadline: d}).DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(d); err != nil {
+		conn.Close()
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if s.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.From); err != nil {
+		return err
+	}
+	for _, to := range s.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
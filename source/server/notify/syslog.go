@@ -0,0 +1,92 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/syslog"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// Paths of the local syslog daemon socket.
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// Syslog is a Notifier writing notifications to a syslog daemon.
+type Syslog struct {
+	// Network and address of the syslog daemon, e.g. "udp" and "localhost:514". The local syslog
+	// daemon is used if Network is empty.
+	Network string
+	Addr    string
+	// Message tag. The program name is used if empty.
+	Tag string
+	// Timeout of a notification attempt. DefaultTimeout is used if zero.
+	Timeout time.Duration
+}
+
+// Notify writes the notification to syslog, with a priority matching its severity. Messages are
+// formatted like log/syslog, which cannot bound the time spent connecting and writing.
+func (s *Syslog) Notify(ctx context.Context, n *Notification) error {
+	d := deadline(ctx, s.Timeout)
+	conn, err := s.dial(ctx, d)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(d); err != nil {
+		return err
+	}
+	p := syslog.LOG_DAEMON
+	switch n.Severity {
+	case Info:
+		p |= syslog.LOG_INFO
+	case Warning:
+		p |= syslog.LOG_WARNING
+	default:
+		p |= syslog.LOG_CRIT
+	}
+	tag := s.Tag
+	if tag == "" {
+		tag = os.Args[0]
+	}
+	msg := fmt.Sprintf("type=%s location=%s zone=%s client=%s: %s: %s", n.Type, n.Location, n.Zone, n.ClientID, n.Subject, strings.Replace(n.Body, "\n", " ", -1))
+	if s.Network == "" {
+		_, err = fmt.Fprintf(conn, "<%d>%s %s[%d]: %s\n", p, time.Now().Format(time.Stamp), tag, os.Getpid(), msg)
+		return err
+	}
+	hostname, _ := os.Hostname()
+	_, err = fmt.Fprintf(conn, "<%d>%s %s %s[%d]: %s\n", p, time.Now().Format(time.RFC3339), hostname, tag, os.Getpid(), msg)
+	return err
+}
+
+// dial connects to the syslog daemon.
+func (s *Syslog) dial(ctx context.Context, deadline time.Time) (net.Conn, error) {
+	dialer := &net.Dialer{Deadline: deadline}
+	if s.Network != "" {
+		return dialer.DialContext(ctx, s.Network, s.Addr)
+	}
+	for _, network := range []string{"unixgram", "unix"} {
+		for _, path := range localSyslogPaths {
+			if conn, err := dialer.DialContext(ctx, network, path); err == nil {
+				return conn, nil
+			}
+		}
+	}
+	return nil, errors.New("failed to connect to the local syslog daemon")
+}
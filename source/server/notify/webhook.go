@@ -0,0 +1,130 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader is the HTTP header carrying the HMAC-SHA256 signature of webhook payloads, in
+// the form "sha256=<hex>".
+const SignatureHeader = "X-Emitto-Signature"
+
+// WebhookPayload is the JSON payload posted by Webhook.
+type WebhookPayload struct {
+	Time     string `json:"time"`
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Location string `json:"location,omitempty"`
+	Zone     string `json:"zone,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body,omitempty"`
+}
+
+// Webhook is a Notifier posting notifications as JSON to an HTTP endpoint.
+type Webhook struct {
+	URL string
+	// Key of the HMAC-SHA256 payload signature. Payloads are not signed if empty.
+	Secret string
+	// HTTP client. A client with DefaultTimeout is used if nil.
+	Client *http.Client
+}
+
+var defaultClient = &http.Client{Timeout: DefaultTimeout}
+
+// Sign returns the value of the SignatureHeader of a payload.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify posts the notification.
+func (w *Webhook) Notify(ctx context.Context, n *Notification) error {
+	b, err := json.Marshal(&WebhookPayload{
+		Time:     n.Time.UTC().Format(time.RFC3339),
+		Type:     n.Type,
+		Severity: n.Severity.String(),
+		Location: n.Location,
+		Zone:     n.Zone,
+		ClientID: n.ClientID,
+		Subject:  n.Subject,
+		Body:     n.Body,
+	})
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	if w.Secret != "" {
+		header.Set(SignatureHeader, Sign(w.Secret, b))
+	}
+	return postJSON(ctx, w.Client, w.URL, header, b)
+}
+
+// Slack is a Notifier posting notifications to a Slack-compatible incoming webhook.
+type Slack struct {
+	URL string
+	// HTTP client. A client with DefaultTimeout is used if nil.
+	Client *http.Client
+}
+
+// Notify posts the notification.
+func (s *Slack) Notify(ctx context.Context, n *Notification) error {
+	text := fmt.Sprintf("[%s] *%s*", n.Severity, n.Subject)
+	if n.Body != "" {
+		text += "\n" + n.Body
+	}
+	b, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, s.Client, s.URL, nil, b)
+}
+
+// postJSON posts a JSON payload, failing on non-2xx responses.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, payload []byte) error {
+	if client == nil {
+		client = defaultClient
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned %s", url, resp.Status)
+	}
+	return nil
+}
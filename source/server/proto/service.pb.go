@@ -168,6 +168,7 @@ func (m *DeployRulesRequest) GetLocation() *Location {
 
 type DeployRulesResponse struct {
 	ClientId             string         `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	RequestId            string         `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	Status               *status.Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
@@ -206,6 +207,13 @@ func (m *DeployRulesResponse) GetClientId() string {
 	return ""
 }
 
+func (m *DeployRulesResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
 func (m *DeployRulesResponse) GetStatus() *status.Status {
 	if m != nil {
 		return m.Status
@@ -611,280 +619,2905 @@ func (m *ListLocationsResponse) GetLocations() []*Location {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Location)(nil), "emitto.service.Location")
-	proto.RegisterType((*Rule)(nil), "emitto.service.Rule")
-	proto.RegisterType((*DeployRulesRequest)(nil), "emitto.service.DeployRulesRequest")
-	proto.RegisterType((*DeployRulesResponse)(nil), "emitto.service.DeployRulesResponse")
-	proto.RegisterType((*AddRuleRequest)(nil), "emitto.service.AddRuleRequest")
-	proto.RegisterType((*ModifyRuleRequest)(nil), "emitto.service.ModifyRuleRequest")
-	proto.RegisterType((*DeleteRuleRequest)(nil), "emitto.service.DeleteRuleRequest")
-	proto.RegisterType((*ListRulesRequest)(nil), "emitto.service.ListRulesRequest")
-	proto.RegisterType((*ListRulesResponse)(nil), "emitto.service.ListRulesResponse")
-	proto.RegisterType((*AddLocationRequest)(nil), "emitto.service.AddLocationRequest")
-	proto.RegisterType((*ModifyLocationRequest)(nil), "emitto.service.ModifyLocationRequest")
-	proto.RegisterType((*DeleteLocationRequest)(nil), "emitto.service.DeleteLocationRequest")
-	proto.RegisterType((*ListLocationsRequest)(nil), "emitto.service.ListLocationsRequest")
-	proto.RegisterType((*ListLocationsResponse)(nil), "emitto.service.ListLocationsResponse")
+type SensorHealth struct {
+	ClientId              string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Time                  string   `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Host                  string   `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	SensorVersion         string   `protobuf:"bytes,4,opt,name=sensor_version,json=sensorVersion,proto3" json:"sensor_version,omitempty"`
+	RuleFileSha256        string   `protobuf:"bytes,5,opt,name=rule_file_sha256,json=ruleFileSha256,proto3" json:"rule_file_sha256,omitempty"`
+	SuricataVersion       string   `protobuf:"bytes,6,opt,name=suricata_version,json=suricataVersion,proto3" json:"suricata_version,omitempty"`
+	SuricataUptimeSeconds int64    `protobuf:"varint,7,opt,name=suricata_uptime_seconds,json=suricataUptimeSeconds,proto3" json:"suricata_uptime_seconds,omitempty"`
+	RunningMode           string   `protobuf:"bytes,8,opt,name=running_mode,json=runningMode,proto3" json:"running_mode,omitempty"`
+	CapturePackets        int64    `protobuf:"varint,9,opt,name=capture_packets,json=capturePackets,proto3" json:"capture_packets,omitempty"`
+	CaptureDrops          int64    `protobuf:"varint,10,opt,name=capture_drops,json=captureDrops,proto3" json:"capture_drops,omitempty"`
+	RulesLoaded           int64    `protobuf:"varint,11,opt,name=rules_loaded,json=rulesLoaded,proto3" json:"rules_loaded,omitempty"`
+	RulesFailed           int64    `protobuf:"varint,12,opt,name=rules_failed,json=rulesFailed,proto3" json:"rules_failed,omitempty"`
+	SuricataError         string   `protobuf:"bytes,13,opt,name=suricata_error,json=suricataError,proto3" json:"suricata_error,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("source/server/proto/service.proto", fileDescriptor_6256c75c7d842e44) }
-
-var fileDescriptor_6256c75c7d842e44 = []byte{
-	// 641 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xdf, 0x6e, 0xd3, 0x3c,
-	0x18, 0xc6, 0xd7, 0x66, 0x6b, 0x9b, 0xb7, 0xdf, 0xaa, 0xaf, 0xa6, 0xdd, 0x42, 0x26, 0xa1, 0xd6,
-	0xa3, 0x52, 0x35, 0x41, 0x8a, 0xca, 0x84, 0x04, 0x42, 0x42, 0x13, 0x1b, 0x52, 0x61, 0x03, 0x2d,
-	0x9c, 0xed, 0x60, 0x55, 0x9a, 0xb8, 0x53, 0xb4, 0xb4, 0x0e, 0x71, 0x82, 0x28, 0x57, 0xc0, 0x95,
-	0x72, 0x1d, 0x28, 0x76, 0xdc, 0x36, 0x49, 0x17, 0x04, 0xe3, 0xcc, 0x7f, 0x7e, 0x7e, 0xfc, 0xe6,
-	0x79, 0xfd, 0x04, 0xba, 0x8c, 0x46, 0x81, 0x4d, 0x06, 0x8c, 0x04, 0x5f, 0x49, 0x30, 0xf0, 0x03,
-	0x1a, 0x52, 0x3e, 0x71, 0x6d, 0x62, 0xf0, 0x19, 0x6a, 0x90, 0x99, 0x1b, 0x86, 0xd4, 0x48, 0x56,
-	0xf5, 0x83, 0x1b, 0x4a, 0x6f, 0x3c, 0x22, 0xd8, 0x49, 0x34, 0x1d, 0x90, 0x99, 0x1f, 0x2e, 0x04,
-	0xac, 0xef, 0x27, 0x9b, 0x81, 0x6f, 0x0f, 0x58, 0x68, 0x85, 0x11, 0x4b, 0x36, 0x3a, 0xd9, 0x53,
-	0x53, 0x97, 0x78, 0xce, 0x78, 0x66, 0xb1, 0x5b, 0x41, 0xe0, 0x63, 0xa8, 0x9d, 0x53, 0xdb, 0x0a,
-	0x5d, 0x3a, 0x47, 0x08, 0xb6, 0xe7, 0xd6, 0x8c, 0x68, 0xa5, 0x4e, 0xa9, 0xaf, 0x9a, 0x7c, 0x8c,
-	0x5a, 0xb0, 0xf3, 0x9d, 0xce, 0x09, 0xd3, 0xca, 0x1d, 0xa5, 0xaf, 0x9a, 0x62, 0x82, 0x2f, 0x61,
-	0xdb, 0x8c, 0x3c, 0x82, 0x1a, 0x50, 0x76, 0x1d, 0xce, 0x2b, 0x66, 0xd9, 0x75, 0x62, 0x85, 0x09,
-	0x75, 0x16, 0x5a, 0x59, 0x28, 0xc4, 0x63, 0xd4, 0x83, 0x86, 0x97, 0xdc, 0x30, 0x16, 0x52, 0x0a,
-	0x97, 0xda, 0x95, 0xab, 0x57, 0x5c, 0xf2, 0x3d, 0xa0, 0x53, 0xe2, 0x7b, 0x74, 0x11, 0x0b, 0x33,
-	0x93, 0x7c, 0x89, 0x08, 0x0b, 0xd1, 0x31, 0xd4, 0x24, 0xc6, 0xaf, 0xa9, 0x0f, 0x35, 0x23, 0xed,
-	0x8c, 0x21, 0xcb, 0x37, 0x97, 0x24, 0xbe, 0x86, 0x07, 0x29, 0x2d, 0xe6, 0xd3, 0x39, 0x23, 0xe8,
-	0x00, 0x54, 0xdb, 0x73, 0xc9, 0x3c, 0x1c, 0x27, 0x45, 0xab, 0x66, 0x4d, 0x2c, 0x8c, 0x1c, 0x74,
-	0x04, 0x15, 0x61, 0x9d, 0xa6, 0xf0, 0x7b, 0x90, 0x21, 0xbc, 0x33, 0x02, 0xdf, 0x36, 0x3e, 0xf3,
-	0x1d, 0x33, 0x21, 0xf0, 0x2b, 0x68, 0x9c, 0x38, 0x4e, 0x2c, 0x2e, 0xeb, 0xec, 0xc3, 0x76, 0x10,
-	0x79, 0x24, 0xa9, 0xb1, 0x95, 0xad, 0x91, 0xa3, 0x9c, 0xc0, 0xdf, 0xa0, 0x79, 0x41, 0x1d, 0x77,
-	0xba, 0xf8, 0xab, 0xe3, 0xe8, 0x25, 0xc0, 0xaa, 0x87, 0xdc, 0xe7, 0xfa, 0x50, 0x97, 0xa5, 0xca,
-	0x36, 0x1b, 0xef, 0x62, 0xe4, 0xc2, 0x62, 0xb7, 0xa6, 0x3a, 0x95, 0x43, 0xfc, 0x04, 0x9a, 0xa7,
-	0xc4, 0x23, 0x21, 0x59, 0xbf, 0x79, 0x1f, 0xaa, 0xb1, 0xee, 0x78, 0xd9, 0xc6, 0x4a, 0x3c, 0x1d,
-	0x39, 0xf8, 0x29, 0xfc, 0x7f, 0xee, 0xb2, 0x30, 0xd5, 0x8d, 0x87, 0x50, 0x4b, 0x60, 0xa6, 0x95,
-	0x3a, 0x4a, 0x5f, 0x31, 0xab, 0x82, 0x66, 0xf8, 0x0d, 0x34, 0xd7, 0xf0, 0xc4, 0xf0, 0x23, 0xd8,
-	0x89, 0xf7, 0x05, 0x7c, 0xd7, 0x77, 0x09, 0x24, 0xee, 0xff, 0x89, 0xe3, 0x2c, 0x9b, 0x79, 0xaf,
-	0xfe, 0xff, 0x28, 0x41, 0x5b, 0x98, 0xfc, 0x4f, 0xf4, 0xee, 0x63, 0xfa, 0x6b, 0x68, 0x0b, 0xd3,
-	0xb3, 0x95, 0x1c, 0xc2, 0x32, 0x00, 0xe3, 0xb5, 0xd4, 0xfd, 0x27, 0x17, 0x3f, 0x5a, 0x33, 0x82,
-	0xf7, 0xa0, 0x15, 0xbb, 0x2a, 0xcf, 0xca, 0x46, 0xe0, 0x4f, 0xd0, 0xce, 0xac, 0x27, 0x8e, 0xbf,
-	0x00, 0x55, 0x0a, 0x48, 0xd7, 0xef, 0xfe, 0xc0, 0x15, 0x3a, 0xfc, 0xb9, 0x03, 0x95, 0x33, 0x8e,
-	0xa1, 0x2b, 0xa8, 0xaf, 0x85, 0x07, 0xe1, 0xec, 0xf1, 0x7c, 0x4a, 0xf5, 0xc3, 0x42, 0x46, 0x94,
-	0x86, 0xb7, 0x9e, 0x95, 0xd0, 0x5b, 0xa8, 0x26, 0xc1, 0x41, 0x8f, 0xb2, 0x67, 0xd2, 0x89, 0xd2,
-	0xf7, 0x72, 0xfe, 0x9e, 0xc5, 0x7f, 0x3c, 0xbc, 0x85, 0x46, 0x00, 0xab, 0x04, 0xa1, 0x6e, 0x56,
-	0x27, 0x97, 0xae, 0x62, 0xa9, 0x55, 0x24, 0xf2, 0x52, 0xb9, 0xb8, 0x14, 0x48, 0x99, 0xa0, 0x2e,
-	0x03, 0x80, 0x3a, 0x39, 0xcf, 0x33, 0x51, 0xd2, 0xbb, 0x05, 0x84, 0x34, 0x0c, 0x7d, 0x80, 0xfa,
-	0x5a, 0x26, 0xf2, 0xad, 0xc8, 0x07, 0xa6, 0xa0, 0xc0, 0x4b, 0x68, 0xa4, 0x33, 0x81, 0x7a, 0x9b,
-	0xad, 0xfb, 0x23, 0xc9, 0xf4, 0xe3, 0xce, 0x4b, 0x6e, 0x7c, 0xfc, 0x05, 0x92, 0xd7, 0xb0, 0x9b,
-	0x7a, 0xd9, 0xe8, 0xf1, 0x26, 0xa3, 0xb2, 0x81, 0xd0, 0x7b, 0xbf, 0xa1, 0xa4, 0xa5, 0x93, 0x0a,
-	0xbf, 0xf1, 0xf9, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x46, 0x5f, 0xec, 0x02, 0x83, 0x07, 0x00,
-	0x00,
+func (m *SensorHealth) Reset()         { *m = SensorHealth{} }
+func (m *SensorHealth) String() string { return proto.CompactTextString(m) }
+func (*SensorHealth) ProtoMessage()    {}
+func (*SensorHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{14}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *SensorHealth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensorHealth.Unmarshal(m, b)
+}
+func (m *SensorHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensorHealth.Marshal(b, m, deterministic)
+}
+func (m *SensorHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensorHealth.Merge(m, src)
+}
+func (m *SensorHealth) XXX_Size() int {
+	return xxx_messageInfo_SensorHealth.Size(m)
+}
+func (m *SensorHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensorHealth.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_SensorHealth proto.InternalMessageInfo
 
-// EmittoClient is the client API for Emitto service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type EmittoClient interface {
-	DeployRules(ctx context.Context, in *DeployRulesRequest, opts ...grpc.CallOption) (Emitto_DeployRulesClient, error)
-	AddRule(ctx context.Context, in *AddRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ModifyRule(ctx context.Context, in *ModifyRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error)
-	AddLocation(ctx context.Context, in *AddLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ModifyLocation(ctx context.Context, in *ModifyLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error)
+func (m *SensorHealth) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
 }
 
-type emittoClient struct {
-	cc *grpc.ClientConn
+func (m *SensorHealth) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
 }
 
-func NewEmittoClient(cc *grpc.ClientConn) EmittoClient {
-	return &emittoClient{cc}
+func (m *SensorHealth) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
 }
 
-func (c *emittoClient) DeployRules(ctx context.Context, in *DeployRulesRequest, opts ...grpc.CallOption) (Emitto_DeployRulesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Emitto_serviceDesc.Streams[0], "/emitto.service.Emitto/DeployRules", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &emittoDeployRulesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *SensorHealth) GetSensorVersion() string {
+	if m != nil {
+		return m.SensorVersion
 	}
-	return x, nil
+	return ""
 }
 
-type Emitto_DeployRulesClient interface {
-	Recv() (*DeployRulesResponse, error)
-	grpc.ClientStream
+func (m *SensorHealth) GetRuleFileSha256() string {
+	if m != nil {
+		return m.RuleFileSha256
+	}
+	return ""
 }
 
-type emittoDeployRulesClient struct {
-	grpc.ClientStream
+func (m *SensorHealth) GetSuricataVersion() string {
+	if m != nil {
+		return m.SuricataVersion
+	}
+	return ""
 }
 
-func (x *emittoDeployRulesClient) Recv() (*DeployRulesResponse, error) {
-	m := new(DeployRulesResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SensorHealth) GetSuricataUptimeSeconds() int64 {
+	if m != nil {
+		return m.SuricataUptimeSeconds
 	}
-	return m, nil
+	return 0
 }
 
-func (c *emittoClient) AddRule(ctx context.Context, in *AddRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/AddRule", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SensorHealth) GetRunningMode() string {
+	if m != nil {
+		return m.RunningMode
 	}
-	return out, nil
+	return ""
 }
 
-func (c *emittoClient) ModifyRule(ctx context.Context, in *ModifyRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ModifyRule", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SensorHealth) GetCapturePackets() int64 {
+	if m != nil {
+		return m.CapturePackets
 	}
-	return out, nil
+	return 0
 }
 
-func (c *emittoClient) DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/DeleteRule", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SensorHealth) GetCaptureDrops() int64 {
+	if m != nil {
+		return m.CaptureDrops
 	}
-	return out, nil
+	return 0
 }
 
-func (c *emittoClient) ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error) {
-	out := new(ListRulesResponse)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListRules", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SensorHealth) GetRulesLoaded() int64 {
+	if m != nil {
+		return m.RulesLoaded
 	}
-	return out, nil
+	return 0
 }
 
-func (c *emittoClient) AddLocation(ctx context.Context, in *AddLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/AddLocation", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SensorHealth) GetRulesFailed() int64 {
+	if m != nil {
+		return m.RulesFailed
 	}
-	return out, nil
+	return 0
 }
 
-func (c *emittoClient) ModifyLocation(ctx context.Context, in *ModifyLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ModifyLocation", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SensorHealth) GetSuricataError() string {
+	if m != nil {
+		return m.SuricataError
 	}
-	return out, nil
+	return ""
 }
 
-func (c *emittoClient) DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/DeleteLocation", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ListSensorHealthRequest struct {
+	ClientIds            []string `protobuf:"bytes,1,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *emittoClient) ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error) {
-	out := new(ListLocationsResponse)
-	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListLocations", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListSensorHealthRequest) Reset()         { *m = ListSensorHealthRequest{} }
+func (m *ListSensorHealthRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSensorHealthRequest) ProtoMessage()    {}
+func (*ListSensorHealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{15}
+}
+
+func (m *ListSensorHealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSensorHealthRequest.Unmarshal(m, b)
+}
+func (m *ListSensorHealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSensorHealthRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSensorHealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSensorHealthRequest.Merge(m, src)
+}
+func (m *ListSensorHealthRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSensorHealthRequest.Size(m)
+}
+func (m *ListSensorHealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSensorHealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSensorHealthRequest proto.InternalMessageInfo
+
+func (m *ListSensorHealthRequest) GetClientIds() []string {
+	if m != nil {
+		return m.ClientIds
 	}
-	return out, nil
+	return nil
 }
 
-// EmittoServer is the server API for Emitto service.
-type EmittoServer interface {
-	DeployRules(*DeployRulesRequest, Emitto_DeployRulesServer) error
-	AddRule(context.Context, *AddRuleRequest) (*empty.Empty, error)
-	ModifyRule(context.Context, *ModifyRuleRequest) (*empty.Empty, error)
-	DeleteRule(context.Context, *DeleteRuleRequest) (*empty.Empty, error)
-	ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error)
-	AddLocation(context.Context, *AddLocationRequest) (*empty.Empty, error)
-	ModifyLocation(context.Context, *ModifyLocationRequest) (*empty.Empty, error)
-	DeleteLocation(context.Context, *DeleteLocationRequest) (*empty.Empty, error)
-	ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error)
+type ListSensorHealthResponse struct {
+	Sensors              []*SensorHealth `protobuf:"bytes,1,rep,name=sensors,proto3" json:"sensors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func RegisterEmittoServer(s *grpc.Server, srv EmittoServer) {
-	s.RegisterService(&_Emitto_serviceDesc, srv)
+func (m *ListSensorHealthResponse) Reset()         { *m = ListSensorHealthResponse{} }
+func (m *ListSensorHealthResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSensorHealthResponse) ProtoMessage()    {}
+func (*ListSensorHealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{16}
 }
 
-func _Emitto_DeployRules_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DeployRulesRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *ListSensorHealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSensorHealthResponse.Unmarshal(m, b)
+}
+func (m *ListSensorHealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSensorHealthResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSensorHealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSensorHealthResponse.Merge(m, src)
+}
+func (m *ListSensorHealthResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSensorHealthResponse.Size(m)
+}
+func (m *ListSensorHealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSensorHealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSensorHealthResponse proto.InternalMessageInfo
+
+func (m *ListSensorHealthResponse) GetSensors() []*SensorHealth {
+	if m != nil {
+		return m.Sensors
 	}
-	return srv.(EmittoServer).DeployRules(m, &emittoDeployRulesServer{stream})
+	return nil
 }
 
-type Emitto_DeployRulesServer interface {
-	Send(*DeployRulesResponse) error
-	grpc.ServerStream
+type DriftEvent struct {
+	Id                     string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientId               string   `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Time                   string   `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	DeployRequestId        string   `protobuf:"bytes,4,opt,name=deploy_request_id,json=deployRequestId,proto3" json:"deploy_request_id,omitempty"`
+	ExpectedRuleFileSha256 string   `protobuf:"bytes,5,opt,name=expected_rule_file_sha256,json=expectedRuleFileSha256,proto3" json:"expected_rule_file_sha256,omitempty"`
+	ReportedRuleFileSha256 string   `protobuf:"bytes,6,opt,name=reported_rule_file_sha256,json=reportedRuleFileSha256,proto3" json:"reported_rule_file_sha256,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
-type emittoDeployRulesServer struct {
-	grpc.ServerStream
+func (m *DriftEvent) Reset()         { *m = DriftEvent{} }
+func (m *DriftEvent) String() string { return proto.CompactTextString(m) }
+func (*DriftEvent) ProtoMessage()    {}
+func (*DriftEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{17}
 }
 
-func (x *emittoDeployRulesServer) Send(m *DeployRulesResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *DriftEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DriftEvent.Unmarshal(m, b)
+}
+func (m *DriftEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DriftEvent.Marshal(b, m, deterministic)
+}
+func (m *DriftEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DriftEvent.Merge(m, src)
+}
+func (m *DriftEvent) XXX_Size() int {
+	return xxx_messageInfo_DriftEvent.Size(m)
+}
+func (m *DriftEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DriftEvent.DiscardUnknown(m)
 }
 
-func _Emitto_AddRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddRuleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(EmittoServer).AddRule(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/emitto.service.Emitto/AddRule",
+var xxx_messageInfo_DriftEvent proto.InternalMessageInfo
+
+func (m *DriftEvent) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EmittoServer).AddRule(ctx, req.(*AddRuleRequest))
+	return ""
+}
+
+func (m *DriftEvent) GetClientId() string {
+	if m != nil {
+		return m.ClientId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Emitto_ModifyRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ModifyRuleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *DriftEvent) GetTime() string {
+	if m != nil {
+		return m.Time
 	}
-	if interceptor == nil {
-		return srv.(EmittoServer).ModifyRule(ctx, in)
+	return ""
+}
+
+func (m *DriftEvent) GetDeployRequestId() string {
+	if m != nil {
+		return m.DeployRequestId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/emitto.service.Emitto/ModifyRule",
+	return ""
+}
+
+func (m *DriftEvent) GetExpectedRuleFileSha256() string {
+	if m != nil {
+		return m.ExpectedRuleFileSha256
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EmittoServer).ModifyRule(ctx, req.(*ModifyRuleRequest))
+	return ""
+}
+
+func (m *DriftEvent) GetReportedRuleFileSha256() string {
+	if m != nil {
+		return m.ReportedRuleFileSha256
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Emitto_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+type ListDriftRequest struct {
+	ClientIds            []string `protobuf:"bytes,1,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	Redeploy             bool     `protobuf:"varint,2,opt,name=redeploy,proto3" json:"redeploy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDriftRequest) Reset()         { *m = ListDriftRequest{} }
+func (m *ListDriftRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDriftRequest) ProtoMessage()    {}
+func (*ListDriftRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{18}
+}
+
+func (m *ListDriftRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDriftRequest.Unmarshal(m, b)
+}
+func (m *ListDriftRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDriftRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDriftRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDriftRequest.Merge(m, src)
+}
+func (m *ListDriftRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDriftRequest.Size(m)
+}
+func (m *ListDriftRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDriftRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDriftRequest proto.InternalMessageInfo
+
+func (m *ListDriftRequest) GetClientIds() []string {
+	if m != nil {
+		return m.ClientIds
+	}
+	return nil
+}
+
+func (m *ListDriftRequest) GetRedeploy() bool {
+	if m != nil {
+		return m.Redeploy
+	}
+	return false
+}
+
+type ListDriftResponse struct {
+	Events               []*DriftEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Redeployments        []*DeployRulesResponse `protobuf:"bytes,2,rep,name=redeployments,proto3" json:"redeployments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListDriftResponse) Reset()         { *m = ListDriftResponse{} }
+func (m *ListDriftResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDriftResponse) ProtoMessage()    {}
+func (*ListDriftResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{19}
+}
+
+func (m *ListDriftResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDriftResponse.Unmarshal(m, b)
+}
+func (m *ListDriftResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDriftResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDriftResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDriftResponse.Merge(m, src)
+}
+func (m *ListDriftResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDriftResponse.Size(m)
+}
+func (m *ListDriftResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDriftResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDriftResponse proto.InternalMessageInfo
+
+func (m *ListDriftResponse) GetEvents() []*DriftEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListDriftResponse) GetRedeployments() []*DeployRulesResponse {
+	if m != nil {
+		return m.Redeployments
+	}
+	return nil
+}
+
+type AlertEvent struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientId             string   `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Time                 string   `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	Host                 string   `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	Location             string   `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Zone                 string   `protobuf:"bytes,6,opt,name=zone,proto3" json:"zone,omitempty"`
+	RuleId               int64    `protobuf:"varint,7,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	SignatureId          int64    `protobuf:"varint,8,opt,name=signature_id,json=signatureId,proto3" json:"signature_id,omitempty"`
+	Signature            string   `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	Category             string   `protobuf:"bytes,10,opt,name=category,proto3" json:"category,omitempty"`
+	Severity             int64    `protobuf:"varint,11,opt,name=severity,proto3" json:"severity,omitempty"`
+	Action               string   `protobuf:"bytes,12,opt,name=action,proto3" json:"action,omitempty"`
+	SrcIp                string   `protobuf:"bytes,13,opt,name=src_ip,json=srcIp,proto3" json:"src_ip,omitempty"`
+	SrcPort              int64    `protobuf:"varint,14,opt,name=src_port,json=srcPort,proto3" json:"src_port,omitempty"`
+	DestIp               string   `protobuf:"bytes,15,opt,name=dest_ip,json=destIp,proto3" json:"dest_ip,omitempty"`
+	DestPort             int64    `protobuf:"varint,16,opt,name=dest_port,json=destPort,proto3" json:"dest_port,omitempty"`
+	Proto                string   `protobuf:"bytes,17,opt,name=proto,proto3" json:"proto,omitempty"`
+	AppProto             string   `protobuf:"bytes,18,opt,name=app_proto,json=appProto,proto3" json:"app_proto,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertEvent) Reset()         { *m = AlertEvent{} }
+func (m *AlertEvent) String() string { return proto.CompactTextString(m) }
+func (*AlertEvent) ProtoMessage()    {}
+func (*AlertEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{20}
+}
+
+func (m *AlertEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertEvent.Unmarshal(m, b)
+}
+func (m *AlertEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertEvent.Marshal(b, m, deterministic)
+}
+func (m *AlertEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertEvent.Merge(m, src)
+}
+func (m *AlertEvent) XXX_Size() int {
+	return xxx_messageInfo_AlertEvent.Size(m)
+}
+func (m *AlertEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertEvent proto.InternalMessageInfo
+
+func (m *AlertEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetRuleId() int64 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *AlertEvent) GetSignatureId() int64 {
+	if m != nil {
+		return m.SignatureId
+	}
+	return 0
+}
+
+func (m *AlertEvent) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetSeverity() int64 {
+	if m != nil {
+		return m.Severity
+	}
+	return 0
+}
+
+func (m *AlertEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetSrcIp() string {
+	if m != nil {
+		return m.SrcIp
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetSrcPort() int64 {
+	if m != nil {
+		return m.SrcPort
+	}
+	return 0
+}
+
+func (m *AlertEvent) GetDestIp() string {
+	if m != nil {
+		return m.DestIp
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetDestPort() int64 {
+	if m != nil {
+		return m.DestPort
+	}
+	return 0
+}
+
+func (m *AlertEvent) GetProto() string {
+	if m != nil {
+		return m.Proto
+	}
+	return ""
+}
+
+func (m *AlertEvent) GetAppProto() string {
+	if m != nil {
+		return m.AppProto
+	}
+	return ""
+}
+
+type ListAlertEventsRequest struct {
+	RuleId               int64    `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Location             string   `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Zone                 string   `protobuf:"bytes,3,opt,name=zone,proto3" json:"zone,omitempty"`
+	ClientId             string   `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Host                 string   `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
+	Since                string   `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	Limit                int32    `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAlertEventsRequest) Reset()         { *m = ListAlertEventsRequest{} }
+func (m *ListAlertEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAlertEventsRequest) ProtoMessage()    {}
+func (*ListAlertEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{21}
+}
+
+func (m *ListAlertEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAlertEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAlertEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAlertEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAlertEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAlertEventsRequest.Merge(m, src)
+}
+func (m *ListAlertEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAlertEventsRequest.Size(m)
+}
+func (m *ListAlertEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAlertEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAlertEventsRequest proto.InternalMessageInfo
+
+func (m *ListAlertEventsRequest) GetRuleId() int64 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *ListAlertEventsRequest) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *ListAlertEventsRequest) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *ListAlertEventsRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *ListAlertEventsRequest) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *ListAlertEventsRequest) GetSince() string {
+	if m != nil {
+		return m.Since
+	}
+	return ""
+}
+
+func (m *ListAlertEventsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListAlertEventsResponse struct {
+	Events               []*AlertEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListAlertEventsResponse) Reset()         { *m = ListAlertEventsResponse{} }
+func (m *ListAlertEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAlertEventsResponse) ProtoMessage()    {}
+func (*ListAlertEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{22}
+}
+
+func (m *ListAlertEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAlertEventsResponse.Unmarshal(m, b)
+}
+func (m *ListAlertEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAlertEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAlertEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAlertEventsResponse.Merge(m, src)
+}
+func (m *ListAlertEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAlertEventsResponse.Size(m)
+}
+func (m *ListAlertEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAlertEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAlertEventsResponse proto.InternalMessageInfo
+
+func (m *ListAlertEventsResponse) GetEvents() []*AlertEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type GetRuleStatsRequest struct {
+	RuleIds              []int64  `protobuf:"varint,1,rep,packed,name=rule_ids,json=ruleIds,proto3" json:"rule_ids,omitempty"`
+	Location             string   `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Zone                 string   `protobuf:"bytes,3,opt,name=zone,proto3" json:"zone,omitempty"`
+	Since                string   `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	Until                string   `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`
+	Interval             string   `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	Top                  int32    `protobuf:"varint,7,opt,name=top,proto3" json:"top,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRuleStatsRequest) Reset()         { *m = GetRuleStatsRequest{} }
+func (m *GetRuleStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRuleStatsRequest) ProtoMessage()    {}
+func (*GetRuleStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{23}
+}
+
+func (m *GetRuleStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRuleStatsRequest.Unmarshal(m, b)
+}
+func (m *GetRuleStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRuleStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRuleStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRuleStatsRequest.Merge(m, src)
+}
+func (m *GetRuleStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRuleStatsRequest.Size(m)
+}
+func (m *GetRuleStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRuleStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRuleStatsRequest proto.InternalMessageInfo
+
+func (m *GetRuleStatsRequest) GetRuleIds() []int64 {
+	if m != nil {
+		return m.RuleIds
+	}
+	return nil
+}
+
+func (m *GetRuleStatsRequest) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *GetRuleStatsRequest) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *GetRuleStatsRequest) GetSince() string {
+	if m != nil {
+		return m.Since
+	}
+	return ""
+}
+
+func (m *GetRuleStatsRequest) GetUntil() string {
+	if m != nil {
+		return m.Until
+	}
+	return ""
+}
+
+func (m *GetRuleStatsRequest) GetInterval() string {
+	if m != nil {
+		return m.Interval
+	}
+	return ""
+}
+
+func (m *GetRuleStatsRequest) GetTop() int32 {
+	if m != nil {
+		return m.Top
+	}
+	return 0
+}
+
+type RuleHitsBucket struct {
+	Start                string   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Hits                 int64    `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RuleHitsBucket) Reset()         { *m = RuleHitsBucket{} }
+func (m *RuleHitsBucket) String() string { return proto.CompactTextString(m) }
+func (*RuleHitsBucket) ProtoMessage()    {}
+func (*RuleHitsBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{24}
+}
+
+func (m *RuleHitsBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleHitsBucket.Unmarshal(m, b)
+}
+func (m *RuleHitsBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleHitsBucket.Marshal(b, m, deterministic)
+}
+func (m *RuleHitsBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleHitsBucket.Merge(m, src)
+}
+func (m *RuleHitsBucket) XXX_Size() int {
+	return xxx_messageInfo_RuleHitsBucket.Size(m)
+}
+func (m *RuleHitsBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleHitsBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleHitsBucket proto.InternalMessageInfo
+
+func (m *RuleHitsBucket) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *RuleHitsBucket) GetHits() int64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+type RuleStats struct {
+	RuleId               int64             `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Location             string            `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Zone                 string            `protobuf:"bytes,3,opt,name=zone,proto3" json:"zone,omitempty"`
+	Hits                 int64             `protobuf:"varint,4,opt,name=hits,proto3" json:"hits,omitempty"`
+	Buckets              []*RuleHitsBucket `protobuf:"bytes,5,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RuleStats) Reset()         { *m = RuleStats{} }
+func (m *RuleStats) String() string { return proto.CompactTextString(m) }
+func (*RuleStats) ProtoMessage()    {}
+func (*RuleStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{25}
+}
+
+func (m *RuleStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleStats.Unmarshal(m, b)
+}
+func (m *RuleStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleStats.Marshal(b, m, deterministic)
+}
+func (m *RuleStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleStats.Merge(m, src)
+}
+func (m *RuleStats) XXX_Size() int {
+	return xxx_messageInfo_RuleStats.Size(m)
+}
+func (m *RuleStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleStats proto.InternalMessageInfo
+
+func (m *RuleStats) GetRuleId() int64 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *RuleStats) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *RuleStats) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *RuleStats) GetHits() int64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *RuleStats) GetBuckets() []*RuleHitsBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+type GetRuleStatsResponse struct {
+	Stats                []*RuleStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	NeverFired           []int64      `protobuf:"varint,2,rep,packed,name=never_fired,json=neverFired,proto3" json:"never_fired,omitempty"`
+	Noisiest             []*RuleStats `protobuf:"bytes,3,rep,name=noisiest,proto3" json:"noisiest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetRuleStatsResponse) Reset()         { *m = GetRuleStatsResponse{} }
+func (m *GetRuleStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRuleStatsResponse) ProtoMessage()    {}
+func (*GetRuleStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{26}
+}
+
+func (m *GetRuleStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRuleStatsResponse.Unmarshal(m, b)
+}
+func (m *GetRuleStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRuleStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRuleStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRuleStatsResponse.Merge(m, src)
+}
+func (m *GetRuleStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRuleStatsResponse.Size(m)
+}
+func (m *GetRuleStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRuleStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRuleStatsResponse proto.InternalMessageInfo
+
+func (m *GetRuleStatsResponse) GetStats() []*RuleStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *GetRuleStatsResponse) GetNeverFired() []int64 {
+	if m != nil {
+		return m.NeverFired
+	}
+	return nil
+}
+
+func (m *GetRuleStatsResponse) GetNoisiest() []*RuleStats {
+	if m != nil {
+		return m.Noisiest
+	}
+	return nil
+}
+
+type Quarantine struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId               int64    `protobuf:"varint,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Location             string   `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Zone                 string   `protobuf:"bytes,4,opt,name=zone,proto3" json:"zone,omitempty"`
+	Action               string   `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Hits                 int64    `protobuf:"varint,6,opt,name=hits,proto3" json:"hits,omitempty"`
+	Window               string   `protobuf:"bytes,7,opt,name=window,proto3" json:"window,omitempty"`
+	Time                 string   `protobuf:"bytes,8,opt,name=time,proto3" json:"time,omitempty"`
+	Active               bool     `protobuf:"varint,9,opt,name=active,proto3" json:"active,omitempty"`
+	UndoTime             string   `protobuf:"bytes,10,opt,name=undo_time,json=undoTime,proto3" json:"undo_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Quarantine) Reset()         { *m = Quarantine{} }
+func (m *Quarantine) String() string { return proto.CompactTextString(m) }
+func (*Quarantine) ProtoMessage()    {}
+func (*Quarantine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{27}
+}
+
+func (m *Quarantine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Quarantine.Unmarshal(m, b)
+}
+func (m *Quarantine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Quarantine.Marshal(b, m, deterministic)
+}
+func (m *Quarantine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Quarantine.Merge(m, src)
+}
+func (m *Quarantine) XXX_Size() int {
+	return xxx_messageInfo_Quarantine.Size(m)
+}
+func (m *Quarantine) XXX_DiscardUnknown() {
+	xxx_messageInfo_Quarantine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Quarantine proto.InternalMessageInfo
+
+func (m *Quarantine) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Quarantine) GetRuleId() int64 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *Quarantine) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *Quarantine) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *Quarantine) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *Quarantine) GetHits() int64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *Quarantine) GetWindow() string {
+	if m != nil {
+		return m.Window
+	}
+	return ""
+}
+
+func (m *Quarantine) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *Quarantine) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *Quarantine) GetUndoTime() string {
+	if m != nil {
+		return m.UndoTime
+	}
+	return ""
+}
+
+type ListQuarantinesRequest struct {
+	ActiveOnly           bool     `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListQuarantinesRequest) Reset()         { *m = ListQuarantinesRequest{} }
+func (m *ListQuarantinesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListQuarantinesRequest) ProtoMessage()    {}
+func (*ListQuarantinesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{28}
+}
+
+func (m *ListQuarantinesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListQuarantinesRequest.Unmarshal(m, b)
+}
+func (m *ListQuarantinesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListQuarantinesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListQuarantinesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListQuarantinesRequest.Merge(m, src)
+}
+func (m *ListQuarantinesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListQuarantinesRequest.Size(m)
+}
+func (m *ListQuarantinesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListQuarantinesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListQuarantinesRequest proto.InternalMessageInfo
+
+func (m *ListQuarantinesRequest) GetActiveOnly() bool {
+	if m != nil {
+		return m.ActiveOnly
+	}
+	return false
+}
+
+type ListQuarantinesResponse struct {
+	Quarantines          []*Quarantine `protobuf:"bytes,1,rep,name=quarantines,proto3" json:"quarantines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListQuarantinesResponse) Reset()         { *m = ListQuarantinesResponse{} }
+func (m *ListQuarantinesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListQuarantinesResponse) ProtoMessage()    {}
+func (*ListQuarantinesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{29}
+}
+
+func (m *ListQuarantinesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListQuarantinesResponse.Unmarshal(m, b)
+}
+func (m *ListQuarantinesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListQuarantinesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListQuarantinesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListQuarantinesResponse.Merge(m, src)
+}
+func (m *ListQuarantinesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListQuarantinesResponse.Size(m)
+}
+func (m *ListQuarantinesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListQuarantinesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListQuarantinesResponse proto.InternalMessageInfo
+
+func (m *ListQuarantinesResponse) GetQuarantines() []*Quarantine {
+	if m != nil {
+		return m.Quarantines
+	}
+	return nil
+}
+
+type UndoQuarantineRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndoQuarantineRequest) Reset()         { *m = UndoQuarantineRequest{} }
+func (m *UndoQuarantineRequest) String() string { return proto.CompactTextString(m) }
+func (*UndoQuarantineRequest) ProtoMessage()    {}
+func (*UndoQuarantineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{30}
+}
+
+func (m *UndoQuarantineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndoQuarantineRequest.Unmarshal(m, b)
+}
+func (m *UndoQuarantineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndoQuarantineRequest.Marshal(b, m, deterministic)
+}
+func (m *UndoQuarantineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndoQuarantineRequest.Merge(m, src)
+}
+func (m *UndoQuarantineRequest) XXX_Size() int {
+	return xxx_messageInfo_UndoQuarantineRequest.Size(m)
+}
+func (m *UndoQuarantineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndoQuarantineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndoQuarantineRequest proto.InternalMessageInfo
+
+func (m *UndoQuarantineRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type UndoQuarantineResponse struct {
+	Redeployments        []*DeployRulesResponse `protobuf:"bytes,1,rep,name=redeployments,proto3" json:"redeployments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *UndoQuarantineResponse) Reset()         { *m = UndoQuarantineResponse{} }
+func (m *UndoQuarantineResponse) String() string { return proto.CompactTextString(m) }
+func (*UndoQuarantineResponse) ProtoMessage()    {}
+func (*UndoQuarantineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{31}
+}
+
+func (m *UndoQuarantineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndoQuarantineResponse.Unmarshal(m, b)
+}
+func (m *UndoQuarantineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndoQuarantineResponse.Marshal(b, m, deterministic)
+}
+func (m *UndoQuarantineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndoQuarantineResponse.Merge(m, src)
+}
+func (m *UndoQuarantineResponse) XXX_Size() int {
+	return xxx_messageInfo_UndoQuarantineResponse.Size(m)
+}
+func (m *UndoQuarantineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndoQuarantineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndoQuarantineResponse proto.InternalMessageInfo
+
+func (m *UndoQuarantineResponse) GetRedeployments() []*DeployRulesResponse {
+	if m != nil {
+		return m.Redeployments
+	}
+	return nil
+}
+
+type AuditEvent struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time                 string   `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Actor                string   `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action               string   `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	RuleId               int64    `protobuf:"varint,5,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Location             string   `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Zone                 string   `protobuf:"bytes,7,opt,name=zone,proto3" json:"zone,omitempty"`
+	Details              string   `protobuf:"bytes,8,opt,name=details,proto3" json:"details,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{32}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetRuleId() int64 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{33}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+type ListAuditEventsResponse struct {
+	Events               []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListAuditEventsResponse) Reset()         { *m = ListAuditEventsResponse{} }
+func (m *ListAuditEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsResponse) ProtoMessage()    {}
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{34}
+}
+
+func (m *ListAuditEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsResponse.Unmarshal(m, b)
+}
+func (m *ListAuditEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsResponse.Merge(m, src)
+}
+func (m *ListAuditEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsResponse.Size(m)
+}
+func (m *ListAuditEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsResponse proto.InternalMessageInfo
+
+func (m *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ReloadRulesRequest struct {
+	Location             *Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	ClientIds            []string  `protobuf:"bytes,2,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ReloadRulesRequest) Reset()         { *m = ReloadRulesRequest{} }
+func (m *ReloadRulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ReloadRulesRequest) ProtoMessage()    {}
+func (*ReloadRulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{35}
+}
+
+func (m *ReloadRulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadRulesRequest.Unmarshal(m, b)
+}
+func (m *ReloadRulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadRulesRequest.Marshal(b, m, deterministic)
+}
+func (m *ReloadRulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadRulesRequest.Merge(m, src)
+}
+func (m *ReloadRulesRequest) XXX_Size() int {
+	return xxx_messageInfo_ReloadRulesRequest.Size(m)
+}
+func (m *ReloadRulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadRulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadRulesRequest proto.InternalMessageInfo
+
+func (m *ReloadRulesRequest) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *ReloadRulesRequest) GetClientIds() []string {
+	if m != nil {
+		return m.ClientIds
+	}
+	return nil
+}
+
+type CollectDiagnosticsRequest struct {
+	Location             *Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	ClientIds            []string  `protobuf:"bytes,2,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	LogLines             int32     `protobuf:"varint,3,opt,name=log_lines,json=logLines,proto3" json:"log_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CollectDiagnosticsRequest) Reset()         { *m = CollectDiagnosticsRequest{} }
+func (m *CollectDiagnosticsRequest) String() string { return proto.CompactTextString(m) }
+func (*CollectDiagnosticsRequest) ProtoMessage()    {}
+func (*CollectDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{36}
+}
+
+func (m *CollectDiagnosticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectDiagnosticsRequest.Unmarshal(m, b)
+}
+func (m *CollectDiagnosticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectDiagnosticsRequest.Marshal(b, m, deterministic)
+}
+func (m *CollectDiagnosticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectDiagnosticsRequest.Merge(m, src)
+}
+func (m *CollectDiagnosticsRequest) XXX_Size() int {
+	return xxx_messageInfo_CollectDiagnosticsRequest.Size(m)
+}
+func (m *CollectDiagnosticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectDiagnosticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectDiagnosticsRequest proto.InternalMessageInfo
+
+func (m *CollectDiagnosticsRequest) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *CollectDiagnosticsRequest) GetClientIds() []string {
+	if m != nil {
+		return m.ClientIds
+	}
+	return nil
+}
+
+func (m *CollectDiagnosticsRequest) GetLogLines() int32 {
+	if m != nil {
+		return m.LogLines
+	}
+	return 0
+}
+
+type RunSensorCommandRequest struct {
+	Location             *Location         `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	ClientIds            []string          `protobuf:"bytes,2,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	Command              string            `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Args                 map[string]string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RunSensorCommandRequest) Reset()         { *m = RunSensorCommandRequest{} }
+func (m *RunSensorCommandRequest) String() string { return proto.CompactTextString(m) }
+func (*RunSensorCommandRequest) ProtoMessage()    {}
+func (*RunSensorCommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{37}
+}
+
+func (m *RunSensorCommandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunSensorCommandRequest.Unmarshal(m, b)
+}
+func (m *RunSensorCommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunSensorCommandRequest.Marshal(b, m, deterministic)
+}
+func (m *RunSensorCommandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunSensorCommandRequest.Merge(m, src)
+}
+func (m *RunSensorCommandRequest) XXX_Size() int {
+	return xxx_messageInfo_RunSensorCommandRequest.Size(m)
+}
+func (m *RunSensorCommandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunSensorCommandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunSensorCommandRequest proto.InternalMessageInfo
+
+func (m *RunSensorCommandRequest) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *RunSensorCommandRequest) GetClientIds() []string {
+	if m != nil {
+		return m.ClientIds
+	}
+	return nil
+}
+
+func (m *RunSensorCommandRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *RunSensorCommandRequest) GetArgs() map[string]string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type Dataset struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Members              []string `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	LocationZones        []string `protobuf:"bytes,4,rep,name=location_zones,json=locationZones,proto3" json:"location_zones,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dataset) Reset()         { *m = Dataset{} }
+func (m *Dataset) String() string { return proto.CompactTextString(m) }
+func (*Dataset) ProtoMessage()    {}
+func (*Dataset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{38}
+}
+
+func (m *Dataset) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dataset.Unmarshal(m, b)
+}
+func (m *Dataset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dataset.Marshal(b, m, deterministic)
+}
+func (m *Dataset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dataset.Merge(m, src)
+}
+func (m *Dataset) XXX_Size() int {
+	return xxx_messageInfo_Dataset.Size(m)
+}
+func (m *Dataset) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dataset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dataset proto.InternalMessageInfo
+
+func (m *Dataset) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Dataset) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Dataset) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *Dataset) GetLocationZones() []string {
+	if m != nil {
+		return m.LocationZones
+	}
+	return nil
+}
+
+type AddDatasetRequest struct {
+	Dataset              *Dataset `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddDatasetRequest) Reset()         { *m = AddDatasetRequest{} }
+func (m *AddDatasetRequest) String() string { return proto.CompactTextString(m) }
+func (*AddDatasetRequest) ProtoMessage()    {}
+func (*AddDatasetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{39}
+}
+
+func (m *AddDatasetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddDatasetRequest.Unmarshal(m, b)
+}
+func (m *AddDatasetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddDatasetRequest.Marshal(b, m, deterministic)
+}
+func (m *AddDatasetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddDatasetRequest.Merge(m, src)
+}
+func (m *AddDatasetRequest) XXX_Size() int {
+	return xxx_messageInfo_AddDatasetRequest.Size(m)
+}
+func (m *AddDatasetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddDatasetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddDatasetRequest proto.InternalMessageInfo
+
+func (m *AddDatasetRequest) GetDataset() *Dataset {
+	if m != nil {
+		return m.Dataset
+	}
+	return nil
+}
+
+type DeleteDatasetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteDatasetRequest) Reset()         { *m = DeleteDatasetRequest{} }
+func (m *DeleteDatasetRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDatasetRequest) ProtoMessage()    {}
+func (*DeleteDatasetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{40}
+}
+
+func (m *DeleteDatasetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDatasetRequest.Unmarshal(m, b)
+}
+func (m *DeleteDatasetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDatasetRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteDatasetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDatasetRequest.Merge(m, src)
+}
+func (m *DeleteDatasetRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteDatasetRequest.Size(m)
+}
+func (m *DeleteDatasetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDatasetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteDatasetRequest proto.InternalMessageInfo
+
+func (m *DeleteDatasetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListDatasetsRequest struct {
+	Names                []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDatasetsRequest) Reset()         { *m = ListDatasetsRequest{} }
+func (m *ListDatasetsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDatasetsRequest) ProtoMessage()    {}
+func (*ListDatasetsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{41}
+}
+
+func (m *ListDatasetsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDatasetsRequest.Unmarshal(m, b)
+}
+func (m *ListDatasetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDatasetsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDatasetsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDatasetsRequest.Merge(m, src)
+}
+func (m *ListDatasetsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDatasetsRequest.Size(m)
+}
+func (m *ListDatasetsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDatasetsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDatasetsRequest proto.InternalMessageInfo
+
+func (m *ListDatasetsRequest) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type ListDatasetsResponse struct {
+	Datasets             []*Dataset `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListDatasetsResponse) Reset()         { *m = ListDatasetsResponse{} }
+func (m *ListDatasetsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDatasetsResponse) ProtoMessage()    {}
+func (*ListDatasetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{42}
+}
+
+func (m *ListDatasetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDatasetsResponse.Unmarshal(m, b)
+}
+func (m *ListDatasetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDatasetsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDatasetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDatasetsResponse.Merge(m, src)
+}
+func (m *ListDatasetsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDatasetsResponse.Size(m)
+}
+func (m *ListDatasetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDatasetsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDatasetsResponse proto.InternalMessageInfo
+
+func (m *ListDatasetsResponse) GetDatasets() []*Dataset {
+	if m != nil {
+		return m.Datasets
+	}
+	return nil
+}
+
+type UpdateDatasetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Add                  []string `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`
+	Remove               []string `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateDatasetRequest) Reset()         { *m = UpdateDatasetRequest{} }
+func (m *UpdateDatasetRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDatasetRequest) ProtoMessage()    {}
+func (*UpdateDatasetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{43}
+}
+
+func (m *UpdateDatasetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDatasetRequest.Unmarshal(m, b)
+}
+func (m *UpdateDatasetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDatasetRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateDatasetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDatasetRequest.Merge(m, src)
+}
+func (m *UpdateDatasetRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateDatasetRequest.Size(m)
+}
+func (m *UpdateDatasetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDatasetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDatasetRequest proto.InternalMessageInfo
+
+func (m *UpdateDatasetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateDatasetRequest) GetAdd() []string {
+	if m != nil {
+		return m.Add
+	}
+	return nil
+}
+
+func (m *UpdateDatasetRequest) GetRemove() []string {
+	if m != nil {
+		return m.Remove
+	}
+	return nil
+}
+
+type SensorRequestResponse struct {
+	ClientId             string         `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	RequestId            string         `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Status               *status.Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SensorRequestResponse) Reset()         { *m = SensorRequestResponse{} }
+func (m *SensorRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*SensorRequestResponse) ProtoMessage()    {}
+func (*SensorRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{44}
+}
+
+func (m *SensorRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensorRequestResponse.Unmarshal(m, b)
+}
+func (m *SensorRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensorRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *SensorRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensorRequestResponse.Merge(m, src)
+}
+func (m *SensorRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_SensorRequestResponse.Size(m)
+}
+func (m *SensorRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensorRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensorRequestResponse proto.InternalMessageInfo
+
+func (m *SensorRequestResponse) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *SensorRequestResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *SensorRequestResponse) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type SensorRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time                 string   `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	ClientId             string   `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Type                 string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Status               string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	RuleFile             string   `protobuf:"bytes,6,opt,name=rule_file,json=ruleFile,proto3" json:"rule_file,omitempty"`
+	Diagnostics          string   `protobuf:"bytes,7,opt,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	Command              string   `protobuf:"bytes,8,opt,name=command,proto3" json:"command,omitempty"`
+	CommandResult        string   `protobuf:"bytes,9,opt,name=command_result,json=commandResult,proto3" json:"command_result,omitempty"`
+	RulesLoaded          int64    `protobuf:"varint,10,opt,name=rules_loaded,json=rulesLoaded,proto3" json:"rules_loaded,omitempty"`
+	RulesFailed          int64    `protobuf:"varint,11,opt,name=rules_failed,json=rulesFailed,proto3" json:"rules_failed,omitempty"`
+	FailedRuleIds        []int64  `protobuf:"varint,12,rep,packed,name=failed_rule_ids,json=failedRuleIds,proto3" json:"failed_rule_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SensorRequest) Reset()         { *m = SensorRequest{} }
+func (m *SensorRequest) String() string { return proto.CompactTextString(m) }
+func (*SensorRequest) ProtoMessage()    {}
+func (*SensorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{45}
+}
+
+func (m *SensorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SensorRequest.Unmarshal(m, b)
+}
+func (m *SensorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SensorRequest.Marshal(b, m, deterministic)
+}
+func (m *SensorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SensorRequest.Merge(m, src)
+}
+func (m *SensorRequest) XXX_Size() int {
+	return xxx_messageInfo_SensorRequest.Size(m)
+}
+func (m *SensorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SensorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SensorRequest proto.InternalMessageInfo
+
+func (m *SensorRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetRuleFile() string {
+	if m != nil {
+		return m.RuleFile
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetDiagnostics() string {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetCommandResult() string {
+	if m != nil {
+		return m.CommandResult
+	}
+	return ""
+}
+
+func (m *SensorRequest) GetRulesLoaded() int64 {
+	if m != nil {
+		return m.RulesLoaded
+	}
+	return 0
+}
+
+func (m *SensorRequest) GetRulesFailed() int64 {
+	if m != nil {
+		return m.RulesFailed
+	}
+	return 0
+}
+
+func (m *SensorRequest) GetFailedRuleIds() []int64 {
+	if m != nil {
+		return m.FailedRuleIds
+	}
+	return nil
+}
+
+type GetSensorRequestsRequest struct {
+	RequestIds           []string `protobuf:"bytes,1,rep,name=request_ids,json=requestIds,proto3" json:"request_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSensorRequestsRequest) Reset()         { *m = GetSensorRequestsRequest{} }
+func (m *GetSensorRequestsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSensorRequestsRequest) ProtoMessage()    {}
+func (*GetSensorRequestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{46}
+}
+
+func (m *GetSensorRequestsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSensorRequestsRequest.Unmarshal(m, b)
+}
+func (m *GetSensorRequestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSensorRequestsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSensorRequestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSensorRequestsRequest.Merge(m, src)
+}
+func (m *GetSensorRequestsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSensorRequestsRequest.Size(m)
+}
+func (m *GetSensorRequestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSensorRequestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSensorRequestsRequest proto.InternalMessageInfo
+
+func (m *GetSensorRequestsRequest) GetRequestIds() []string {
+	if m != nil {
+		return m.RequestIds
+	}
+	return nil
+}
+
+type GetSensorRequestsResponse struct {
+	Requests             []*SensorRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetSensorRequestsResponse) Reset()         { *m = GetSensorRequestsResponse{} }
+func (m *GetSensorRequestsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSensorRequestsResponse) ProtoMessage()    {}
+func (*GetSensorRequestsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{47}
+}
+
+func (m *GetSensorRequestsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSensorRequestsResponse.Unmarshal(m, b)
+}
+func (m *GetSensorRequestsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSensorRequestsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSensorRequestsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSensorRequestsResponse.Merge(m, src)
+}
+func (m *GetSensorRequestsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSensorRequestsResponse.Size(m)
+}
+func (m *GetSensorRequestsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSensorRequestsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSensorRequestsResponse proto.InternalMessageInfo
+
+func (m *GetSensorRequestsResponse) GetRequests() []*SensorRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+type ImportIndicatorsRequest struct {
+	Format               string   `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Source               string   `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	LocationZones        []string `protobuf:"bytes,4,rep,name=location_zones,json=locationZones,proto3" json:"location_zones,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportIndicatorsRequest) Reset()         { *m = ImportIndicatorsRequest{} }
+func (m *ImportIndicatorsRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportIndicatorsRequest) ProtoMessage()    {}
+func (*ImportIndicatorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{48}
+}
+
+func (m *ImportIndicatorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportIndicatorsRequest.Unmarshal(m, b)
+}
+func (m *ImportIndicatorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportIndicatorsRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportIndicatorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportIndicatorsRequest.Merge(m, src)
+}
+func (m *ImportIndicatorsRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportIndicatorsRequest.Size(m)
+}
+func (m *ImportIndicatorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportIndicatorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportIndicatorsRequest proto.InternalMessageInfo
+
+func (m *ImportIndicatorsRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *ImportIndicatorsRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ImportIndicatorsRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ImportIndicatorsRequest) GetLocationZones() []string {
+	if m != nil {
+		return m.LocationZones
+	}
+	return nil
+}
+
+type ImportIndicatorsResponse struct {
+	AddedRuleIds         []int64  `protobuf:"varint,1,rep,packed,name=added_rule_ids,json=addedRuleIds,proto3" json:"added_rule_ids,omitempty"`
+	UpdatedRuleIds       []int64  `protobuf:"varint,2,rep,packed,name=updated_rule_ids,json=updatedRuleIds,proto3" json:"updated_rule_ids,omitempty"`
+	UnchangedRuleIds     []int64  `protobuf:"varint,3,rep,packed,name=unchanged_rule_ids,json=unchangedRuleIds,proto3" json:"unchanged_rule_ids,omitempty"`
+	Skipped              int32    `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportIndicatorsResponse) Reset()         { *m = ImportIndicatorsResponse{} }
+func (m *ImportIndicatorsResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportIndicatorsResponse) ProtoMessage()    {}
+func (*ImportIndicatorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6256c75c7d842e44, []int{49}
+}
+
+func (m *ImportIndicatorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportIndicatorsResponse.Unmarshal(m, b)
+}
+func (m *ImportIndicatorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportIndicatorsResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportIndicatorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportIndicatorsResponse.Merge(m, src)
+}
+func (m *ImportIndicatorsResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportIndicatorsResponse.Size(m)
+}
+func (m *ImportIndicatorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportIndicatorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportIndicatorsResponse proto.InternalMessageInfo
+
+func (m *ImportIndicatorsResponse) GetAddedRuleIds() []int64 {
+	if m != nil {
+		return m.AddedRuleIds
+	}
+	return nil
+}
+
+func (m *ImportIndicatorsResponse) GetUpdatedRuleIds() []int64 {
+	if m != nil {
+		return m.UpdatedRuleIds
+	}
+	return nil
+}
+
+func (m *ImportIndicatorsResponse) GetUnchangedRuleIds() []int64 {
+	if m != nil {
+		return m.UnchangedRuleIds
+	}
+	return nil
+}
+
+func (m *ImportIndicatorsResponse) GetSkipped() int32 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Location)(nil), "emitto.service.Location")
+	proto.RegisterType((*Rule)(nil), "emitto.service.Rule")
+	proto.RegisterType((*DeployRulesRequest)(nil), "emitto.service.DeployRulesRequest")
+	proto.RegisterType((*DeployRulesResponse)(nil), "emitto.service.DeployRulesResponse")
+	proto.RegisterType((*AddRuleRequest)(nil), "emitto.service.AddRuleRequest")
+	proto.RegisterType((*ModifyRuleRequest)(nil), "emitto.service.ModifyRuleRequest")
+	proto.RegisterType((*DeleteRuleRequest)(nil), "emitto.service.DeleteRuleRequest")
+	proto.RegisterType((*ListRulesRequest)(nil), "emitto.service.ListRulesRequest")
+	proto.RegisterType((*ListRulesResponse)(nil), "emitto.service.ListRulesResponse")
+	proto.RegisterType((*AddLocationRequest)(nil), "emitto.service.AddLocationRequest")
+	proto.RegisterType((*ModifyLocationRequest)(nil), "emitto.service.ModifyLocationRequest")
+	proto.RegisterType((*DeleteLocationRequest)(nil), "emitto.service.DeleteLocationRequest")
+	proto.RegisterType((*ListLocationsRequest)(nil), "emitto.service.ListLocationsRequest")
+	proto.RegisterType((*ListLocationsResponse)(nil), "emitto.service.ListLocationsResponse")
+	proto.RegisterType((*SensorHealth)(nil), "emitto.service.SensorHealth")
+	proto.RegisterType((*ListSensorHealthRequest)(nil), "emitto.service.ListSensorHealthRequest")
+	proto.RegisterType((*ListSensorHealthResponse)(nil), "emitto.service.ListSensorHealthResponse")
+	proto.RegisterType((*DriftEvent)(nil), "emitto.service.DriftEvent")
+	proto.RegisterType((*ListDriftRequest)(nil), "emitto.service.ListDriftRequest")
+	proto.RegisterType((*ListDriftResponse)(nil), "emitto.service.ListDriftResponse")
+	proto.RegisterType((*AlertEvent)(nil), "emitto.service.AlertEvent")
+	proto.RegisterType((*ListAlertEventsRequest)(nil), "emitto.service.ListAlertEventsRequest")
+	proto.RegisterType((*ListAlertEventsResponse)(nil), "emitto.service.ListAlertEventsResponse")
+	proto.RegisterType((*GetRuleStatsRequest)(nil), "emitto.service.GetRuleStatsRequest")
+	proto.RegisterType((*RuleHitsBucket)(nil), "emitto.service.RuleHitsBucket")
+	proto.RegisterType((*RuleStats)(nil), "emitto.service.RuleStats")
+	proto.RegisterType((*GetRuleStatsResponse)(nil), "emitto.service.GetRuleStatsResponse")
+	proto.RegisterType((*Quarantine)(nil), "emitto.service.Quarantine")
+	proto.RegisterType((*ListQuarantinesRequest)(nil), "emitto.service.ListQuarantinesRequest")
+	proto.RegisterType((*ListQuarantinesResponse)(nil), "emitto.service.ListQuarantinesResponse")
+	proto.RegisterType((*UndoQuarantineRequest)(nil), "emitto.service.UndoQuarantineRequest")
+	proto.RegisterType((*UndoQuarantineResponse)(nil), "emitto.service.UndoQuarantineResponse")
+	proto.RegisterType((*AuditEvent)(nil), "emitto.service.AuditEvent")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "emitto.service.ListAuditEventsRequest")
+	proto.RegisterType((*ListAuditEventsResponse)(nil), "emitto.service.ListAuditEventsResponse")
+	proto.RegisterType((*ReloadRulesRequest)(nil), "emitto.service.ReloadRulesRequest")
+	proto.RegisterType((*CollectDiagnosticsRequest)(nil), "emitto.service.CollectDiagnosticsRequest")
+	proto.RegisterType((*RunSensorCommandRequest)(nil), "emitto.service.RunSensorCommandRequest")
+	proto.RegisterMapType((map[string]string)(nil), "emitto.service.RunSensorCommandRequest.ArgsEntry")
+	proto.RegisterType((*Dataset)(nil), "emitto.service.Dataset")
+	proto.RegisterType((*AddDatasetRequest)(nil), "emitto.service.AddDatasetRequest")
+	proto.RegisterType((*DeleteDatasetRequest)(nil), "emitto.service.DeleteDatasetRequest")
+	proto.RegisterType((*ListDatasetsRequest)(nil), "emitto.service.ListDatasetsRequest")
+	proto.RegisterType((*ListDatasetsResponse)(nil), "emitto.service.ListDatasetsResponse")
+	proto.RegisterType((*UpdateDatasetRequest)(nil), "emitto.service.UpdateDatasetRequest")
+	proto.RegisterType((*SensorRequestResponse)(nil), "emitto.service.SensorRequestResponse")
+	proto.RegisterType((*SensorRequest)(nil), "emitto.service.SensorRequest")
+	proto.RegisterType((*GetSensorRequestsRequest)(nil), "emitto.service.GetSensorRequestsRequest")
+	proto.RegisterType((*GetSensorRequestsResponse)(nil), "emitto.service.GetSensorRequestsResponse")
+	proto.RegisterType((*ImportIndicatorsRequest)(nil), "emitto.service.ImportIndicatorsRequest")
+	proto.RegisterType((*ImportIndicatorsResponse)(nil), "emitto.service.ImportIndicatorsResponse")
+}
+
+func init() { proto.RegisterFile("source/server/proto/service.proto", fileDescriptor_6256c75c7d842e44) }
+
+var fileDescriptor_6256c75c7d842e44 = []byte{
+	// 2503 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcd, 0x72, 0xdc, 0xc6,
+	0xf1, 0xf7, 0x7e, 0xef, 0xf6, 0x2e, 0x97, 0xe4, 0x68, 0x49, 0x82, 0x6b, 0xeb, 0x6f, 0x0a, 0x96,
+	0x44, 0x4a, 0x7f, 0x87, 0x8a, 0x65, 0x5b, 0x91, 0x1c, 0x57, 0xa5, 0x18, 0x91, 0xb2, 0x37, 0x96,
+	0x22, 0x09, 0xb2, 0x9c, 0x2a, 0x3b, 0x15, 0x04, 0x02, 0x86, 0x2b, 0x44, 0x58, 0x00, 0x9e, 0x01,
+	0x68, 0x6f, 0xaa, 0x52, 0xb9, 0xe4, 0xe0, 0x4b, 0x2e, 0x79, 0x81, 0x54, 0xe5, 0x11, 0x72, 0xcc,
+	0x21, 0x55, 0x79, 0x89, 0xbc, 0x41, 0x2e, 0xb9, 0xa4, 0x2a, 0x97, 0x5c, 0x53, 0xf3, 0xb9, 0xc0,
+	0x02, 0xbb, 0x24, 0x2d, 0x39, 0x37, 0x74, 0xcf, 0x6f, 0x7a, 0x66, 0xfa, 0x6b, 0x7a, 0x1a, 0x70,
+	0x89, 0x46, 0x29, 0x71, 0xf1, 0x0d, 0x8a, 0xc9, 0x09, 0x26, 0x37, 0x62, 0x12, 0x25, 0x11, 0x27,
+	0x7c, 0x17, 0xef, 0x73, 0x0a, 0xf5, 0xf1, 0xc4, 0x4f, 0x92, 0x68, 0x5f, 0x72, 0x87, 0xaf, 0x8f,
+	0xa3, 0x68, 0x1c, 0x60, 0x81, 0x7d, 0x96, 0x1e, 0xdf, 0xc0, 0x93, 0x38, 0x99, 0x0a, 0xf0, 0x70,
+	0x4b, 0x0e, 0x92, 0xd8, 0xbd, 0x41, 0x13, 0x27, 0x49, 0xa9, 0x1c, 0xd8, 0x99, 0x9f, 0x75, 0xec,
+	0xe3, 0xc0, 0xb3, 0x27, 0x0e, 0x7d, 0x21, 0x10, 0xe6, 0x7b, 0xd0, 0xbe, 0x1f, 0xb9, 0x4e, 0xe2,
+	0x47, 0x21, 0x42, 0x50, 0x0f, 0x9d, 0x09, 0x36, 0x2a, 0x3b, 0x95, 0xbd, 0x8e, 0xc5, 0xbf, 0xd1,
+	0x00, 0x1a, 0xbf, 0x8e, 0x42, 0x4c, 0x8d, 0xea, 0x4e, 0x6d, 0xaf, 0x63, 0x09, 0xc2, 0x7c, 0x0c,
+	0x75, 0x2b, 0x0d, 0x30, 0xea, 0x43, 0xd5, 0xf7, 0x38, 0xbe, 0x66, 0x55, 0x7d, 0x8f, 0x49, 0x78,
+	0x16, 0x79, 0x53, 0xa3, 0x2a, 0x24, 0xb0, 0x6f, 0x74, 0x05, 0xfa, 0x81, 0x5c, 0xc1, 0x16, 0xa2,
+	0x6a, 0x5c, 0xd4, 0x8a, 0xe2, 0x7e, 0xce, 0x45, 0xfe, 0x04, 0xd0, 0x21, 0x8e, 0x83, 0x68, 0xca,
+	0x04, 0x53, 0x0b, 0x7f, 0x99, 0x62, 0x9a, 0xa0, 0xf7, 0xa0, 0xad, 0x60, 0x7c, 0x99, 0xee, 0x4d,
+	0x63, 0x3f, 0xaf, 0x99, 0x7d, 0xb5, 0x7d, 0x4b, 0x23, 0xcd, 0xdf, 0xc0, 0x85, 0x9c, 0x2c, 0x1a,
+	0x47, 0x21, 0xc5, 0xe8, 0x75, 0xe8, 0xb8, 0x81, 0x8f, 0xc3, 0xc4, 0x96, 0x9b, 0xee, 0x58, 0x6d,
+	0xc1, 0x18, 0x79, 0xe8, 0x22, 0x00, 0x11, 0x8b, 0xb2, 0x51, 0x71, 0x80, 0x8e, 0xe4, 0x8c, 0x3c,
+	0x74, 0x1d, 0x9a, 0x42, 0xb3, 0x46, 0x8d, 0x6f, 0x03, 0xed, 0x0b, 0xd5, 0xee, 0x93, 0xd8, 0xdd,
+	0x7f, 0xc2, 0x47, 0x2c, 0x89, 0x30, 0x3f, 0x80, 0xfe, 0x81, 0xe7, 0xb1, 0xb5, 0xd5, 0x31, 0xf6,
+	0xa0, 0x4e, 0xd2, 0x00, 0xcb, 0x23, 0x0c, 0xe6, 0x8f, 0xc0, 0xa1, 0x1c, 0x61, 0x7e, 0x0d, 0xeb,
+	0x0f, 0x22, 0xcf, 0x3f, 0x9e, 0x7e, 0xab, 0xe9, 0xe8, 0x0e, 0xc0, 0xcc, 0xc4, 0xfc, 0x14, 0xdd,
+	0x9b, 0x43, 0xb5, 0x55, 0xe5, 0x05, 0xfb, 0xf7, 0x18, 0xe4, 0x81, 0x43, 0x5f, 0x58, 0x9d, 0x63,
+	0xf5, 0x69, 0xbe, 0x0d, 0xeb, 0x87, 0x38, 0xc0, 0x09, 0xce, 0xae, 0xbc, 0x05, 0x2d, 0x26, 0xd7,
+	0xd6, 0x56, 0x6e, 0x32, 0x72, 0xe4, 0x99, 0xdf, 0x83, 0xb5, 0xfb, 0x3e, 0x4d, 0x72, 0xc6, 0xda,
+	0x86, 0xb6, 0x04, 0x53, 0xa3, 0xb2, 0x53, 0xdb, 0xab, 0x59, 0x2d, 0x81, 0xa6, 0xe6, 0x8f, 0x60,
+	0x3d, 0x03, 0x97, 0xf6, 0xb8, 0x0e, 0x0d, 0x36, 0x2e, 0xc0, 0x8b, 0xce, 0x25, 0x20, 0xcc, 0x3d,
+	0x0e, 0x3c, 0x4f, 0xdb, 0xfa, 0xa5, 0xdc, 0xe3, 0x9b, 0x0a, 0x6c, 0x08, 0x25, 0xbf, 0x12, 0x79,
+	0x2f, 0xa3, 0xf4, 0x0f, 0x61, 0x43, 0x28, 0x7d, 0x7e, 0x27, 0x6f, 0x81, 0x8e, 0x0f, 0x3b, 0x13,
+	0x94, 0x3d, 0xc5, 0xfc, 0xa9, 0x33, 0xc1, 0xe6, 0x26, 0x0c, 0x98, 0x56, 0xd5, 0x5c, 0x65, 0x08,
+	0xf3, 0x21, 0x6c, 0xcc, 0xf1, 0xa5, 0xc6, 0x6f, 0x41, 0x47, 0x09, 0x50, 0x5a, 0x5f, 0x7c, 0xc0,
+	0x19, 0xd4, 0xfc, 0x47, 0x0d, 0x7a, 0x4f, 0x70, 0x48, 0x23, 0xf2, 0x31, 0x76, 0x82, 0xe4, 0xf9,
+	0xf2, 0x50, 0x42, 0x50, 0x4f, 0xfc, 0x09, 0x56, 0x59, 0x80, 0x7d, 0x33, 0xde, 0xf3, 0x88, 0x26,
+	0x3c, 0x7a, 0x3a, 0x16, 0xff, 0x66, 0x99, 0x81, 0x72, 0xa1, 0xf6, 0x09, 0x26, 0x94, 0xe9, 0xbc,
+	0xce, 0x47, 0x57, 0x04, 0xf7, 0x33, 0xc1, 0x44, 0x7b, 0xb0, 0xc6, 0xdd, 0xea, 0xd8, 0x0f, 0xb0,
+	0x4d, 0x9f, 0x3b, 0x37, 0xdf, 0xbf, 0x65, 0x34, 0x38, 0xb0, 0xcf, 0xf8, 0xf7, 0xfc, 0x00, 0x3f,
+	0xe1, 0x5c, 0x74, 0x0d, 0xd6, 0x68, 0x4a, 0x7c, 0xd7, 0x49, 0x1c, 0x2d, 0xb2, 0xc9, 0x91, 0xab,
+	0x8a, 0xaf, 0x84, 0xde, 0x82, 0x2d, 0x0d, 0x4d, 0x63, 0xb6, 0x45, 0x9b, 0x62, 0x37, 0x0a, 0x3d,
+	0x6a, 0xb4, 0xb8, 0xa3, 0x6f, 0xa8, 0xe1, 0xa7, 0x7c, 0xf4, 0x89, 0x18, 0x44, 0x97, 0xa0, 0x47,
+	0xd2, 0x30, 0xf4, 0xc3, 0xb1, 0x3d, 0x89, 0x3c, 0x6c, 0xb4, 0xb9, 0xf8, 0xae, 0xe4, 0x3d, 0x88,
+	0x3c, 0x8c, 0x76, 0x61, 0xd5, 0x75, 0xe2, 0x24, 0x25, 0xd8, 0x8e, 0x1d, 0xf7, 0x05, 0x4e, 0xa8,
+	0xd1, 0xe1, 0x22, 0xfb, 0x92, 0xfd, 0x48, 0x70, 0x99, 0x8d, 0x15, 0xd0, 0x23, 0x51, 0x4c, 0x0d,
+	0xe0, 0xb0, 0x9e, 0x64, 0x1e, 0x32, 0x9e, 0x58, 0x30, 0xc0, 0xd4, 0x0e, 0x22, 0xc7, 0xc3, 0x9e,
+	0xd1, 0xe5, 0x98, 0x2e, 0xe7, 0xdd, 0xe7, 0xac, 0x19, 0xe4, 0xd8, 0xf1, 0x03, 0xec, 0x19, 0xbd,
+	0x0c, 0xe4, 0x1e, 0x67, 0x71, 0x55, 0xab, 0xe3, 0x62, 0x42, 0x22, 0x62, 0xac, 0x48, 0x55, 0x4b,
+	0xee, 0x11, 0x63, 0x9a, 0xb7, 0x61, 0x8b, 0x39, 0x4e, 0xd6, 0xd4, 0xca, 0x21, 0x2f, 0x02, 0x68,
+	0x8b, 0x0b, 0xdf, 0xe9, 0x58, 0x1d, 0x65, 0x72, 0x6a, 0x5a, 0x60, 0x14, 0x67, 0x6a, 0xaf, 0x6b,
+	0x09, 0x8b, 0x2a, 0x9f, 0x7b, 0x63, 0xde, 0xe7, 0x72, 0xd3, 0x14, 0xd8, 0xfc, 0x57, 0x05, 0xe0,
+	0x90, 0xf8, 0xc7, 0xc9, 0xd1, 0x09, 0x0e, 0x93, 0xcc, 0x65, 0xd3, 0xe1, 0x97, 0x4d, 0xce, 0x07,
+	0xab, 0x0b, 0x7c, 0xb0, 0x96, 0xf1, 0xc1, 0xeb, 0xb0, 0xee, 0xf1, 0x6b, 0xc1, 0xce, 0x64, 0x7a,
+	0xe1, 0x72, 0xab, 0x62, 0xc0, 0xd2, 0xf9, 0xfe, 0x0e, 0x6c, 0xe3, 0xaf, 0x63, 0xec, 0x26, 0xd8,
+	0xb3, 0x17, 0x78, 0xdf, 0xa6, 0x02, 0x58, 0x79, 0x2f, 0xbc, 0x03, 0xdb, 0x04, 0xc7, 0x11, 0x29,
+	0x9d, 0x2a, 0xdc, 0x71, 0x53, 0x01, 0xf2, 0x53, 0xcd, 0x07, 0x22, 0xab, 0xf2, 0x43, 0x9f, 0x4d,
+	0xf1, 0x68, 0x08, 0x6d, 0x82, 0xc5, 0xee, 0xb9, 0x12, 0xda, 0x96, 0xa6, 0xcd, 0x3f, 0x54, 0x60,
+	0x3d, 0x23, 0x4f, 0x9a, 0xe3, 0x26, 0x34, 0x31, 0x53, 0xa8, 0xb2, 0xc6, 0x70, 0xde, 0x1a, 0x33,
+	0x9d, 0x5b, 0x12, 0x89, 0x46, 0xb0, 0xa2, 0xa4, 0x4e, 0xf8, 0xd4, 0x2a, 0x9f, 0xfa, 0x56, 0x61,
+	0x6a, 0xf1, 0xda, 0xb5, 0xf2, 0x33, 0xcd, 0xbf, 0xd7, 0x00, 0x0e, 0x02, 0x4c, 0x5e, 0x95, 0x55,
+	0x55, 0x66, 0xa9, 0x67, 0x32, 0xcb, 0x30, 0x93, 0xc7, 0x85, 0xb1, 0x34, 0xcd, 0xf0, 0xac, 0x0c,
+	0x91, 0x96, 0xe0, 0xdf, 0xd9, 0x6b, 0xae, 0x95, 0xbd, 0xe6, 0x58, 0x68, 0x51, 0x7f, 0x1c, 0x3a,
+	0x3c, 0x48, 0x7d, 0x8f, 0x87, 0x7b, 0xcd, 0xea, 0x6a, 0xde, 0xc8, 0x43, 0x6f, 0x40, 0x47, 0x93,
+	0x3c, 0xd0, 0x3b, 0xd6, 0x8c, 0xc1, 0x76, 0xe2, 0x3a, 0x09, 0x1e, 0x47, 0x64, 0x6a, 0x80, 0x3c,
+	0x8d, 0xa4, 0xd9, 0x18, 0xc5, 0x27, 0x98, 0xf8, 0xc9, 0x54, 0x86, 0xb5, 0xa6, 0xd1, 0x26, 0x34,
+	0x1d, 0x97, 0xef, 0xbf, 0xc7, 0x67, 0x49, 0x0a, 0x6d, 0x40, 0x93, 0x12, 0xd7, 0xf6, 0x63, 0x19,
+	0xc0, 0x0d, 0x4a, 0xdc, 0x51, 0xcc, 0xae, 0x5e, 0xc6, 0x66, 0x4e, 0x65, 0xf4, 0xb9, 0xa8, 0x16,
+	0x25, 0xee, 0xa3, 0x88, 0xf0, 0x2b, 0xdc, 0xe3, 0xbe, 0x1e, 0x1b, 0xab, 0x42, 0x14, 0x23, 0x47,
+	0x31, 0xd3, 0x34, 0x1f, 0xe0, 0x93, 0xd6, 0xc4, 0xfa, 0x8c, 0xc1, 0x67, 0x0d, 0xa0, 0xc1, 0x6f,
+	0x2e, 0x63, 0x5d, 0x2c, 0xc3, 0x09, 0x36, 0xc5, 0x89, 0x63, 0x5b, 0x8c, 0x20, 0x71, 0x1c, 0x27,
+	0x8e, 0x1f, 0x31, 0xda, 0xfc, 0x6b, 0x05, 0x36, 0x99, 0xb7, 0xcd, 0x8c, 0x4b, 0x4f, 0x2b, 0x23,
+	0x72, 0x86, 0xaa, 0x2e, 0x30, 0x54, 0x2d, 0x63, 0xa8, 0x9c, 0x77, 0xd4, 0x8b, 0xde, 0xc1, 0x3d,
+	0xa1, 0x91, 0xf1, 0x84, 0x01, 0x34, 0xa8, 0x1f, 0xba, 0xca, 0xdc, 0x82, 0x60, 0xdc, 0xc0, 0x9f,
+	0xf8, 0x09, 0xb7, 0x76, 0xc3, 0x12, 0x84, 0xf9, 0x00, 0xb6, 0x0a, 0xfb, 0x3f, 0x6b, 0xcc, 0xcc,
+	0x26, 0xa9, 0x98, 0x31, 0xff, 0x52, 0x81, 0x0b, 0x1f, 0x61, 0x5e, 0xf3, 0xb0, 0x02, 0xf1, 0x0c,
+	0x65, 0xd2, 0xb9, 0xd5, 0xa1, 0x4f, 0x57, 0x9f, 0x3b, 0x5d, 0x1a, 0x26, 0x7e, 0x20, 0x15, 0x21,
+	0x08, 0x26, 0xdb, 0x0f, 0x13, 0x4c, 0x4e, 0x9c, 0x40, 0x2a, 0x43, 0xd3, 0x68, 0x0d, 0x6a, 0x49,
+	0x14, 0x4b, 0x6d, 0xb0, 0x4f, 0x56, 0xc3, 0xb2, 0x8d, 0x7f, 0xec, 0x27, 0xf4, 0xc7, 0x29, 0xbb,
+	0xae, 0xf8, 0x5a, 0x89, 0x43, 0x12, 0x19, 0xab, 0x82, 0xe0, 0x3a, 0xf7, 0x79, 0x3e, 0x60, 0x66,
+	0xe5, 0xdf, 0xe6, 0x9f, 0x2a, 0xd0, 0xd1, 0xa7, 0x7e, 0x75, 0xb6, 0x57, 0x4b, 0xd5, 0x67, 0x4b,
+	0xa1, 0xdb, 0xd0, 0x7a, 0x96, 0x8a, 0x3b, 0xb6, 0xc1, 0x0d, 0xf3, 0x7f, 0x65, 0x45, 0xe4, 0xec,
+	0x14, 0x96, 0x82, 0x9b, 0x7f, 0xac, 0xc0, 0x20, 0x6f, 0x1d, 0x69, 0xea, 0x1b, 0xfc, 0x9c, 0xda,
+	0xd2, 0xdb, 0x65, 0x02, 0xc5, 0x0c, 0x81, 0x43, 0x6f, 0x42, 0x37, 0x64, 0x61, 0x6b, 0x1f, 0xfb,
+	0x04, 0x7b, 0x3c, 0x33, 0xd6, 0x2c, 0xe0, 0xac, 0x7b, 0x8c, 0x83, 0xde, 0x87, 0x76, 0x18, 0xf9,
+	0xd4, 0xc7, 0xbc, 0xfe, 0x39, 0x45, 0xa8, 0x86, 0x9a, 0xff, 0xae, 0x00, 0x3c, 0x4e, 0x1d, 0xe2,
+	0x84, 0x89, 0x1f, 0xe2, 0x42, 0xa2, 0xcc, 0xe8, 0xb5, 0xba, 0x50, 0xaf, 0xb5, 0x05, 0x7a, 0xad,
+	0x67, 0xf4, 0x3a, 0x4b, 0x35, 0x8d, 0x5c, 0xaa, 0x51, 0xfa, 0x6e, 0x66, 0xf4, 0xbd, 0x09, 0xcd,
+	0xaf, 0xfc, 0xd0, 0x8b, 0xbe, 0xe2, 0xbe, 0xd2, 0xb1, 0x24, 0xa5, 0x13, 0x73, 0x3b, 0x93, 0x98,
+	0xa5, 0xdc, 0x13, 0x91, 0x15, 0xdb, 0x96, 0xa4, 0x58, 0x0c, 0xa7, 0xa1, 0x17, 0xd9, 0x7c, 0x82,
+	0xcc, 0x89, 0x8c, 0xf1, 0xa9, 0x3f, 0xc1, 0xe6, 0x1d, 0x91, 0x43, 0x66, 0xe7, 0xd6, 0x61, 0xf3,
+	0x26, 0x74, 0x85, 0x00, 0x3b, 0x0a, 0x83, 0x29, 0x57, 0x44, 0xdb, 0x02, 0xc1, 0x7a, 0x18, 0x06,
+	0x53, 0xf3, 0x67, 0xb0, 0x55, 0x98, 0x2a, 0x6d, 0xfa, 0x21, 0x74, 0xbf, 0x9c, 0xb1, 0x17, 0xc5,
+	0xf0, 0x6c, 0xa6, 0x95, 0x85, 0x9b, 0xbb, 0xb0, 0xf1, 0x34, 0xf4, 0xa2, 0xcc, 0xb0, 0xdc, 0xd2,
+	0x9c, 0x49, 0x4c, 0x17, 0x36, 0xe7, 0x81, 0x72, 0x03, 0x85, 0xfb, 0xb3, 0xf2, 0xad, 0xef, 0xcf,
+	0xbf, 0x55, 0x00, 0x0e, 0x52, 0xcf, 0x5f, 0x70, 0x7f, 0x96, 0x15, 0xdf, 0x03, 0x68, 0x38, 0x6e,
+	0x12, 0x11, 0xe9, 0x0e, 0x82, 0xc8, 0xd8, 0xbd, 0x9e, 0xb3, 0x7b, 0xc6, 0xb1, 0x1a, 0x0b, 0x1d,
+	0xab, 0xb9, 0xc0, 0xb1, 0x5a, 0x19, 0xc7, 0x32, 0xd8, 0xcd, 0x93, 0x38, 0x7e, 0x40, 0xa5, 0x5f,
+	0x28, 0xd2, 0x34, 0xe4, 0x4d, 0xa1, 0x8f, 0xa1, 0x9f, 0x2e, 0x2a, 0x07, 0x67, 0x47, 0xce, 0x9c,
+	0x83, 0xf5, 0x24, 0x9d, 0x83, 0x7d, 0x40, 0x16, 0x66, 0x95, 0xf3, 0xcb, 0x77, 0x15, 0xe6, 0x0a,
+	0xb1, 0xea, 0x7c, 0x05, 0xfc, 0xfb, 0x0a, 0x6c, 0xdf, 0x8d, 0x82, 0x00, 0xbb, 0xc9, 0xa1, 0xef,
+	0x8c, 0xc3, 0x88, 0x26, 0xbe, 0xfb, 0x9d, 0x2e, 0xc9, 0x22, 0x29, 0x88, 0xc6, 0x76, 0xe0, 0x8b,
+	0xae, 0x0a, 0x4b, 0xde, 0xed, 0x20, 0x1a, 0xdf, 0xe7, 0x5e, 0xfb, 0x4d, 0x15, 0xb6, 0xac, 0x34,
+	0x14, 0xa5, 0xf5, 0xdd, 0x68, 0x32, 0x71, 0x42, 0xef, 0x3b, 0xdd, 0x8d, 0x01, 0x2d, 0x57, 0x2c,
+	0x23, 0xfd, 0x4c, 0x91, 0xe8, 0x08, 0xea, 0x0e, 0x19, 0xb3, 0xcc, 0xcd, 0xec, 0xf6, 0x4e, 0x31,
+	0xf9, 0x95, 0xee, 0x72, 0xff, 0x80, 0x8c, 0xe9, 0x51, 0x98, 0x90, 0xa9, 0xc5, 0xa7, 0x0f, 0x7f,
+	0x00, 0x1d, 0xcd, 0x62, 0x57, 0xd6, 0x0b, 0x3c, 0x95, 0x8e, 0xcf, 0x3e, 0x99, 0x97, 0x9f, 0x38,
+	0x41, 0xaa, 0x5c, 0x5f, 0x10, 0x1f, 0x54, 0x6f, 0x57, 0x4c, 0x02, 0xad, 0x43, 0x27, 0x71, 0x28,
+	0x4e, 0x4a, 0x7b, 0x5c, 0x2c, 0x64, 0xa6, 0xf1, 0x2c, 0x64, 0xa6, 0x31, 0xf7, 0xdd, 0x09, 0x9e,
+	0x3c, 0xc3, 0x44, 0xb5, 0xab, 0x14, 0x59, 0xd2, 0xcf, 0xaa, 0x97, 0xf5, 0xb3, 0xee, 0xc1, 0xfa,
+	0x81, 0xe7, 0xc9, 0x65, 0x95, 0xde, 0xdf, 0x81, 0x96, 0x27, 0x38, 0x52, 0xed, 0x5b, 0x85, 0x04,
+	0x20, 0x27, 0x28, 0x9c, 0x79, 0x1d, 0x06, 0xa2, 0x43, 0x30, 0x27, 0xaa, 0xe4, 0x20, 0xe6, 0xff,
+	0xc3, 0x05, 0x5e, 0xee, 0x0b, 0xa4, 0xf6, 0xbd, 0x01, 0x34, 0xd8, 0xb0, 0x7a, 0x3c, 0x08, 0xc2,
+	0xfc, 0x04, 0x06, 0x79, 0xb0, 0x0c, 0xb3, 0x77, 0xa1, 0x2d, 0xd7, 0x56, 0x81, 0xb6, 0x70, 0x93,
+	0x1a, 0x68, 0x7e, 0x0a, 0x83, 0xa7, 0xb1, 0xe7, 0x9c, 0x65, 0x97, 0xcc, 0x72, 0x8e, 0xe7, 0x49,
+	0xff, 0x61, 0x9f, 0x2c, 0x13, 0x11, 0x3c, 0x89, 0x4e, 0xb0, 0xd4, 0xb5, 0xa4, 0xcc, 0xdf, 0xc2,
+	0x86, 0x70, 0x0c, 0x29, 0xee, 0x7f, 0xde, 0xc9, 0xfb, 0x67, 0x15, 0x56, 0x72, 0x3b, 0x38, 0x53,
+	0xba, 0xcd, 0xed, 0xae, 0x56, 0xf2, 0x84, 0x61, 0xce, 0x56, 0xcf, 0x38, 0xdb, 0xa6, 0xde, 0x92,
+	0xbc, 0x81, 0x05, 0xc5, 0x04, 0xe9, 0x07, 0xa4, 0xca, 0xb8, 0xaa, 0xe5, 0x81, 0x76, 0xa0, 0xeb,
+	0xcd, 0xf2, 0x8c, 0x4c, 0xbc, 0x59, 0x56, 0x36, 0x20, 0xdb, 0xf9, 0x80, 0xbc, 0x02, 0x7d, 0xf9,
+	0x69, 0x13, 0x4c, 0xd3, 0x20, 0x91, 0x0f, 0x97, 0x15, 0x57, 0x45, 0x20, 0x63, 0x16, 0x7a, 0x0f,
+	0x70, 0x7a, 0xef, 0xa1, 0x5b, 0xec, 0x3d, 0x5c, 0x85, 0x55, 0x31, 0x68, 0xeb, 0xb2, 0xb7, 0xc7,
+	0x6b, 0xa4, 0x15, 0xc1, 0xb6, 0x64, 0x8f, 0xf0, 0x87, 0x60, 0x7c, 0x84, 0x93, 0x9c, 0xba, 0xb3,
+	0x97, 0xff, 0xcc, 0xa6, 0xca, 0x91, 0x41, 0x1b, 0x95, 0x9a, 0x9f, 0xc1, 0x76, 0xc9, 0x64, 0xe9,
+	0x2e, 0x77, 0xd8, 0x1b, 0x59, 0xf0, 0xa4, 0x4b, 0x5f, 0x2c, 0xef, 0x40, 0x28, 0x3f, 0xd3, 0x70,
+	0xf3, 0x77, 0x15, 0xd8, 0x1a, 0x4d, 0xd8, 0x13, 0x69, 0x14, 0x7a, 0xbe, 0xeb, 0x24, 0x11, 0xd1,
+	0x9b, 0xda, 0x84, 0xe6, 0x71, 0x44, 0x26, 0x8e, 0x2a, 0x89, 0x25, 0xc5, 0x4c, 0xcc, 0x02, 0x83,
+	0xfb, 0x44, 0xcf, 0xe2, 0xdf, 0xdc, 0xc4, 0xbc, 0xe9, 0x2f, 0x1d, 0x42, 0x52, 0x67, 0xcd, 0x26,
+	0x7f, 0xae, 0x80, 0x51, 0xdc, 0x86, 0x3c, 0xde, 0x65, 0xe8, 0x3b, 0x9e, 0x97, 0xd5, 0xaf, 0x78,
+	0x56, 0xf4, 0x38, 0x57, 0xaa, 0x97, 0xb5, 0xd1, 0x52, 0x1e, 0xa2, 0x19, 0x9c, 0xa8, 0x55, 0xfb,
+	0x92, 0xaf, 0x90, 0x6f, 0x03, 0x4a, 0x43, 0xf7, 0xb9, 0x13, 0x8e, 0xb3, 0xd8, 0x1a, 0xc7, 0xae,
+	0xe9, 0x11, 0x85, 0x36, 0xa0, 0x45, 0x5f, 0xf8, 0x71, 0x8c, 0xc5, 0x83, 0xac, 0x61, 0x29, 0xf2,
+	0xe6, 0x7f, 0xd6, 0xa1, 0x79, 0xc4, 0xd5, 0x8c, 0x3e, 0x87, 0x6e, 0xa6, 0xb4, 0x41, 0xe6, 0xd2,
+	0xba, 0x87, 0x6b, 0x77, 0x78, 0x96, 0xda, 0xc8, 0x7c, 0xed, 0xfb, 0x15, 0x74, 0x17, 0x5a, 0xb2,
+	0xdd, 0x8e, 0x0a, 0xd5, 0x7f, 0xbe, 0x0f, 0x3f, 0xdc, 0x2c, 0x74, 0x65, 0x8f, 0xd8, 0x6f, 0x14,
+	0xf3, 0x35, 0x34, 0x02, 0x98, 0xf5, 0xdd, 0xd1, 0xa5, 0x79, 0x39, 0x85, 0x9e, 0xfc, 0x72, 0x51,
+	0xb3, 0x46, 0x7a, 0x51, 0x54, 0xa1, 0xc9, 0xbe, 0x44, 0x94, 0x05, 0x1d, 0xdd, 0x36, 0x47, 0x3b,
+	0x85, 0x2b, 0x7a, 0xae, 0x01, 0x3f, 0xbc, 0xb4, 0x04, 0xa1, 0x14, 0x86, 0x3e, 0x81, 0x6e, 0xa6,
+	0x93, 0x5e, 0x34, 0x45, 0xb1, 0xcd, 0xbe, 0x64, 0x83, 0x8f, 0xa1, 0x9f, 0xef, 0xa4, 0xa3, 0x2b,
+	0xe5, 0xaa, 0x3b, 0x97, 0xc8, 0x7c, 0x4b, 0xbc, 0x28, 0xb2, 0xb4, 0x65, 0xbe, 0x44, 0xe4, 0x2f,
+	0x60, 0x25, 0xd7, 0x0f, 0x47, 0x97, 0xcb, 0x14, 0x35, 0xdf, 0x46, 0x1f, 0x5e, 0x39, 0x05, 0xa5,
+	0x55, 0x3a, 0x16, 0x6d, 0xbb, 0x5c, 0x87, 0x7c, 0xb7, 0x6c, 0x72, 0x49, 0x63, 0x75, 0xb8, 0x77,
+	0x3a, 0x50, 0x2f, 0x24, 0xfd, 0x81, 0x37, 0xe8, 0xca, 0xfd, 0x21, 0xdb, 0x3a, 0x1c, 0x5e, 0x5a,
+	0x82, 0xd0, 0x32, 0x3d, 0x58, 0x9d, 0xeb, 0x7a, 0xa0, 0xab, 0x65, 0xf3, 0x8a, 0x6d, 0x9d, 0xe1,
+	0xee, 0xa9, 0x38, 0xbd, 0xca, 0x17, 0xd0, 0xcb, 0xbe, 0xb6, 0x51, 0x21, 0xba, 0x4b, 0x3a, 0x25,
+	0xc3, 0xcb, 0xcb, 0x41, 0xf3, 0x47, 0xc8, 0xbc, 0xfc, 0xca, 0x8f, 0x50, 0x7c, 0x55, 0x0e, 0x77,
+	0x4f, 0xc5, 0xe9, 0x55, 0x1c, 0xe8, 0xe7, 0x5f, 0x77, 0x45, 0xc7, 0x2c, 0x7d, 0x26, 0x0e, 0xaf,
+	0x9e, 0x06, 0x2b, 0xd8, 0x62, 0xf6, 0xfa, 0x59, 0x60, 0x8b, 0xc2, 0xc3, 0x69, 0xb8, 0x7b, 0x2a,
+	0x4e, 0xaf, 0xf2, 0x73, 0xe8, 0x66, 0x1e, 0x45, 0xc5, 0x0c, 0x50, 0x7c, 0x31, 0x15, 0x43, 0xa1,
+	0xb4, 0x2e, 0xe3, 0xe9, 0xf8, 0x57, 0x80, 0x8a, 0xcf, 0x20, 0x74, 0x6d, 0x5e, 0xc0, 0xc2, 0xa7,
+	0xd2, 0x79, 0xd6, 0x3a, 0x86, 0xb5, 0xf9, 0xc7, 0x43, 0x31, 0xf0, 0x16, 0x3c, 0x2f, 0xce, 0xb3,
+	0xce, 0x08, 0x60, 0x56, 0xcc, 0x17, 0x53, 0x7a, 0xa1, 0xd0, 0x5f, 0x92, 0x8b, 0x1e, 0xc2, 0x4a,
+	0xae, 0x9e, 0x2f, 0xe6, 0xa2, 0xb2, 0x72, 0x7f, 0x89, 0xc0, 0x2f, 0xa0, 0x97, 0xad, 0xe3, 0x8b,
+	0x91, 0x55, 0xf2, 0x24, 0x18, 0x5e, 0x5e, 0x0e, 0xd2, 0xae, 0xf2, 0x4b, 0x58, 0xc9, 0xd5, 0xf5,
+	0xc5, 0xdd, 0x96, 0x95, 0xfd, 0xe7, 0x73, 0x97, 0xf5, 0x42, 0xe1, 0x86, 0xf6, 0x4a, 0x02, 0xbf,
+	0xb4, 0x30, 0x1c, 0x5e, 0x3b, 0x03, 0x32, 0x9b, 0xa7, 0xe7, 0x8b, 0xa8, 0xa2, 0xbb, 0x2c, 0xa8,
+	0xf6, 0x86, 0x7b, 0xa7, 0x03, 0xd5, 0x42, 0xcf, 0x9a, 0xdc, 0x4a, 0xef, 0xfe, 0x77, 0x00, 0x2a,
+	0x22, 0x86, 0x8e, 0xe9, 0x21, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EmittoClient is the client API for Emitto service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EmittoClient interface {
+	DeployRules(ctx context.Context, in *DeployRulesRequest, opts ...grpc.CallOption) (Emitto_DeployRulesClient, error)
+	AddRule(ctx context.Context, in *AddRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ModifyRule(ctx context.Context, in *ModifyRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error)
+	AddLocation(ctx context.Context, in *AddLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ModifyLocation(ctx context.Context, in *ModifyLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error)
+	ListSensorHealth(ctx context.Context, in *ListSensorHealthRequest, opts ...grpc.CallOption) (*ListSensorHealthResponse, error)
+	ListDrift(ctx context.Context, in *ListDriftRequest, opts ...grpc.CallOption) (*ListDriftResponse, error)
+	ListAlertEvents(ctx context.Context, in *ListAlertEventsRequest, opts ...grpc.CallOption) (*ListAlertEventsResponse, error)
+	GetRuleStats(ctx context.Context, in *GetRuleStatsRequest, opts ...grpc.CallOption) (*GetRuleStatsResponse, error)
+	ListQuarantines(ctx context.Context, in *ListQuarantinesRequest, opts ...grpc.CallOption) (*ListQuarantinesResponse, error)
+	UndoQuarantine(ctx context.Context, in *UndoQuarantineRequest, opts ...grpc.CallOption) (*UndoQuarantineResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	ReloadRules(ctx context.Context, in *ReloadRulesRequest, opts ...grpc.CallOption) (Emitto_ReloadRulesClient, error)
+	CollectDiagnostics(ctx context.Context, in *CollectDiagnosticsRequest, opts ...grpc.CallOption) (Emitto_CollectDiagnosticsClient, error)
+	RunSensorCommand(ctx context.Context, in *RunSensorCommandRequest, opts ...grpc.CallOption) (Emitto_RunSensorCommandClient, error)
+	AddDataset(ctx context.Context, in *AddDatasetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteDataset(ctx context.Context, in *DeleteDatasetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error)
+	UpdateDataset(ctx context.Context, in *UpdateDatasetRequest, opts ...grpc.CallOption) (Emitto_UpdateDatasetClient, error)
+	GetSensorRequests(ctx context.Context, in *GetSensorRequestsRequest, opts ...grpc.CallOption) (*GetSensorRequestsResponse, error)
+	ImportIndicators(ctx context.Context, in *ImportIndicatorsRequest, opts ...grpc.CallOption) (*ImportIndicatorsResponse, error)
+}
+
+type emittoClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEmittoClient(cc *grpc.ClientConn) EmittoClient {
+	return &emittoClient{cc}
+}
+
+func (c *emittoClient) DeployRules(ctx context.Context, in *DeployRulesRequest, opts ...grpc.CallOption) (Emitto_DeployRulesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Emitto_serviceDesc.Streams[0], "/emitto.service.Emitto/DeployRules", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emittoDeployRulesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Emitto_DeployRulesClient interface {
+	Recv() (*DeployRulesResponse, error)
+	grpc.ClientStream
+}
+
+type emittoDeployRulesClient struct {
+	grpc.ClientStream
+}
+
+func (x *emittoDeployRulesClient) Recv() (*DeployRulesResponse, error) {
+	m := new(DeployRulesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emittoClient) AddRule(ctx context.Context, in *AddRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/AddRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ModifyRule(ctx context.Context, in *ModifyRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ModifyRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/DeleteRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error) {
+	out := new(ListRulesResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) AddLocation(ctx context.Context, in *AddLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/AddLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ModifyLocation(ctx context.Context, in *ModifyLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ModifyLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) DeleteLocation(ctx context.Context, in *DeleteLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/DeleteLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error) {
+	out := new(ListLocationsResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListLocations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListSensorHealth(ctx context.Context, in *ListSensorHealthRequest, opts ...grpc.CallOption) (*ListSensorHealthResponse, error) {
+	out := new(ListSensorHealthResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListSensorHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListDrift(ctx context.Context, in *ListDriftRequest, opts ...grpc.CallOption) (*ListDriftResponse, error) {
+	out := new(ListDriftResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListDrift", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListAlertEvents(ctx context.Context, in *ListAlertEventsRequest, opts ...grpc.CallOption) (*ListAlertEventsResponse, error) {
+	out := new(ListAlertEventsResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListAlertEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) GetRuleStats(ctx context.Context, in *GetRuleStatsRequest, opts ...grpc.CallOption) (*GetRuleStatsResponse, error) {
+	out := new(GetRuleStatsResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/GetRuleStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListQuarantines(ctx context.Context, in *ListQuarantinesRequest, opts ...grpc.CallOption) (*ListQuarantinesResponse, error) {
+	out := new(ListQuarantinesResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListQuarantines", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) UndoQuarantine(ctx context.Context, in *UndoQuarantineRequest, opts ...grpc.CallOption) (*UndoQuarantineResponse, error) {
+	out := new(UndoQuarantineResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/UndoQuarantine", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ReloadRules(ctx context.Context, in *ReloadRulesRequest, opts ...grpc.CallOption) (Emitto_ReloadRulesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Emitto_serviceDesc.Streams[1], "/emitto.service.Emitto/ReloadRules", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emittoReloadRulesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Emitto_ReloadRulesClient interface {
+	Recv() (*SensorRequestResponse, error)
+	grpc.ClientStream
+}
+
+type emittoReloadRulesClient struct {
+	grpc.ClientStream
+}
+
+func (x *emittoReloadRulesClient) Recv() (*SensorRequestResponse, error) {
+	m := new(SensorRequestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emittoClient) CollectDiagnostics(ctx context.Context, in *CollectDiagnosticsRequest, opts ...grpc.CallOption) (Emitto_CollectDiagnosticsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Emitto_serviceDesc.Streams[2], "/emitto.service.Emitto/CollectDiagnostics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emittoCollectDiagnosticsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Emitto_CollectDiagnosticsClient interface {
+	Recv() (*SensorRequestResponse, error)
+	grpc.ClientStream
+}
+
+type emittoCollectDiagnosticsClient struct {
+	grpc.ClientStream
+}
+
+func (x *emittoCollectDiagnosticsClient) Recv() (*SensorRequestResponse, error) {
+	m := new(SensorRequestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emittoClient) RunSensorCommand(ctx context.Context, in *RunSensorCommandRequest, opts ...grpc.CallOption) (Emitto_RunSensorCommandClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Emitto_serviceDesc.Streams[3], "/emitto.service.Emitto/RunSensorCommand", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emittoRunSensorCommandClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Emitto_RunSensorCommandClient interface {
+	Recv() (*SensorRequestResponse, error)
+	grpc.ClientStream
+}
+
+type emittoRunSensorCommandClient struct {
+	grpc.ClientStream
+}
+
+func (x *emittoRunSensorCommandClient) Recv() (*SensorRequestResponse, error) {
+	m := new(SensorRequestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emittoClient) AddDataset(ctx context.Context, in *AddDatasetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/AddDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) DeleteDataset(ctx context.Context, in *DeleteDatasetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/DeleteDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error) {
+	out := new(ListDatasetsResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ListDatasets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) UpdateDataset(ctx context.Context, in *UpdateDatasetRequest, opts ...grpc.CallOption) (Emitto_UpdateDatasetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Emitto_serviceDesc.Streams[4], "/emitto.service.Emitto/UpdateDataset", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emittoUpdateDatasetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Emitto_UpdateDatasetClient interface {
+	Recv() (*SensorRequestResponse, error)
+	grpc.ClientStream
+}
+
+type emittoUpdateDatasetClient struct {
+	grpc.ClientStream
+}
+
+func (x *emittoUpdateDatasetClient) Recv() (*SensorRequestResponse, error) {
+	m := new(SensorRequestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emittoClient) GetSensorRequests(ctx context.Context, in *GetSensorRequestsRequest, opts ...grpc.CallOption) (*GetSensorRequestsResponse, error) {
+	out := new(GetSensorRequestsResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/GetSensorRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emittoClient) ImportIndicators(ctx context.Context, in *ImportIndicatorsRequest, opts ...grpc.CallOption) (*ImportIndicatorsResponse, error) {
+	out := new(ImportIndicatorsResponse)
+	err := c.cc.Invoke(ctx, "/emitto.service.Emitto/ImportIndicators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmittoServer is the server API for Emitto service.
+type EmittoServer interface {
+	DeployRules(*DeployRulesRequest, Emitto_DeployRulesServer) error
+	AddRule(context.Context, *AddRuleRequest) (*empty.Empty, error)
+	ModifyRule(context.Context, *ModifyRuleRequest) (*empty.Empty, error)
+	DeleteRule(context.Context, *DeleteRuleRequest) (*empty.Empty, error)
+	ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error)
+	AddLocation(context.Context, *AddLocationRequest) (*empty.Empty, error)
+	ModifyLocation(context.Context, *ModifyLocationRequest) (*empty.Empty, error)
+	DeleteLocation(context.Context, *DeleteLocationRequest) (*empty.Empty, error)
+	ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error)
+	ListSensorHealth(context.Context, *ListSensorHealthRequest) (*ListSensorHealthResponse, error)
+	ListDrift(context.Context, *ListDriftRequest) (*ListDriftResponse, error)
+	ListAlertEvents(context.Context, *ListAlertEventsRequest) (*ListAlertEventsResponse, error)
+	GetRuleStats(context.Context, *GetRuleStatsRequest) (*GetRuleStatsResponse, error)
+	ListQuarantines(context.Context, *ListQuarantinesRequest) (*ListQuarantinesResponse, error)
+	UndoQuarantine(context.Context, *UndoQuarantineRequest) (*UndoQuarantineResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	ReloadRules(*ReloadRulesRequest, Emitto_ReloadRulesServer) error
+	CollectDiagnostics(*CollectDiagnosticsRequest, Emitto_CollectDiagnosticsServer) error
+	RunSensorCommand(*RunSensorCommandRequest, Emitto_RunSensorCommandServer) error
+	AddDataset(context.Context, *AddDatasetRequest) (*empty.Empty, error)
+	DeleteDataset(context.Context, *DeleteDatasetRequest) (*empty.Empty, error)
+	ListDatasets(context.Context, *ListDatasetsRequest) (*ListDatasetsResponse, error)
+	UpdateDataset(*UpdateDatasetRequest, Emitto_UpdateDatasetServer) error
+	GetSensorRequests(context.Context, *GetSensorRequestsRequest) (*GetSensorRequestsResponse, error)
+	ImportIndicators(context.Context, *ImportIndicatorsRequest) (*ImportIndicatorsResponse, error)
+}
+
+func RegisterEmittoServer(s *grpc.Server, srv EmittoServer) {
+	s.RegisterService(&_Emitto_serviceDesc, srv)
+}
+
+func _Emitto_DeployRules_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeployRulesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmittoServer).DeployRules(m, &emittoDeployRulesServer{stream})
+}
+
+type Emitto_DeployRulesServer interface {
+	Send(*DeployRulesResponse) error
+	grpc.ServerStream
+}
+
+type emittoDeployRulesServer struct {
+	grpc.ServerStream
+}
+
+func (x *emittoDeployRulesServer) Send(m *DeployRulesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Emitto_AddRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).AddRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/AddRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).AddRule(ctx, req.(*AddRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ModifyRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ModifyRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ModifyRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ModifyRule(ctx, req.(*ModifyRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRuleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
@@ -992,6 +3625,306 @@ func _Emitto_ListLocations_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Emitto_ListSensorHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSensorHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ListSensorHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ListSensorHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ListSensorHealth(ctx, req.(*ListSensorHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ListDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ListDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ListDrift",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ListDrift(ctx, req.(*ListDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ListAlertEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ListAlertEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ListAlertEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ListAlertEvents(ctx, req.(*ListAlertEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_GetRuleStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRuleStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).GetRuleStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/GetRuleStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).GetRuleStats(ctx, req.(*GetRuleStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ListQuarantines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuarantinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ListQuarantines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ListQuarantines",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ListQuarantines(ctx, req.(*ListQuarantinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_UndoQuarantine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoQuarantineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).UndoQuarantine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/UndoQuarantine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).UndoQuarantine(ctx, req.(*UndoQuarantineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ReloadRules_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReloadRulesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmittoServer).ReloadRules(m, &emittoReloadRulesServer{stream})
+}
+
+type Emitto_ReloadRulesServer interface {
+	Send(*SensorRequestResponse) error
+	grpc.ServerStream
+}
+
+type emittoReloadRulesServer struct {
+	grpc.ServerStream
+}
+
+func (x *emittoReloadRulesServer) Send(m *SensorRequestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Emitto_CollectDiagnostics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CollectDiagnosticsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmittoServer).CollectDiagnostics(m, &emittoCollectDiagnosticsServer{stream})
+}
+
+type Emitto_CollectDiagnosticsServer interface {
+	Send(*SensorRequestResponse) error
+	grpc.ServerStream
+}
+
+type emittoCollectDiagnosticsServer struct {
+	grpc.ServerStream
+}
+
+func (x *emittoCollectDiagnosticsServer) Send(m *SensorRequestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Emitto_RunSensorCommand_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunSensorCommandRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmittoServer).RunSensorCommand(m, &emittoRunSensorCommandServer{stream})
+}
+
+type Emitto_RunSensorCommandServer interface {
+	Send(*SensorRequestResponse) error
+	grpc.ServerStream
+}
+
+type emittoRunSensorCommandServer struct {
+	grpc.ServerStream
+}
+
+func (x *emittoRunSensorCommandServer) Send(m *SensorRequestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Emitto_AddDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).AddDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/AddDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).AddDataset(ctx, req.(*AddDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_DeleteDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).DeleteDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/DeleteDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).DeleteDataset(ctx, req.(*DeleteDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ListDatasets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDatasetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ListDatasets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ListDatasets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ListDatasets(ctx, req.(*ListDatasetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_UpdateDataset_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateDatasetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmittoServer).UpdateDataset(m, &emittoUpdateDatasetServer{stream})
+}
+
+type Emitto_UpdateDatasetServer interface {
+	Send(*SensorRequestResponse) error
+	grpc.ServerStream
+}
+
+type emittoUpdateDatasetServer struct {
+	grpc.ServerStream
+}
+
+func (x *emittoUpdateDatasetServer) Send(m *SensorRequestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Emitto_GetSensorRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSensorRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).GetSensorRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/GetSensorRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).GetSensorRequests(ctx, req.(*GetSensorRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emitto_ImportIndicators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportIndicatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmittoServer).ImportIndicators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emitto.service.Emitto/ImportIndicators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmittoServer).ImportIndicators(ctx, req.(*ImportIndicatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Emitto_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "emitto.service.Emitto",
 	HandlerType: (*EmittoServer)(nil),
@@ -1028,6 +3961,54 @@ var _Emitto_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListLocations",
 			Handler:    _Emitto_ListLocations_Handler,
 		},
+		{
+			MethodName: "ListSensorHealth",
+			Handler:    _Emitto_ListSensorHealth_Handler,
+		},
+		{
+			MethodName: "ListDrift",
+			Handler:    _Emitto_ListDrift_Handler,
+		},
+		{
+			MethodName: "ListAlertEvents",
+			Handler:    _Emitto_ListAlertEvents_Handler,
+		},
+		{
+			MethodName: "GetRuleStats",
+			Handler:    _Emitto_GetRuleStats_Handler,
+		},
+		{
+			MethodName: "ListQuarantines",
+			Handler:    _Emitto_ListQuarantines_Handler,
+		},
+		{
+			MethodName: "UndoQuarantine",
+			Handler:    _Emitto_UndoQuarantine_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Emitto_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "AddDataset",
+			Handler:    _Emitto_AddDataset_Handler,
+		},
+		{
+			MethodName: "DeleteDataset",
+			Handler:    _Emitto_DeleteDataset_Handler,
+		},
+		{
+			MethodName: "ListDatasets",
+			Handler:    _Emitto_ListDatasets_Handler,
+		},
+		{
+			MethodName: "GetSensorRequests",
+			Handler:    _Emitto_GetSensorRequests_Handler,
+		},
+		{
+			MethodName: "ImportIndicators",
+			Handler:    _Emitto_ImportIndicators_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1035,6 +4016,26 @@ var _Emitto_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Emitto_DeployRules_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReloadRules",
+			Handler:       _Emitto_ReloadRules_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CollectDiagnostics",
+			Handler:       _Emitto_CollectDiagnostics_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RunSensorCommand",
+			Handler:       _Emitto_RunSensorCommand_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UpdateDataset",
+			Handler:       _Emitto_UpdateDataset_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "source/server/proto/service.proto",
 }
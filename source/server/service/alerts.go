@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/emitto/source/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	spb "github.com/google/emitto/source/sensor/proto"
+	svpb "github.com/google/emitto/source/server/proto"
+)
+
+// defaultAlertEventsLimit is the maximum number of alert events listed when no limit is requested.
+const defaultAlertEventsLimit = 1000
+
+// storeAlertEvents stores the alert events forwarded by a sensor, mapping their signature IDs back
+// to rule IDs.
+func (s *Service) storeAlertEvents(ctx context.Context, clientID string, msg *spb.SensorMessage) error {
+	events := resources.ProtoToAlertEvents(clientID, msg)
+	if d := msg.GetEveAlerts().GetDropped(); d > 0 {
+		log.Infof("Client %s dropped %d alert events by sampling or rate limiting", clientID, d)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	sids, err := s.ruleSIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		e.RuleID = sids[e.SignatureID]
+	}
+	return s.store.AddAlertEvents(ctx, events)
+}
+
+// ListAlertEvents lists the alert events forwarded by sensors, most recent first.
+func (s *Service) ListAlertEvents(ctx context.Context, req *svpb.ListAlertEventsRequest) (*svpb.ListAlertEventsResponse, error) {
+	f := &resources.AlertEventFilter{
+		RuleID:   req.GetRuleId(),
+		Location: req.GetLocation(),
+		Zone:     req.GetZone(),
+		ClientID: req.GetClientId(),
+		Host:     req.GetHost(),
+		Limit:    int(req.GetLimit()),
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid limit: %d", req.GetLimit())
+	}
+	if f.Limit == 0 {
+		f.Limit = defaultAlertEventsLimit
+	}
+	if req.GetSince() != "" {
+		t, err := time.Parse(time.RFC3339, req.GetSince())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid since time %q: %v", req.GetSince(), err)
+		}
+		f.Since = t
+	}
+	events, err := s.store.ListAlertEvents(ctx, f)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list alert events: %v", err)
+	}
+	resp := &svpb.ListAlertEventsResponse{}
+	for _, e := range events {
+		resp.Events = append(resp.Events, resources.AlertEventToProto(e))
+	}
+	return resp, nil
+}
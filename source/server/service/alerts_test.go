@@ -0,0 +1,226 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sensorpb "github.com/google/emitto/source/sensor/proto"
+	evepb "github.com/google/emitto/source/sensor/suricata/proto"
+	spb "github.com/google/emitto/source/server/proto"
+)
+
+func TestAlertEvents(t *testing.T) {
+	ctx := context.Background()
+	ds := store.NewMemoryStore()
+	if err := ds.AddRule(ctx, &resources.Rule{ID: 7, Body: "alert ip any any -> any any (sid:1001;)"}); err != nil {
+		t.Fatal(err)
+	}
+	s := New(ds, nil, nil, nil)
+
+	processSensorMessage(t, s, []byte{0x0a}, &sensorpb.SensorMessage{
+		Id: "msg1",
+		Type: &sensorpb.SensorMessage_EveAlerts{
+			EveAlerts: &sensorpb.EVEAlerts{
+				Host: &sensorpb.Host{Fqdn: "sensor1", Org: "test", Zone: "dmz"},
+				Events: []*evepb.EVE{
+					{
+						Timestamp: "2019-05-13T14:00:00.000000+0000",
+						EventType: "alert",
+						SrcIp:     "10.0.0.1",
+						Alert:     &evepb.Alert{SignatureId: 1001, Signature: "a"},
+					},
+					{
+						Timestamp: "2019-05-13T16:01:00.000000+0200",
+						EventType: "alert",
+						SrcIp:     "10.0.0.2",
+						Alert:     &evepb.Alert{SignatureId: 1002, Signature: "b"},
+					},
+				},
+				Dropped: 3,
+			},
+		},
+	})
+
+	for _, tt := range []struct {
+		desc    string
+		req     *spb.ListAlertEventsRequest
+		want    *spb.ListAlertEventsResponse
+		wantErr codes.Code
+	}{
+		{
+			desc: "all events",
+			req:  &spb.ListAlertEventsRequest{},
+			want: &spb.ListAlertEventsResponse{Events: []*spb.AlertEvent{
+				{
+					Id:          "msg1-1",
+					ClientId:    "0A",
+					Time:        "Mon, 13 May 2019 14:01:00 +0000",
+					Host:        "sensor1",
+					Location:    "test",
+					Zone:        "dmz",
+					SignatureId: 1002,
+					Signature:   "b",
+					SrcIp:       "10.0.0.2",
+				},
+				{
+					Id:          "msg1-0",
+					ClientId:    "0A",
+					Time:        "Mon, 13 May 2019 14:00:00 +0000",
+					Host:        "sensor1",
+					Location:    "test",
+					Zone:        "dmz",
+					RuleId:      7,
+					SignatureId: 1001,
+					Signature:   "a",
+					SrcIp:       "10.0.0.1",
+				},
+			}},
+		},
+		{
+			desc: "by rule",
+			req:  &spb.ListAlertEventsRequest{RuleId: 7, Location: "test", Since: "2019-05-13T13:00:00Z"},
+			want: &spb.ListAlertEventsResponse{Events: []*spb.AlertEvent{
+				{
+					Id:          "msg1-0",
+					ClientId:    "0A",
+					Time:        "Mon, 13 May 2019 14:00:00 +0000",
+					Host:        "sensor1",
+					Location:    "test",
+					Zone:        "dmz",
+					RuleId:      7,
+					SignatureId: 1001,
+					Signature:   "a",
+					SrcIp:       "10.0.0.1",
+				},
+			}},
+		},
+		{
+			desc: "no matches",
+			req:  &spb.ListAlertEventsRequest{Zone: "prod"},
+			want: &spb.ListAlertEventsResponse{},
+		},
+		{
+			desc:    "invalid since",
+			req:     &spb.ListAlertEventsRequest{Since: "yesterday"},
+			wantErr: codes.InvalidArgument,
+		},
+	} {
+		got, err := s.ListAlertEvents(ctx, tt.req)
+		if c := status.Code(err); c != tt.wantErr {
+			t.Errorf("%s: got %v, want %v", tt.desc, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.Comparer(proto.Equal)); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+// countingRuleStore counts the listings of all rules.
+type countingRuleStore struct {
+	store.Store
+	lists int
+}
+
+func (s *countingRuleStore) ListRules(ctx context.Context, ids []int64) ([]*resources.Rule, error) {
+	if ids == nil {
+		s.lists++
+	}
+	return s.Store.ListRules(ctx, ids)
+}
+
+func TestRuleSIDs(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	ds := &countingRuleStore{Store: store.NewMemoryStore()}
+	s := New(ds, nil, nil, nil)
+	if _, err := s.AddRule(ctx, &spb.AddRuleRequest{Rule: &spb.Rule{Id: 7, Body: "alert ip any any -> any any (sid:1001;)"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		desc      string
+		change    func() error
+		want      map[int64]int64
+		wantLists int
+	}{
+		{
+			desc:      "first lookup",
+			want:      map[int64]int64{1001: 7},
+			wantLists: 1,
+		},
+		{
+			desc:      "cached",
+			want:      map[int64]int64{1001: 7},
+			wantLists: 1,
+		},
+		{
+			desc: "rule added",
+			change: func() error {
+				_, err := s.AddRule(ctx, &spb.AddRuleRequest{Rule: &spb.Rule{Id: 8, Body: "alert ip any any -> any any (sid:1002;)"}})
+				return err
+			},
+			want:      map[int64]int64{1001: 7, 1002: 8},
+			wantLists: 2,
+		},
+		{
+			desc: "rule deleted",
+			change: func() error {
+				_, err := s.DeleteRule(ctx, &spb.DeleteRuleRequest{RuleId: 7})
+				return err
+			},
+			want:      map[int64]int64{1002: 8},
+			wantLists: 3,
+		},
+		{
+			desc: "expired",
+			change: func() error {
+				now = now.Add(ruleSIDsTTL)
+				return nil
+			},
+			want:      map[int64]int64{1002: 8},
+			wantLists: 4,
+		},
+	} {
+		if tt.change != nil {
+			if err := tt.change(); err != nil {
+				t.Fatalf("%s: %v", tt.desc, err)
+			}
+		}
+		got, err := s.ruleSIDs(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+		if ds.lists != tt.wantLists {
+			t.Errorf("%s: got %d rule listings, want %d", tt.desc, ds.lists, tt.wantLists)
+		}
+	}
+}
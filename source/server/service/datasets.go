@@ -0,0 +1,178 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/emitto/source/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	emptypb "github.com/golang/protobuf/ptypes/empty"
+	spb "github.com/google/emitto/source/sensor/proto"
+	svpb "github.com/google/emitto/source/server/proto"
+)
+
+// AddDataset adds the provided Dataset. It is deployed with the rules of its location zones.
+func (s *Service) AddDataset(ctx context.Context, req *svpb.AddDatasetRequest) (*emptypb.Empty, error) {
+	d := resources.ProtoToDataset(req.GetDataset())
+	if err := resources.NormalizeDataset(d); err != nil {
+		return &emptypb.Empty{}, status.Errorf(codes.InvalidArgument, "invalid dataset: %v", err)
+	}
+	if err := s.store.AddDataset(ctx, d); err != nil {
+		return &emptypb.Empty{}, status.Errorf(codes.Internal, "failed to add dataset %q: %v", d.Name, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteDataset deletes an existing Dataset by name. Sensors keep its file until redeployed.
+func (s *Service) DeleteDataset(ctx context.Context, req *svpb.DeleteDatasetRequest) (*emptypb.Empty, error) {
+	if err := s.store.DeleteDataset(ctx, req.GetName()); err != nil {
+		return &emptypb.Empty{}, status.Errorf(codes.Internal, "failed to delete dataset %q: %v", req.GetName(), err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListDatasets returns the Datasets with the provided names, or all Datasets if no names are
+// provided.
+func (s *Service) ListDatasets(ctx context.Context, req *svpb.ListDatasetsRequest) (*svpb.ListDatasetsResponse, error) {
+	var datasets []*resources.Dataset
+	if len(req.GetNames()) == 0 {
+		var err error
+		if datasets, err = s.store.ListDatasets(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list datasets: %v", err)
+		}
+	}
+	for _, name := range req.GetNames() {
+		d, err := s.store.GetDataset(ctx, name)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "failed to get dataset %q: %v", name, err)
+		}
+		datasets = append(datasets, d)
+	}
+	resp := &svpb.ListDatasetsResponse{}
+	for _, d := range datasets {
+		resp.Datasets = append(resp.Datasets, resources.DatasetToProto(d))
+	}
+	return resp, nil
+}
+
+// UpdateDataset adds and removes members of a Dataset, then sends the changes to the sensors in
+// the location zones of the Dataset, which apply them live.
+func (s *Service) UpdateDataset(req *svpb.UpdateDatasetRequest, stream svpb.Emitto_UpdateDatasetServer) error {
+	ctx := stream.Context()
+	d, add, remove, err := s.updateDatasetMembers(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	ids, err := s.clientIDsByLocZones(ctx, d.LocZones)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		log.Warningf("No clients in the location zones %q of dataset %q", d.LocZones, d.Name)
+	}
+	r := &spb.SensorRequest{Type: &spb.SensorRequest_UpdateDataset{UpdateDataset: &spb.UpdateDataset{
+		Name:   d.Name,
+		Type:   string(d.Type),
+		Add:    add,
+		Remove: remove,
+	}}}
+	return s.sendRequests(ctx, ids, &resources.SensorRequest{Type: resources.UpdateDataset}, r, stream.Send)
+}
+
+// updateDatasetMembers adds and removes members of a Dataset, returning the Dataset before the
+// update and the normalized members added and removed. Concurrent updates are serialized, so that
+// none is lost.
+func (s *Service) updateDatasetMembers(ctx context.Context, req *svpb.UpdateDatasetRequest) (d *resources.Dataset, add, remove []string, err error) {
+	s.datasetMu.Lock()
+	defer s.datasetMu.Unlock()
+
+	if d, err = s.store.GetDataset(ctx, req.GetName()); err != nil {
+		return nil, nil, nil, status.Errorf(codes.NotFound, "failed to get dataset %q: %v", req.GetName(), err)
+	}
+	if add, err = resources.NormalizeDatasetMembers(d.Type, req.GetAdd()); err != nil {
+		return nil, nil, nil, status.Errorf(codes.InvalidArgument, "invalid member to add: %v", err)
+	}
+	if remove, err = resources.NormalizeDatasetMembers(d.Type, req.GetRemove()); err != nil {
+		return nil, nil, nil, status.Errorf(codes.InvalidArgument, "invalid member to remove: %v", err)
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil, nil, status.Error(codes.InvalidArgument, "no members to add or remove")
+	}
+	members := resources.UpdateDatasetMembers(d.Members, add, remove)
+	if err := s.store.ModifyDataset(ctx, &resources.Dataset{Name: d.Name, Members: members}); err != nil {
+		return nil, nil, nil, status.Errorf(codes.Internal, "failed to modify dataset %q: %v", d.Name, err)
+	}
+	return d, add, remove, nil
+}
+
+// clientIDsByLocZones returns the IDs of the clients in the location zones, e.g. "google:dmz".
+func (s *Service) clientIDsByLocZones(ctx context.Context, locZones []string) ([][]byte, error) {
+	var (
+		names []string
+		zones = make(map[string][]string)
+	)
+	for _, lz := range locZones {
+		i := strings.LastIndex(lz, ":")
+		if i < 0 {
+			continue
+		}
+		name := lz[:i]
+		if _, ok := zones[name]; !ok {
+			names = append(names, name)
+		}
+		zones[name] = append(zones[name], lz[i+1:])
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	clients, err := s.fleetspeak.ListClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids [][]byte
+	seen := make(map[string]bool)
+	for _, name := range names {
+		for _, id := range getClientIDsByLocation(clients, &svpb.Location{Name: name, Zones: zones[name]}) {
+			if !seen[string(id)] {
+				seen[string(id)] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// filterDatasetsByLocation returns the datasets deployed in a zone of the location.
+func filterDatasetsByLocation(datasets []*resources.Dataset, loc *svpb.Location) []*resources.Dataset {
+	var res []*resources.Dataset
+	for _, d := range datasets {
+	match:
+		for _, lz := range d.LocZones {
+			for _, z := range loc.GetZones() {
+				if lz == loc.GetName()+":"+z {
+					res = append(res, d)
+					break match
+				}
+			}
+		}
+	}
+	return res
+}
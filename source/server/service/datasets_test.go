@@ -0,0 +1,187 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sensorpb "github.com/google/emitto/source/sensor/proto"
+	spb "github.com/google/emitto/source/server/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+	fsspb "github.com/google/fleetspeak/fleetspeak/src/server/proto/fleetspeak_server"
+)
+
+func TestDatasets(t *testing.T) {
+	ctx := context.Background()
+	var inserted []*sensorpb.SensorRequest
+	fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+		listClients: func(*fsspb.ListClientsRequest) (*fsspb.ListClientsResponse, error) {
+			return &fsspb.ListClientsResponse{Clients: testClients}, nil
+		},
+		insertMessage: func(m *fspb.Message) (*fspb.EmptyMessage, error) {
+			var req sensorpb.SensorRequest
+			if err := ptypes.UnmarshalAny(m.GetData(), &req); err != nil {
+				return nil, err
+			}
+			inserted = append(inserted, &req)
+			return &fspb.EmptyMessage{}, nil
+		},
+	})
+	defer fc.Close()
+	defer stopFs()
+	ds := store.NewMemoryStore()
+	for _, r := range testRules {
+		if err := ds.AddRule(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, stopServer := initServerAndClient(t, New(ds, filestore.NewMemoryFileStore(), fc, nil))
+	defer stopServer()
+
+	if _, err := c.AddDataset(ctx, &spb.AddDatasetRequest{Dataset: &spb.Dataset{
+		Name:    "bad_ips",
+		Type:    "ipv4",
+		Members: []string{"evil.example.com"},
+	}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got err=%v for an invalid member, want code %v", err, codes.InvalidArgument)
+	}
+	if _, err := c.AddDataset(ctx, &spb.AddDatasetRequest{Dataset: &spb.Dataset{
+		Name:          "bad_ips",
+		Type:          "ipv4",
+		Members:       []string{"10.0.0.1", "10.0.0.2"},
+		LocationZones: []string{"a:dmz", "b:dmz"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Members are updated, then sent to the sensors of the dataset location zones.
+	stream, err := c.UpdateDataset(ctx, &spb.UpdateDatasetRequest{
+		Name:   "bad_ips",
+		Add:    []string{"10.0.0.3", "10.0.0.1"},
+		Remove: []string{"10.0.0.2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := recvAll(t, stream.Recv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, r := range got {
+		ids = append(ids, r.GetClientId())
+	}
+	// "client_a", "client_b" and "client_d".
+	if diff := cmp.Diff([]string{"636C69656E745F61", "636C69656E745F62", "636C69656E745F64"}, ids); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	want := &sensorpb.UpdateDataset{Name: "bad_ips", Type: "ipv4", Add: []string{"10.0.0.3", "10.0.0.1"}, Remove: []string{"10.0.0.2"}}
+	for _, r := range inserted {
+		if diff := cmp.Diff(want, r.GetUpdateDataset(), cmp.Comparer(proto.Equal)); diff != "" {
+			t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+		}
+	}
+	resp, err := c.ListDatasets(ctx, &spb.ListDatasetsRequest{Names: []string{"bad_ips"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDatasets := []*spb.Dataset{{
+		Name:          "bad_ips",
+		Type:          "ipv4",
+		Members:       []string{"10.0.0.1", "10.0.0.3"},
+		LocationZones: []string{"a:dmz", "b:dmz"},
+	}}
+	if diff := cmp.Diff(wantDatasets, resp.GetDatasets(), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Deployments include the datasets of the location.
+	inserted = nil
+	deploy, err := c.DeployRules(ctx, &spb.DeployRulesRequest{Location: &spb.Location{Name: "a", Zones: []string{"dmz"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := deploy.Recv(); err != nil {
+			break
+		}
+	}
+	if len(inserted) == 0 {
+		t.Fatal("no deployments were inserted")
+	}
+	wantDeployed := []*sensorpb.Dataset{{Name: "bad_ips", Type: "ipv4", Members: []string{"10.0.0.1", "10.0.0.3"}}}
+	if diff := cmp.Diff(wantDeployed, inserted[0].GetDeployRules().GetDatasets(), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.DeleteDataset(ctx, &spb.DeleteDatasetRequest{Name: "bad_ips"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListDatasets(ctx, &spb.ListDatasetsRequest{Names: []string{"bad_ips"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("got err=%v for a deleted dataset, want code %v", err, codes.NotFound)
+	}
+}
+
+// slowDatasetStore delays dataset modifications, widening the window for concurrent updates to be
+// lost.
+type slowDatasetStore struct {
+	store.Store
+}
+
+func (s slowDatasetStore) ModifyDataset(ctx context.Context, d *resources.Dataset) error {
+	time.Sleep(time.Millisecond)
+	return s.Store.ModifyDataset(ctx, d)
+}
+
+func TestUpdateDatasetConcurrently(t *testing.T) {
+	ctx := context.Background()
+	ds := store.NewMemoryStore()
+	if err := ds.AddDataset(ctx, &resources.Dataset{Name: "bad_ips", Type: "ipv4"}); err != nil {
+		t.Fatal(err)
+	}
+	s := New(slowDatasetStore{ds}, filestore.NewMemoryFileStore(), nil, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, _, _, err := s.updateDatasetMembers(ctx, &spb.UpdateDatasetRequest{Name: "bad_ips", Add: []string{fmt.Sprintf("10.0.0.%d", i)}}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	d, err := ds.GetDataset(ctx, "bad_ips")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Members) != 50 {
+		t.Errorf("got %d members, want 50: no update may be lost", len(d.Members))
+	}
+}
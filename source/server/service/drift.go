@@ -0,0 +1,164 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/emitto/source/resources"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	spb "github.com/google/emitto/source/sensor/proto"
+	svpb "github.com/google/emitto/source/server/proto"
+)
+
+// recordDeployment records a DeployRules request which the sensor confirmed as successful, so that
+// rule file hashes reported in later heartbeats can be checked against it.
+func (s *Service) recordDeployment(ctx context.Context, resp *spb.SensorResponse) error {
+	if codes.Code(resp.GetStatus().GetCode()) != codes.OK {
+		return nil
+	}
+	req, err := s.store.GetSensorRequest(ctx, resp.GetId())
+	if err != nil {
+		return err
+	}
+	if req.Type != resources.DeployRules || req.RuleFileSHA256 == "" {
+		return nil
+	}
+	return s.store.PutDeployment(ctx, &resources.Deployment{
+		ClientID:       req.ClientID,
+		RequestID:      req.ID,
+		RuleFile:       req.RuleFile,
+		RuleFileSHA256: req.RuleFileSHA256,
+		Location:       req.Location,
+		Zones:          req.Zones,
+		Generation:     req.Generation,
+		Time:           timeNow().Format(time.RFC1123Z),
+	})
+}
+
+// checkDrift compares the rule file hash reported by a sensor against its last successful
+// deployment. A drift event is recorded once for every distinct drifted rule file.
+func (s *Service) checkDrift(ctx context.Context, clientID string, h *spb.Heartbeat) error {
+	reported := hex.EncodeToString(h.GetRuleFileSha256())
+	if reported == "" {
+		// The sensor could not hash its rule file or does not support reporting it.
+		return nil
+	}
+	d, err := s.store.GetDeployment(ctx, clientID)
+	if err != nil {
+		// Nothing was deployed to the sensor yet.
+		return nil
+	}
+	switch reported {
+	case d.RuleFileSHA256:
+		if d.DriftSHA256 == "" {
+			return nil
+		}
+		log.Infof("Client %s is no longer drifted", clientID)
+		d.DriftSHA256 = ""
+		return s.store.PutDeployment(ctx, d)
+	case d.DriftSHA256:
+		// Already recorded.
+		return nil
+	}
+	e := &resources.DriftEvent{
+		ID:              uuid.New().String(),
+		ClientID:        clientID,
+		Time:            timeNow().Format(time.RFC1123Z),
+		DeployRequestID: d.RequestID,
+		ExpectedSHA256:  d.RuleFileSHA256,
+		ReportedSHA256:  reported,
+	}
+	log.Warningf("Client %s reported rule file %s, expected %s", clientID, reported, d.RuleFileSHA256)
+	if err := s.store.AddDriftEvent(ctx, e); err != nil {
+		return err
+	}
+	d.DriftSHA256 = reported
+	return s.store.PutDeployment(ctx, d)
+}
+
+// ListDrift returns the drift events for the provided client IDs, or for all clients if no client
+// IDs are provided. If requested, the last successful deployment is redeployed to the currently
+// drifted sensors.
+func (s *Service) ListDrift(ctx context.Context, req *svpb.ListDriftRequest) (*svpb.ListDriftResponse, error) {
+	ids := make(map[string]bool)
+	for _, id := range req.GetClientIds() {
+		ids[id] = true
+	}
+	match := func(id string) bool { return len(ids) == 0 || ids[id] }
+
+	events, err := s.store.ListDriftEvents(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list drift events: %v", err)
+	}
+	resp := &svpb.ListDriftResponse{}
+	for _, e := range events {
+		if match(e.ClientID) {
+			resp.Events = append(resp.Events, resources.DriftEventToProto(e))
+		}
+	}
+	if !req.GetRedeploy() {
+		return resp, nil
+	}
+	deployments, err := s.store.ListDeployments(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list deployments: %v", err)
+	}
+	for _, d := range deployments {
+		if d.DriftSHA256 == "" || !match(d.ClientID) {
+			continue
+		}
+		rid, st := s.redeploy(ctx, d)
+		resp.Redeployments = append(resp.Redeployments, &svpb.DeployRulesResponse{
+			ClientId:  d.ClientID,
+			RequestId: rid,
+			Status:    st.Proto(),
+		})
+	}
+	return resp, nil
+}
+
+// redeploy sends the rule file of a deployment to its sensor again. The request ID is returned if
+// the request was sent.
+func (s *Service) redeploy(ctx context.Context, d *resources.Deployment) (string, *status.Status) {
+	id, err := hex.DecodeString(d.ClientID)
+	if err != nil {
+		return "", status.New(codes.InvalidArgument, fmt.Sprintf("invalid client ID %q: %v", d.ClientID, err))
+	}
+	ruleFile, err := s.fileStore.GetRuleFile(ctx, d.RuleFile)
+	if err != nil {
+		return "", status.New(codes.NotFound, fmt.Sprintf("failed to get rule file %q: %v", d.RuleFile, err))
+	}
+	if sum := sha256.Sum256(ruleFile); hex.EncodeToString(sum[:]) != d.RuleFileSHA256 {
+		return "", status.New(codes.DataLoss, fmt.Sprintf("rule file %q does not match the deployed rule file", d.RuleFile))
+	}
+	// The deployment keeps its generation, so that sensors still reject it once a newer deployment of
+	// the location is installed.
+	loc := &svpb.Location{Name: d.Location, Zones: d.Zones}
+	deploy, err := s.newDeployRules(ctx, d.RuleFile, ruleFile, loc, d.Generation)
+	if err != nil {
+		return "", status.Convert(err)
+	}
+	log.Infof("Redeploying rule file %q to drifted client %s", d.RuleFile, d.ClientID)
+	return s.sendDeployRules(ctx, id, loc, deploy, ruleFile)
+}
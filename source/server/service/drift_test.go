@@ -0,0 +1,174 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sensorpb "github.com/google/emitto/source/sensor/proto"
+	spb "github.com/google/emitto/source/server/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+)
+
+func processSensorMessage(t *testing.T, s *Service, clientID []byte, msg *sensorpb.SensorMessage) {
+	t.Helper()
+	data, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Process(context.Background(), &fspb.Message{
+		Source: &fspb.Address{ClientId: clientID},
+		Data:   data,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func heartbeatMessage(ruleFile []byte) *sensorpb.SensorMessage {
+	sum := sha256.Sum256(ruleFile)
+	return &sensorpb.SensorMessage{
+		Type: &sensorpb.SensorMessage_Heartbeat{
+			Heartbeat: &sensorpb.Heartbeat{RuleFileSha256: sum[:]},
+		},
+	}
+}
+
+func TestDrift(t *testing.T) {
+	ctx := context.Background()
+	clientID := []byte{0x0a}
+	deployed := []byte("alert ip any any -> any any (sid:1;)\n")
+	edited := []byte("alert ip any any -> any any (sid:2;)\n")
+
+	ds := store.NewMemoryStore()
+	if err := ds.AddDataset(ctx, &resources.Dataset{Name: "bad_ips", Type: "ipv4", Members: []string{"10.0.0.1"}, LocZones: []string{"a:dmz"}}); err != nil {
+		t.Fatal(err)
+	}
+	fs := filestore.NewMemoryFileStore()
+	if err := fs.AddRuleFile(ctx, "a/rules", deployed); err != nil {
+		t.Fatal(err)
+	}
+	var inserted []*sensorpb.SensorRequest
+	fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+		insertMessage: func(m *fspb.Message) (*fspb.EmptyMessage, error) {
+			var req sensorpb.SensorRequest
+			if err := ptypes.UnmarshalAny(m.GetData(), &req); err != nil {
+				return nil, err
+			}
+			inserted = append(inserted, &req)
+			return &fspb.EmptyMessage{}, nil
+		},
+	})
+	defer fc.Close()
+	defer stopFs()
+	s := New(ds, fs, fc, nil)
+
+	loc := &spb.Location{Name: "a", Zones: []string{"dmz"}}
+	deploy, err := s.newDeployRules(ctx, "a/rules", deployed, loc, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, st := s.sendDeployRules(ctx, clientID, loc, deploy, deployed); st.Code() != codes.OK {
+		t.Fatalf("sendDeployRules() got %v", st.Proto())
+	}
+	if len(inserted) != 1 {
+		t.Fatalf("got %d inserted messages, want 1", len(inserted))
+	}
+	// A heartbeat before the deployment is confirmed does not result in drift.
+	processSensorMessage(t, s, clientID, heartbeatMessage(edited))
+	processSensorMessage(t, s, clientID, &sensorpb.SensorMessage{
+		Type: &sensorpb.SensorMessage_Response{
+			Response: &sensorpb.SensorResponse{
+				Id:     inserted[0].GetId(),
+				Status: status.New(codes.OK, "OK").Proto(),
+			},
+		},
+	})
+	processSensorMessage(t, s, clientID, heartbeatMessage(deployed))
+
+	resp, err := s.ListDrift(ctx, &spb.ListDriftRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(resp.GetEvents()); n != 0 {
+		t.Fatalf("got %d drift events for an undrifted sensor, want 0", n)
+	}
+
+	// Repeated heartbeats with the same drifted rule file are recorded once.
+	processSensorMessage(t, s, clientID, heartbeatMessage(edited))
+	processSensorMessage(t, s, clientID, heartbeatMessage(edited))
+
+	resp, err = s.ListDrift(ctx, &spb.ListDriftRequest{Redeploy: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(resp.GetEvents()); n != 1 {
+		t.Fatalf("got %d drift events, want 1", n)
+	}
+	deployedSum := sha256.Sum256(deployed)
+	editedSum := sha256.Sum256(edited)
+	e := resp.GetEvents()[0]
+	if e.GetClientId() != "0A" || e.GetExpectedRuleFileSha256() != hex.EncodeToString(deployedSum[:]) ||
+		e.GetReportedRuleFileSha256() != hex.EncodeToString(editedSum[:]) || e.GetDeployRequestId() != inserted[0].GetId() {
+		t.Errorf("unexpected drift event: %+v", e)
+	}
+	if n := len(resp.GetRedeployments()); n != 1 {
+		t.Fatalf("got %d redeployments, want 1", n)
+	}
+	if c := codes.Code(resp.GetRedeployments()[0].GetStatus().GetCode()); c != codes.OK {
+		t.Errorf("redeployment got %v, want OK", resp.GetRedeployments()[0].GetStatus())
+	}
+	if len(inserted) != 2 {
+		t.Fatalf("expected the rule file to be redeployed, got %v", inserted)
+	}
+	// The redeployment carries the location, generation and datasets of the deployment.
+	want := &sensorpb.DeployRules{
+		RuleFile:   "a/rules",
+		Location:   "a",
+		Generation: 1,
+		Datasets:   []*sensorpb.Dataset{{Name: "bad_ips", Type: "ipv4", Members: []string{"10.0.0.1"}}},
+	}
+	if diff := cmp.Diff(want, inserted[1].GetDeployRules(), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// The sensor is no longer drifted once it reports the deployed rule file.
+	processSensorMessage(t, s, clientID, heartbeatMessage(deployed))
+	resp, err = s.ListDrift(ctx, &spb.ListDriftRequest{ClientIds: []string{"0A"}, Redeploy: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(resp.GetRedeployments()); n != 0 {
+		t.Errorf("got %d redeployments for an undrifted sensor, want 0", n)
+	}
+	resp, err = s.ListDrift(ctx, &spb.ListDriftRequest{ClientIds: []string{"0B"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(resp.GetEvents()); n != 0 {
+		t.Errorf("got %d drift events for another client, want 0", n)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"github.com/google/emitto/source/server/ioc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	svpb "github.com/google/emitto/source/server/proto"
+)
+
+// ImportIndicators generates rules from the indicators of a file and stores them, enabled in the
+// requested location zones. The rules must then be deployed with DeployRules.
+func (s *Service) ImportIndicators(ctx context.Context, req *svpb.ImportIndicatorsRequest) (*svpb.ImportIndicatorsResponse, error) {
+	if s.indicators == nil {
+		return nil, status.Error(codes.FailedPrecondition, "indicator import is not configured")
+	}
+	if req.GetSource() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no indicator source")
+	}
+	if len(req.GetLocationZones()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no location zones")
+	}
+	indicators, skipped, err := ioc.Parse(req.GetFormat(), req.GetData())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse indicators from %q: %v", req.GetSource(), err)
+	}
+	res, err := s.indicators.Import(ctx, req.GetSource(), indicators, req.GetLocationZones())
+	// Rules may have been added or modified even if the import failed.
+	s.resetRuleSIDs()
+	if err == ioc.ErrSIDsExhausted {
+		return nil, status.Errorf(codes.ResourceExhausted, "failed to import indicators from %q: %v", req.GetSource(), err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to import indicators from %q: %v", req.GetSource(), err)
+	}
+	return &svpb.ImportIndicatorsResponse{
+		AddedRuleIds:     res.Added,
+		UpdatedRuleIds:   res.Updated,
+		UnchangedRuleIds: res.Unchanged,
+		Skipped:          int32(skipped),
+	}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/server/ioc"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spb "github.com/google/emitto/source/server/proto"
+)
+
+func TestImportIndicators(t *testing.T) {
+	ctx := context.Background()
+	ds := store.NewMemoryStore()
+	s := New(ds, filestore.NewMemoryFileStore(), nil, nil)
+	c, stopServer := initServerAndClient(t, s)
+	defer stopServer()
+
+	req := &spb.ImportIndicatorsRequest{
+		Format:        ioc.FormatCSV,
+		Data:          []byte("type,value\ndomain,evil.example.com\nurl,http://evil.example.com/a\nip,10.0.0.1\n"),
+		Source:        "phishing.csv",
+		LocationZones: []string{"a:dmz"},
+	}
+	if _, err := c.ImportIndicators(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("got err=%v without a generator, want code %v", err, codes.FailedPrecondition)
+	}
+
+	g, err := ioc.NewGenerator(ds, 1000, 1003, ioc.DefaultTemplates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetIndicatorGenerator(g)
+	for _, tt := range []struct {
+		desc string
+		req  *spb.ImportIndicatorsRequest
+		want codes.Code
+	}{
+		{desc: "no source", req: &spb.ImportIndicatorsRequest{Format: ioc.FormatCSV, LocationZones: []string{"a:dmz"}}, want: codes.InvalidArgument},
+		{desc: "no location zones", req: &spb.ImportIndicatorsRequest{Format: ioc.FormatCSV, Source: "a.csv"}, want: codes.InvalidArgument},
+		{desc: "invalid file", req: &spb.ImportIndicatorsRequest{Format: ioc.FormatMISP, Data: []byte("{"), Source: "a.json", LocationZones: []string{"a:dmz"}}, want: codes.InvalidArgument},
+	} {
+		if _, err := c.ImportIndicators(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("%s: got err=%v, want code %v", tt.desc, err, tt.want)
+		}
+	}
+
+	resp, err := c.ImportIndicators(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &spb.ImportIndicatorsResponse{AddedRuleIds: []int64{1000, 1001, 1002, 1003}, Skipped: 1}
+	if diff := cmp.Diff(want, resp, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	resp, err = c.ImportIndicators(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &spb.ImportIndicatorsResponse{UnchangedRuleIds: []int64{1000, 1001, 1002, 1003}, Skipped: 1}
+	if diff := cmp.Diff(want, resp, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// The SID range is full.
+	req.Data = []byte("type,value\nip,10.0.0.2\n")
+	if _, err := c.ImportIndicators(ctx, req); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("got err=%v, want code %v", err, codes.ResourceExhausted)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/notify"
+	"google.golang.org/grpc/codes"
+
+	log "github.com/golang/glog"
+	spb "github.com/google/emitto/source/sensor/proto"
+)
+
+// Bounds of the notifications sent in the background.
+const (
+	// Maximum number of queued notifications. Further notifications are dropped.
+	notificationQueueSize = 1000
+	// Timeout of sending a notification, including retries. Sinks configured with a notify.Router
+	// time out independently, within this bound.
+	notificationTimeout = 5 * time.Minute
+)
+
+// closeNotifier waits for the notifications queued by the notifier, if it queues them.
+func (s *Service) closeNotifier() {
+	if a, ok := s.notifier.(*notify.Async); ok {
+		a.Close()
+	}
+}
+
+// sendNotification notifies operators, logging failures.
+func (s *Service) sendNotification(ctx context.Context, n *notify.Notification) {
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		log.Errorf("Failed to send %s notification (%s): %v", n.Type, n.Subject, err)
+	}
+}
+
+// notifySensorAlert notifies operators of a sensor alert.
+func (s *Service) notifySensorAlert(ctx context.Context, clientID string, a *spb.SensorAlert) {
+	t, err := ptypes.Timestamp(a.GetTime())
+	if err != nil {
+		t = timeNow()
+	}
+	subject := fmt.Sprintf("Sensor alert from client %s", clientID)
+	if a.GetPolicy() != "" {
+		subject = fmt.Sprintf("Sensor alert from client %s (policy %q)", clientID, a.GetPolicy())
+	}
+	var body bytes.Buffer
+	body.WriteString(a.GetStatus().GetMessage())
+	for _, c := range a.GetTopSignatures() {
+		fmt.Fprintf(&body, "\nSignature %s (%s): %d alerts", c.GetKey(), c.GetDescription(), c.GetCount())
+	}
+	for _, c := range a.GetTopHosts() {
+		fmt.Fprintf(&body, "\nHost %s: %d alerts", c.GetKey(), c.GetCount())
+	}
+	s.sendNotification(ctx, &notify.Notification{
+		Time:     t,
+		Type:     notify.SensorAlert,
+		Severity: notify.Warning,
+		Location: a.GetHost().GetOrg(),
+		Zone:     a.GetHost().GetZone(),
+		ClientID: clientID,
+		Subject:  subject,
+		Body:     body.String(),
+	})
+}
+
+// notifyDeployResult notifies operators of the result of a rule deployment reported by a sensor.
+// Responses to other sensor requests are ignored.
+func (s *Service) notifyDeployResult(ctx context.Context, clientID string, resp *spb.SensorResponse, failedRuleIDs []int64) error {
+	req, err := s.store.GetSensorRequest(ctx, resp.GetId())
+	if err != nil {
+		return err
+	}
+	if req.Type != resources.DeployRules {
+		return nil
+	}
+	n := &notify.Notification{
+		Time:     timeNow(),
+		Type:     notify.DeployResult,
+		Location: resp.GetHost().GetOrg(),
+		Zone:     resp.GetHost().GetZone(),
+		ClientID: clientID,
+	}
+	if t, err := ptypes.Timestamp(resp.GetTime()); err == nil {
+		n.Time = t
+	}
+	switch {
+	case codes.Code(resp.GetStatus().GetCode()) != codes.OK:
+		n.Severity = notify.Critical
+		n.Subject = fmt.Sprintf("Rule deployment to client %s failed", clientID)
+	case len(failedRuleIDs) > 0:
+		n.Severity = notify.Warning
+		n.Subject = fmt.Sprintf("Rule deployment to client %s succeeded with %d failed rules", clientID, len(failedRuleIDs))
+	default:
+		n.Severity = notify.Info
+		n.Subject = fmt.Sprintf("Rule deployment to client %s succeeded", clientID)
+	}
+	n.Body = fmt.Sprintf("Rule file %q requested at %s: %s", req.RuleFile, req.Time, resp.GetStatus().GetMessage())
+	if len(failedRuleIDs) > 0 {
+		n.Body += fmt.Sprintf("\nFailed rules: %v", failedRuleIDs)
+	}
+	s.sendNotification(ctx, n)
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/notify"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sensorpb "github.com/google/emitto/source/sensor/proto"
+)
+
+// fakeNotifier records notifications.
+type fakeNotifier struct {
+	notifications []*notify.Notification
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, m *notify.Notification) error {
+	n.notifications = append(n.notifications, m)
+	return nil
+}
+
+func TestNotifications(t *testing.T) {
+	ctx := context.Background()
+	ds := store.NewMemoryStore()
+	if err := ds.AddRule(ctx, &resources.Rule{ID: 1, Body: "alert ip any any -> any any (sid:100;)"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*resources.SensorRequest{
+		{ID: "ok", ClientID: "0A", Type: resources.DeployRules, RuleFile: "a/rules", Time: "Mon, 13 May 2019 13:59:00 +0000"},
+		{ID: "failed", ClientID: "0A", Type: resources.DeployRules, RuleFile: "a/rules", Time: "Mon, 13 May 2019 13:59:00 +0000"},
+		{ID: "partial", ClientID: "0A", Type: resources.DeployRules, RuleFile: "a/rules", Time: "Mon, 13 May 2019 13:59:00 +0000"},
+	} {
+		if err := ds.AddSensorRequest(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := New(ds, filestore.NewMemoryFileStore(), nil, nil)
+	n := &fakeNotifier{}
+	s.SetNotifier(n)
+
+	now := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+	ts, err := ptypes.TimestampProto(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := &sensorpb.Host{Org: "a", Zone: "dmz"}
+	response := func(id string, st *status.Status, failed ...*sensorpb.RuleError) *sensorpb.SensorMessage {
+		return &sensorpb.SensorMessage{
+			Type: &sensorpb.SensorMessage_Response{
+				Response: &sensorpb.SensorResponse{Id: id, Time: ts, Host: host, Status: st.Proto(), FailedRules: failed},
+			},
+		}
+	}
+	for _, msg := range []*sensorpb.SensorMessage{
+		{
+			Type: &sensorpb.SensorMessage_Alert{
+				Alert: &sensorpb.SensorAlert{
+					Time:          ts,
+					Host:          host,
+					Status:        status.New(codes.Internal, "Sensor has detected: 100 alerts in last: 1m0s").Proto(),
+					Policy:        "default",
+					TopSignatures: []*sensorpb.AlertContributor{{Key: "100", Description: "test", Count: 90}},
+					TopHosts:      []*sensorpb.AlertContributor{{Key: "10.0.0.1", Count: 100}},
+				},
+			},
+		},
+		response("ok", status.New(codes.OK, "OK")),
+		response("failed", status.New(codes.InvalidArgument, "invalid rule file")),
+		response("partial", status.New(codes.OK, "OK"), &sensorpb.RuleError{Sid: 100}),
+	} {
+		processSensorMessage(t, s, []byte{0x0a}, msg)
+	}
+
+	want := []*notify.Notification{
+		{
+			Time:     now,
+			Type:     notify.SensorAlert,
+			Severity: notify.Warning,
+			Location: "a",
+			Zone:     "dmz",
+			ClientID: "0A",
+			Subject:  `Sensor alert from client 0A (policy "default")`,
+			Body:     "Sensor has detected: 100 alerts in last: 1m0s\nSignature 100 (test): 90 alerts\nHost 10.0.0.1: 100 alerts",
+		},
+		{
+			Time:     now,
+			Type:     notify.DeployResult,
+			Severity: notify.Info,
+			Location: "a",
+			Zone:     "dmz",
+			ClientID: "0A",
+			Subject:  "Rule deployment to client 0A succeeded",
+			Body:     `Rule file "a/rules" requested at Mon, 13 May 2019 13:59:00 +0000: OK`,
+		},
+		{
+			Time:     now,
+			Type:     notify.DeployResult,
+			Severity: notify.Critical,
+			Location: "a",
+			Zone:     "dmz",
+			ClientID: "0A",
+			Subject:  "Rule deployment to client 0A failed",
+			Body:     `Rule file "a/rules" requested at Mon, 13 May 2019 13:59:00 +0000: invalid rule file`,
+		},
+		{
+			Time:     now,
+			Type:     notify.DeployResult,
+			Severity: notify.Warning,
+			Location: "a",
+			Zone:     "dmz",
+			ClientID: "0A",
+			Subject:  "Rule deployment to client 0A succeeded with 1 failed rules",
+			Body:     "Rule file \"a/rules\" requested at Mon, 13 May 2019 13:59:00 +0000: OK\nFailed rules: [1]",
+		},
+	}
+	// Wait for the notifications sent in the background.
+	s.Close()
+	if diff := cmp.Diff(want, n.notifications); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/emitto/source/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spb "github.com/google/emitto/source/sensor/proto"
+	svpb "github.com/google/emitto/source/server/proto"
+)
+
+// ReloadRules instructs the targeted sensors to reload their rules engine.
+func (s *Service) ReloadRules(req *svpb.ReloadRulesRequest, stream svpb.Emitto_ReloadRulesServer) error {
+	r := &spb.SensorRequest{Type: &spb.SensorRequest_ReloadRules{ReloadRules: &spb.ReloadRules{}}}
+	m := &resources.SensorRequest{Type: resources.ReloadRules}
+	return s.sendOperation(stream.Context(), req.GetLocation(), req.GetClientIds(), m, r, stream.Send)
+}
+
+// CollectDiagnostics instructs the targeted sensors to collect troubleshooting information. The
+// diagnostics reported by the sensors are retrieved with GetSensorRequests.
+func (s *Service) CollectDiagnostics(req *svpb.CollectDiagnosticsRequest, stream svpb.Emitto_CollectDiagnosticsServer) error {
+	r := &spb.SensorRequest{Type: &spb.SensorRequest_CollectDiagnostics{
+		CollectDiagnostics: &spb.CollectDiagnostics{LogLines: req.GetLogLines()},
+	}}
+	m := &resources.SensorRequest{Type: resources.CollectDiagnostics}
+	return s.sendOperation(stream.Context(), req.GetLocation(), req.GetClientIds(), m, r, stream.Send)
+}
+
+// RunSensorCommand instructs the targeted sensors to run a Suricata Unix socket command. Sensors
+// reject the commands missing from their allow-list. The command results reported by the sensors
+// are retrieved with GetSensorRequests.
+func (s *Service) RunSensorCommand(req *svpb.RunSensorCommandRequest, stream svpb.Emitto_RunSensorCommandServer) error {
+	if req.GetCommand() == "" {
+		return status.Error(codes.InvalidArgument, "no command provided")
+	}
+	r := &spb.SensorRequest{Type: &spb.SensorRequest_RunCommand{
+		RunCommand: &spb.RunCommand{Name: req.GetCommand(), Args: req.GetArgs()},
+	}}
+	m := &resources.SensorRequest{Type: resources.RunCommand, Command: req.GetCommand()}
+	return s.sendOperation(stream.Context(), req.GetLocation(), req.GetClientIds(), m, r, stream.Send)
+}
+
+// GetSensorRequests returns the sensor requests with the provided IDs.
+func (s *Service) GetSensorRequests(ctx context.Context, req *svpb.GetSensorRequestsRequest) (*svpb.GetSensorRequestsResponse, error) {
+	resp := &svpb.GetSensorRequestsResponse{}
+	for _, id := range req.GetRequestIds() {
+		r, err := s.store.GetSensorRequest(ctx, id)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "failed to get sensor request %q: %v", id, err)
+		}
+		resp.Requests = append(resp.Requests, resources.SensorRequestToProto(r))
+	}
+	return resp, nil
+}
+
+// sendOperation sends a sensor request, logged as described by tmpl, to every targeted sensor,
+// calling send with the result for every sensor.
+func (s *Service) sendOperation(ctx context.Context, loc *svpb.Location, clientIDs []string, tmpl *resources.SensorRequest, r *spb.SensorRequest, send func(*svpb.SensorRequestResponse) error) error {
+	ids, err := s.targetClients(ctx, loc, clientIDs)
+	if err != nil {
+		return err
+	}
+	return s.sendRequests(ctx, ids, tmpl, r, send)
+}
+
+// sendRequests sends a sensor request, logged as described by tmpl, to the clients, calling send
+// with the result for every client.
+func (s *Service) sendRequests(ctx context.Context, ids [][]byte, tmpl *resources.SensorRequest, r *spb.SensorRequest, send func(*svpb.SensorRequestResponse) error) error {
+	for _, id := range ids {
+		m := *tmpl
+		st := s.sendSensorRequest(ctx, id, &m, proto.Clone(r).(*spb.SensorRequest))
+		resp := &svpb.SensorRequestResponse{
+			ClientId: fmt.Sprintf("%X", id),
+			Status:   st.Proto(),
+		}
+		if st.Code() == codes.OK {
+			resp.RequestId = m.ID
+		}
+		if err := send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// targetClients returns the IDs of the provided clients (Hex-encoded bytes), or else of the clients
+// in the location.
+func (s *Service) targetClients(ctx context.Context, loc *svpb.Location, clientIDs []string) ([][]byte, error) {
+	if len(clientIDs) > 0 {
+		var ids [][]byte
+		for _, c := range clientIDs {
+			id, err := hex.DecodeString(c)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid client ID %q: %v", c, err)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+	if loc.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no location or client IDs provided")
+	}
+	clients, err := s.fleetspeak.ListClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := getClientIDsByLocation(clients, loc)
+	if len(ids) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "no clients for location: %v", loc)
+	}
+	return ids, nil
+}
@@ -0,0 +1,304 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sensorpb "github.com/google/emitto/source/sensor/proto"
+	spb "github.com/google/emitto/source/server/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+	fsspb "github.com/google/fleetspeak/fleetspeak/src/server/proto/fleetspeak_server"
+)
+
+// recvAll returns the responses of a SensorRequestResponse stream.
+func recvAll(t *testing.T, recv func() (*spb.SensorRequestResponse, error)) ([]*spb.SensorRequestResponse, error) {
+	t.Helper()
+	var got []*spb.SensorRequestResponse
+	for {
+		r, err := recv()
+		if err == io.EOF {
+			return got, nil
+		}
+		if err != nil {
+			return got, err
+		}
+		got = append(got, r)
+	}
+}
+
+func TestReloadRules(t *testing.T) {
+	ctx := context.Background()
+	var inserted []*sensorpb.SensorRequest
+	fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+		listClients: func(*fsspb.ListClientsRequest) (*fsspb.ListClientsResponse, error) {
+			return &fsspb.ListClientsResponse{Clients: testClients}, nil
+		},
+		insertMessage: func(m *fspb.Message) (*fspb.EmptyMessage, error) {
+			var req sensorpb.SensorRequest
+			if err := ptypes.UnmarshalAny(m.GetData(), &req); err != nil {
+				return nil, err
+			}
+			inserted = append(inserted, &req)
+			return &fspb.EmptyMessage{}, nil
+		},
+	})
+	defer fc.Close()
+	defer stopFs()
+	ds := store.NewMemoryStore()
+	c, stopServer := initServerAndClient(t, New(ds, filestore.NewMemoryFileStore(), fc, nil))
+	defer stopServer()
+
+	tests := []struct {
+		desc    string
+		req     *spb.ReloadRulesRequest
+		want    []string
+		wantErr codes.Code
+	}{
+		{
+			desc: "location",
+			req:  &spb.ReloadRulesRequest{Location: &spb.Location{Name: "a", Zones: []string{"dmz"}}},
+			want: []string{"636C69656E745F61", "636C69656E745F62"},
+		},
+		{
+			desc: "client IDs",
+			req: &spb.ReloadRulesRequest{
+				Location:  &spb.Location{Name: "a", Zones: []string{"dmz"}},
+				ClientIds: []string{"636C69656E745F62"},
+			},
+			want: []string{"636C69656E745F62"},
+		},
+		{
+			desc:    "invalid client ID",
+			req:     &spb.ReloadRulesRequest{ClientIds: []string{"client_b"}},
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			desc:    "no target",
+			req:     &spb.ReloadRulesRequest{},
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			desc:    "no clients in location",
+			req:     &spb.ReloadRulesRequest{Location: &spb.Location{Name: "unknown", Zones: []string{"dmz"}}},
+			wantErr: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inserted = nil
+			stream, err := c.ReloadRules(ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := recvAll(t, stream.Recv)
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("got err=%v, want code %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var ids []string
+			for i, r := range got {
+				ids = append(ids, r.GetClientId())
+				if r.GetStatus().GetCode() != int32(codes.OK) {
+					t.Errorf("got status %v for client %s", r.GetStatus(), r.GetClientId())
+				}
+				if inserted[i].GetReloadRules() == nil || inserted[i].GetId() != r.GetRequestId() {
+					t.Errorf("got inserted request %v, want ReloadRules request %q", inserted[i], r.GetRequestId())
+				}
+				req, err := ds.GetSensorRequest(ctx, r.GetRequestId())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if req.Type != resources.ReloadRules || req.ClientID != r.GetClientId() {
+					t.Errorf("got sensor request %+v, want a ReloadRules request for client %s", req, r.GetClientId())
+				}
+			}
+			if diff := cmp.Diff(tt.want, ids); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	var inserted []*sensorpb.SensorRequest
+	fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+		insertMessage: func(m *fspb.Message) (*fspb.EmptyMessage, error) {
+			var req sensorpb.SensorRequest
+			if err := ptypes.UnmarshalAny(m.GetData(), &req); err != nil {
+				return nil, err
+			}
+			inserted = append(inserted, &req)
+			return &fspb.EmptyMessage{}, nil
+		},
+	})
+	defer fc.Close()
+	defer stopFs()
+	ds := store.NewMemoryStore()
+	s := New(ds, filestore.NewMemoryFileStore(), fc, nil)
+	c, stopServer := initServerAndClient(t, s)
+	defer stopServer()
+
+	stream, err := c.CollectDiagnostics(ctx, &spb.CollectDiagnosticsRequest{ClientIds: []string{"636C69656E745F61"}, LogLines: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := recvAll(t, stream.Recv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || len(inserted) != 1 {
+		t.Fatalf("got responses %v and inserted requests %v, want one each", got, inserted)
+	}
+	id := got[0].GetRequestId()
+	if n := inserted[0].GetCollectDiagnostics().GetLogLines(); n != 5 {
+		t.Errorf("got %d requested log lines, want 5", n)
+	}
+
+	// The sensor reports its diagnostics.
+	diag := &sensorpb.Diagnostics{SuricataVersion: "4.1.4", SuricataLog: []string{"started"}}
+	data, err := ptypes.MarshalAny(&sensorpb.SensorMessage{
+		Type: &sensorpb.SensorMessage_Response{
+			Response: &sensorpb.SensorResponse{
+				Id:          id,
+				Status:      status.New(codes.OK, "OK").Proto(),
+				Diagnostics: diag,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Process(ctx, &fspb.Message{Data: data}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.GetSensorRequests(ctx, &spb.GetSensorRequestsRequest{RequestIds: []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetRequests()) != 1 {
+		t.Fatalf("got %d sensor requests, want 1", len(resp.GetRequests()))
+	}
+	r := resp.GetRequests()[0]
+	r.Time = ""
+	want := &spb.SensorRequest{
+		Id:          id,
+		ClientId:    "636C69656E745F61",
+		Type:        string(resources.CollectDiagnostics),
+		Status:      `message:"OK" `,
+		Diagnostics: diag.String(),
+	}
+	if diff := cmp.Diff(want, r, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.GetSensorRequests(ctx, &spb.GetSensorRequestsRequest{RequestIds: []string{"unknown"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("got err=%v for an unknown request, want code %v", err, codes.NotFound)
+	}
+}
+
+func TestRunSensorCommand(t *testing.T) {
+	ctx := context.Background()
+	var inserted []*sensorpb.SensorRequest
+	fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+		insertMessage: func(m *fspb.Message) (*fspb.EmptyMessage, error) {
+			var req sensorpb.SensorRequest
+			if err := ptypes.UnmarshalAny(m.GetData(), &req); err != nil {
+				return nil, err
+			}
+			inserted = append(inserted, &req)
+			return &fspb.EmptyMessage{}, nil
+		},
+	})
+	defer fc.Close()
+	defer stopFs()
+	ds := store.NewMemoryStore()
+	s := New(ds, filestore.NewMemoryFileStore(), fc, nil)
+	c, stopServer := initServerAndClient(t, s)
+	defer stopServer()
+
+	stream, err := c.RunSensorCommand(ctx, &spb.RunSensorCommandRequest{ClientIds: []string{"636C69656E745F61"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recvAll(t, stream.Recv); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got err=%v without a command, want code %v", err, codes.InvalidArgument)
+	}
+
+	args := map[string]string{"iface": "eth0"}
+	stream, err = c.RunSensorCommand(ctx, &spb.RunSensorCommandRequest{
+		ClientIds: []string{"636C69656E745F61"},
+		Command:   "iface-stat",
+		Args:      args,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := recvAll(t, stream.Recv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || len(inserted) != 1 {
+		t.Fatalf("got responses %v and inserted requests %v, want one each", got, inserted)
+	}
+	want := &sensorpb.RunCommand{Name: "iface-stat", Args: args}
+	if diff := cmp.Diff(want, inserted[0].GetRunCommand(), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// The sensor reports the command result.
+	id := got[0].GetRequestId()
+	data, err := ptypes.MarshalAny(&sensorpb.SensorMessage{
+		Type: &sensorpb.SensorMessage_Response{
+			Response: &sensorpb.SensorResponse{
+				Id:            id,
+				Status:        status.New(codes.OK, "OK").Proto(),
+				CommandResult: `{"pkts":10}`,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Process(ctx, &fspb.Message{Data: data}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.GetSensorRequests(ctx, &spb.GetSensorRequestsRequest{RequestIds: []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetRequests()) != 1 {
+		t.Fatalf("got %d sensor requests, want 1", len(resp.GetRequests()))
+	}
+	r := resp.GetRequests()[0]
+	if r.GetType() != string(resources.RunCommand) || r.GetCommand() != "iface-stat" || r.GetCommandResult() != `{"pkts":10}` {
+		t.Errorf("got sensor request %v, want the iface-stat result", r)
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/notify"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	svpb "github.com/google/emitto/source/server/proto"
+)
+
+// quarantineActor is the audit event actor of automatic quarantines.
+const quarantineActor = "quarantine"
+
+// QuarantinePolicy defines when and how noisy rules are quarantined.
+type QuarantinePolicy struct {
+	// A rule is quarantined in a location zone once it reports more than Threshold hits within
+	// Window.
+	Threshold int64
+	Window    time.Duration
+	// Quarantine action.
+	Action resources.QuarantineAction
+}
+
+// quarantineKey identifies a rule in a location zone.
+type quarantineKey struct {
+	ruleID         int64
+	location, zone string
+}
+
+// checkQuarantine quarantines the rules of the reported hits which exceed the quarantine threshold
+// in the location zone of the sensor.
+func (s *Service) checkQuarantine(ctx context.Context, hits []*resources.RuleHits) error {
+	p := s.quarantine
+	if p == nil {
+		return nil
+	}
+	quarantines, err := s.store.ListQuarantines(ctx)
+	if err != nil {
+		return err
+	}
+	checked := make(map[quarantineKey]bool)
+	for _, q := range quarantines {
+		if q.Active {
+			checked[quarantineKey{q.RuleID, q.Location, q.Zone}] = true
+		}
+	}
+	for _, h := range hits {
+		k := quarantineKey{h.RuleID, h.Location, h.Zone}
+		if h.RuleID == 0 || checked[k] {
+			continue
+		}
+		checked[k] = true
+		recent, err := s.store.ListRuleHits(ctx, &resources.RuleHitsFilter{
+			RuleIDs:  []int64{h.RuleID},
+			Location: h.Location,
+			Zone:     h.Zone,
+			Since:    timeNow().Add(-p.Window),
+		})
+		if err != nil {
+			return err
+		}
+		var n int64
+		for _, r := range recent {
+			n += r.Count
+		}
+		if n <= p.Threshold {
+			continue
+		}
+		unlock := s.lockQuarantine(k)
+		err = s.quarantineRule(ctx, k, n)
+		unlock()
+		if err != nil {
+			log.Errorf("Failed to quarantine rule %d in %s:%s: %v", k.ruleID, k.location, k.zone, err)
+		}
+	}
+	return nil
+}
+
+// lockQuarantine serializes the quarantine changes of a rule in a location zone, returning the
+// function releasing the lock.
+func (s *Service) lockQuarantine(k quarantineKey) func() {
+	s.quarantineMu.Lock()
+	if s.quarantineLocks == nil {
+		s.quarantineLocks = make(map[quarantineKey]*sync.Mutex)
+	}
+	l, ok := s.quarantineLocks[k]
+	if !ok {
+		l = new(sync.Mutex)
+		s.quarantineLocks[k] = l
+	}
+	s.quarantineMu.Unlock()
+	l.Lock()
+	return l.Unlock
+}
+
+// quarantineRule quarantines a rule in a location zone according to the quarantine policy, and
+// redeploys rules to the location zone. It is a no-op if the rule is already quarantined, e.g. by
+// a concurrent check. The caller must hold the lock of the quarantine key.
+func (s *Service) quarantineRule(ctx context.Context, k quarantineKey, hits int64) error {
+	quarantines, err := s.store.ListQuarantines(ctx)
+	if err != nil {
+		return err
+	}
+	for _, q := range quarantines {
+		if q.Active && (quarantineKey{q.RuleID, q.Location, q.Zone}) == k {
+			return nil
+		}
+	}
+	p := s.quarantine
+	q := &resources.Quarantine{
+		ID:       uuid.New().String(),
+		RuleID:   k.ruleID,
+		Location: k.location,
+		Zone:     k.zone,
+		Action:   p.Action,
+		Hits:     hits,
+		Window:   p.Window.String(),
+		Time:     timeNow().Format(time.RFC1123Z),
+		Active:   true,
+	}
+	switch p.Action {
+	case resources.QuarantineRemove:
+		if err := s.modifyLocZones(ctx, k, false); err != nil {
+			return err
+		}
+	case resources.QuarantineSuppress:
+	default:
+		return fmt.Errorf("unsupported quarantine action %q", p.Action)
+	}
+	if err := s.store.PutQuarantine(ctx, q); err != nil {
+		return err
+	}
+	s.audit(ctx, notify.QuarantineRule, notify.Warning, k, fmt.Sprintf("Rule %d reported %d hits in %s:%s within %s; quarantine %s (%s)", k.ruleID, hits, k.location, k.zone, q.Window, q.ID, q.Action))
+	s.redeployZone(ctx, k)
+	return nil
+}
+
+// modifyLocZones removes the location zone from the LocZones of a rule, or adds it back.
+func (s *Service) modifyLocZones(ctx context.Context, k quarantineKey, add bool) error {
+	rules, err := s.store.ListRules(ctx, []int64{k.ruleID})
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return status.Errorf(codes.NotFound, "rule %d not found", k.ruleID)
+	}
+	r := rules[0]
+	lz := k.location + ":" + k.zone
+	// A non-nil slice, so that removing the last location zone is applied by the Store.
+	locZones := []string{}
+	var found bool
+	for _, z := range r.LocZones {
+		if z == lz {
+			found = true
+			if !add {
+				continue
+			}
+		}
+		locZones = append(locZones, z)
+	}
+	if add && !found {
+		locZones = append(locZones, lz)
+	}
+	return s.store.ModifyRule(ctx, &resources.Rule{ID: r.ID, LocZones: locZones})
+}
+
+// audit records an audit event and notifies operators.
+func (s *Service) audit(ctx context.Context, action string, severity notify.Severity, k quarantineKey, details string) {
+	e := &resources.AuditEvent{
+		ID:       uuid.New().String(),
+		Time:     timeNow(),
+		Actor:    quarantineActor,
+		Action:   action,
+		RuleID:   k.ruleID,
+		Location: k.location,
+		Zone:     k.zone,
+		Details:  details,
+	}
+	if err := s.store.AddAuditEvent(ctx, e); err != nil {
+		log.Errorf("Failed to record audit event (%+v): %v", e, err)
+	}
+	s.sendNotification(ctx, &notify.Notification{
+		Time:     e.Time,
+		Type:     action,
+		Severity: severity,
+		Location: k.location,
+		Zone:     k.zone,
+		Subject:  fmt.Sprintf("Emitto %s: rule %d in %s:%s", strings.Replace(action, "_", " ", -1), k.ruleID, k.location, k.zone),
+		Body:     details,
+	})
+}
+
+// redeployZone deploys rules to the location zone, returning the results per sensor.
+func (s *Service) redeployZone(ctx context.Context, k quarantineKey) []*svpb.DeployRulesResponse {
+	var resps []*svpb.DeployRulesResponse
+	loc := &svpb.Location{Name: k.location, Zones: []string{k.zone}}
+	if err := s.deployLocation(ctx, loc, func(resp *svpb.DeployRulesResponse) error {
+		if codes.Code(resp.GetStatus().GetCode()) != codes.OK {
+			log.Errorf("Failed to redeploy rules to client %s: %v", resp.GetClientId(), resp.GetStatus())
+		}
+		resps = append(resps, resp)
+		return nil
+	}); err != nil {
+		log.Errorf("Failed to redeploy rules to %s:%s: %v", k.location, k.zone, err)
+	}
+	return resps
+}
+
+// thresholdRE matches the threshold option of a rule.
+var thresholdRE = regexp.MustCompile(`\s*threshold\s*:[^;]*;`)
+
+// suppressRule replaces the threshold of a rule body with one limiting the rule to a single alert
+// per window.
+func suppressRule(body string, window time.Duration) string {
+	body = thresholdRE.ReplaceAllString(body, "")
+	i := strings.LastIndex(body, ")")
+	if i < 0 {
+		return body
+	}
+	opt := fmt.Sprintf("threshold: type limit, track by_rule, count 1, seconds %d;", int64(window/time.Second))
+	return strings.TrimRight(body[:i], " ") + " " + opt + body[i:]
+}
+
+// suppressQuarantined returns the rules to deploy to the location zone, with a threshold added to
+// the rules with an active suppress quarantine in the zone.
+func (s *Service) suppressQuarantined(ctx context.Context, rules []*resources.Rule, zone *svpb.Location) ([]*resources.Rule, error) {
+	quarantines, err := s.store.ListQuarantines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suppressed := make(map[int64]time.Duration)
+	for _, q := range quarantines {
+		if !q.Active || q.Action != resources.QuarantineSuppress || q.Location != zone.GetName() || q.Zone != zone.GetZones()[0] {
+			continue
+		}
+		w, err := time.ParseDuration(q.Window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window of quarantine %s: %v", q.ID, err)
+		}
+		suppressed[q.RuleID] = w
+	}
+	if len(suppressed) == 0 {
+		return rules, nil
+	}
+	res := make([]*resources.Rule, 0, len(rules))
+	for _, r := range rules {
+		if w, ok := suppressed[r.ID]; ok {
+			cp := *r
+			cp.Body = suppressRule(r.Body, w)
+			r = &cp
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// ListQuarantines lists rule quarantines.
+func (s *Service) ListQuarantines(ctx context.Context, req *svpb.ListQuarantinesRequest) (*svpb.ListQuarantinesResponse, error) {
+	quarantines, err := s.store.ListQuarantines(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list quarantines: %v", err)
+	}
+	resp := &svpb.ListQuarantinesResponse{}
+	for _, q := range quarantines {
+		if req.GetActiveOnly() && !q.Active {
+			continue
+		}
+		resp.Quarantines = append(resp.Quarantines, resources.QuarantineToProto(q))
+	}
+	return resp, nil
+}
+
+// UndoQuarantine undoes a rule quarantine and redeploys rules to the location zone.
+func (s *Service) UndoQuarantine(ctx context.Context, req *svpb.UndoQuarantineRequest) (*svpb.UndoQuarantineResponse, error) {
+	q, err := s.store.GetQuarantine(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get quarantine %q: %v", req.GetId(), err)
+	}
+	k := quarantineKey{q.RuleID, q.Location, q.Zone}
+	unlock := s.lockQuarantine(k)
+	defer unlock()
+	// The quarantine is read again under the lock, since it may have been undone concurrently.
+	if q, err = s.store.GetQuarantine(ctx, req.GetId()); err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get quarantine %q: %v", req.GetId(), err)
+	}
+	if !q.Active {
+		return nil, status.Errorf(codes.FailedPrecondition, "quarantine %q was already undone", q.ID)
+	}
+	if q.Action == resources.QuarantineRemove {
+		if err := s.modifyLocZones(ctx, k, true); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to restore rule %d: %v", q.RuleID, err)
+		}
+	}
+	q.Active = false
+	q.UndoTime = timeNow().Format(time.RFC1123Z)
+	if err := s.store.PutQuarantine(ctx, q); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update quarantine %q: %v", q.ID, err)
+	}
+	s.audit(ctx, notify.UndoQuarantine, notify.Info, k, fmt.Sprintf("Quarantine %s (%s) of rule %d in %s:%s was undone", q.ID, q.Action, q.RuleID, q.Location, q.Zone))
+	return &svpb.UndoQuarantineResponse{Redeployments: s.redeployZone(ctx, k)}, nil
+}
+
+// ListAuditEvents lists all audit events.
+func (s *Service) ListAuditEvents(ctx context.Context, req *svpb.ListAuditEventsRequest) (*svpb.ListAuditEventsResponse, error) {
+	events, err := s.store.ListAuditEvents(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list audit events: %v", err)
+	}
+	resp := &svpb.ListAuditEventsResponse{}
+	for _, e := range events {
+		resp.Events = append(resp.Events, resources.AuditEventToProto(e))
+	}
+	return resp, nil
+}
@@ -0,0 +1,327 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/emitto/source/filestore"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sensorpb "github.com/google/emitto/source/sensor/proto"
+	spb "github.com/google/emitto/source/server/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+	fsspb "github.com/google/fleetspeak/fleetspeak/src/server/proto/fleetspeak_server"
+)
+
+// deployedRules returns the sorted rules of the last rule file deployed to the zone of location "a".
+func deployedRules(t *testing.T, fs *filestore.MemoryFileStore, zone string) []string {
+	t.Helper()
+	ctx := context.Background()
+	path := ruleFilepath("a", zone)
+	ruleFile, err := fs.GetRuleFile(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.DeleteRuleFile(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+	rules := strings.Split(strings.TrimSpace(string(ruleFile)), "\n")
+	sort.Strings(rules)
+	return rules
+}
+
+func TestSuppressRule(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		body string
+		want string
+	}{
+		{
+			desc: "without threshold",
+			body: `alert ip any any -> any any (msg:"test"; sid:1;)`,
+			want: `alert ip any any -> any any (msg:"test"; sid:1; threshold: type limit, track by_rule, count 1, seconds 3600;)`,
+		},
+		{
+			desc: "with threshold",
+			body: `alert ip any any -> any any (msg:"test"; threshold: type both, track by_src, count 5, seconds 60; sid:1;)`,
+			want: `alert ip any any -> any any (msg:"test"; sid:1; threshold: type limit, track by_rule, count 1, seconds 3600;)`,
+		},
+	} {
+		if got := suppressRule(tt.body, time.Hour); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestQuarantine(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		desc string
+		// Action of the quarantine policy.
+		action resources.QuarantineAction
+		// Expected rule file deployed to location zone "a:dmz" while rule 1 is quarantined.
+		wantRules []string
+		// Expected LocZones of rule 1 while it is quarantined.
+		wantLocZones []string
+	}{
+		{
+			desc:         "remove",
+			action:       resources.QuarantineRemove,
+			wantRules:    []string{"alert ip any any -> any any (sid:1002;)"},
+			wantLocZones: []string{"a:corp"},
+		},
+		{
+			desc:   "suppress",
+			action: resources.QuarantineSuppress,
+			wantRules: []string{
+				"alert ip any any -> any any (sid:1001; threshold: type limit, track by_rule, count 1, seconds 3600;)",
+				"alert ip any any -> any any (sid:1002;)",
+			},
+			wantLocZones: []string{"a:corp", "a:dmz"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			now := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+			timeNow = func() time.Time { return now }
+			ds := store.NewMemoryStore()
+			for _, r := range []*resources.Rule{
+				{ID: 1, Body: "alert ip any any -> any any (sid:1001;)", LocZones: []string{"a:corp", "a:dmz"}},
+				{ID: 2, Body: "alert ip any any -> any any (sid:1002;)", LocZones: []string{"a:dmz"}},
+			} {
+				if err := ds.AddRule(ctx, r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			fs := filestore.NewMemoryFileStore()
+			var inserted int
+			fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+				listClients: func(*fsspb.ListClientsRequest) (*fsspb.ListClientsResponse, error) {
+					return &fsspb.ListClientsResponse{Clients: testClients}, nil
+				},
+				insertMessage: func(*fspb.Message) (*fspb.EmptyMessage, error) {
+					inserted++
+					return &fspb.EmptyMessage{}, nil
+				},
+			})
+			defer fc.Close()
+			defer stopFs()
+			n := &fakeNotifier{}
+			s := New(ds, fs, fc, nil)
+			s.SetNotifier(n)
+			s.SetQuarantinePolicy(&QuarantinePolicy{Threshold: 100, Window: time.Hour, Action: tt.action})
+
+			hits := func(id string, count int64) {
+				m := ruleHitsMessage(t, id, "dmz", now.Add(-30*time.Minute), &sensorpb.RuleHit{SignatureId: 1001, Rev: 1, Count: count})
+				m.GetRuleHits().Host.Org = "a"
+				processSensorMessage(t, s, []byte("client_a"), m)
+			}
+			// Hits up to the threshold, or outside the window, do not quarantine the rule.
+			old := ruleHitsMessage(t, "msg0", "dmz", now.Add(-3*time.Hour), &sensorpb.RuleHit{SignatureId: 1001, Rev: 1, Count: 1000})
+			old.GetRuleHits().Host.Org = "a"
+			processSensorMessage(t, s, []byte("client_a"), old)
+			hits("msg1", 60)
+			hits("msg2", 40)
+			resp, err := s.ListQuarantines(ctx, &spb.ListQuarantinesRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.GetQuarantines()) != 0 {
+				t.Fatalf("got %d quarantines below the threshold, want 0", len(resp.GetQuarantines()))
+			}
+
+			hits("msg3", 1)
+			// Further hits do not quarantine the rule again.
+			hits("msg4", 1000)
+			resp, err = s.ListQuarantines(ctx, &spb.ListQuarantinesRequest{ActiveOnly: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.GetQuarantines()) != 1 {
+				t.Fatalf("got %d quarantines, want 1", len(resp.GetQuarantines()))
+			}
+			q := resp.GetQuarantines()[0]
+			want := &spb.Quarantine{
+				Id:       q.GetId(),
+				RuleId:   1,
+				Location: "a",
+				Zone:     "dmz",
+				Action:   string(tt.action),
+				Hits:     101,
+				Window:   "1h0m0s",
+				Time:     "Mon, 13 May 2019 14:00:00 +0000",
+				Active:   true,
+			}
+			if diff := cmp.Diff(want, q); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			// Rules were redeployed to both "a:dmz" clients.
+			if inserted != 2 {
+				t.Errorf("got %d deployments, want 2", inserted)
+			}
+			if diff := cmp.Diff(tt.wantRules, deployedRules(t, fs, "dmz")); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			rules, err := ds.ListRules(ctx, []int64{1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantLocZones, rules[0].LocZones); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+
+			// Deploying to several zones of the location keeps the quarantine to its zone.
+			var deployed []string
+			if err := s.deployLocation(ctx, &spb.Location{Name: "a", Zones: []string{"dmz", "corp"}}, func(resp *spb.DeployRulesResponse) error {
+				if codes.Code(resp.GetStatus().GetCode()) != codes.OK {
+					t.Errorf("deployment to client %s got %v", resp.GetClientId(), resp.GetStatus())
+				}
+				deployed = append(deployed, resp.GetClientId())
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			// "client_a", "client_b" and "client_c".
+			if diff := cmp.Diff([]string{"636C69656E745F61", "636C69656E745F62", "636C69656E745F63"}, deployed); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRules, deployedRules(t, fs, "dmz")); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{"alert ip any any -> any any (sid:1001;)"}, deployedRules(t, fs, "corp")); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+
+			now = now.Add(time.Minute)
+			undo, err := s.UndoQuarantine(ctx, &spb.UndoQuarantineRequest{Id: q.GetId()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(undo.GetRedeployments()) != 2 {
+				t.Errorf("got %d redeployments, want 2", len(undo.GetRedeployments()))
+			}
+			if diff := cmp.Diff([]string{"alert ip any any -> any any (sid:1001;)", "alert ip any any -> any any (sid:1002;)"}, deployedRules(t, fs, "dmz")); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			rules, err = ds.ListRules(ctx, []int64{1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff([]string{"a:corp", "a:dmz"}, rules[0].LocZones); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			if _, err := s.UndoQuarantine(ctx, &spb.UndoQuarantineRequest{Id: q.GetId()}); status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("got err=%v for an undone quarantine, want %v", err, codes.FailedPrecondition)
+			}
+			if _, err := s.UndoQuarantine(ctx, &spb.UndoQuarantineRequest{Id: "unknown"}); status.Code(err) != codes.NotFound {
+				t.Errorf("got err=%v for an unknown quarantine, want %v", err, codes.NotFound)
+			}
+
+			events, err := s.ListAuditEvents(ctx, &spb.ListAuditEventsRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var actions []string
+			for _, e := range events.GetEvents() {
+				actions = append(actions, e.GetAction())
+			}
+			if diff := cmp.Diff([]string{"quarantine_rule", "undo_quarantine"}, actions); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+			s.Close()
+			if len(n.notifications) != 2 {
+				t.Errorf("got %d notifications, want 2", len(n.notifications))
+			}
+		})
+	}
+}
+
+// slowRuleStore delays rule modifications, widening the window for concurrent checks to quarantine
+// a rule twice.
+type slowRuleStore struct {
+	store.Store
+}
+
+func (s slowRuleStore) ModifyRule(ctx context.Context, r *resources.Rule) error {
+	time.Sleep(time.Millisecond)
+	return s.Store.ModifyRule(ctx, r)
+}
+
+func TestCheckQuarantineConcurrently(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	ds := store.NewMemoryStore()
+	if err := ds.AddRule(ctx, &resources.Rule{ID: 1, Body: "alert ip any any -> any any (sid:1001;)", LocZones: []string{"a:dmz"}}); err != nil {
+		t.Fatal(err)
+	}
+	hits := []*resources.RuleHits{{ID: "h1", RuleID: 1, Location: "a", Zone: "dmz", End: now, Count: 1000}}
+	if err := ds.AddRuleHits(ctx, hits); err != nil {
+		t.Fatal(err)
+	}
+	fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+		listClients: func(*fsspb.ListClientsRequest) (*fsspb.ListClientsResponse, error) {
+			return &fsspb.ListClientsResponse{}, nil
+		},
+	})
+	defer fc.Close()
+	defer stopFs()
+	s := New(slowRuleStore{ds}, filestore.NewMemoryFileStore(), fc, nil)
+	s.SetQuarantinePolicy(&QuarantinePolicy{Threshold: 100, Window: time.Hour, Action: resources.QuarantineRemove})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.checkQuarantine(ctx, hits); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	quarantines, err := ds.ListQuarantines(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quarantines) != 1 {
+		t.Errorf("got %d quarantines, want 1", len(quarantines))
+	}
+}
+
+// noRulesStore finds no rules.
+type noRulesStore struct {
+	store.Store
+}
+
+func (noRulesStore) ListRules(context.Context, []int64) ([]*resources.Rule, error) {
+	return nil, nil
+}
+
+func TestModifyLocZonesUnknownRule(t *testing.T) {
+	s := New(noRulesStore{store.NewMemoryStore()}, nil, nil, nil)
+	if err := s.modifyLocZones(context.Background(), quarantineKey{1, "a", "dmz"}, false); status.Code(err) != codes.NotFound {
+		t.Errorf("got err=%v for an unknown rule, want %v", err, codes.NotFound)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/emitto/source/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	spb "github.com/google/emitto/source/sensor/proto"
+	svpb "github.com/google/emitto/source/server/proto"
+)
+
+// defaultNoisiestRules is the number of noisiest rules reported when none is requested.
+const defaultNoisiestRules = 10
+
+// storeRuleHits stores the rule hits reported by a sensor, mapping their signature IDs back to rule
+// IDs, and quarantines the rules which flood the location zone of the sensor.
+func (s *Service) storeRuleHits(ctx context.Context, clientID string, msg *spb.SensorMessage) error {
+	hits := resources.ProtoToRuleHits(clientID, msg)
+	if len(hits) == 0 {
+		return nil
+	}
+	sids, err := s.ruleSIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, h := range hits {
+		id, ok := sids[h.SignatureID]
+		if !ok {
+			log.Warningf("No rule found for rule hits (sid=%d) of client %s", h.SignatureID, clientID)
+		}
+		h.RuleID = id
+	}
+	if err := s.store.AddRuleHits(ctx, hits); err != nil {
+		return err
+	}
+	return s.checkQuarantine(ctx, hits)
+}
+
+// ruleStats accumulates the hits of a rule.
+type ruleStats struct {
+	stats   *svpb.RuleStats
+	buckets map[time.Time]int64
+}
+
+func (r *ruleStats) add(h *resources.RuleHits, interval time.Duration) {
+	r.stats.Hits += h.Count
+	if interval > 0 {
+		r.buckets[h.Start.UTC().Truncate(interval)] += h.Count
+	}
+}
+
+// proto returns the rule statistics with their buckets ordered by start.
+func (r *ruleStats) proto() *svpb.RuleStats {
+	starts := make([]time.Time, 0, len(r.buckets))
+	for t := range r.buckets {
+		starts = append(starts, t)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	for _, t := range starts {
+		r.stats.Buckets = append(r.stats.Buckets, &svpb.RuleHitsBucket{
+			Start: t.Format(time.RFC1123Z),
+			Hits:  r.buckets[t],
+		})
+	}
+	return r.stats
+}
+
+// GetRuleStats gets the hits per rule, location and zone reported by sensors, and reports the rules
+// which never fired and the noisiest rules.
+func (s *Service) GetRuleStats(ctx context.Context, req *svpb.GetRuleStatsRequest) (*svpb.GetRuleStatsResponse, error) {
+	f := &resources.RuleHitsFilter{
+		RuleIDs:  req.GetRuleIds(),
+		Location: req.GetLocation(),
+		Zone:     req.GetZone(),
+	}
+	for _, t := range []struct {
+		value string
+		dst   *time.Time
+	}{{req.GetSince(), &f.Since}, {req.GetUntil(), &f.Until}} {
+		if t.value == "" {
+			continue
+		}
+		v, err := time.Parse(time.RFC3339, t.value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid time %q: %v", t.value, err)
+		}
+		*t.dst = v
+	}
+	var interval time.Duration
+	if req.GetInterval() != "" {
+		d, err := time.ParseDuration(req.GetInterval())
+		if err != nil || d <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid interval %q", req.GetInterval())
+		}
+		interval = d
+	}
+	top := int(req.GetTop())
+	switch {
+	case top < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid top: %d", top)
+	case top == 0:
+		top = defaultNoisiestRules
+	}
+
+	rules, err := s.store.ListRules(ctx, req.GetRuleIds())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to list rules: %v", err)
+	}
+	hits, err := s.store.ListRuleHits(ctx, f)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list rule hits: %v", err)
+	}
+
+	type zoneKey struct {
+		ruleID         int64
+		location, zone string
+	}
+	zones := make(map[zoneKey]*ruleStats)
+	totals := make(map[int64]*ruleStats)
+	for _, h := range hits {
+		if h.RuleID == 0 {
+			continue
+		}
+		k := zoneKey{h.RuleID, h.Location, h.Zone}
+		if _, ok := zones[k]; !ok {
+			zones[k] = &ruleStats{
+				stats:   &svpb.RuleStats{RuleId: h.RuleID, Location: h.Location, Zone: h.Zone},
+				buckets: make(map[time.Time]int64),
+			}
+		}
+		zones[k].add(h, interval)
+		if _, ok := totals[h.RuleID]; !ok {
+			totals[h.RuleID] = &ruleStats{
+				stats:   &svpb.RuleStats{RuleId: h.RuleID},
+				buckets: make(map[time.Time]int64),
+			}
+		}
+		totals[h.RuleID].add(h, interval)
+	}
+
+	resp := &svpb.GetRuleStatsResponse{}
+	for _, r := range zones {
+		resp.Stats = append(resp.Stats, r.proto())
+	}
+	sort.Slice(resp.Stats, func(i, j int) bool {
+		a, b := resp.Stats[i], resp.Stats[j]
+		if a.GetRuleId() != b.GetRuleId() {
+			return a.GetRuleId() < b.GetRuleId()
+		}
+		if a.GetLocation() != b.GetLocation() {
+			return a.GetLocation() < b.GetLocation()
+		}
+		return a.GetZone() < b.GetZone()
+	})
+	for _, r := range totals {
+		resp.Noisiest = append(resp.Noisiest, r.proto())
+	}
+	sort.Slice(resp.Noisiest, func(i, j int) bool {
+		a, b := resp.Noisiest[i], resp.Noisiest[j]
+		if a.GetHits() != b.GetHits() {
+			return a.GetHits() > b.GetHits()
+		}
+		return a.GetRuleId() < b.GetRuleId()
+	})
+	if len(resp.Noisiest) > top {
+		resp.Noisiest = resp.Noisiest[:top]
+	}
+	for _, r := range rules {
+		if _, ok := totals[r.ID]; !ok {
+			resp.NeverFired = append(resp.NeverFired, r.ID)
+		}
+	}
+	sort.Slice(resp.NeverFired, func(i, j int) bool { return resp.NeverFired[i] < resp.NeverFired[j] })
+	return resp, nil
+}
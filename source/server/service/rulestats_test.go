@@ -0,0 +1,169 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/server/store"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sensorpb "github.com/google/emitto/source/sensor/proto"
+	spb "github.com/google/emitto/source/server/proto"
+)
+
+func ruleHitsMessage(t *testing.T, id, zone string, start time.Time, hits ...*sensorpb.RuleHit) *sensorpb.SensorMessage {
+	t.Helper()
+	s, err := ptypes.TimestampProto(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := ptypes.TimestampProto(start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &sensorpb.SensorMessage{
+		Id: id,
+		Type: &sensorpb.SensorMessage_RuleHits{
+			RuleHits: &sensorpb.RuleHits{
+				Start: s,
+				End:   e,
+				Host:  &sensorpb.Host{Org: "test", Zone: zone},
+				Hits:  hits,
+			},
+		},
+	}
+}
+
+func TestGetRuleStats(t *testing.T) {
+	ctx := context.Background()
+	ds := store.NewMemoryStore()
+	for _, r := range []*resources.Rule{
+		{ID: 1, Body: "alert ip any any -> any any (sid:1001;)"},
+		{ID: 2, Body: "alert ip any any -> any any (sid:1002;)"},
+		{ID: 3, Body: "alert ip any any -> any any (sid:1003;)"},
+	} {
+		if err := ds.AddRule(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := New(ds, nil, nil, nil)
+
+	start := time.Date(2019, 5, 13, 12, 0, 0, 0, time.UTC)
+	processSensorMessage(t, s, []byte{0x0a}, ruleHitsMessage(t, "msg1", "dmz", start,
+		&sensorpb.RuleHit{SignatureId: 1001, Rev: 1, Count: 5},
+		&sensorpb.RuleHit{SignatureId: 1002, Rev: 1, Count: 1},
+		&sensorpb.RuleHit{SignatureId: 9999, Rev: 1, Count: 100},
+	))
+	processSensorMessage(t, s, []byte{0x0a}, ruleHitsMessage(t, "msg2", "dmz", start.Add(time.Hour),
+		&sensorpb.RuleHit{SignatureId: 1001, Rev: 2, Count: 3},
+	))
+	processSensorMessage(t, s, []byte{0x0b}, ruleHitsMessage(t, "msg3", "prod", start,
+		&sensorpb.RuleHit{SignatureId: 1002, Rev: 1, Count: 4},
+	))
+
+	for _, tt := range []struct {
+		desc    string
+		req     *spb.GetRuleStatsRequest
+		want    *spb.GetRuleStatsResponse
+		wantErr codes.Code
+	}{
+		{
+			desc: "all rules",
+			req:  &spb.GetRuleStatsRequest{Interval: "1h"},
+			want: &spb.GetRuleStatsResponse{
+				Stats: []*spb.RuleStats{
+					{
+						RuleId:   1,
+						Location: "test",
+						Zone:     "dmz",
+						Hits:     8,
+						Buckets: []*spb.RuleHitsBucket{
+							{Start: "Mon, 13 May 2019 12:00:00 +0000", Hits: 5},
+							{Start: "Mon, 13 May 2019 13:00:00 +0000", Hits: 3},
+						},
+					},
+					{
+						RuleId:   2,
+						Location: "test",
+						Zone:     "dmz",
+						Hits:     1,
+						Buckets:  []*spb.RuleHitsBucket{{Start: "Mon, 13 May 2019 12:00:00 +0000", Hits: 1}},
+					},
+					{
+						RuleId:   2,
+						Location: "test",
+						Zone:     "prod",
+						Hits:     4,
+						Buckets:  []*spb.RuleHitsBucket{{Start: "Mon, 13 May 2019 12:00:00 +0000", Hits: 4}},
+					},
+				},
+				NeverFired: []int64{3},
+				Noisiest: []*spb.RuleStats{
+					{
+						RuleId: 1,
+						Hits:   8,
+						Buckets: []*spb.RuleHitsBucket{
+							{Start: "Mon, 13 May 2019 12:00:00 +0000", Hits: 5},
+							{Start: "Mon, 13 May 2019 13:00:00 +0000", Hits: 3},
+						},
+					},
+					{
+						RuleId:  2,
+						Hits:    5,
+						Buckets: []*spb.RuleHitsBucket{{Start: "Mon, 13 May 2019 12:00:00 +0000", Hits: 5}},
+					},
+				},
+			},
+		},
+		{
+			desc: "zone and time range",
+			req:  &spb.GetRuleStatsRequest{Zone: "dmz", Since: "2019-05-13T13:30:00Z", Top: 1},
+			want: &spb.GetRuleStatsResponse{
+				Stats:      []*spb.RuleStats{{RuleId: 1, Location: "test", Zone: "dmz", Hits: 3}},
+				NeverFired: []int64{2, 3},
+				Noisiest:   []*spb.RuleStats{{RuleId: 1, Hits: 3}},
+			},
+		},
+		{
+			desc: "selected rules",
+			req:  &spb.GetRuleStatsRequest{RuleIds: []int64{3}},
+			want: &spb.GetRuleStatsResponse{NeverFired: []int64{3}},
+		},
+		{
+			desc:    "invalid interval",
+			req:     &spb.GetRuleStatsRequest{Interval: "-1h"},
+			wantErr: codes.InvalidArgument,
+		},
+	} {
+		got, err := s.GetRuleStats(ctx, tt.req)
+		if c := status.Code(err); c != tt.wantErr {
+			t.Errorf("%s: got %v, want %v", tt.desc, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.Comparer(proto.Equal)); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
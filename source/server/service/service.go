@@ -17,15 +17,23 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/emitto/source/filestore"
 	"github.com/google/emitto/source/resources"
+	"github.com/google/emitto/source/sensor/suricata"
 	"github.com/google/emitto/source/server/fleetspeak"
+	"github.com/google/emitto/source/server/ioc"
+	"github.com/google/emitto/source/server/notify"
 	"github.com/google/emitto/source/server/store"
+	"github.com/google/emitto/source/signing"
 	"github.com/google/uuid"
+	"golang.org/x/crypto/ed25519"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -53,85 +61,224 @@ type Service struct {
 	store      store.Store
 	fileStore  filestore.FileStore
 	fleetspeak FleetspeakAdminClient
+	// Key used to sign generated rule files. Rule files are not signed if nil.
+	signingKey ed25519.PrivateKey
+	// Notifier for operator notifications.
+	notifier notify.Notifier
+	// Policy for quarantining noisy rules. Rules are not quarantined if nil.
+	quarantine *QuarantinePolicy
+	// Generator of rules from indicator files. Indicators cannot be imported if nil.
+	indicators *ioc.Generator
+
+	datasetMu sync.Mutex // Serializes the read-modify-write of dataset members.
+
+	quarantineMu sync.Mutex // Guards quarantineLocks.
+	// Locks serializing the quarantine changes of a rule in a location zone.
+	quarantineLocks map[quarantineKey]*sync.Mutex
+
+	sidsMu sync.Mutex // Guards the fields below.
+	// Cached map of rule SIDs to rule IDs, and the time it was built. Reset when rules change.
+	sids     map[int64]int64
+	sidsTime time.Time
 }
 
 // New returns a new emitto Service.
-func New(store store.Store, filestore filestore.FileStore, fs FleetspeakAdminClient) *Service {
-	return &Service{store, filestore, fs}
+func New(store store.Store, filestore filestore.FileStore, fs FleetspeakAdminClient, signingKey ed25519.PrivateKey) *Service {
+	return &Service{
+		store:      store,
+		fileStore:  filestore,
+		fleetspeak: fs,
+		signingKey: signingKey,
+		notifier:   notify.Log{},
+	}
+}
+
+// SetNotifier sets the Notifier for operator notifications, which are sent in the background so
+// that slow sinks do not delay the Service. Notifications are logged by default.
+func (s *Service) SetNotifier(n notify.Notifier) {
+	s.closeNotifier()
+	s.notifier = notify.NewAsync(n, notificationQueueSize, notificationTimeout)
+}
+
+// Close waits for the queued notifications to be sent. The Service must not be used after Close.
+func (s *Service) Close() {
+	s.closeNotifier()
+}
+
+// SetQuarantinePolicy sets the policy for quarantining noisy rules.
+func (s *Service) SetQuarantinePolicy(p *QuarantinePolicy) {
+	s.quarantine = p
+}
+
+// SetIndicatorGenerator sets the Generator of rules from imported indicator files.
+func (s *Service) SetIndicatorGenerator(g *ioc.Generator) {
+	s.indicators = g
 }
 
 // DeployRules generates a rule file and deploys it to the sensors in the provided location.
 func (s *Service) DeployRules(req *svpb.DeployRulesRequest, stream svpb.Emitto_DeployRulesServer) error {
-	ctx := stream.Context()
+	return s.deployLocation(stream.Context(), req.GetLocation(), stream.Send)
+}
+
+// deployLocation generates a rule file for every zone of the location and deploys it to the sensors
+// in the zone, calling send with the result for every sensor. Rule files are generated per zone,
+// as rules and their quarantines apply to location zones.
+func (s *Service) deployLocation(ctx context.Context, loc *svpb.Location, send func(*svpb.DeployRulesResponse) error) error {
 	// Get clients.
 	clients, err := s.fleetspeak.ListClients(ctx)
 	if err != nil {
 		return err
 	}
 	log.V(1).Infof("DeployRules() listed clients:\n%s", fleetspeak.ParseClients(clients))
-	ids := getClientIDsByLocation(clients, req.GetLocation())
-	if len(ids) == 0 {
-		return status.Errorf(codes.FailedPrecondition, "no clients for location: %v", req.GetLocation())
+	if len(getClientIDsByLocation(clients, loc)) == 0 {
+		return status.Errorf(codes.FailedPrecondition, "no clients for location: %v", loc)
 	}
-	// Create rule file.
 	rules, err := s.store.ListRules(ctx, nil)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to list rules: %v", err)
 	}
-
-	if rules = filterRulesByLocation(rules, req.GetLocation()); len(rules) == 0 {
-		return status.Errorf(codes.FailedPrecondition, "no rules found for %q", req.GetLocation())
-	}
-	path := ruleFilepath(req.GetLocation().GetName())
-	if err := s.fileStore.AddRuleFile(ctx, path, resources.MakeRuleFile(rules)); err != nil {
-		return err
+	if len(filterRulesByLocation(rules, loc)) == 0 {
+		return status.Errorf(codes.FailedPrecondition, "no rules found for %q", loc)
 	}
-
-	for _, id := range ids {
-		resp := &svpb.DeployRulesResponse{
-			ClientId: fmt.Sprintf("%X", id),
-			Status:   status.New(codes.OK, "OK").Proto(), // Default; will be reset for errors.
-		}
-		rid := uuid.New().String()
-		// Log sensor request. This is a precondition to sending the request.
-		m := &resources.SensorRequest{
-			ID:       rid,
-			Time:     timeNow().Format(time.RFC1123Z),
-			ClientID: fmt.Sprintf("%X", id),
-			Type:     resources.DeployRules,
-		}
-		if err := s.store.AddSensorRequest(ctx, m); err != nil {
-			resp.Status = status.New(codes.FailedPrecondition, fmt.Sprintf("failed to add sensor message (%+v): %v", m, err)).Proto()
-			if err := stream.Send(resp); err != nil {
-				return err
+	// The deployment time orders the deployments of the location, so that sensors reject older
+	// ones delivered late.
+	generation := timeNow().UnixNano()
+	deployed := make(map[string]bool)
+	for _, z := range loc.GetZones() {
+		zone := &svpb.Location{Name: loc.GetName(), Zones: []string{z}}
+		var ids [][]byte
+		for _, id := range getClientIDsByLocation(clients, zone) {
+			// Sensors labeled with several zones receive the rule file of the first one.
+			if !deployed[string(id)] {
+				deployed[string(id)] = true
+				ids = append(ids, id)
 			}
+		}
+		if len(ids) == 0 {
 			continue
 		}
-		// Send sensor request to client.
-		r := &spb.SensorRequest{
-			Id:   rid,
-			Time: &tspb.Timestamp{Seconds: time.Now().Unix()},
-			Type: &spb.SensorRequest_DeployRules{&spb.DeployRules{RuleFile: path}},
+		if err := s.deployZone(ctx, zone, rules, ids, generation, send); err != nil {
+			return err
 		}
-		if err := s.fleetspeak.InsertMessage(ctx, r, id); err != nil {
-			// Clean up Store entry - do not fail hard on this.
-			if err := s.store.DeleteSensorRequest(ctx, rid); err != nil {
-				log.Errorf("Failed to remove sensor request (%+v): %v", r, err)
+	}
+	return nil
+}
+
+// deployZone generates the rule file of a location zone from the provided rules and deploys it to
+// the provided sensors, calling send with the result for every sensor.
+func (s *Service) deployZone(ctx context.Context, zone *svpb.Location, rules []*resources.Rule, ids [][]byte, generation int64, send func(*svpb.DeployRulesResponse) error) error {
+	if rules = filterRulesByLocation(rules, zone); len(rules) == 0 {
+		// Other zones of the location have rules.
+		st := status.New(codes.FailedPrecondition, fmt.Sprintf("no rules found for %q", zone))
+		for _, id := range ids {
+			if err := send(&svpb.DeployRulesResponse{ClientId: fmt.Sprintf("%X", id), Status: st.Proto()}); err != nil {
+				return err
 			}
-			resp.Status = status.New(codes.Internal, fmt.Sprintf("failed to insert message: %v", err)).Proto()
 		}
-		if err := stream.Send(resp); err != nil {
+		return nil
+	}
+	rules, err := s.suppressQuarantined(ctx, rules, zone)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to apply quarantines: %v", err)
+	}
+	path := ruleFilepath(zone.GetName(), zone.GetZones()[0])
+	ruleFile := resources.MakeRuleFile(rules)
+	if err := s.fileStore.AddRuleFile(ctx, path, ruleFile); err != nil {
+		return err
+	}
+	deploy, err := s.newDeployRules(ctx, path, ruleFile, zone, generation)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		rid, st := s.sendDeployRules(ctx, id, zone, deploy, ruleFile)
+		resp := &svpb.DeployRulesResponse{
+			ClientId:  fmt.Sprintf("%X", id),
+			RequestId: rid,
+			Status:    st.Proto(),
+		}
+		if err := send(resp); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// newDeployRules builds a signed DeployRules request for the rule file of the location, stamped
+// with the deployment generation and the datasets of the location zones.
+func (s *Service) newDeployRules(ctx context.Context, path string, ruleFile []byte, loc *svpb.Location, generation int64) (*spb.DeployRules, error) {
+	deploy := s.signRuleFile(path, ruleFile)
+	datasets, err := s.store.ListDatasets(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list datasets: %v", err)
+	}
+	for _, d := range filterDatasetsByLocation(datasets, loc) {
+		deploy.Datasets = append(deploy.Datasets, resources.DatasetToSensorProto(d))
+	}
+	deploy.Location, deploy.Generation = loc.GetName(), generation
+	return deploy, nil
+}
+
+// signRuleFile builds a DeployRules request for the rule file, signed if a signing key is set.
+func (s *Service) signRuleFile(path string, ruleFile []byte) *spb.DeployRules {
+	deploy := &spb.DeployRules{RuleFile: path}
+	if s.signingKey != nil {
+		b := signing.Sign(s.signingKey, ruleFile)
+		deploy.Sha256 = b.SHA256
+		deploy.Signature = b.Signature
+	}
+	return deploy
+}
+
+// sendDeployRules logs a DeployRules sensor request for the location and sends it to the client. The
+// request ID is returned if the request was sent.
+func (s *Service) sendDeployRules(ctx context.Context, id []byte, loc *svpb.Location, deploy *spb.DeployRules, ruleFile []byte) (string, *status.Status) {
+	sum := sha256.Sum256(ruleFile)
+	m := &resources.SensorRequest{
+		Type:           resources.DeployRules,
+		RuleFile:       deploy.GetRuleFile(),
+		RuleFileSHA256: hex.EncodeToString(sum[:]),
+		Location:       loc.GetName(),
+		Zones:          loc.GetZones(),
+		Generation:     deploy.GetGeneration(),
+	}
+	st := s.sendSensorRequest(ctx, id, m, &spb.SensorRequest{Type: &spb.SensorRequest_DeployRules{DeployRules: deploy}})
+	if st.Code() != codes.OK {
+		return "", st
+	}
+	return m.ID, st
+}
+
+// sendSensorRequest logs a sensor request, described by m, and sends it to the client. The request
+// ID and time are set on both m and r.
+func (s *Service) sendSensorRequest(ctx context.Context, id []byte, m *resources.SensorRequest, r *spb.SensorRequest) *status.Status {
+	rid := uuid.New().String()
+	m.ID = rid
+	m.Time = timeNow().Format(time.RFC1123Z)
+	m.ClientID = fmt.Sprintf("%X", id)
+	// Log sensor request. This is a precondition to sending the request.
+	if err := s.store.AddSensorRequest(ctx, m); err != nil {
+		return status.New(codes.FailedPrecondition, fmt.Sprintf("failed to add sensor message (%+v): %v", m, err))
+	}
+	// Send sensor request to client.
+	r.Id = rid
+	r.Time = &tspb.Timestamp{Seconds: time.Now().Unix()}
+	if err := s.fleetspeak.InsertMessage(ctx, r, id); err != nil {
+		// Clean up Store entry - do not fail hard on this.
+		if err := s.store.DeleteSensorRequest(ctx, rid); err != nil {
+			log.Errorf("Failed to remove sensor request (%+v): %v", r, err)
+		}
+		return status.New(codes.Internal, fmt.Sprintf("failed to insert message: %v", err))
+	}
+	return status.New(codes.OK, "OK")
+}
+
 // AddRule adds the provided Rule.
 func (s *Service) AddRule(ctx context.Context, req *svpb.AddRuleRequest) (*emptypb.Empty, error) {
 	if err := s.store.AddRule(ctx, resources.ProtoToRule(req.GetRule())); err != nil {
 		return &emptypb.Empty{}, status.Errorf(codes.Internal, "failed to add rule: %v", err)
 	}
+	s.resetRuleSIDs()
 	return &emptypb.Empty{}, nil
 }
 
@@ -145,6 +292,7 @@ func (s *Service) ModifyRule(ctx context.Context, req *svpb.ModifyRuleRequest) (
 	if err := s.store.ModifyRule(ctx, r); err != nil {
 		return &emptypb.Empty{}, status.Errorf(codes.Internal, "failed to modify rule (%+v): %v", r, err)
 	}
+	s.resetRuleSIDs()
 	return &emptypb.Empty{}, nil
 }
 
@@ -153,6 +301,7 @@ func (s *Service) DeleteRule(ctx context.Context, req *svpb.DeleteRuleRequest) (
 	if err := s.store.DeleteRule(ctx, req.GetRuleId()); err != nil {
 		return &emptypb.Empty{}, status.Errorf(codes.Internal, "failed to delete rule (id=%d): %v", req.GetRuleId(), err)
 	}
+	s.resetRuleSIDs()
 	return &emptypb.Empty{}, nil
 }
 
@@ -210,6 +359,96 @@ func (s *Service) ListLocations(ctx context.Context, req *svpb.ListLocationsRequ
 	return resp, nil
 }
 
+// ListSensorHealth returns the latest health reported by the sensors with the provided client IDs,
+// or by all sensors if no client IDs are provided.
+func (s *Service) ListSensorHealth(ctx context.Context, req *svpb.ListSensorHealthRequest) (*svpb.ListSensorHealthResponse, error) {
+	var health []*resources.SensorHealth
+	if len(req.GetClientIds()) == 0 {
+		h, err := s.store.ListSensorHealth(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list sensor health: %v", err)
+		}
+		health = h
+	}
+	for _, id := range req.GetClientIds() {
+		h, err := s.store.GetSensorHealth(ctx, id)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "failed to get sensor health (client=%s): %v", id, err)
+		}
+		health = append(health, h)
+	}
+	resp := &svpb.ListSensorHealthResponse{}
+	for _, h := range health {
+		resp.Sensors = append(resp.Sensors, resources.SensorHealthToProto(h))
+	}
+	return resp, nil
+}
+
+// failedRuleIDs maps the rules which a sensor rejected or failed to load back to Rule IDs by SID.
+func (s *Service) failedRuleIDs(ctx context.Context, resp *spb.SensorResponse) ([]int64, error) {
+	var errs []*spb.RuleError
+	errs = append(errs, resp.GetRuleErrors()...)
+	errs = append(errs, resp.GetFailedRules()...)
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	sids, err := s.ruleSIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, e := range errs {
+		sid := e.GetSid()
+		if sid == 0 {
+			sid = suricata.ParseSID(e.GetRule())
+		}
+		id, ok := sids[sid]
+		if !ok {
+			log.Warningf("No rule found for failed rule (sid=%d): %s", sid, e.GetRule())
+			continue
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// ruleSIDsTTL is the time after which the cached rule SIDs are rebuilt, to pick up rules changed
+// by other servers sharing the store.
+const ruleSIDsTTL = time.Minute
+
+// ruleSIDs maps the SIDs of the stored rules to their rule IDs. The map is cached, and must not be
+// modified.
+func (s *Service) ruleSIDs(ctx context.Context) (map[int64]int64, error) {
+	s.sidsMu.Lock()
+	defer s.sidsMu.Unlock()
+	if s.sids != nil && timeNow().Sub(s.sidsTime) < ruleSIDsTTL {
+		return s.sids, nil
+	}
+	rules, err := s.store.ListRules(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	sids := make(map[int64]int64)
+	for _, r := range rules {
+		if sid := suricata.ParseSID(r.Body); sid != 0 {
+			sids[sid] = r.ID
+		}
+	}
+	s.sids, s.sidsTime = sids, timeNow()
+	return sids, nil
+}
+
+// resetRuleSIDs drops the cached rule SIDs after rules are changed.
+func (s *Service) resetRuleSIDs() {
+	s.sidsMu.Lock()
+	s.sids = nil
+	s.sidsMu.Unlock()
+}
+
 // Process receives Fleetspeak messages and stores the enclosed SensorResponse.
 func (s *Service) Process(ctx context.Context, m *fspb.Message) (*fspb.EmptyMessage, error) {
 	var msg spb.SensorMessage
@@ -221,17 +460,58 @@ func (s *Service) Process(ctx context.Context, m *fspb.Message) (*fspb.EmptyMess
 	switch t := msg.Type.(type) {
 	case *spb.SensorMessage_Response:
 		req := resources.ProtoToSensorRequest(&msg)
+		if codes.Code(msg.GetResponse().GetStatus().GetCode()) == codes.AlreadyExists {
+			// The sensor ignored a redelivered request; keep the outcome it first reported.
+			log.Infof("Sensor %X ignored duplicate sensor request (%s)", m.GetSource().GetClientId(), req.ID)
+			break
+		}
+		ids, err := s.failedRuleIDs(ctx, msg.GetResponse())
+		if err != nil {
+			log.Errorf("Failed to map failed rules of sensor request (%s): %v", req.ID, err)
+		}
+		req.FailedRuleIDs = ids
 		if err := s.store.ModifySensorRequest(ctx, req); err != nil {
 			log.Errorf("Failed to update sensor request (%+v)", req)
 		}
+		if err := s.recordDeployment(ctx, msg.GetResponse()); err != nil {
+			log.Errorf("Failed to record deployment of sensor request (%s): %v", req.ID, err)
+		}
+		clientID := fmt.Sprintf("%X", m.GetSource().GetClientId())
+		if err := s.notifyDeployResult(ctx, clientID, msg.GetResponse(), ids); err != nil {
+			log.Errorf("Failed to notify result of sensor request (%s): %v", req.ID, err)
+		}
 	case *spb.SensorMessage_Alert:
-		if err := s.store.AddSensorMessage(ctx, resources.ProtoToSensorMessage(&msg)); err != nil {
+		clientID := fmt.Sprintf("%X", m.GetSource().GetClientId())
+		sm := resources.ProtoToSensorMessage(&msg)
+		sm.ClientID = clientID
+		if err := s.store.AddSensorMessage(ctx, sm); err != nil {
 			log.Errorf("Failed to store sensor alert (%+v)", msg.GetAlert())
 		}
+		s.notifySensorAlert(ctx, clientID, msg.GetAlert())
 	case *spb.SensorMessage_Heartbeat:
-		if err := s.store.AddSensorMessage(ctx, resources.ProtoToSensorMessage(&msg)); err != nil {
+		clientID := fmt.Sprintf("%X", m.GetSource().GetClientId())
+		sm := resources.ProtoToSensorMessage(&msg)
+		sm.ClientID = clientID
+		if err := s.store.AddSensorMessage(ctx, sm); err != nil {
 			log.Errorf("Failed to store sensor heartbeat (%+v)", msg.GetHeartbeat())
 		}
+		h := resources.ProtoToSensorHealth(clientID, msg.GetHeartbeat())
+		if err := s.store.PutSensorHealth(ctx, h); err != nil {
+			log.Errorf("Failed to store sensor health (%+v): %v", h, err)
+		}
+		if err := s.checkDrift(ctx, clientID, msg.GetHeartbeat()); err != nil {
+			log.Errorf("Failed to check rule drift of client %s: %v", clientID, err)
+		}
+	case *spb.SensorMessage_EveAlerts:
+		clientID := fmt.Sprintf("%X", m.GetSource().GetClientId())
+		if err := s.storeAlertEvents(ctx, clientID, &msg); err != nil {
+			log.Errorf("Failed to store alert events of client %s: %v", clientID, err)
+		}
+	case *spb.SensorMessage_RuleHits:
+		clientID := fmt.Sprintf("%X", m.GetSource().GetClientId())
+		if err := s.storeRuleHits(ctx, clientID, &msg); err != nil {
+			log.Errorf("Failed to store rule hits of client %s: %v", clientID, err)
+		}
 	default:
 		log.Errorf("Unknown sensor message type (%T)", t)
 	}
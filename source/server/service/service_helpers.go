@@ -29,15 +29,14 @@ import (
 
 var timeNow = time.Now // Stubbed out for testing.
 
-func ruleFilepath(location string) string {
-	return filepath.Join(location, fmt.Sprintf("%s/%d", timeNow().Format("2006/01/02"), timeNow().Unix()))
+func ruleFilepath(location, zone string) string {
+	return filepath.Join(location, zone, fmt.Sprintf("%s/%d", timeNow().Format("2006/01/02"), timeNow().Unix()))
 }
 
 func filterRulesByLocation(rules []*resources.Rule, loc *spb.Location) []*resources.Rule {
 	matches := make(map[int64]*resources.Rule)
 	for _, r := range rules {
 		for _, lz := range r.LocZones {
-			// fmt.Printf("LOCZONE: %+v\n", lz)
 			// Filter by location name first.
 			if strings.HasPrefix(lz, loc.GetName()+":") {
 				for _, z := range loc.GetZones() {
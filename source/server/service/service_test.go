@@ -23,16 +23,22 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/emitto/source/filestore"
 	"github.com/google/emitto/source/resources"
 	"github.com/google/emitto/source/server/fleetspeak"
 	"github.com/google/emitto/source/server/store"
+	"github.com/google/emitto/source/signing"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/crypto/ed25519"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	log "github.com/golang/glog"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+	sensorpb "github.com/google/emitto/source/sensor/proto"
 	spb "github.com/google/emitto/source/server/proto"
 	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
 	fsspb "github.com/google/fleetspeak/fleetspeak/src/server/proto/fleetspeak_server"
@@ -157,6 +163,13 @@ func TestDeployRules(t *testing.T) {
 				if err != nil {
 					return
 				}
+				// Sent requests are logged under the returned request ID.
+				if r.GetStatus().GetCode() == int32(codes.OK) {
+					if _, err := ds.GetSensorRequest(ctx, r.GetRequestId()); err != nil {
+						t.Errorf("failed to get sensor request %q: %v", r.GetRequestId(), err)
+					}
+				}
+				r.RequestId = ""
 				got = append(got, r)
 			}
 			if diff := cmp.Diff(tt.want, got, cmp.Comparer(proto.Equal)); diff != "" {
@@ -166,6 +179,68 @@ func TestDeployRules(t *testing.T) {
 	}
 }
 
+func TestDeployRulesSigned(t *testing.T) {
+	ctx := context.Background()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ds := store.NewMemoryStore()
+	for _, r := range testRules {
+		if err := ds.AddRule(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fs := filestore.NewMemoryFileStore()
+	var inserted []*fspb.Message
+	fc, stopFs := initFSAdminServerAndClient(t, &fakeFSAdminServer{
+		listClients: func(*fsspb.ListClientsRequest) (*fsspb.ListClientsResponse, error) {
+			return &fsspb.ListClientsResponse{Clients: testClients}, nil
+		},
+		insertMessage: func(m *fspb.Message) (*fspb.EmptyMessage, error) {
+			inserted = append(inserted, m)
+			return &fspb.EmptyMessage{}, nil
+		},
+	})
+	defer fc.Close()
+	defer stopFs()
+	c, stopServer := initServerAndClient(t, New(ds, fs, fc, priv))
+	defer stopServer()
+
+	stream, err := c.DeployRules(ctx, &spb.DeployRulesRequest{Location: &spb.Location{Name: "a", Zones: []string{"dmz"}}})
+	if err != nil {
+		t.Fatalf("failed to deploy rules: %v", err)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(inserted) == 0 {
+		t.Fatal("no messages were inserted")
+	}
+	for _, m := range inserted {
+		var req sensorpb.SensorRequest
+		if err := ptypes.UnmarshalAny(m.GetData(), &req); err != nil {
+			t.Fatal(err)
+		}
+		d := req.GetDeployRules()
+		rules, err := fs.GetRuleFile(ctx, d.GetRuleFile())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := signing.Verify(pub, rules, &signing.Bundle{SHA256: d.GetSha256(), Signature: d.GetSignature()}); err != nil {
+			t.Errorf("failed to verify deployed rule file: %v", err)
+		}
+		if d.GetLocation() != "a" || d.GetGeneration() == 0 {
+			t.Errorf("got location %q and generation %d, want location %q with a generation", d.GetLocation(), d.GetGeneration(), "a")
+		}
+	}
+}
+
 func TestModifyRule(t *testing.T) {
 	ctx := context.Background()
 	timeNow := func() time.Time {
@@ -358,3 +433,180 @@ func (s *fakeFSAdminServer) InsertMessage(_ context.Context, m *fspb.Message) (*
 func (s *fakeFSAdminServer) ListClients(_ context.Context, req *fsspb.ListClientsRequest) (*fsspb.ListClientsResponse, error) {
 	return s.listClients(req)
 }
+
+func TestProcessSensorResponse(t *testing.T) {
+	ctx := context.Background()
+	ds := store.NewMemoryStore()
+	for _, r := range []*resources.Rule{
+		{ID: 1111, Body: "alert ip any any -> any any (sid:100;)"},
+		{ID: 2222, Body: "alert ip any any -> any any (sid:200;)"},
+		{ID: 3333, Body: "alert ip any any -> any any (foo; sid:300;)"},
+	} {
+		if err := ds.AddRule(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ds.AddSensorRequest(ctx, &resources.SensorRequest{ID: "1", ClientID: "A", Type: resources.DeployRules}); err != nil {
+		t.Fatal(err)
+	}
+	s := New(ds, filestore.NewMemoryFileStore(), nil, nil)
+
+	msg := &sensorpb.SensorMessage{
+		Type: &sensorpb.SensorMessage_Response{
+			Response: &sensorpb.SensorResponse{
+				Id:           "1",
+				Status:       status.New(codes.OK, "OK").Proto(),
+				RulesetStats: []*sensorpb.RulesetStats{{RulesLoaded: 2, RulesFailed: 1}},
+				FailedRules: []*sensorpb.RuleError{
+					{Sid: 300, Rule: "alert ip any any -> any any (foo; sid:300;)"},
+					{Rule: "alert ip any any -> any any (bar; sid:999;)"},
+				},
+			},
+		},
+	}
+	data, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Process(ctx, &fspb.Message{Data: data}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ds.GetSensorRequest(ctx, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &resources.SensorRequest{
+		ID:            "1",
+		ClientID:      "A",
+		Type:          resources.DeployRules,
+		Status:        `message:"OK" `,
+		RulesLoaded:   2,
+		RulesFailed:   1,
+		FailedRuleIDs: []int64{3333},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(resources.SensorRequest{}, "LastModified")); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// The deployment result is returned by the API.
+	resp, err := s.GetSensorRequests(ctx, &spb.GetSensorRequestsRequest{RequestIds: []string{"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantResp := &spb.GetSensorRequestsResponse{
+		Requests: []*spb.SensorRequest{{
+			Id:            "1",
+			ClientId:      "A",
+			Type:          string(resources.DeployRules),
+			Status:        `message:"OK" `,
+			RulesLoaded:   2,
+			RulesFailed:   1,
+			FailedRuleIds: []int64{3333},
+		}},
+	}
+	if diff := cmp.Diff(wantResp, resp, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("GetSensorRequests: expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	// Responses to redelivered requests do not replace the first outcome.
+	msg.GetResponse().Status = status.New(codes.AlreadyExists, "request already processed").Proto()
+	if data, err = ptypes.MarshalAny(msg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Process(ctx, &fspb.Message{Data: data}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = ds.GetSensorRequest(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(resources.SensorRequest{}, "LastModified")); diff != "" {
+		t.Errorf("duplicate response: expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProcessHeartbeat(t *testing.T) {
+	ctx := context.Background()
+	ds := store.NewMemoryStore()
+	s := New(ds, filestore.NewMemoryFileStore(), nil, nil)
+
+	msg := &sensorpb.SensorMessage{
+		Id: "hb1",
+		Type: &sensorpb.SensorMessage_Heartbeat{
+			Heartbeat: &sensorpb.Heartbeat{
+				Time:           &tspb.Timestamp{Seconds: 123},
+				Host:           &sensorpb.Host{Fqdn: "sensor1"},
+				SensorVersion:  "1.2.3",
+				RuleFileSha256: []byte{0xab, 0xcd},
+				Suricata: &sensorpb.SuricataHealth{
+					Version:       "4.1.4 RELEASE",
+					UptimeSeconds: 3600,
+					RunningMode:   "workers",
+					Interfaces: []*sensorpb.InterfaceStats{
+						{Name: "eth0", Packets: 100, Drops: 2},
+						{Name: "eth1", Packets: 10, Drops: 1},
+					},
+					RulesLoaded: 10,
+					RulesFailed: 1,
+				},
+			},
+		},
+	}
+	data, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Process(ctx, &fspb.Message{
+		Source: &fspb.Address{ClientId: []byte{0x0a}},
+		Data:   data,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &spb.SensorHealth{
+		ClientId:              "0A",
+		Time:                  "Thu, 01 Jan 1970 00:02:03 +0000",
+		Host:                  `fqdn:"sensor1" `,
+		SensorVersion:         "1.2.3",
+		RuleFileSha256:        "abcd",
+		SuricataVersion:       "4.1.4 RELEASE",
+		SuricataUptimeSeconds: 3600,
+		RunningMode:           "workers",
+		CapturePackets:        110,
+		CaptureDrops:          3,
+		RulesLoaded:           10,
+		RulesFailed:           1,
+	}
+	for _, tt := range []struct {
+		desc    string
+		req     *spb.ListSensorHealthRequest
+		want    []*spb.SensorHealth
+		wantErr bool
+	}{
+		{
+			desc: "all sensors",
+			req:  &spb.ListSensorHealthRequest{},
+			want: []*spb.SensorHealth{want},
+		},
+		{
+			desc: "by client ID",
+			req:  &spb.ListSensorHealthRequest{ClientIds: []string{"0A"}},
+			want: []*spb.SensorHealth{want},
+		},
+		{
+			desc:    "unknown client ID",
+			req:     &spb.ListSensorHealthRequest{ClientIds: []string{"0B"}},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			resp, err := s.ListSensorHealth(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got err=%v, wantErr=%t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, resp.GetSensors(), cmp.Comparer(proto.Equal)); diff != "" {
+				t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
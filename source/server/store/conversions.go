@@ -17,6 +17,7 @@ package store
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -45,6 +46,15 @@ func MutateLocation(src, dst *resources.Location) error {
 	return mutateFields(reflect.ValueOf(*src), dst, m)
 }
 
+// MutateDataset applies mutable, non-empty field mutations from the src to dst Dataset.
+func MutateDataset(src, dst *resources.Dataset) error {
+	m, err := resources.MutationsMapping(resources.Dataset{})
+	if err != nil {
+		return err
+	}
+	return mutateFields(reflect.ValueOf(*src), dst, m)
+}
+
 // MutateSensorRequest applies mutable, non-empty field mutations from the src to dst SensorRequest.
 func MutateSensorRequest(src, dst *resources.SensorRequest) error {
 	m, err := resources.MutationsMapping(resources.SensorRequest{})
@@ -68,6 +78,8 @@ func mutateFields(src reflect.Value, dst interface{}, fields map[string]bool) er
 					d = reflect.ValueOf(t).Elem()
 				case *resources.SensorRequest:
 					d = reflect.ValueOf(t).Elem()
+				case *resources.Dataset:
+					d = reflect.ValueOf(t).Elem()
 				default:
 					return fmt.Errorf("invalid mutable type: %T", t)
 				}
@@ -86,3 +98,19 @@ func isZero(v reflect.Value) bool {
 	// All other types.
 	return v.Interface() == reflect.Zero(v.Type()).Interface()
 }
+
+// sortSensorMessages sorts sensor messages by time and ID. Messages with an invalid time are first.
+func sortSensorMessages(msgs []*resources.SensorMessage) {
+	times := make(map[*resources.SensorMessage]time.Time, len(msgs))
+	for _, m := range msgs {
+		t, _ := time.Parse(time.RFC1123Z, m.Time)
+		times[m] = t
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		ti, tj := times[msgs[i]], times[msgs[j]]
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return msgs[i].ID < msgs[j].ID
+	})
+}
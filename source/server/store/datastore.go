@@ -28,8 +28,18 @@ const (
 	datastoreAddr     = "dns:///datastore.googleapis.com:443"
 	locationKind      = "Location"
 	ruleKind          = "Rule"
+	datasetKind       = "Dataset"
 	sensorRequestKind = "SensorRequest"
 	sensorMessageKind = "SensorMessage"
+	sensorHealthKind  = "SensorHealth"
+	deploymentKind    = "Deployment"
+	driftEventKind    = "DriftEvent"
+	alertEventKind    = "AlertEvent"
+	ruleHitsKind      = "RuleHits"
+	quarantineKind    = "Quarantine"
+	auditEventKind    = "AuditEvent"
+	// maxPutMulti is the maximum number of entities written by a single Datastore call.
+	maxPutMulti = 500
 )
 
 // DataStore represents a Google Cloud Datastore implementation of a Store.
@@ -236,6 +246,82 @@ func (s *DataStore) ListRules(ctx context.Context, ids []int64) ([]*resources.Ru
 	return all, nil
 }
 
+func datasetKey(name string) *datastore.Key {
+	return &datastore.Key{
+		Kind: datasetKind,
+		Name: name,
+	}
+}
+
+// datasetExists returns true if there is a dataset with the given name.
+func (s *DataStore) datasetExists(ctx context.Context, name string) (bool, error) {
+	query := datastore.NewQuery(datasetKind).Filter("__key__ =", datasetKey(name)).KeysOnly()
+	c, err := s.client.Count(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	return c == 1, nil
+}
+
+// AddDataset adds the given dataset.
+func (s *DataStore) AddDataset(ctx context.Context, d *resources.Dataset) error {
+	switch ok, err := s.datasetExists(ctx, d.Name); {
+	case err != nil:
+		return err
+	case ok:
+		return fmt.Errorf("dataset %q already exists", d.Name)
+	default:
+		d.LastModified = TimeNow().Format(time.RFC1123Z)
+		_, err = s.client.Put(ctx, datasetKey(d.Name), d)
+		return err
+	}
+}
+
+// ModifyDataset modifies an existing dataset with the provided dataset.
+func (s *DataStore) ModifyDataset(ctx context.Context, d *resources.Dataset) error {
+	dataset, err := s.GetDataset(ctx, d.Name)
+	if err != nil {
+		return fmt.Errorf("unable to get dataset %q: %v", d.Name, err)
+	}
+	if err := MutateDataset(d, dataset); err != nil {
+		return fmt.Errorf("unable to mutate dataset src=%+v dst=%+v: %v", d, dataset, err)
+	}
+	dataset.LastModified = TimeNow().Format(time.RFC1123Z)
+	_, err = s.client.Put(ctx, datasetKey(d.Name), dataset)
+	return err
+}
+
+// DeleteDataset deletes the dataset with the given name.
+func (s *DataStore) DeleteDataset(ctx context.Context, name string) error {
+	switch ok, err := s.datasetExists(ctx, name); {
+	case err != nil:
+		return err
+	case !ok:
+		return fmt.Errorf("dataset %q does not exist", name)
+	default:
+		return s.client.Delete(ctx, datasetKey(name))
+	}
+}
+
+// GetDataset gets the dataset with the given name.
+func (s *DataStore) GetDataset(ctx context.Context, name string) (*resources.Dataset, error) {
+	d := new(resources.Dataset)
+	if err := s.client.Get(ctx, datasetKey(name), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ListDatasets lists all datasets, ordered by name.
+func (s *DataStore) ListDatasets(ctx context.Context) ([]*resources.Dataset, error) {
+	var all []*resources.Dataset
+	query := datastore.NewQuery(datasetKind).Order("Name")
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 func sensorRequestKey(id string) *datastore.Key {
 	return &datastore.Key{
 		Kind: sensorRequestKind,
@@ -335,7 +421,336 @@ func (s *DataStore) AddSensorMessage(ctx context.Context, m *resources.SensorMes
 	case ok:
 		return fmt.Errorf("sensor request %q already exists", m.ID)
 	default:
+		m.Received = TimeNow()
 		_, err = s.client.Put(ctx, sensorMessageKey(m.ID), m)
 		return err
 	}
 }
+
+// ListSensorMessages lists the sensor messages selected by the filter, ordered by time and ID.
+func (s *DataStore) ListSensorMessages(ctx context.Context, f *resources.SensorMessageFilter) ([]*resources.SensorMessage, error) {
+	query := datastore.NewQuery(sensorMessageKind)
+	if f.Type != "" {
+		query = query.Filter("Type =", string(f.Type))
+	}
+	if !f.ReceivedSince.IsZero() {
+		// The inequality filter property must be sorted first.
+		query = query.Filter("Received >=", f.ReceivedSince).Order("Received")
+	}
+	var all []*resources.SensorMessage
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	// Message times are stored as formatted strings, so they are filtered and ordered here.
+	msgs := all[:0]
+	for _, m := range all {
+		if f.Match(m) {
+			msgs = append(msgs, m)
+		}
+	}
+	sortSensorMessages(msgs)
+	return msgs, nil
+}
+
+func sensorHealthKey(clientID string) *datastore.Key {
+	return &datastore.Key{
+		Kind: sensorHealthKind,
+		Name: clientID,
+	}
+}
+
+// PutSensorHealth adds or replaces the sensor health of a client.
+func (s *DataStore) PutSensorHealth(ctx context.Context, h *resources.SensorHealth) error {
+	h.LastModified = TimeNow().Format(time.RFC1123Z)
+	_, err := s.client.Put(ctx, sensorHealthKey(h.ClientID), h)
+	return err
+}
+
+// GetSensorHealth gets the sensor health of the client with the given ID.
+func (s *DataStore) GetSensorHealth(ctx context.Context, clientID string) (*resources.SensorHealth, error) {
+	h := new(resources.SensorHealth)
+	if err := s.client.Get(ctx, sensorHealthKey(clientID), h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// ListSensorHealth lists the sensor health of all clients, ordered by client ID.
+func (s *DataStore) ListSensorHealth(ctx context.Context) ([]*resources.SensorHealth, error) {
+	var all []*resources.SensorHealth
+	query := datastore.NewQuery(sensorHealthKind).Order("ClientID")
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func deploymentKey(clientID string) *datastore.Key {
+	return &datastore.Key{
+		Kind: deploymentKind,
+		Name: clientID,
+	}
+}
+
+// PutDeployment adds or replaces the deployment of a client.
+func (s *DataStore) PutDeployment(ctx context.Context, d *resources.Deployment) error {
+	d.LastModified = TimeNow().Format(time.RFC1123Z)
+	_, err := s.client.Put(ctx, deploymentKey(d.ClientID), d)
+	return err
+}
+
+// GetDeployment gets the deployment of the client with the given ID.
+func (s *DataStore) GetDeployment(ctx context.Context, clientID string) (*resources.Deployment, error) {
+	d := new(resources.Deployment)
+	if err := s.client.Get(ctx, deploymentKey(clientID), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ListDeployments lists the deployments of all clients, ordered by client ID.
+func (s *DataStore) ListDeployments(ctx context.Context) ([]*resources.Deployment, error) {
+	var all []*resources.Deployment
+	query := datastore.NewQuery(deploymentKind).Order("ClientID")
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func driftEventKey(id string) *datastore.Key {
+	return &datastore.Key{
+		Kind: driftEventKind,
+		Name: id,
+	}
+}
+
+// driftEventExists returns true if there is a drift event with the given ID.
+func (s *DataStore) driftEventExists(ctx context.Context, id string) (bool, error) {
+	query := datastore.NewQuery(driftEventKind).Filter("__key__ =", driftEventKey(id)).KeysOnly()
+	c, err := s.client.Count(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	return c == 1, nil
+}
+
+// AddDriftEvent adds the given drift event.
+func (s *DataStore) AddDriftEvent(ctx context.Context, e *resources.DriftEvent) error {
+	switch ok, err := s.driftEventExists(ctx, e.ID); {
+	case err != nil:
+		return err
+	case ok:
+		return fmt.Errorf("drift event %q already exists", e.ID)
+	default:
+		_, err = s.client.Put(ctx, driftEventKey(e.ID), e)
+		return err
+	}
+}
+
+// ListDriftEvents lists all drift events, ordered by client ID and event ID.
+func (s *DataStore) ListDriftEvents(ctx context.Context) ([]*resources.DriftEvent, error) {
+	var all []*resources.DriftEvent
+	query := datastore.NewQuery(driftEventKind).Order("ClientID").Order("ID")
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func alertEventKey(id string) *datastore.Key {
+	return &datastore.Key{
+		Kind: alertEventKind,
+		Name: id,
+	}
+}
+
+// AddAlertEvents adds or replaces the given alert events by ID.
+func (s *DataStore) AddAlertEvents(ctx context.Context, events []*resources.AlertEvent) error {
+	now := TimeNow()
+	for _, e := range events {
+		e.Received = now
+	}
+	for len(events) > 0 {
+		n := len(events)
+		if n > maxPutMulti {
+			n = maxPutMulti
+		}
+		keys := make([]*datastore.Key, n)
+		for i, e := range events[:n] {
+			keys[i] = alertEventKey(e.ID)
+		}
+		if _, err := s.client.PutMulti(ctx, keys, events[:n]); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+// ListAlertEvents lists the alert events selected by the filter, ordered by decreasing time. Events
+// selected by reception time are ordered by decreasing reception time first. The query requires the
+// composite indexes of index.yaml.
+func (s *DataStore) ListAlertEvents(ctx context.Context, f *resources.AlertEventFilter) ([]*resources.AlertEvent, error) {
+	query := datastore.NewQuery(alertEventKind)
+	if f.RuleID != 0 {
+		query = query.Filter("RuleID =", f.RuleID)
+	}
+	if f.Location != "" {
+		query = query.Filter("Location =", f.Location)
+	}
+	if f.Zone != "" {
+		query = query.Filter("Zone =", f.Zone)
+	}
+	if f.ClientID != "" {
+		query = query.Filter("ClientID =", f.ClientID)
+	}
+	if f.Host != "" {
+		query = query.Filter("Host =", f.Host)
+	}
+	switch {
+	case !f.Since.IsZero() && !f.ReceivedSince.IsZero():
+		return nil, fmt.Errorf("alert events cannot be selected by both time and reception time")
+	case !f.Since.IsZero():
+		query = query.Filter("Time >=", f.Since)
+	case !f.ReceivedSince.IsZero():
+		// The inequality filter property must be sorted first.
+		query = query.Filter("Received >=", f.ReceivedSince).Order("-Received")
+	}
+	query = query.Order("-Time").Order("-__key__")
+	if f.Limit > 0 {
+		query = query.Limit(f.Limit)
+	}
+	var all []*resources.AlertEvent
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func ruleHitsKey(id string) *datastore.Key {
+	return &datastore.Key{
+		Kind: ruleHitsKind,
+		Name: id,
+	}
+}
+
+// AddRuleHits adds or replaces the given rule hits by ID.
+func (s *DataStore) AddRuleHits(ctx context.Context, hits []*resources.RuleHits) error {
+	for len(hits) > 0 {
+		n := len(hits)
+		if n > maxPutMulti {
+			n = maxPutMulti
+		}
+		keys := make([]*datastore.Key, n)
+		for i, h := range hits[:n] {
+			keys[i] = ruleHitsKey(h.ID)
+		}
+		if _, err := s.client.PutMulti(ctx, keys, hits[:n]); err != nil {
+			return err
+		}
+		hits = hits[n:]
+	}
+	return nil
+}
+
+// ListRuleHits lists the rule hits selected by the filter, ordered by reporting period end. Only
+// the location, zone and start of the time range are queried; the other criteria are applied to
+// the query results, since Datastore restricts inequality filters to a single property. The query
+// requires the composite indexes of index.yaml.
+func (s *DataStore) ListRuleHits(ctx context.Context, f *resources.RuleHitsFilter) ([]*resources.RuleHits, error) {
+	query := datastore.NewQuery(ruleHitsKind)
+	if f.Location != "" {
+		query = query.Filter("Location =", f.Location)
+	}
+	if f.Zone != "" {
+		query = query.Filter("Zone =", f.Zone)
+	}
+	if !f.Since.IsZero() {
+		query = query.Filter("End >=", f.Since)
+	}
+	query = query.Order("End").Order("__key__")
+	var all []*resources.RuleHits
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	hits := all[:0]
+	for _, h := range all {
+		if f.Match(h) {
+			hits = append(hits, h)
+		}
+	}
+	return hits, nil
+}
+
+func quarantineKey(id string) *datastore.Key {
+	return &datastore.Key{
+		Kind: quarantineKind,
+		Name: id,
+	}
+}
+
+// PutQuarantine adds or replaces the given quarantine.
+func (s *DataStore) PutQuarantine(ctx context.Context, q *resources.Quarantine) error {
+	_, err := s.client.Put(ctx, quarantineKey(q.ID), q)
+	return err
+}
+
+// GetQuarantine gets the quarantine with the given ID.
+func (s *DataStore) GetQuarantine(ctx context.Context, id string) (*resources.Quarantine, error) {
+	q := new(resources.Quarantine)
+	if err := s.client.Get(ctx, quarantineKey(id), q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// ListQuarantines lists all quarantines, ordered by rule ID and quarantine ID.
+func (s *DataStore) ListQuarantines(ctx context.Context) ([]*resources.Quarantine, error) {
+	var all []*resources.Quarantine
+	query := datastore.NewQuery(quarantineKind).Order("RuleID").Order("ID")
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func auditEventKey(id string) *datastore.Key {
+	return &datastore.Key{
+		Kind: auditEventKind,
+		Name: id,
+	}
+}
+
+// auditEventExists returns true if there is an audit event with the given ID.
+func (s *DataStore) auditEventExists(ctx context.Context, id string) (bool, error) {
+	query := datastore.NewQuery(auditEventKind).Filter("__key__ =", auditEventKey(id)).KeysOnly()
+	c, err := s.client.Count(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	return c == 1, nil
+}
+
+// AddAuditEvent adds the given audit event.
+func (s *DataStore) AddAuditEvent(ctx context.Context, e *resources.AuditEvent) error {
+	switch ok, err := s.auditEventExists(ctx, e.ID); {
+	case err != nil:
+		return err
+	case ok:
+		return fmt.Errorf("audit event %q already exists", e.ID)
+	default:
+		_, err = s.client.Put(ctx, auditEventKey(e.ID), e)
+		return err
+	}
+}
+
+// ListAuditEvents lists all audit events, ordered by time and event ID.
+func (s *DataStore) ListAuditEvents(ctx context.Context) ([]*resources.AuditEvent, error) {
+	var all []*resources.AuditEvent
+	query := datastore.NewQuery(auditEventKind).Order("Time").Order("ID")
+	if _, err := s.client.GetAll(ctx, query, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
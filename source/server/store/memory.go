@@ -29,8 +29,16 @@ type MemoryStore struct {
 	m              sync.Mutex
 	locations      map[string]resources.Location
 	rules          map[int64]resources.Rule
+	datasets       map[string]resources.Dataset
 	sensorRequests map[string]resources.SensorRequest
 	sensorMessages map[string]resources.SensorMessage
+	sensorHealth   map[string]resources.SensorHealth
+	deployments    map[string]resources.Deployment
+	driftEvents    map[string]resources.DriftEvent
+	alertEvents    map[string]resources.AlertEvent
+	ruleHits       map[string]resources.RuleHits
+	quarantines    map[string]resources.Quarantine
+	auditEvents    map[string]resources.AuditEvent
 }
 
 // NewMemoryStore returns a MemoryStore.
@@ -38,8 +46,16 @@ func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
 		locations:      make(map[string]resources.Location),
 		rules:          make(map[int64]resources.Rule),
+		datasets:       make(map[string]resources.Dataset),
 		sensorRequests: make(map[string]resources.SensorRequest),
 		sensorMessages: make(map[string]resources.SensorMessage),
+		sensorHealth:   make(map[string]resources.SensorHealth),
+		deployments:    make(map[string]resources.Deployment),
+		driftEvents:    make(map[string]resources.DriftEvent),
+		alertEvents:    make(map[string]resources.AlertEvent),
+		ruleHits:       make(map[string]resources.RuleHits),
+		quarantines:    make(map[string]resources.Quarantine),
+		auditEvents:    make(map[string]resources.AuditEvent),
 	}
 }
 
@@ -184,6 +200,80 @@ func (s *MemoryStore) ListRules(ctx context.Context, ids []int64) ([]*resources.
 	return rules, nil
 }
 
+// AddDataset adds a Dataset to the store.
+func (s *MemoryStore) AddDataset(ctx context.Context, d *resources.Dataset) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	cp := *d
+	if _, ok := s.datasets[cp.Name]; ok {
+		return fmt.Errorf("dataset %q already exists", cp.Name)
+	}
+	cp.Members = append([]string(nil), d.Members...)
+	cp.LastModified = TimeNow().Format(time.RFC1123Z)
+	s.datasets[cp.Name] = cp
+	return nil
+}
+
+// ModifyDataset modifies an existing dataset with the provided dataset.
+func (s *MemoryStore) ModifyDataset(ctx context.Context, d *resources.Dataset) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	cp := *d
+	cp.Members = append([]string(nil), d.Members...)
+	dataset, ok := s.datasets[cp.Name]
+	if !ok {
+		return fmt.Errorf("dataset %q does not exist", cp.Name)
+	}
+	if err := MutateDataset(&cp, &dataset); err != nil {
+		return fmt.Errorf("unable to mutate dataset src=%+v dst=%+v: %v", cp, dataset, err)
+	}
+	dataset.LastModified = TimeNow().Format(time.RFC1123Z)
+	s.datasets[cp.Name] = dataset
+	return nil
+}
+
+// DeleteDataset deletes an existing Dataset.
+func (s *MemoryStore) DeleteDataset(ctx context.Context, name string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if _, ok := s.datasets[name]; !ok {
+		return fmt.Errorf("dataset %q does not exist", name)
+	}
+	delete(s.datasets, name)
+	return nil
+}
+
+// GetDataset returns the dataset with the given name.
+func (s *MemoryStore) GetDataset(ctx context.Context, name string) (*resources.Dataset, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	d, ok := s.datasets[name]
+	if !ok {
+		return nil, fmt.Errorf("dataset %q does not exist", name)
+	}
+	d.Members = append([]string(nil), d.Members...)
+	return &d, nil
+}
+
+// ListDatasets returns all datasets, ordered by name.
+func (s *MemoryStore) ListDatasets(ctx context.Context) ([]*resources.Dataset, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	datasets := make([]*resources.Dataset, 0, len(s.datasets))
+	for name := range s.datasets {
+		d := s.datasets[name]
+		d.Members = append([]string(nil), d.Members...)
+		datasets = append(datasets, &d)
+	}
+	sort.Slice(datasets, func(i, j int) bool { return datasets[i].Name < datasets[j].Name })
+	return datasets, nil
+}
+
 // AddSensorRequest adds a sensor request.
 func (s *MemoryStore) AddSensorRequest(ctx context.Context, r *resources.SensorRequest) error {
 	s.m.Lock()
@@ -249,6 +339,275 @@ func (s *MemoryStore) AddSensorMessage(ctx context.Context, m *resources.SensorM
 	if _, ok := s.sensorMessages[cp.ID]; ok {
 		return fmt.Errorf("sensor message %q already exists", cp.ID)
 	}
+	cp.Received = TimeNow()
 	s.sensorMessages[cp.ID] = cp
 	return nil
 }
+
+// ListSensorMessages returns the sensor messages selected by the filter, ordered by time and ID.
+func (s *MemoryStore) ListSensorMessages(ctx context.Context, f *resources.SensorMessageFilter) ([]*resources.SensorMessage, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var msgs []*resources.SensorMessage
+	for id := range s.sensorMessages {
+		m := s.sensorMessages[id]
+		if f.Match(&m) {
+			msgs = append(msgs, &m)
+		}
+	}
+	sortSensorMessages(msgs)
+	return msgs, nil
+}
+
+// PutSensorHealth adds or replaces the sensor health of a client.
+func (s *MemoryStore) PutSensorHealth(ctx context.Context, h *resources.SensorHealth) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	cp := *h
+	cp.LastModified = TimeNow().Format(time.RFC1123Z)
+	s.sensorHealth[cp.ClientID] = cp
+	return nil
+}
+
+// GetSensorHealth returns the sensor health of the client with the given ID.
+func (s *MemoryStore) GetSensorHealth(ctx context.Context, clientID string) (*resources.SensorHealth, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	h, ok := s.sensorHealth[clientID]
+	if !ok {
+		return nil, fmt.Errorf("sensor health for client %q does not exist", clientID)
+	}
+	return &h, nil
+}
+
+// ListSensorHealth returns the sensor health of all clients, ordered by client ID.
+func (s *MemoryStore) ListSensorHealth(ctx context.Context) ([]*resources.SensorHealth, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	health := make([]*resources.SensorHealth, 0, len(s.sensorHealth))
+	for id := range s.sensorHealth {
+		h := s.sensorHealth[id]
+		health = append(health, &h)
+	}
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].ClientID < health[j].ClientID
+	})
+	return health, nil
+}
+
+// PutDeployment adds or replaces the deployment of a client.
+func (s *MemoryStore) PutDeployment(ctx context.Context, d *resources.Deployment) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	cp := *d
+	cp.LastModified = TimeNow().Format(time.RFC1123Z)
+	s.deployments[cp.ClientID] = cp
+	return nil
+}
+
+// GetDeployment returns the deployment of the client with the given ID.
+func (s *MemoryStore) GetDeployment(ctx context.Context, clientID string) (*resources.Deployment, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	d, ok := s.deployments[clientID]
+	if !ok {
+		return nil, fmt.Errorf("deployment for client %q does not exist", clientID)
+	}
+	return &d, nil
+}
+
+// ListDeployments returns the deployments of all clients, ordered by client ID.
+func (s *MemoryStore) ListDeployments(ctx context.Context) ([]*resources.Deployment, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	deployments := make([]*resources.Deployment, 0, len(s.deployments))
+	for id := range s.deployments {
+		d := s.deployments[id]
+		deployments = append(deployments, &d)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].ClientID < deployments[j].ClientID
+	})
+	return deployments, nil
+}
+
+// AddDriftEvent adds a drift event.
+func (s *MemoryStore) AddDriftEvent(ctx context.Context, e *resources.DriftEvent) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	cp := *e
+	if _, ok := s.driftEvents[cp.ID]; ok {
+		return fmt.Errorf("drift event %q already exists", cp.ID)
+	}
+	s.driftEvents[cp.ID] = cp
+	return nil
+}
+
+// ListDriftEvents returns all drift events, ordered by client ID and event ID.
+func (s *MemoryStore) ListDriftEvents(ctx context.Context) ([]*resources.DriftEvent, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	events := make([]*resources.DriftEvent, 0, len(s.driftEvents))
+	for id := range s.driftEvents {
+		e := s.driftEvents[id]
+		events = append(events, &e)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].ClientID != events[j].ClientID {
+			return events[i].ClientID < events[j].ClientID
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
+
+// AddAlertEvents adds or replaces alert events by ID.
+func (s *MemoryStore) AddAlertEvents(ctx context.Context, events []*resources.AlertEvent) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := TimeNow()
+	for _, e := range events {
+		cp := *e
+		cp.Received = now
+		s.alertEvents[cp.ID] = cp
+	}
+	return nil
+}
+
+// ListAlertEvents returns the alert events selected by the filter, ordered by decreasing time and
+// event ID.
+func (s *MemoryStore) ListAlertEvents(ctx context.Context, f *resources.AlertEventFilter) ([]*resources.AlertEvent, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var events []*resources.AlertEvent
+	for id := range s.alertEvents {
+		e := s.alertEvents[id]
+		if f.Match(&e) {
+			events = append(events, &e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.After(events[j].Time)
+		}
+		return events[i].ID > events[j].ID
+	})
+	if f.Limit > 0 && len(events) > f.Limit {
+		events = events[:f.Limit]
+	}
+	return events, nil
+}
+
+// AddRuleHits adds or replaces rule hits by ID.
+func (s *MemoryStore) AddRuleHits(ctx context.Context, hits []*resources.RuleHits) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	for _, h := range hits {
+		s.ruleHits[h.ID] = *h
+	}
+	return nil
+}
+
+// ListRuleHits returns the rule hits selected by the filter, ordered by reporting period end and ID.
+func (s *MemoryStore) ListRuleHits(ctx context.Context, f *resources.RuleHitsFilter) ([]*resources.RuleHits, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var hits []*resources.RuleHits
+	for id := range s.ruleHits {
+		h := s.ruleHits[id]
+		if f.Match(&h) {
+			hits = append(hits, &h)
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if !hits[i].End.Equal(hits[j].End) {
+			return hits[i].End.Before(hits[j].End)
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	return hits, nil
+}
+
+// PutQuarantine adds or replaces a quarantine.
+func (s *MemoryStore) PutQuarantine(ctx context.Context, q *resources.Quarantine) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.quarantines[q.ID] = *q
+	return nil
+}
+
+// GetQuarantine returns the quarantine with the given ID.
+func (s *MemoryStore) GetQuarantine(ctx context.Context, id string) (*resources.Quarantine, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	q, ok := s.quarantines[id]
+	if !ok {
+		return nil, fmt.Errorf("quarantine %q does not exist", id)
+	}
+	return &q, nil
+}
+
+// ListQuarantines returns all quarantines, ordered by rule ID and quarantine ID.
+func (s *MemoryStore) ListQuarantines(ctx context.Context) ([]*resources.Quarantine, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	quarantines := make([]*resources.Quarantine, 0, len(s.quarantines))
+	for id := range s.quarantines {
+		q := s.quarantines[id]
+		quarantines = append(quarantines, &q)
+	}
+	sort.Slice(quarantines, func(i, j int) bool {
+		if quarantines[i].RuleID != quarantines[j].RuleID {
+			return quarantines[i].RuleID < quarantines[j].RuleID
+		}
+		return quarantines[i].ID < quarantines[j].ID
+	})
+	return quarantines, nil
+}
+
+// AddAuditEvent adds an audit event.
+func (s *MemoryStore) AddAuditEvent(ctx context.Context, e *resources.AuditEvent) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if _, ok := s.auditEvents[e.ID]; ok {
+		return fmt.Errorf("audit event %q already exists", e.ID)
+	}
+	s.auditEvents[e.ID] = *e
+	return nil
+}
+
+// ListAuditEvents returns all audit events, ordered by time and event ID.
+func (s *MemoryStore) ListAuditEvents(ctx context.Context) ([]*resources.AuditEvent, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	events := make([]*resources.AuditEvent, 0, len(s.auditEvents))
+	for id := range s.auditEvents {
+		e := s.auditEvents[id]
+		events = append(events, &e)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
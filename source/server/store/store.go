@@ -43,6 +43,17 @@ type Store interface {
 	// ListRules lists stored Rules by ID.
 	ListRules(ctx context.Context, ids []int64) ([]*resources.Rule, error)
 
+	// AddDataset adds a new Dataset.
+	AddDataset(ctx context.Context, d *resources.Dataset) error
+	// ModifyDataset modifies an existing Dataset.
+	ModifyDataset(ctx context.Context, d *resources.Dataset) error
+	// DeleteDataset removes an existing Dataset by name.
+	DeleteDataset(ctx context.Context, name string) error
+	// GetDataset retrieves a Dataset by name.
+	GetDataset(ctx context.Context, name string) (*resources.Dataset, error)
+	// ListDatasets lists all stored Datasets.
+	ListDatasets(ctx context.Context) ([]*resources.Dataset, error)
+
 	// AddSensorRequest adds a new SensorRequest.
 	AddSensorRequest(ctx context.Context, r *resources.SensorRequest) error
 	// ModifySensorRequest updates an existing SensorRequest.
@@ -54,4 +65,47 @@ type Store interface {
 
 	// AddSensorMessage adds a new SensorMessage.
 	AddSensorMessage(ctx context.Context, r *resources.SensorMessage) error
+	// ListSensorMessages lists the SensorMessages selected by the filter, oldest first.
+	ListSensorMessages(ctx context.Context, f *resources.SensorMessageFilter) ([]*resources.SensorMessage, error)
+
+	// PutSensorHealth adds or replaces the SensorHealth of a client.
+	PutSensorHealth(ctx context.Context, h *resources.SensorHealth) error
+	// GetSensorHealth retrieves the SensorHealth of a client by client ID.
+	GetSensorHealth(ctx context.Context, clientID string) (*resources.SensorHealth, error)
+	// ListSensorHealth lists the SensorHealth of all clients.
+	ListSensorHealth(ctx context.Context) ([]*resources.SensorHealth, error)
+
+	// PutDeployment adds or replaces the Deployment of a client.
+	PutDeployment(ctx context.Context, d *resources.Deployment) error
+	// GetDeployment retrieves the Deployment of a client by client ID.
+	GetDeployment(ctx context.Context, clientID string) (*resources.Deployment, error)
+	// ListDeployments lists the Deployments of all clients.
+	ListDeployments(ctx context.Context) ([]*resources.Deployment, error)
+
+	// AddDriftEvent adds a new DriftEvent.
+	AddDriftEvent(ctx context.Context, e *resources.DriftEvent) error
+	// ListDriftEvents lists all stored DriftEvents.
+	ListDriftEvents(ctx context.Context) ([]*resources.DriftEvent, error)
+
+	// AddAlertEvents adds or replaces AlertEvents by ID.
+	AddAlertEvents(ctx context.Context, events []*resources.AlertEvent) error
+	// ListAlertEvents lists the AlertEvents selected by the filter, most recent first.
+	ListAlertEvents(ctx context.Context, f *resources.AlertEventFilter) ([]*resources.AlertEvent, error)
+
+	// AddRuleHits adds or replaces RuleHits by ID.
+	AddRuleHits(ctx context.Context, hits []*resources.RuleHits) error
+	// ListRuleHits lists the RuleHits selected by the filter, ordered by reporting period.
+	ListRuleHits(ctx context.Context, f *resources.RuleHitsFilter) ([]*resources.RuleHits, error)
+
+	// PutQuarantine adds or replaces a Quarantine.
+	PutQuarantine(ctx context.Context, q *resources.Quarantine) error
+	// GetQuarantine retrieves a Quarantine by ID.
+	GetQuarantine(ctx context.Context, id string) (*resources.Quarantine, error)
+	// ListQuarantines lists all stored Quarantines.
+	ListQuarantines(ctx context.Context) ([]*resources.Quarantine, error)
+
+	// AddAuditEvent adds a new AuditEvent.
+	AddAuditEvent(ctx context.Context, e *resources.AuditEvent) error
+	// ListAuditEvents lists all stored AuditEvents.
+	ListAuditEvents(ctx context.Context) ([]*resources.AuditEvent, error)
 }
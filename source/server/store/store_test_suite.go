@@ -91,12 +91,165 @@ var (
 		Status:   "OK",
 	}
 
+	sensorHealth1 = &resources.SensorHealth{
+		ClientID:        "dest1",
+		SuricataVersion: "4.1.4 RELEASE",
+		RulesLoaded:     10,
+	}
+	sensorHealth2 = &resources.SensorHealth{
+		ClientID:        "dest2",
+		SuricataVersion: "4.1.4 RELEASE",
+		RulesLoaded:     20,
+	}
+
+	deployment1 = &resources.Deployment{
+		ClientID:       "dest1",
+		RequestID:      "req1",
+		RuleFile:       "test/rules",
+		RuleFileSHA256: "abcd",
+	}
+
+	driftEvent1 = &resources.DriftEvent{
+		ID:             "drift1",
+		ClientID:       "dest2",
+		ExpectedSHA256: "abcd",
+		ReportedSHA256: "ef01",
+	}
+	driftEvent2 = &resources.DriftEvent{
+		ID:             "drift2",
+		ClientID:       "dest1",
+		ExpectedSHA256: "abcd",
+		ReportedSHA256: "ef01",
+	}
+
+	ruleHits1 = &resources.RuleHits{
+		ID:          "msg1-1001-1",
+		ClientID:    "dest1",
+		Location:    "test",
+		Zone:        "dmz",
+		RuleID:      1,
+		SignatureID: 1001,
+		Rev:         1,
+		Count:       10,
+		Start:       time.Date(2019, 5, 13, 13, 0, 0, 0, time.UTC),
+		End:         time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC),
+	}
+	ruleHits2 = &resources.RuleHits{
+		ID:          "msg2-1002-1",
+		ClientID:    "dest2",
+		Location:    "test",
+		Zone:        "prod",
+		RuleID:      2,
+		SignatureID: 1002,
+		Rev:         1,
+		Count:       5,
+		Start:       time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC),
+		End:         time.Date(2019, 5, 13, 15, 0, 0, 0, time.UTC),
+	}
+
+	dataset1 = &resources.Dataset{
+		Name:     "bad_ips",
+		Type:     resources.DatasetIPv4,
+		Members:  []string{"10.0.0.1", "10.0.0.2"},
+		LocZones: []string{"test:prod"},
+	}
+	dataset2 = &resources.Dataset{
+		Name:     "bad_domains",
+		Type:     resources.DatasetString,
+		Members:  []string{"evil.example.com"},
+		LocZones: []string{"test:prod", "test:canary"},
+	}
+
+	quarantine1 = &resources.Quarantine{
+		ID:       "q1",
+		RuleID:   2,
+		Location: "test",
+		Zone:     "dmz",
+		Action:   resources.QuarantineRemove,
+		Hits:     1000,
+		Window:   "1h0m0s",
+		Active:   true,
+	}
+	quarantine2 = &resources.Quarantine{
+		ID:       "q2",
+		RuleID:   1,
+		Location: "test",
+		Zone:     "prod",
+		Action:   resources.QuarantineSuppress,
+		Hits:     2000,
+		Window:   "1h0m0s",
+		Active:   true,
+	}
+
+	auditEvent1 = &resources.AuditEvent{
+		ID:     "audit1",
+		Time:   time.Date(2019, 5, 13, 15, 0, 0, 0, time.UTC),
+		Actor:  "quarantine",
+		Action: "quarantine_rule",
+		RuleID: 1,
+	}
+	auditEvent2 = &resources.AuditEvent{
+		ID:     "audit2",
+		Time:   time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC),
+		Actor:  "quarantine",
+		Action: "quarantine_rule",
+		RuleID: 2,
+	}
+
+	alertEvent1 = &resources.AlertEvent{
+		ID:          "msg1-0",
+		ClientID:    "dest1",
+		Time:        time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC),
+		Host:        "sensor1",
+		Location:    "test",
+		Zone:        "dmz",
+		RuleID:      1,
+		SignatureID: 1001,
+		SrcIP:       "10.0.0.1",
+	}
+	alertEvent2 = &resources.AlertEvent{
+		ID:          "msg1-1",
+		ClientID:    "dest1",
+		Time:        time.Date(2019, 5, 13, 14, 1, 0, 0, time.UTC),
+		Host:        "sensor1",
+		Location:    "test",
+		Zone:        "dmz",
+		RuleID:      2,
+		SignatureID: 1002,
+		SrcIP:       "10.0.0.2",
+	}
+	alertEvent3 = &resources.AlertEvent{
+		ID:          "msg2-0",
+		ClientID:    "dest2",
+		Time:        time.Date(2019, 5, 13, 14, 2, 0, 0, time.UTC),
+		Host:        "sensor2",
+		Location:    "test",
+		Zone:        "prod",
+		RuleID:      1,
+		SignatureID: 1001,
+		SrcIP:       "10.0.0.3",
+	}
+
 	sensorMessage1 = &resources.SensorMessage{
 		ID:       "req1",
+		Time:     "Mon, 13 May 2019 14:00:00 +0000",
 		ClientID: "dest1",
 		Type:     resources.Alert,
 		Status:   "ERROR",
 	}
+	sensorMessage2 = &resources.SensorMessage{
+		ID:       "req2",
+		Time:     "Mon, 13 May 2019 13:00:00 +0000",
+		ClientID: "dest1",
+		Type:     resources.Heartbeat,
+	}
+	sensorMessage3 = &resources.SensorMessage{
+		ID:       "req3",
+		Time:     "Mon, 13 May 2019 15:00:00 +0000",
+		ClientID: "dest2",
+		Type:     resources.Alert,
+		Status:   "ERROR",
+	}
 )
 
 func (s *suite) TestAddLocation(t *testing.T) {
@@ -308,6 +461,64 @@ func (s *suite) TestListAllRules(t *testing.T) {
 	}
 }
 
+func (s *suite) TestDataset(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	TimeNow = func() time.Time {
+		return time.Date(2000, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC", 0))
+	}
+
+	if err := st.ModifyDataset(ctx, dataset1); err == nil {
+		t.Error("ModifyDataset on a non-existing dataset should have failed")
+	}
+	for _, d := range []*resources.Dataset{dataset1, dataset2} {
+		cp := *d
+		if err := st.AddDataset(ctx, &cp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.AddDataset(ctx, dataset1); err == nil {
+		t.Error("adding an existing dataset should have failed")
+	}
+	modified := resources.Dataset{Name: dataset1.Name, Members: []string{"10.0.0.3"}}
+	if err := st.ModifyDataset(ctx, &modified); err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.GetDataset(ctx, dataset1.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := *dataset1
+	want.Members = []string{"10.0.0.3"}
+	want.LastModified = TimeNow().Format(time.RFC1123Z)
+	if diff := cmp.Diff(&want, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := st.DeleteDataset(ctx, dataset1.Name); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.DeleteDataset(ctx, dataset1.Name); err == nil {
+		t.Error("deleting a non-existing dataset should have failed")
+	}
+	if _, err := st.GetDataset(ctx, dataset1.Name); err == nil {
+		t.Error("getting a deleted dataset should have failed")
+	}
+	all, err := st.ListDatasets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = *dataset2
+	want.LastModified = TimeNow().Format(time.RFC1123Z)
+	if diff := cmp.Diff([]*resources.Dataset{&want}, all); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func (s *suite) TestAddSensorRequest(t *testing.T) {
 	st, err := s.builder()
 	if err != nil {
@@ -339,6 +550,9 @@ func (s *suite) TestModifySensorRequest(t *testing.T) {
 	}
 	cp := *sensorRequest1
 	cp.Status = "NEW"
+	cp.RulesLoaded = 10
+	cp.RulesFailed = 2
+	cp.FailedRuleIDs = []int64{1111, 2222}
 	if err := st.ModifySensorRequest(ctx, &cp); err != nil {
 		t.Error(err)
 	}
@@ -384,3 +598,365 @@ func (s *suite) TestAddSensorMessage(t *testing.T) {
 		t.Error("adding a duplicate sensor message should have raised an error")
 	}
 }
+
+func (s *suite) TestListSensorMessages(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// Messages are received one second apart.
+	received := make(map[string]time.Time)
+	for i, m := range []*resources.SensorMessage{sensorMessage3, sensorMessage1, sensorMessage2} {
+		now := time.Date(2000, 1, 1, 0, 0, i, 0, time.UTC)
+		TimeNow = func() time.Time { return now }
+		if err := st.AddSensorMessage(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+		received[m.ID] = now
+	}
+	withReceived := func(msgs ...*resources.SensorMessage) []*resources.SensorMessage {
+		var want []*resources.SensorMessage
+		for _, m := range msgs {
+			cp := *m
+			cp.Received = received[m.ID]
+			want = append(want, &cp)
+		}
+		return want
+	}
+	for _, tt := range []struct {
+		desc   string
+		filter *resources.SensorMessageFilter
+		want   []*resources.SensorMessage
+	}{
+		{
+			desc:   "all messages",
+			filter: &resources.SensorMessageFilter{},
+			want:   withReceived(sensorMessage2, sensorMessage1, sensorMessage3),
+		},
+		{
+			desc:   "alerts",
+			filter: &resources.SensorMessageFilter{Type: resources.Alert},
+			want:   withReceived(sensorMessage1, sensorMessage3),
+		},
+		{
+			desc:   "since",
+			filter: &resources.SensorMessageFilter{Since: time.Date(2019, 5, 13, 14, 0, 0, 0, time.UTC)},
+			want:   withReceived(sensorMessage1, sensorMessage3),
+		},
+		{
+			desc:   "received since",
+			filter: &resources.SensorMessageFilter{ReceivedSince: time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC)},
+			want:   withReceived(sensorMessage2, sensorMessage1),
+		},
+	} {
+		got, err := st.ListSensorMessages(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func (s *suite) TestPutSensorHealth(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	TimeNow = func() time.Time {
+		return time.Date(2000, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC", 0))
+	}
+
+	if _, err := st.GetSensorHealth(ctx, sensorHealth1.ClientID); err == nil {
+		t.Error("GetSensorHealth for an unknown client should have failed")
+	}
+	for _, h := range []*resources.SensorHealth{sensorHealth2, sensorHealth1} {
+		cp := *h
+		if err := st.PutSensorHealth(ctx, &cp); err != nil {
+			t.Error(err)
+		}
+	}
+	// Replace the health of the first client.
+	latest := *sensorHealth1
+	latest.RulesLoaded = 11
+	if err := st.PutSensorHealth(ctx, &latest); err != nil {
+		t.Error(err)
+	}
+	latest.LastModified = TimeNow().Format(time.RFC1123Z)
+
+	got, err := st.GetSensorHealth(ctx, latest.ClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&latest, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+
+	all, err := st.ListSensorHealth(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want2 := *sensorHealth2
+	want2.LastModified = TimeNow().Format(time.RFC1123Z)
+	if diff := cmp.Diff([]*resources.SensorHealth{&latest, &want2}, all); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func (s *suite) TestPutDeployment(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	TimeNow = func() time.Time {
+		return time.Date(2000, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC", 0))
+	}
+
+	if _, err := st.GetDeployment(ctx, deployment1.ClientID); err == nil {
+		t.Error("GetDeployment for an unknown client should have failed")
+	}
+	cp := *deployment1
+	if err := st.PutDeployment(ctx, &cp); err != nil {
+		t.Error(err)
+	}
+	// Record drift for the deployment.
+	cp.DriftSHA256 = "ef01"
+	if err := st.PutDeployment(ctx, &cp); err != nil {
+		t.Error(err)
+	}
+	cp.LastModified = TimeNow().Format(time.RFC1123Z)
+
+	got, err := st.GetDeployment(ctx, cp.ClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&cp, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	all, err := st.ListDeployments(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*resources.Deployment{&cp}, all); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func (s *suite) TestAddDriftEvent(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, e := range []*resources.DriftEvent{driftEvent1, driftEvent2} {
+		if err := st.AddDriftEvent(ctx, e); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := st.AddDriftEvent(ctx, driftEvent1); err == nil {
+		t.Error("adding a duplicate drift event should have raised an error")
+	}
+	got, err := st.ListDriftEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*resources.DriftEvent{driftEvent2, driftEvent1}, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func (s *suite) TestAddAlertEvents(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	first := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	TimeNow = func() time.Time { return first }
+	if err := st.AddAlertEvents(ctx, []*resources.AlertEvent{alertEvent1, alertEvent2, alertEvent3}); err != nil {
+		t.Fatal(err)
+	}
+	// Adding events again is idempotent, apart from their reception time.
+	second := first.Add(time.Minute)
+	TimeNow = func() time.Time { return second }
+	if err := st.AddAlertEvents(ctx, []*resources.AlertEvent{alertEvent1}); err != nil {
+		t.Fatal(err)
+	}
+	withReceived := func(events ...*resources.AlertEvent) []*resources.AlertEvent {
+		var want []*resources.AlertEvent
+		for _, e := range events {
+			cp := *e
+			cp.Received = first
+			if e.ID == alertEvent1.ID {
+				cp.Received = second
+			}
+			want = append(want, &cp)
+		}
+		return want
+	}
+	for _, tt := range []struct {
+		desc   string
+		filter *resources.AlertEventFilter
+		want   []*resources.AlertEvent
+	}{
+		{
+			desc:   "all events",
+			filter: &resources.AlertEventFilter{},
+			want:   withReceived(alertEvent3, alertEvent2, alertEvent1),
+		},
+		{
+			desc:   "by rule",
+			filter: &resources.AlertEventFilter{RuleID: 1},
+			want:   withReceived(alertEvent3, alertEvent1),
+		},
+		{
+			desc:   "by location and zone",
+			filter: &resources.AlertEventFilter{Location: "test", Zone: "dmz"},
+			want:   withReceived(alertEvent2, alertEvent1),
+		},
+		{
+			desc:   "by host",
+			filter: &resources.AlertEventFilter{Host: "sensor2"},
+			want:   withReceived(alertEvent3),
+		},
+		{
+			desc:   "since",
+			filter: &resources.AlertEventFilter{Since: alertEvent2.Time},
+			want:   withReceived(alertEvent3, alertEvent2),
+		},
+		{
+			desc:   "received since",
+			filter: &resources.AlertEventFilter{ReceivedSince: second},
+			want:   withReceived(alertEvent1),
+		},
+		{
+			desc:   "limit",
+			filter: &resources.AlertEventFilter{ClientID: "dest1", Limit: 1},
+			want:   withReceived(alertEvent2),
+		},
+	} {
+		got, err := st.ListAlertEvents(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func (s *suite) TestAddRuleHits(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := st.AddRuleHits(ctx, []*resources.RuleHits{ruleHits2, ruleHits1}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		desc   string
+		filter *resources.RuleHitsFilter
+		want   []*resources.RuleHits
+	}{
+		{
+			desc:   "all hits",
+			filter: &resources.RuleHitsFilter{},
+			want:   []*resources.RuleHits{ruleHits1, ruleHits2},
+		},
+		{
+			desc:   "by rule",
+			filter: &resources.RuleHitsFilter{RuleIDs: []int64{2, 3}},
+			want:   []*resources.RuleHits{ruleHits2},
+		},
+		{
+			desc:   "by zone",
+			filter: &resources.RuleHitsFilter{Location: "test", Zone: "dmz"},
+			want:   []*resources.RuleHits{ruleHits1},
+		},
+		{
+			desc:   "time range",
+			filter: &resources.RuleHitsFilter{Since: ruleHits1.End.Add(time.Minute), Until: ruleHits2.End},
+			want:   []*resources.RuleHits{ruleHits2},
+		},
+	} {
+		got, err := st.ListRuleHits(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.desc, err)
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+			t.Errorf("%s: expectation mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func (s *suite) TestPutQuarantine(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, q := range []*resources.Quarantine{quarantine1, quarantine2} {
+		if err := st.PutQuarantine(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	undone := *quarantine1
+	undone.Active = false
+	undone.UndoTime = "Mon, 13 May 2019 16:00:00 +0000"
+	if err := st.PutQuarantine(ctx, &undone); err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.GetQuarantine(ctx, quarantine1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&undone, got); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := st.GetQuarantine(ctx, "unknown"); err == nil {
+		t.Error("getting a nonexistent quarantine should have raised an error")
+	}
+	all, err := st.ListQuarantines(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*resources.Quarantine{quarantine2, &undone}, all); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func (s *suite) TestAddAuditEvent(t *testing.T) {
+	st, err := s.builder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, e := range []*resources.AuditEvent{auditEvent1, auditEvent2} {
+		if err := st.AddAuditEvent(ctx, e); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := st.AddAuditEvent(ctx, auditEvent1); err == nil {
+		t.Error("adding a duplicate audit event should have raised an error")
+	}
+	got, err := st.ListAuditEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*resources.AuditEvent{auditEvent2, auditEvent1}, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+}
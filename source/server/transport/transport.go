@@ -0,0 +1,418 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transport provides the server side of the direct gRPC transport to sensors, used instead
+// of Fleetspeak where it cannot run. Sensors connect to the server over mutually authenticated TLS
+// and keep a bidirectional stream open, on which they register with their labels, send
+// SensorMessages and receive SensorRequests.
+//
+// Sensors are identified by their certificate: as Fleetspeak does with client keys, the client ID
+// is derived from the certificate public key, and the location labels of a sensor must name the
+// organizations (locations) and organizational units (zones) of the certificate subject.
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	spb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+	fsspb "github.com/google/fleetspeak/fleetspeak/src/server/proto/fleetspeak_server"
+)
+
+const (
+	// Service name set on the messages passed to the Processor, as for Fleetspeak messages.
+	serviceName = "Emitto"
+	// Service name of the sensor labels, as for the labels of Fleetspeak clients.
+	labelServiceName = "client"
+	// Maximum number of requests queued for a disconnected sensor.
+	maxPending = 100
+)
+
+// Processor processes the messages sent by sensors, as the Fleetspeak Processor service does.
+type Processor interface {
+	Process(ctx context.Context, m *fspb.Message) (*fspb.EmptyMessage, error)
+}
+
+// sensor is a sensor known to the Server.
+type sensor struct {
+	id          []byte
+	labels      []string
+	lastContact time.Time
+	// Requests awaiting delivery, in insertion order.
+	pending []*spb.SensorRequest
+	// Signals the current stream of the sensor that requests are pending; nil if disconnected.
+	wake chan struct{}
+}
+
+// Server implements the SensorTransport service, and acts as the Fleetspeak admin client of the
+// Emitto service: it lists the sensors which registered since it started, and delivers requests to
+// them. Requests for disconnected sensors are delivered when they reconnect.
+type Server struct {
+	mu        sync.Mutex
+	processor Processor
+	sensors   map[string]*sensor
+}
+
+// NewServer returns a new Server.
+func NewServer() *Server {
+	return &Server{sensors: make(map[string]*sensor)}
+}
+
+// SetProcessor sets the Processor of the messages sent by sensors.
+func (s *Server) SetProcessor(p Processor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processor = p
+}
+
+// ClientID returns the client ID of the sensor presenting the certificate: the first 8 bytes of the
+// SHA-256 digest of its public key.
+func ClientID(cert *x509.Certificate) []byte {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return sum[:8]
+}
+
+// peerCertificate returns the verified certificate of the sensor of a stream.
+func peerCertificate(ctx context.Context) (*x509.Certificate, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown peer")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "the sensor did not present a verified certificate")
+	}
+	return info.State.VerifiedChains[0][0], nil
+}
+
+// checkLabels checks that the location labels of a sensor name the organizations and organizational
+// units of the subject of its certificate. Other labels are not used to target sensors.
+func checkLabels(cert *x509.Certificate, labels []string) error {
+	for _, l := range labels {
+		var allowed []string
+		switch {
+		case strings.HasPrefix(l, resources.LocationNamePrefix):
+			allowed = cert.Subject.Organization
+			l = strings.TrimPrefix(l, resources.LocationNamePrefix)
+		case strings.HasPrefix(l, resources.LocationZonePrefix):
+			allowed = cert.Subject.OrganizationalUnit
+			l = strings.TrimPrefix(l, resources.LocationZonePrefix)
+		default:
+			continue
+		}
+		if !contains(allowed, l) {
+			return status.Errorf(codes.PermissionDenied, "the sensor certificate does not allow location label %q", l)
+		}
+	}
+	return nil
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Connect handles the stream of a sensor. The client ID sent by the sensor in its registration is
+// ignored in favor of the one derived from its certificate.
+func (s *Server) Connect(stream spb.SensorTransport_ConnectServer) error {
+	ctx := stream.Context()
+	cert, err := peerCertificate(ctx)
+	if err != nil {
+		return err
+	}
+	clientID := ClientID(cert)
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	reg := first.GetRegistration()
+	if reg == nil {
+		return status.Error(codes.InvalidArgument, "the first message must register the sensor")
+	}
+	if err := checkLabels(cert, reg.GetLabels()); err != nil {
+		return err
+	}
+	id := fmt.Sprintf("%X", clientID)
+	wake := s.register(clientID, reg.GetLabels(), true)
+	defer s.unregister(id, wake)
+	log.Infof("Sensor %s connected with labels %v", id, reg.GetLabels())
+
+	// Only the sender goroutine calls stream.Send.
+	acks := make(chan string, maxPending)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.send(ctx, stream, id, wake, acks)
+	}()
+	for {
+		up, err := stream.Recv()
+		if err == io.EOF {
+			log.Infof("Sensor %s disconnected", id)
+			return nil
+		}
+		if err != nil {
+			log.Warningf("Stream of sensor %s failed: %v", id, err)
+			return err
+		}
+		switch t := up.Type.(type) {
+		case *spb.TransportUpstream_Registration:
+			if err := checkLabels(cert, t.Registration.GetLabels()); err != nil {
+				return err
+			}
+			s.register(clientID, t.Registration.GetLabels(), false)
+			log.Infof("Sensor %s changed labels to %v", id, t.Registration.GetLabels())
+		case *spb.TransportUpstream_Message:
+			s.touch(id)
+			// Messages which fail to be processed are acknowledged too, as Fleetspeak does, since
+			// sending them again would not help.
+			if err := s.process(ctx, clientID, t.Message); err != nil {
+				log.Errorf("Failed to process message (%s) of sensor %s: %v", t.Message.GetId(), id, err)
+			}
+			select {
+			case acks <- t.Message.GetId():
+			case err := <-errc:
+				return err
+			}
+		default:
+			log.Errorf("Unknown upstream message type (%T) from sensor %s", t, id)
+		}
+	}
+}
+
+// send sends the acknowledgements and the pending requests of a sensor on its stream until ctx is
+// done or sending fails.
+func (s *Server) send(ctx context.Context, stream spb.SensorTransport_ConnectServer, id string, wake <-chan struct{}, acks <-chan string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case a := <-acks:
+			if err := stream.Send(&spb.TransportDownstream{Type: &spb.TransportDownstream_Ack{Ack: a}}); err != nil {
+				return err
+			}
+		case <-wake:
+			for {
+				req := s.nextPending(id)
+				if req == nil {
+					break
+				}
+				if err := stream.Send(&spb.TransportDownstream{Type: &spb.TransportDownstream_Request{Request: req}}); err != nil {
+					s.requeue(id, req)
+					return err
+				}
+				log.Infof("Sent request (%s) to sensor %s", req.GetId(), id)
+			}
+		}
+	}
+}
+
+// process passes a SensorMessage to the Processor as a Fleetspeak message from the sensor.
+func (s *Server) process(ctx context.Context, clientID []byte, msg *spb.SensorMessage) error {
+	s.mu.Lock()
+	p := s.processor
+	s.mu.Unlock()
+	if p == nil {
+		return errors.New("no processor")
+	}
+	data, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		return err
+	}
+	_, err = p.Process(ctx, &fspb.Message{
+		SourceMessageId: []byte(msg.GetId()),
+		Source: &fspb.Address{
+			ClientId:    clientID,
+			ServiceName: serviceName,
+		},
+		Destination: &fspb.Address{
+			ServiceName: serviceName,
+		},
+		Data: data,
+	})
+	return err
+}
+
+// register records a sensor registration, and returns the channel signaling its stream. A new
+// stream replaces the previous one of the sensor, if any.
+func (s *Server) register(clientID []byte, labels []string, newStream bool) chan struct{} {
+	id := fmt.Sprintf("%X", clientID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.sensors[id]
+	if !ok {
+		c = &sensor{id: clientID}
+		s.sensors[id] = c
+	}
+	c.labels = labels
+	c.lastContact = time.Now()
+	if newStream {
+		c.wake = make(chan struct{}, 1)
+	}
+	if len(c.pending) > 0 {
+		signal(c.wake)
+	}
+	return c.wake
+}
+
+// unregister marks a sensor as disconnected, unless it reconnected on another stream.
+func (s *Server) unregister(id string, wake chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.sensors[id]; ok && c.wake == wake {
+		c.wake = nil
+	}
+}
+
+// touch updates the last contact time of a sensor.
+func (s *Server) touch(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.sensors[id]; ok {
+		c.lastContact = time.Now()
+	}
+}
+
+// nextPending removes and returns the oldest pending request of a sensor, nil if there is none.
+func (s *Server) nextPending(id string) *spb.SensorRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.sensors[id]
+	if !ok || len(c.pending) == 0 {
+		return nil
+	}
+	req := c.pending[0]
+	c.pending = c.pending[1:]
+	return req
+}
+
+// requeue puts back a request which could not be sent at the front of the pending requests.
+func (s *Server) requeue(id string, req *spb.SensorRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.sensors[id]; ok {
+		c.pending = append([]*spb.SensorRequest{req}, c.pending...)
+	}
+}
+
+// InsertMessage queues a SensorRequest for delivery to the sensor identified by id.
+func (s *Server) InsertMessage(ctx context.Context, req *spb.SensorRequest, id []byte) error {
+	key := fmt.Sprintf("%X", id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.sensors[key]
+	if !ok {
+		return fmt.Errorf("unknown sensor %s", key)
+	}
+	if len(c.pending) >= maxPending {
+		return fmt.Errorf("too many pending requests for sensor %s", key)
+	}
+	c.pending = append(c.pending, req)
+	if c.wake != nil {
+		signal(c.wake)
+	} else {
+		log.Infof("Sensor %s is disconnected; request (%s) will be sent when it reconnects", key, req.GetId())
+	}
+	return nil
+}
+
+// ListClients returns the sensors which registered since the Server started, by ascending last
+// contact time, in the form of Fleetspeak clients.
+func (s *Server) ListClients(ctx context.Context) ([]*fsspb.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.sensors) == 0 {
+		return nil, errors.New("no clients found")
+	}
+	var clients []*fsspb.Client
+	for _, c := range s.sensors {
+		t, err := ptypes.TimestampProto(c.lastContact)
+		if err != nil {
+			return nil, err
+		}
+		fc := &fsspb.Client{ClientId: c.id, LastContactTime: t}
+		for _, l := range c.labels {
+			fc.Labels = append(fc.Labels, &fspb.Label{ServiceName: labelServiceName, Label: l})
+		}
+		clients = append(clients, fc)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		ti, tj := clients[i].GetLastContactTime(), clients[j].GetLastContactTime()
+		return ti.GetSeconds() < tj.GetSeconds() || (ti.GetSeconds() == tj.GetSeconds() && ti.GetNanos() < tj.GetNanos())
+	})
+	return clients, nil
+}
+
+// Close is a no-op; sensor streams end when the gRPC server stops.
+func (s *Server) Close() error {
+	return nil
+}
+
+// signal wakes up a stream without blocking.
+func signal(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// ServerCredentials returns the TLS credentials of the server, which require sensors to present a
+// certificate signed by a CA in caFile.
+func ServerCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %v", err)
+	}
+	b, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sensor CA file %q: %v", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificate in sensor CA file %q", caFile)
+	}
+	return serverCredentials(cert, pool), nil
+}
+
+// serverCredentials returns the TLS credentials of the server, which require sensors to present a
+// certificate signed by a CA of the pool.
+func serverCredentials(cert tls.Certificate, pool *x509.CertPool) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/emitto/source/resources"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spb "github.com/google/emitto/source/sensor/proto"
+	fspb "github.com/google/fleetspeak/fleetspeak/src/common/proto/fleetspeak"
+)
+
+type fakeProcessor struct {
+	mu   sync.Mutex
+	msgs []*fspb.Message
+}
+
+func (p *fakeProcessor) Process(ctx context.Context, m *fspb.Message) (*fspb.EmptyMessage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.msgs = append(p.msgs, m)
+	return &fspb.EmptyMessage{}, nil
+}
+
+// startServer serves a Server over TLS on a local port, and returns its address and the PKI of
+// the server and sensors.
+func startServer(t *testing.T, s *Server) (string, *TestPKI, func()) {
+	t.Helper()
+	pki, err := NewTestPKI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds, err := pki.ServerCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer(grpc.Creds(creds))
+	spb.RegisterSensorTransportServer(gs, s)
+	go gs.Serve(l)
+	return l.Addr().String(), pki, gs.Stop
+}
+
+// connect opens a stream to the server as a new sensor of the zone of the location, and returns
+// the stream and the client ID of the sensor.
+func connect(t *testing.T, addr string, pki *TestPKI, location, zone string) (spb.SensorTransport_ConnectClient, []byte, func()) {
+	t.Helper()
+	creds, id, err := pki.SensorCredentials(location, zone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := spb.NewSensorTransportClient(conn).Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stream, id, func() { conn.Close() }
+}
+
+func register(id []byte, labels ...string) *spb.TransportUpstream {
+	return &spb.TransportUpstream{Type: &spb.TransportUpstream_Registration{
+		Registration: &spb.Registration{ClientId: id, Labels: labels},
+	}}
+}
+
+// waitClients waits until the Server knows n sensors.
+func waitClients(t *testing.T, s *Server, n int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if c, err := s.ListClients(context.Background()); err == nil && len(c) == n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d sensors", n)
+}
+
+func TestConnect(t *testing.T) {
+	ctx := context.Background()
+	p := &fakeProcessor{}
+	s := NewServer()
+	s.SetProcessor(p)
+	addr, pki, stop := startServer(t, s)
+	defer stop()
+
+	if _, err := s.ListClients(ctx); err == nil {
+		t.Error("expected an error without sensors")
+	}
+	creds, id, err := pki.SensorCredentials("company1", "dmz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.InsertMessage(ctx, &spb.SensorRequest{Id: "early"}, id); err == nil {
+		t.Error("expected an error for an unknown sensor")
+	}
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	stream, err := spb.NewSensorTransportClient(conn).Connect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := resources.LocationLabels("company1", "dmz")
+	if err := stream.Send(register(id, labels...)); err != nil {
+		t.Fatal(err)
+	}
+	waitClients(t, s, 1)
+	clients, err := s.ListClients(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*fspb.Label{
+		{ServiceName: "client", Label: labels[0]},
+		{ServiceName: "client", Label: labels[1]},
+	}
+	if diff := cmp.Diff(want, clients[0].GetLabels(), cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("labels mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(id, clients[0].GetClientId()); diff != "" {
+		t.Errorf("client ID mismatch (-want +got):\n%s", diff)
+	}
+
+	// Requests are delivered in order.
+	for _, r := range []string{"1", "2"} {
+		if err := s.InsertMessage(ctx, &spb.SensorRequest{Id: r}, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range []string{"1", "2"} {
+		down, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := down.GetRequest().GetId(); got != r {
+			t.Errorf("got request %q, want %q", got, r)
+		}
+	}
+
+	// Messages are processed as Fleetspeak messages of the sensor, then acknowledged.
+	msg := &spb.SensorMessage{Id: "m1", Type: &spb.SensorMessage_Heartbeat{Heartbeat: &spb.Heartbeat{SensorVersion: "1"}}}
+	if err := stream.Send(&spb.TransportUpstream{Type: &spb.TransportUpstream_Message{Message: msg}}); err != nil {
+		t.Fatal(err)
+	}
+	down, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if down.GetAck() != "m1" {
+		t.Errorf("got %v, want an acknowledgement of %q", down, "m1")
+	}
+	p.mu.Lock()
+	if len(p.msgs) != 1 {
+		t.Fatalf("got %d processed messages, want 1", len(p.msgs))
+	}
+	got := new(spb.SensorMessage)
+	if err := ptypes.UnmarshalAny(p.msgs[0].GetData(), got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(msg, got, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("expectation mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(id, p.msgs[0].GetSource().GetClientId()); diff != "" {
+		t.Errorf("source client ID mismatch (-want +got):\n%s", diff)
+	}
+	p.mu.Unlock()
+
+	// Requests for a disconnected sensor are delivered when it reconnects.
+	stream.CloseSend()
+	if _, err := stream.Recv(); err == nil {
+		t.Fatal("expected the stream to end")
+	}
+	if err := s.InsertMessage(ctx, &spb.SensorRequest{Id: "3"}, id); err != nil {
+		t.Fatal(err)
+	}
+	stream, err = spb.NewSensorTransportClient(conn).Connect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(register(id, labels...)); err != nil {
+		t.Fatal(err)
+	}
+	down, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := down.GetRequest().GetId(); got != "3" {
+		t.Errorf("got request %q after reconnecting, want %q", got, "3")
+	}
+}
+
+func TestConnectWithoutRegistration(t *testing.T) {
+	s := NewServer()
+	addr, pki, stop := startServer(t, s)
+	defer stop()
+
+	stream, _, closeConn := connect(t, addr, pki, "company1", "dmz")
+	defer closeConn()
+	if err := stream.Send(&spb.TransportUpstream{Type: &spb.TransportUpstream_Message{Message: &spb.SensorMessage{Id: "m1"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err == nil {
+		t.Error("expected an error for an unregistered stream")
+	}
+}
+
+func TestConnectIdentity(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer()
+	s.SetProcessor(&fakeProcessor{})
+	addr, pki, stop := startServer(t, s)
+	defer stop()
+
+	stream1, id1, close1 := connect(t, addr, pki, "company1", "dmz")
+	defer close1()
+	if err := stream1.Send(register(id1, resources.LocationLabels("company1", "dmz")...)); err != nil {
+		t.Fatal(err)
+	}
+	waitClients(t, s, 1)
+
+	// A sensor registering with the client ID of another sensor is identified by its certificate.
+	stream2, id2, close2 := connect(t, addr, pki, "company1", "prod")
+	defer close2()
+	if err := stream2.Send(register(id1, resources.LocationLabels("company1", "prod")...)); err != nil {
+		t.Fatal(err)
+	}
+	waitClients(t, s, 2)
+	clients, err := s.ListClients(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]byte
+	for _, c := range clients {
+		got = append(got, c.GetClientId())
+	}
+	if diff := cmp.Diff([][]byte{id1, id2}, got); diff != "" {
+		t.Errorf("client IDs mismatch (-want +got):\n%s", diff)
+	}
+	// Requests for the first sensor are not delivered to the second one.
+	if err := s.InsertMessage(ctx, &spb.SensorRequest{Id: "r1"}, id1); err != nil {
+		t.Fatal(err)
+	}
+	down, err := stream1.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := down.GetRequest().GetId(); got != "r1" {
+		t.Errorf("got request %q, want %q", got, "r1")
+	}
+
+	// Sensors cannot claim the labels of locations and zones their certificate does not allow.
+	for _, labels := range [][]string{
+		resources.LocationLabels("company2", "dmz"),
+		resources.LocationLabels("company1", "dmz"),
+	} {
+		stream, _, closeConn := connect(t, addr, pki, "company1", "prod")
+		if err := stream.Send(register(nil, labels...)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Recv(); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("labels %v: got err=%v, want code %v", labels, err, codes.PermissionDenied)
+		}
+		closeConn()
+	}
+	// Nor change their labels to them later.
+	if err := stream2.Send(register(nil, resources.LocationLabels("company1", "dmz")...)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream2.Recv(); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("got err=%v, want code %v", err, codes.PermissionDenied)
+	}
+}
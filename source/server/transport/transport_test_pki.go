@@ -0,0 +1,129 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TestPKI is a certificate authority issuing the certificates of a server on localhost and of its
+// sensors, for testing the transport.
+type TestPKI struct {
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	pool   *x509.CertPool
+	serial int64
+}
+
+// NewTestPKI creates a new TestPKI.
+func NewTestPKI() (*TestPKI, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Emitto test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &TestPKI{cert: cert, key: key, pool: pool, serial: 1}, nil
+}
+
+// issue issues a certificate for the subject, usable by a server on localhost or by a client.
+func (p *TestPKI) issue(subject pkix.Name, server bool) (tls.Certificate, *x509.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	p.serial++
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(p.serial),
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		tmpl.DNSNames = []string{"localhost"}
+		tmpl.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, p.cert, &key.PublicKey, p.key)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, cert, nil
+}
+
+// ServerCredentials returns the credentials of the server, which require sensors to present a
+// certificate issued by the TestPKI.
+func (p *TestPKI) ServerCredentials() (credentials.TransportCredentials, error) {
+	c, _, err := p.issue(pkix.Name{CommonName: "localhost"}, true)
+	if err != nil {
+		return nil, err
+	}
+	return serverCredentials(c, p.pool), nil
+}
+
+// SensorCredentials returns the credentials of a new sensor whose certificate allows the location
+// labels of the zone of the location, if not empty, and the client ID of the sensor.
+func (p *TestPKI) SensorCredentials(location, zone string) (credentials.TransportCredentials, []byte, error) {
+	subject := pkix.Name{CommonName: "sensor"}
+	if location != "" {
+		subject.Organization = []string{location}
+	}
+	if zone != "" {
+		subject.OrganizationalUnit = []string{zone}
+	}
+	c, cert, err := p.issue(subject, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{c},
+		RootCAs:      p.pool,
+		ServerName:   "localhost",
+	})
+	return creds, ClientID(cert), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signing contains functionality to sign and verify Emitto rule files.
+//
+// Keys are stored on disk as base64-encoded raw Ed25519 keys. A private key file may contain
+// either the 32 byte seed or the full 64 byte private key.
+package signing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	// ErrDigestMismatch indicates the data does not match the expected SHA-256 digest, e.g. the
+	// rule file was truncated or modified in transit.
+	ErrDigestMismatch = errors.New("SHA-256 digest mismatch")
+	// ErrBadSignature indicates the signature was not produced by the pinned key.
+	ErrBadSignature = errors.New("invalid signature")
+)
+
+// Bundle contains the integrity details for a rule file.
+type Bundle struct {
+	// SHA-256 digest of the rule file.
+	SHA256 []byte
+	// Ed25519 signature over the rule file.
+	Signature []byte
+}
+
+// Sign returns the digest and signature for the provided data.
+func Sign(key ed25519.PrivateKey, data []byte) *Bundle {
+	sum := sha256.Sum256(data)
+	return &Bundle{
+		SHA256:    sum[:],
+		Signature: ed25519.Sign(key, data),
+	}
+}
+
+// Verify checks that data matches the digest and signature in the bundle for the provided key.
+func Verify(key ed25519.PublicKey, data []byte, b *Bundle) error {
+	sum := sha256.Sum256(data)
+	if !bytes.Equal(sum[:], b.SHA256) {
+		return fmt.Errorf("%v: got %x, want %x", ErrDigestMismatch, sum, b.SHA256)
+	}
+	if !ed25519.Verify(key, data, b.Signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// LoadPrivateKey reads an Ed25519 private key from a file.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	b, err := readKey(path)
+	if err != nil {
+		return nil, err
+	}
+	switch len(b) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(b), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(b), nil
+	default:
+		return nil, fmt.Errorf("invalid private key size in %q: %d bytes", path, len(b))
+	}
+}
+
+// LoadPublicKey reads an Ed25519 public key from a file.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	b, err := readKey(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key size in %q: %d bytes", path, len(b))
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+func readKey(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %q: %v", path, err)
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key file %q: %v", path, err)
+	}
+	return b, nil
+}
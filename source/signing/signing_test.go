@@ -0,0 +1,117 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("alert tcp any any -> any any (sid:1;)\n")
+	b := Sign(priv, data)
+
+	for _, tt := range []struct {
+		desc    string
+		key     ed25519.PublicKey
+		data    []byte
+		wantErr string
+	}{
+		{
+			desc: "valid",
+			key:  pub,
+			data: data,
+		},
+		{
+			desc:    "truncated",
+			key:     pub,
+			data:    data[:10],
+			wantErr: ErrDigestMismatch.Error(),
+		},
+		{
+			desc:    "wrong key",
+			key:     otherPub,
+			data:    data,
+			wantErr: ErrBadSignature.Error(),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := Verify(tt.key, tt.data, b)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Verify() returned unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Verify() got err=%v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	d, err := ioutil.TempDir("", "keys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privFile := filepath.Join(d, "priv")
+	if err := ioutil.WriteFile(privFile, []byte(base64.StdEncoding.EncodeToString(priv)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	pubFile := filepath.Join(d, "pub")
+	if err := ioutil.WriteFile(pubFile, []byte(base64.StdEncoding.EncodeToString(pub)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gotPriv, err := LoadPrivateKey(privFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(priv, gotPriv); diff != "" {
+		t.Errorf("private key mismatch (-want +got):\n%s", diff)
+	}
+	gotPub, err := LoadPublicKey(pubFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(pub, gotPub); diff != "" {
+		t.Errorf("public key mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := LoadPublicKey(privFile); err == nil {
+		t.Error("LoadPublicKey() with a private key file should have failed")
+	}
+}